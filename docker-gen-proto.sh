@@ -32,15 +32,17 @@ gen_proto_python() {
 
 gen_proto_ts() {
   echo "Generating Typescript protobuf files for $1"
-  docker build -f "src/$1/genproto/Dockerfile" -t "$1-genproto" .
-  docker run --rm -e SERVICE=$1 -v $(pwd):/build "$1-genproto" /bin/sh -c '
+  service=$1
+  shift
+  docker build -f "src/$service/genproto/Dockerfile" -t "$service-genproto" .
+  docker run --rm -e SERVICE=$service -e PROTOS="$*" -v $(pwd):/build "$service-genproto" /bin/sh -c '
     mkdir -p /build/src/$SERVICE/protos && \
     protoc -I /build/pb \
     --plugin=protoc-gen-ts_proto=/app/node_modules/.bin/protoc-gen-ts_proto \
     --ts_proto_opt=esModuleInterop=true \
     --ts_proto_out="/build/src/$SERVICE/protos" \
     --ts_proto_opt=outputServices=grpc-js \
-    /build/pb/demo.proto'
+    /build/pb/demo.proto $(for p in $PROTOS; do echo /build/pb/$p; done)'
 }
 
 if [ -z "$1" ]; then
@@ -50,7 +52,7 @@ if [ -z "$1" ]; then
   gen_proto_go checkout
   gen_proto_cpp currency
   #gen_proto_ruby email
-  gen_proto_ts frontend
+  gen_proto_ts frontend google/rpc/status.proto google/rpc/error_details.proto
   #gen_proto_js payment
   gen_proto_go product-catalog
   #gen_proto_php quote
@@ -47,6 +47,14 @@ gen_proto_python() {
   cd "$base_dir" || return
 }
 
+gen_proto_ruby() {
+  echo "Generating Ruby protobuf files for $1"
+  cd "$base_dir"/src/"$1" || return
+  mkdir -p proto
+  grpc_tools_ruby_protoc -I ../../pb --ruby_out=./proto --grpc_out=./proto ../../pb/demo.proto
+  cd "$base_dir" || return
+}
+
 gen_proto_rust() {
   echo "Generating Rust protobuf files for $1"
   cd "$base_dir"/src/"$1" || return
@@ -61,7 +69,7 @@ gen_proto_ts() {
   cd "$base_dir"/src/"$1" || return
   cp -r "$base_dir"/pb .
   mkdir -p ./protos
-  protoc -I ./pb  --plugin=./node_modules/.bin/protoc-gen-ts_proto --ts_proto_opt=esModuleInterop=true --ts_proto_out=./protos --ts_proto_opt=outputServices=grpc-js demo.proto
+  protoc -I ./pb  --plugin=./node_modules/.bin/protoc-gen-ts_proto --ts_proto_opt=esModuleInterop=true --ts_proto_out=./protos --ts_proto_opt=outputServices=grpc-js demo.proto "${@:2}"
   cd "$base_dir" || return
 }
 
@@ -70,8 +78,8 @@ gen_proto_dotnet accounting
 gen_proto_dotnet cart
 gen_proto_go checkout
 # gen_proto_cpp currency
-# gen_proto_ruby email
-gen_proto_ts frontend
+gen_proto_ruby email
+gen_proto_ts frontend google/rpc/status.proto google/rpc/error_details.proto
 gen_proto_ts react-native-app
 gen_proto_js payment
 gen_proto_go product-catalog
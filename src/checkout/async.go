@@ -0,0 +1,290 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/rpcerr"
+)
+
+// Statuses of the orders submitted with SubmitOrder before they are placed
+// or held, and of those that failed.
+const (
+	orderStatusSubmitted  = "SUBMITTED"
+	orderStatusProcessing = "PROCESSING"
+	orderStatusFailed     = "FAILED"
+)
+
+const (
+	// defaultOrderWorkers and defaultOrderQueueSize size the order queue
+	// when CHECKOUT_ORDER_WORKERS and CHECKOUT_ORDER_QUEUE_SIZE are not set.
+	defaultOrderWorkers   = 4
+	defaultOrderQueueSize = 100
+	// submittedOrderTimeout bounds the processing of a submitted order, as
+	// the deadline of a PlaceOrder client would.
+	submittedOrderTimeout = time.Minute
+	// orderStatusRetention is how long WatchOrder can still replay the
+	// status updates of an order once its status is final.
+	orderStatusRetention = time.Hour
+)
+
+// submittedOrder is an order queued by SubmitOrder.
+type submittedOrder struct {
+	id      string
+	req     *pb.PlaceOrderRequest
+	tenders []*tender
+	// submitted is the span of the SubmitOrder call, which the span that
+	// processes the order links to.
+	submitted   trace.SpanContext
+	submittedAt time.Time
+}
+
+// orderQueue processes the orders submitted with SubmitOrder in a pool of
+// workers.
+type orderQueue struct {
+	mu     sync.Mutex
+	closed bool
+	orders chan *submittedOrder
+	wg     sync.WaitGroup
+}
+
+func newOrderQueue(size int) *orderQueue {
+	return &orderQueue{orders: make(chan *submittedOrder, size)}
+}
+
+// start starts workers that process the orders of q with process until q
+// is closed.
+func (q *orderQueue) start(workers int, process func(*submittedOrder)) {
+	for i := 0; i < workers; i++ {
+		q.wg.Add(1)
+		go func() {
+			defer q.wg.Done()
+			for o := range q.orders {
+				process(o)
+			}
+		}()
+	}
+}
+
+// push queues o, and reports whether there was room for it.
+func (q *orderQueue) push(o *submittedOrder) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return false
+	}
+	select {
+	case q.orders <- o:
+		return true
+	default:
+		return false
+	}
+}
+
+// close stops accepting orders, and waits until the workers processed those
+// queued or ctx is done.
+func (q *orderQueue) close(ctx context.Context) error {
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.orders)
+	}
+	q.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%d submitted orders were not processed: %w", len(q.orders), ctx.Err())
+	}
+}
+
+// orderTracker keeps the status updates of the orders submitted with
+// SubmitOrder in memory, for WatchOrder. A nil *orderTracker tracks
+// nothing.
+type orderTracker struct {
+	mu     sync.Mutex
+	orders map[string]*trackedOrder
+	// retention is how long orders are kept once their status is final.
+	retention time.Duration
+}
+
+type trackedOrder struct {
+	updates []*pb.OrderStatusUpdate
+	// changed is closed, and replaced, on every update.
+	changed chan struct{}
+	final   bool
+}
+
+func newOrderTracker(retention time.Duration) *orderTracker {
+	return &orderTracker{orders: make(map[string]*trackedOrder), retention: retention}
+}
+
+// track starts tracking the order with id, as submitted.
+func (t *orderTracker) track(id string) {
+	t.mu.Lock()
+	t.orders[id] = &trackedOrder{changed: make(chan struct{})}
+	t.mu.Unlock()
+	t.update(&pb.OrderStatusUpdate{OrderId: id, Status: orderStatusSubmitted})
+}
+
+// update records u, unless its order is not tracked or its status is
+// already final.
+func (t *orderTracker) update(u *pb.OrderStatusUpdate) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	o, ok := t.orders[u.OrderId]
+	if !ok || o.final {
+		return
+	}
+	if u.UpdatedAt == 0 {
+		u.UpdatedAt = time.Now().UnixMilli()
+	}
+	o.updates = append(o.updates, u)
+	close(o.changed)
+	o.changed = make(chan struct{})
+
+	switch u.Status {
+	case orderStatusPlaced, orderStatusRejected, orderStatusExpired, orderStatusFailed:
+		o.final = true
+		time.AfterFunc(t.retention, func() {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			delete(t.orders, u.OrderId)
+		})
+	}
+}
+
+// since returns the updates of the order with id after its first n, a
+// channel closed on its next update, and whether its status is final. It
+// reports false if the order is not tracked.
+func (t *orderTracker) since(id string, n int) ([]*pb.OrderStatusUpdate, <-chan struct{}, bool, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	o, ok := t.orders[id]
+	if !ok {
+		return nil, nil, false, false
+	}
+	return o.updates[n:], o.changed, o.final, true
+}
+
+// orderStatusUpdate returns the update of the order with id to status, with
+// its result once placed or held, or with err if it failed.
+func orderStatusUpdate(id, status string, result *pb.OrderResult, err error) *pb.OrderStatusUpdate {
+	u := &pb.OrderStatusUpdate{OrderId: id, Status: status, Order: result}
+	if d, ok := rpcerr.Decode(err); ok {
+		u.ErrorCode, u.ErrorReason, u.ErrorMessage = d.Code.String(), d.Reason, d.Message
+	} else if err != nil {
+		u.ErrorCode, u.ErrorMessage = codes.Unknown.String(), err.Error()
+	}
+	return u
+}
+
+// SubmitOrder validates an order and queues it to be placed by the order
+// workers. The order is then placed as PlaceOrder would, and its status
+// updates are streamed by WatchOrder.
+func (cs *checkout) SubmitOrder(ctx context.Context, req *pb.PlaceOrderRequest) (*pb.SubmitOrderResponse, error) {
+	span := trace.SpanFromContext(ctx)
+	log.WithContext(ctx).Infof("[SubmitOrder] user_id=%q user_currency=%q", req.UserId, req.UserCurrency)
+
+	orderID, tenders, err := cs.acceptOrder(ctx, req)
+	if err != nil {
+		span.AddEvent("error", trace.WithAttributes(semconv.ExceptionMessageKey.String(err.Error())))
+		return nil, err
+	}
+
+	cs.orderStatuses.track(orderID)
+	o := &submittedOrder{id: orderID, req: req, tenders: tenders, submitted: span.SpanContext(), submittedAt: time.Now()}
+	if !cs.orderQueue.push(o) {
+		cs.forgetCards(tenders)
+		err = orderError(ctx, codes.ResourceExhausted, reasonOrderQueueFull, fmt.Errorf("too many orders are being processed, retry later"))
+		cs.orderStatuses.update(orderStatusUpdate(orderID, orderStatusFailed, nil, err))
+		span.AddEvent("error", trace.WithAttributes(semconv.ExceptionMessageKey.String(err.Error())))
+		return nil, err
+	}
+	span.SetAttributes(attribute.String("app.order.id", orderID))
+	span.AddEvent("submitted")
+	return &pb.SubmitOrderResponse{OrderId: orderID}, nil
+}
+
+// processOrder places a submitted order, in a span linked to the span of
+// its submission.
+func (cs *checkout) processOrder(o *submittedOrder) {
+	ctx, cancel := context.WithTimeout(context.Background(), submittedOrderTimeout)
+	defer cancel()
+	ctx, span := tracer.Start(ctx, "processOrder",
+		trace.WithLinks(trace.Link{SpanContext: o.submitted}),
+		trace.WithAttributes(
+			attribute.String("app.order.id", o.id),
+			attribute.String("app.user.id", o.req.UserId),
+			attribute.String("app.user.currency", o.req.UserCurrency),
+			attribute.Float64("app.order.queue.duration", time.Since(o.submittedAt).Seconds()),
+		),
+	)
+	defer span.End()
+	defer cs.forgetCards(o.tenders)
+
+	cs.orderStatuses.update(orderStatusUpdate(o.id, orderStatusProcessing, nil, nil))
+	result, err := cs.placeOrder(ctx, o.id, o.req, o.tenders)
+	if err != nil {
+		span.SetStatus(otelcodes.Error, err.Error())
+		log.WithContext(ctx).Warnf("submitted order %s failed: %+v", o.id, err)
+		cs.orderStatuses.update(orderStatusUpdate(o.id, orderStatusFailed, nil, err))
+		return
+	}
+	cs.orderStatuses.update(orderStatusUpdate(o.id, result.Status, result, nil))
+}
+
+// WatchOrder streams the status updates of a submitted order until its
+// status is final or the client goes away.
+func (cs *checkout) WatchOrder(req *pb.WatchOrderRequest, stream grpc.ServerStreamingServer[pb.OrderStatusUpdate]) error {
+	ctx := stream.Context()
+	if req.GetOrderId() == "" {
+		return invalidRequestError(ctx, []rpcerr.FieldViolation{{Field: "order_id", Description: "is required"}})
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("app.order.id", req.GetOrderId()))
+
+	var sent int
+	for {
+		updates, changed, final, ok := cs.orderStatuses.since(req.GetOrderId(), sent)
+		if !ok {
+			return rpcerr.Error(ctx, codes.NotFound, fmt.Sprintf("order %s was not submitted", req.GetOrderId()),
+				rpcerr.ErrorInfo(errorDomain, reasonOrderNotSubmitted))
+		}
+		for _, u := range updates {
+			if err := stream.Send(u); err != nil {
+				return err
+			}
+		}
+		sent += len(updates)
+		if final {
+			return nil
+		}
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
+)
+
+func TestOrderQueue(t *testing.T) {
+	q := newOrderQueue(2)
+	for i, want := range []bool{true, true, false} {
+		if got := q.push(&submittedOrder{id: string(rune('a' + i))}); got != want {
+			t.Errorf("push() #%d = %t, want %t", i, got, want)
+		}
+	}
+
+	var mu sync.Mutex
+	var processed []string
+	q.start(2, func(o *submittedOrder) {
+		mu.Lock()
+		defer mu.Unlock()
+		processed = append(processed, o.id)
+	})
+	if err := q.close(context.Background()); err != nil {
+		t.Fatalf("close() error = %v", err)
+	}
+	if len(processed) != 2 {
+		t.Errorf("processed %v, want the 2 queued orders", processed)
+	}
+	if q.push(&submittedOrder{id: "d"}) {
+		t.Error("push() after close = true")
+	}
+	// Closing twice is a no-op.
+	if err := q.close(context.Background()); err != nil {
+		t.Errorf("second close() error = %v", err)
+	}
+}
+
+// fakeWatchStream is a WatchOrder stream that hands the updates sent to it
+// to the test.
+type fakeWatchStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *pb.OrderStatusUpdate
+}
+
+func (s *fakeWatchStream) Context() context.Context { return s.ctx }
+
+func (s *fakeWatchStream) Send(u *pb.OrderStatusUpdate) error {
+	s.sent <- u
+	return nil
+}
+
+func TestWatchOrder(t *testing.T) {
+	cs := &checkout{orderStatuses: newOrderTracker(time.Minute)}
+	cs.orderStatuses.track("order-1")
+	cs.orderStatuses.update(orderStatusUpdate("order-1", orderStatusProcessing, nil, nil))
+
+	stream := &fakeWatchStream{ctx: context.Background(), sent: make(chan *pb.OrderStatusUpdate, 10)}
+	done := make(chan error)
+	go func() { done <- cs.WatchOrder(&pb.WatchOrderRequest{OrderId: "order-1"}, stream) }()
+
+	// The updates before the call are replayed.
+	for _, want := range []string{orderStatusSubmitted, orderStatusProcessing} {
+		if u := <-stream.sent; u.GetStatus() != want || u.GetUpdatedAt() == 0 {
+			t.Errorf("update = %v, want status %s", u, want)
+		}
+	}
+
+	cs.orderStatuses.update(orderStatusUpdate("order-1", orderStatusOnHold, &pb.OrderResult{OrderId: "order-1"}, nil))
+	if u := <-stream.sent; u.GetStatus() != orderStatusOnHold || u.GetOrder().GetOrderId() != "order-1" {
+		t.Errorf("update = %v, want the order on hold", u)
+	}
+	cs.orderStatuses.update(orderStatusUpdate("order-1", orderStatusFailed, nil,
+		orderError(context.Background(), codes.Unavailable, reasonShippingFailed, errors.New("shipment failed"))))
+	u := <-stream.sent
+	if got, want := []string{u.GetStatus(), u.GetErrorCode(), u.GetErrorReason(), u.GetErrorMessage()},
+		[]string{orderStatusFailed, "Unavailable", reasonShippingFailed, "shipment failed"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("update = %v, want %v", got, want)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("WatchOrder() error = %v", err)
+	}
+
+	// Final statuses are not updated.
+	cs.orderStatuses.update(orderStatusUpdate("order-1", orderStatusPlaced, nil, nil))
+	if updates, _, final, _ := cs.orderStatuses.since("order-1", 0); len(updates) != 4 || !final {
+		t.Errorf("updates = %v, final = %t", updates, final)
+	}
+
+	err := cs.WatchOrder(&pb.WatchOrderRequest{OrderId: "order-2"}, stream)
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("WatchOrder(unknown order) error = %v, want NotFound", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cs.orderStatuses.track("order-3")
+	stream = &fakeWatchStream{ctx: ctx, sent: make(chan *pb.OrderStatusUpdate, 10)}
+	go func() { done <- cs.WatchOrder(&pb.WatchOrderRequest{OrderId: "order-3"}, stream) }()
+	<-stream.sent
+	cancel()
+	if err := <-done; status.Code(err) != codes.Canceled {
+		t.Errorf("WatchOrder() error after cancel = %v, want Canceled", err)
+	}
+}
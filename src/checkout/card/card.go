@@ -0,0 +1,251 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package card validates payment cards and keeps their numbers out of the
+// rest of checkout. Cards are exchanged for tokens in a Vault as soon as a
+// request is validated, so that only the token, brand and last four digits
+// reach logs, spans, Kafka and the payment service, and RedactHook masks
+// any card number that is logged anyway.
+package card
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/rpcerr"
+)
+
+// Brand is the network of a card.
+type Brand string
+
+// Brands detected from card numbers.
+const (
+	Visa       Brand = "visa"
+	Mastercard Brand = "mastercard"
+	Amex       Brand = "amex"
+	Discover   Brand = "discover"
+	Unknown    Brand = "unknown"
+)
+
+// digits returns number without the spaces and dashes it is usually
+// written with, and reports whether only digits remain.
+func digits(number string) (string, bool) {
+	d := strings.NewReplacer(" ", "", "-", "").Replace(number)
+	if d == "" {
+		return "", false
+	}
+	for _, r := range d {
+		if r < '0' || r > '9' {
+			return "", false
+		}
+	}
+	return d, true
+}
+
+// luhn reports whether the digits d have a valid Luhn check digit.
+func luhn(d string) bool {
+	var sum int
+	double := false
+	for i := len(d) - 1; i >= 0; i-- {
+		n := int(d[i] - '0')
+		if double {
+			if n *= 2; n > 9 {
+				n -= 9
+			}
+		}
+		sum += n
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// DetectBrand returns the brand of the card with number from its issuer
+// identification number.
+func DetectBrand(number string) Brand {
+	d, ok := digits(number)
+	if !ok {
+		return Unknown
+	}
+	prefix := func(n int) int {
+		if len(d) < n {
+			return -1
+		}
+		var v int
+		for _, r := range d[:n] {
+			v = v*10 + int(r-'0')
+		}
+		return v
+	}
+	switch {
+	case d[0] == '4':
+		return Visa
+	case prefix(2) >= 51 && prefix(2) <= 55, prefix(4) >= 2221 && prefix(4) <= 2720:
+		return Mastercard
+	case prefix(2) == 34, prefix(2) == 37:
+		return Amex
+	case prefix(4) == 6011, prefix(2) == 65, prefix(3) >= 644 && prefix(3) <= 649:
+		return Discover
+	}
+	return Unknown
+}
+
+// cvvDigits returns the number of digits of the security code of brand.
+func cvvDigits(brand Brand) int {
+	if brand == Amex {
+		return 4
+	}
+	return 3
+}
+
+// Validate returns the invalid fields of card at now, named after the
+// fields of PlaceOrderRequest.
+func Validate(card *pb.CreditCardInfo, now time.Time) []rpcerr.FieldViolation {
+	var violations []rpcerr.FieldViolation
+	invalid := func(field, description string) {
+		violations = append(violations, rpcerr.FieldViolation{Field: "credit_card." + field, Description: description})
+	}
+
+	d, ok := digits(card.GetCreditCardNumber())
+	brand := DetectBrand(d)
+	switch {
+	case !ok || len(d) < 12 || len(d) > 19:
+		invalid("credit_card_number", "must have 12 to 19 digits")
+	case !luhn(d):
+		invalid("credit_card_number", "is not a valid card number")
+	case brand == Unknown:
+		invalid("credit_card_number", "is not a card of a supported brand")
+	}
+
+	month, year := card.GetCreditCardExpirationMonth(), card.GetCreditCardExpirationYear()
+	if month < 1 || month > 12 {
+		invalid("credit_card_expiration_month", "must be between 1 and 12")
+	} else if int(year)*12+int(month) < now.Year()*12+int(now.Month()) {
+		invalid("credit_card_expiration_year", fmt.Sprintf("card expired in %02d/%d", month, year))
+	}
+
+	n := cvvDigits(brand)
+	if cvv := card.GetCreditCardCvv(); cvv < 0 || len(fmt.Sprint(cvv)) > n {
+		invalid("credit_card_cvv", fmt.Sprintf("must have %d digits", n))
+	}
+	return violations
+}
+
+// Card is a tokenized card: what the rest of checkout knows of it.
+type Card struct {
+	Token    string
+	Brand    Brand
+	LastFour string
+	// Fingerprint identifies the card number without revealing it, so that
+	// the orders paid with the same card can be related.
+	Fingerprint     string
+	ExpirationYear  int32
+	ExpirationMonth int32
+}
+
+// String returns the brand and last four digits of c.
+func (c Card) String() string {
+	return fmt.Sprintf("%s ending in %s", c.Brand, c.LastFour)
+}
+
+// PaymentCard returns c as sent to the payment service.
+func (c Card) PaymentCard() *pb.PaymentCard {
+	return &pb.PaymentCard{
+		Token:           c.Token,
+		Brand:           string(c.Brand),
+		LastFour:        c.LastFour,
+		ExpirationYear:  c.ExpirationYear,
+		ExpirationMonth: c.ExpirationMonth,
+	}
+}
+
+// Fingerprint returns an identifier of the card with number that does not
+// reveal it.
+func Fingerprint(number string) string {
+	d, _ := digits(number)
+	sum := sha256.Sum256([]byte(d))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Vault keeps the cards of the orders being placed in memory, by token.
+// Cards are forgotten when their order no longer needs them, or after the
+// TTL of the vault at the latest. It is safe for concurrent use, and prints
+// without the cards it holds.
+type Vault struct {
+	ttl   time.Duration
+	mu    sync.Mutex
+	cards map[string]vaulted
+}
+
+type vaulted struct {
+	card      *pb.CreditCardInfo
+	expiresAt time.Time
+}
+
+// NewVault returns a Vault that keeps cards for at most ttl.
+func NewVault(ttl time.Duration) *Vault {
+	return &Vault{ttl: ttl, cards: make(map[string]vaulted)}
+}
+
+// Tokenize stores info in v and returns its token. info must be valid.
+func (v *Vault) Tokenize(info *pb.CreditCardInfo) Card {
+	d, _ := digits(info.GetCreditCardNumber())
+	token := make([]byte, 16)
+	_, _ = rand.Read(token)
+	c := Card{
+		Token:           "tok_" + hex.EncodeToString(token),
+		Brand:           DetectBrand(d),
+		Fingerprint:     Fingerprint(d),
+		ExpirationYear:  info.GetCreditCardExpirationYear(),
+		ExpirationMonth: info.GetCreditCardExpirationMonth(),
+	}
+	if len(d) >= 4 {
+		c.LastFour = d[len(d)-4:]
+	}
+
+	now := time.Now()
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	for token, e := range v.cards {
+		if now.After(e.expiresAt) {
+			delete(v.cards, token)
+		}
+	}
+	v.cards[c.Token] = vaulted{card: info, expiresAt: now.Add(v.ttl)}
+	return c
+}
+
+// Detokenize returns the card of token, and reports whether v holds it.
+func (v *Vault) Detokenize(token string) (*pb.CreditCardInfo, bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	e, ok := v.cards[token]
+	if !ok || time.Now().After(e.expiresAt) {
+		return nil, false
+	}
+	return e.card, true
+}
+
+// Forget removes the card of token from v.
+func (v *Vault) Forget(token string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	delete(v.cards, token)
+}
+
+// String describes v without the cards it holds.
+func (v *Vault) String() string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return fmt.Sprintf("card.Vault(%d cards)", len(v.cards))
+}
+
+// GoString implements fmt.GoStringer like String, for %#v.
+func (v *Vault) GoString() string {
+	return v.String()
+}
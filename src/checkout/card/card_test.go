@@ -0,0 +1,140 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package card
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
+)
+
+func TestDetectBrand(t *testing.T) {
+	tests := map[string]Brand{
+		"4432-8015-6152-0454": Visa,
+		"5555 5555 5555 4444": Mastercard,
+		"2223003122003222":    Mastercard,
+		"378282246310005":     Amex,
+		"6011111111111117":    Discover,
+		"3530111333300000":    Unknown,
+		"not a number":        Unknown,
+	}
+	for number, want := range tests {
+		if got := DetectBrand(number); got != want {
+			t.Errorf("DetectBrand(%q) = %s, want %s", number, got, want)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC)
+	card := func(number string, cvv, year, month int32) *pb.CreditCardInfo {
+		return &pb.CreditCardInfo{CreditCardNumber: number, CreditCardCvv: cvv, CreditCardExpirationYear: year, CreditCardExpirationMonth: month}
+	}
+	tests := []struct {
+		name string
+		card *pb.CreditCardInfo
+		want []string
+	}{
+		{"valid visa", card("4432-8015-6152-0454", 672, 2039, 1), nil},
+		{"valid amex", card("3782 822463 10005", 1234, 2025, 3), nil},
+		{"expires this month", card("5555555555554444", 123, 2025, 3), nil},
+		{"expired", card("5555555555554444", 123, 2025, 2), []string{"credit_card.credit_card_expiration_year"}},
+		{"bad month", card("5555555555554444", 123, 2030, 13), []string{"credit_card.credit_card_expiration_month"}},
+		{"bad check digit", card("4432-8015-6152-0455", 672, 2039, 1), []string{"credit_card.credit_card_number"}},
+		{"too short", card("4242", 672, 2039, 1), []string{"credit_card.credit_card_number"}},
+		{"letters", card("4432-8015-6152-045x", 672, 2039, 1), []string{"credit_card.credit_card_number"}},
+		{"unknown brand", card("3530111333300000", 672, 2039, 1), []string{"credit_card.credit_card_number"}},
+		{"four digit cvv on visa", card("4432-8015-6152-0454", 6720, 2039, 1), []string{"credit_card.credit_card_cvv"}},
+		{
+			"everything wrong",
+			card("", -1, 2020, 0),
+			[]string{"credit_card.credit_card_number", "credit_card.credit_card_expiration_month", "credit_card.credit_card_cvv"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, v := range Validate(tt.card, now) {
+				got = append(got, v.Field)
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVault(t *testing.T) {
+	info := &pb.CreditCardInfo{CreditCardNumber: "4432-8015-6152-0454", CreditCardExpirationYear: 2039, CreditCardExpirationMonth: 1}
+	v := NewVault(time.Minute)
+
+	c := v.Tokenize(info)
+	if !strings.HasPrefix(c.Token, "tok_") || c.Brand != Visa || c.LastFour != "0454" || c.ExpirationYear != 2039 {
+		t.Errorf("Tokenize() = %+v", c)
+	}
+	if c.Fingerprint != Fingerprint("4432 8015 6152 0454") || c.Fingerprint == Fingerprint("4532-4211-7434-1278") {
+		t.Errorf("Fingerprint = %q", c.Fingerprint)
+	}
+	if other := v.Tokenize(info); other.Token == c.Token {
+		t.Error("Tokenize() returned the same token twice")
+	}
+	if got, ok := v.Detokenize(c.Token); !ok || got != info {
+		t.Errorf("Detokenize() = %v, %t", got, ok)
+	}
+
+	for _, s := range []string{fmt.Sprint(c), fmt.Sprintf("%+v", c), fmt.Sprintf("%+v", v), fmt.Sprintf("%#v", v)} {
+		if strings.Contains(s, "8015") {
+			t.Errorf("printed card number: %s", s)
+		}
+	}
+
+	v.Forget(c.Token)
+	if _, ok := v.Detokenize(c.Token); ok {
+		t.Error("Detokenize() after Forget() = true")
+	}
+	if _, ok := NewVault(-time.Second).Detokenize(NewVault(-time.Second).Tokenize(info).Token); ok {
+		t.Error("Detokenize() of an expired card = true")
+	}
+}
+
+func TestRedact(t *testing.T) {
+	tests := map[string]string{
+		"charging 4432-8015-6152-0454 now":       "charging ************0454 now",
+		"cards 4432801561520454,378282246310005": "cards ************0454,***********0005",
+		"order 1234567890123456 is not a card":   "order 1234567890123456 is not a card",
+		"total 1999.99 for 2 items":              "total 1999.99 for 2 items",
+	}
+	for in, want := range tests {
+		if got := Redact(in); got != want {
+			t.Errorf("Redact(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestRedactHook(t *testing.T) {
+	var out bytes.Buffer
+	log := logrus.New()
+	log.Out = &out
+	log.Formatter = &logrus.JSONFormatter{}
+	log.AddHook(RedactHook{})
+
+	log.WithField("card", "5555 5555 5555 4444").
+		WithError(errors.New("declined 4432-8015-6152-0454")).
+		Infof("charging %s", "378282246310005")
+
+	for _, number := range []string{"5555 5555 5555 4444", "4432-8015-6152-0454", "378282246310005"} {
+		if strings.Contains(out.String(), number) {
+			t.Errorf("log contains %s: %s", number, out.String())
+		}
+	}
+	if !strings.Contains(out.String(), "***********0005") {
+		t.Errorf("log = %s", out.String())
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package confirmation renders the confirmations of placed orders that are
+// sent to customers, and sends them with a Transport: the gRPC or HTTP API
+// of the email service, or a local file for offline runs. A Sender retries
+// the deliveries that fail, with exponential backoff.
+package confirmation
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+
+	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/money"
+)
+
+//go:embed templates
+var templates embed.FS
+
+var funcs = map[string]any{"money": money.Format}
+
+var (
+	htmlTemplate = htmltemplate.Must(htmltemplate.New("confirmation.html").Funcs(funcs).ParseFS(templates, "templates/confirmation.html"))
+	textTemplate = texttemplate.Must(texttemplate.New("confirmation.txt").Funcs(funcs).ParseFS(templates, "templates/confirmation.txt"))
+)
+
+// Message is the confirmation of Order sent to To.
+type Message struct {
+	To      string
+	Subject string
+	HTML    string
+	Text    string
+	Order   *pb.OrderResult
+}
+
+// view is what the templates render.
+type view struct {
+	Order           *pb.OrderResult
+	Lines           []line
+	Subtotal, Total *pb.Money
+}
+
+// line is an item of the order and the amount it costs.
+type line struct {
+	ProductID     string
+	Quantity      int32
+	Price, Amount *pb.Money
+}
+
+// Render returns the confirmation of order to send to email.
+func Render(email string, order *pb.OrderResult) (Message, error) {
+	shipping := order.GetShippingCost()
+	if shipping == nil && len(order.GetItems()) > 0 {
+		shipping = &pb.Money{CurrencyCode: order.GetItems()[0].GetCost().GetCurrencyCode()}
+	}
+	v := view{Order: order, Subtotal: &pb.Money{CurrencyCode: shipping.GetCurrencyCode()}}
+	for _, it := range order.GetItems() {
+		l := line{
+			ProductID: it.GetItem().GetProductId(),
+			Quantity:  it.GetItem().GetQuantity(),
+			Price:     it.GetCost(),
+			Amount:    money.MultiplySlow(it.GetCost(), uint32(it.GetItem().GetQuantity())),
+		}
+		var err error
+		if v.Subtotal, err = money.Sum(v.Subtotal, l.Amount); err != nil {
+			return Message{}, fmt.Errorf("failed to add the amount of %s to the subtotal: %v", l.ProductID, err)
+		}
+		v.Lines = append(v.Lines, l)
+	}
+	var err error
+	if v.Total, err = money.Sum(v.Subtotal, shipping); err != nil {
+		return Message{}, fmt.Errorf("failed to add shipping to the total: %v", err)
+	}
+
+	var html, text bytes.Buffer
+	if err := htmlTemplate.Execute(&html, v); err != nil {
+		return Message{}, fmt.Errorf("failed to render HTML confirmation: %v", err)
+	}
+	if err := textTemplate.Execute(&text, v); err != nil {
+		return Message{}, fmt.Errorf("failed to render plaintext confirmation: %v", err)
+	}
+	return Message{
+		To:      email,
+		Subject: fmt.Sprintf("Your order %s is confirmed", order.GetOrderId()),
+		HTML:    html.String(),
+		Text:    text.String(),
+		Order:   order,
+	}, nil
+}
@@ -0,0 +1,226 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package confirmation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
+)
+
+func eur(units int64, nanos int32) *pb.Money {
+	return &pb.Money{CurrencyCode: "EUR", Units: units, Nanos: nanos}
+}
+
+func testOrder() *pb.OrderResult {
+	return &pb.OrderResult{
+		OrderId:         "order-1",
+		ShippingCost:    eur(8, 490000000),
+		ShippingMethod:  "express",
+		ShippingAddress: &pb.Address{StreetAddress: "1 <Main> St", City: "Berlin", Country: "Germany", ZipCode: "10115"},
+		Items: []*pb.OrderItem{
+			{Item: &pb.CartItem{ProductId: "66VCHSJNUP", Quantity: 1}, Cost: eur(349, 950000000)},
+			{Item: &pb.CartItem{ProductId: "OLJCESPC7Z", Quantity: 3}, Cost: eur(101, 960000000)},
+		},
+		Shipments: []*pb.OrderShipment{
+			{Warehouse: "optics", TrackingId: "track-1", Status: "SHIPPED"},
+			{Warehouse: "books", Status: "FAILED"},
+		},
+		Payments: []*pb.OrderPayment{{Description: "visa ending in 0454", Amount: eur(664, 320000000), Refunded: eur(309, 880000000)}},
+	}
+}
+
+func TestRender(t *testing.T) {
+	m, err := Render("alice@example.com", testOrder())
+	if err != nil {
+		t.Fatalf("Render() error = %v", err)
+	}
+	if m.To != "alice@example.com" || m.Subject != "Your order order-1 is confirmed" {
+		t.Errorf("Render() = %q to %q", m.Subject, m.To)
+	}
+	for _, want := range []string{
+		"order-1",
+		"349.95 EUR", "305.88 EUR",
+		"Subtotal: 655.83 EUR",
+		"Shipping (express): 8.49 EUR",
+		"Total: 664.32 EUR",
+		"tracking ID track-1",
+		"From books: not shipped, its items and shipping were refunded",
+		"visa ending in 0454: 664.32 EUR, 309.88 EUR refunded",
+	} {
+		if !strings.Contains(m.HTML, want) {
+			t.Errorf("HTML confirmation does not contain %q:\n%s", want, m.HTML)
+		}
+		if !strings.Contains(m.Text, want) {
+			t.Errorf("plaintext confirmation does not contain %q:\n%s", want, m.Text)
+		}
+	}
+	if !strings.Contains(m.HTML, "1 &lt;Main&gt; St") || !strings.Contains(m.Text, "1 <Main> St") {
+		t.Errorf("address is not escaped in HTML only:\n%s\n%s", m.HTML, m.Text)
+	}
+}
+
+// fakeTransport fails with the errors in errs, in turn, then succeeds.
+type fakeTransport struct {
+	errs  []error
+	calls int
+}
+
+func (*fakeTransport) Name() string { return "fake" }
+
+func (t *fakeTransport) Send(context.Context, Message) error {
+	t.calls++
+	if t.calls <= len(t.errs) {
+		return t.errs[t.calls-1]
+	}
+	return nil
+}
+
+func TestSender(t *testing.T) {
+	unavailable := errors.New("unavailable")
+	tests := []struct {
+		name         string
+		errs         []error
+		attempts     int
+		wantAttempts int
+		wantErr      bool
+	}{
+		{name: "first attempt", attempts: 3, wantAttempts: 1},
+		{name: "retried", errs: []error{unavailable, unavailable}, attempts: 3, wantAttempts: 3},
+		{name: "out of attempts", errs: []error{unavailable, unavailable, unavailable}, attempts: 3, wantAttempts: 3, wantErr: true},
+		{name: "permanent", errs: []error{Permanent(unavailable)}, attempts: 3, wantAttempts: 1, wantErr: true},
+		{name: "no retries", errs: []error{unavailable}, wantAttempts: 1, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := Sender{Transport: &fakeTransport{errs: tt.errs}, Attempts: tt.attempts, Backoff: time.Millisecond}
+			attempts, err := s.Send(context.Background(), Message{})
+			if attempts != tt.wantAttempts || (err != nil) != tt.wantErr {
+				t.Errorf("Send() = %d, %v, want %d attempts, error %t", attempts, err, tt.wantAttempts, tt.wantErr)
+			}
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s := Sender{Transport: &fakeTransport{errs: []error{unavailable}}, Attempts: 3, Backoff: time.Hour}
+	if attempts, err := s.Send(ctx, Message{}); attempts != 1 || !errors.Is(err, unavailable) {
+		t.Errorf("Send() with a cancelled context = %d, %v", attempts, err)
+	}
+}
+
+// fakeEmailClient fails SendOrderConfirmation with the codes in codes, in
+// turn, and records the requests it receives.
+type fakeEmailClient struct {
+	pb.EmailServiceClient
+	codes    []codes.Code
+	requests []*pb.SendOrderConfirmationRequest
+}
+
+func (c *fakeEmailClient) SendOrderConfirmation(_ context.Context, req *pb.SendOrderConfirmationRequest, _ ...grpc.CallOption) (*pb.Empty, error) {
+	c.requests = append(c.requests, req)
+	if code := c.codes[len(c.requests)-1]; code != codes.OK {
+		return nil, status.Error(code, "failed")
+	}
+	return &pb.Empty{}, nil
+}
+
+func TestGRPCTransport(t *testing.T) {
+	client := &fakeEmailClient{codes: []codes.Code{codes.Unavailable, codes.OK, codes.InvalidArgument}}
+	m, err := Render("alice@example.com", testOrder())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := Sender{Transport: GRPCTransport{Client: client}, Attempts: 3, Backoff: time.Millisecond}
+	if attempts, err := s.Send(context.Background(), m); attempts != 2 || err != nil {
+		t.Fatalf("Send() = %d, %v, want 2 attempts", attempts, err)
+	}
+	req := client.requests[1]
+	if req.Email != m.To || req.Subject != m.Subject || req.HtmlBody != m.HTML || req.TextBody != m.Text || req.Order.GetOrderId() != "order-1" {
+		t.Errorf("request = %v", req)
+	}
+
+	if attempts, err := s.Send(context.Background(), m); attempts != 1 || !IsPermanent(err) {
+		t.Errorf("Send() = %d, %v, want a permanent error", attempts, err)
+	}
+}
+
+func TestHTTPTransport(t *testing.T) {
+	statuses := []int{http.StatusServiceUnavailable, http.StatusOK, http.StatusBadRequest}
+	var bodies []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode request: %v", err)
+		}
+		bodies = append(bodies, body)
+		w.WriteHeader(statuses[len(bodies)-1])
+	}))
+	defer srv.Close()
+
+	m, err := Render("alice@example.com", testOrder())
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := Sender{Transport: HTTPTransport{URL: srv.URL}, Attempts: 3, Backoff: time.Millisecond}
+	if attempts, err := s.Send(context.Background(), m); attempts != 2 || err != nil {
+		t.Fatalf("Send() = %d, %v, want 2 attempts", attempts, err)
+	}
+	if body := bodies[1]; body["email"] != m.To || body["html_body"] != m.HTML || body["text_body"] != m.Text {
+		t.Errorf("request = %v", body)
+	}
+	if order, _ := bodies[1]["order"].(map[string]any); order["order_id"] != "order-1" {
+		t.Errorf("order of request = %v", bodies[1]["order"])
+	}
+
+	if attempts, err := s.Send(context.Background(), m); attempts != 1 || !IsPermanent(err) {
+		t.Errorf("Send() = %d, %v, want a permanent error", attempts, err)
+	}
+}
+
+func TestFileTransport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "confirmations.jsonl")
+	transport := NewFileTransport(path)
+	m, err := Render("alice@example.com", testOrder())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 2; i++ {
+		if err := transport.Send(context.Background(), m); err != nil {
+			t.Fatalf("Send() error = %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("file has %d lines, want 2", len(lines))
+	}
+	var written map[string]any
+	if err := json.Unmarshal([]byte(lines[1]), &written); err != nil {
+		t.Fatal(err)
+	}
+	if written["orderId"] != "order-1" || written["textBody"] != m.Text {
+		t.Errorf("written confirmation = %v", written)
+	}
+
+	if err := NewFileTransport(t.TempDir()).Send(context.Background(), m); !IsPermanent(err) {
+		t.Errorf("Send() to a directory error = %v, want a permanent error", err)
+	}
+}
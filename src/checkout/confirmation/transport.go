@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package confirmation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
+)
+
+// Transport sends confirmations.
+type Transport interface {
+	// Name names the transport, such as "http".
+	Name() string
+	Send(ctx context.Context, m Message) error
+}
+
+// permanentError is a failure to send that retrying does not fix.
+type permanentError struct{ err error }
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent marks err as a failure that a Sender does not retry.
+func Permanent(err error) error { return permanentError{err} }
+
+// IsPermanent reports whether err was marked as Permanent.
+func IsPermanent(err error) bool { return errors.As(err, &permanentError{}) }
+
+// GRPCTransport sends confirmations with the SendOrderConfirmation RPC of
+// the email service. Errors are retried if their code is Unavailable,
+// DeadlineExceeded, ResourceExhausted or Aborted.
+type GRPCTransport struct {
+	Client pb.EmailServiceClient
+}
+
+func (GRPCTransport) Name() string { return "grpc" }
+
+func (t GRPCTransport) Send(ctx context.Context, m Message) error {
+	_, err := t.Client.SendOrderConfirmation(ctx, &pb.SendOrderConfirmationRequest{
+		Email:    m.To,
+		Order:    m.Order,
+		Subject:  m.Subject,
+		HtmlBody: m.HTML,
+		TextBody: m.Text,
+	})
+	switch status.Code(err) {
+	case codes.OK:
+		return nil
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return fmt.Errorf("failed to call SendOrderConfirmation: %v", err)
+	}
+	return Permanent(fmt.Errorf("failed to call SendOrderConfirmation: %v", err))
+}
+
+// HTTPTransport POSTs confirmations to URL as JSON. Errors are retried if
+// the request fails or the status is 429 or 5xx.
+type HTTPTransport struct {
+	URL    string
+	Client *http.Client
+}
+
+func (HTTPTransport) Name() string { return "http" }
+
+func (t HTTPTransport) Send(ctx context.Context, m Message) error {
+	payload, err := json.Marshal(map[string]interface{}{
+		"email":     m.To,
+		"order":     m.Order,
+		"subject":   m.Subject,
+		"html_body": m.HTML,
+		"text_body": m.Text,
+	})
+	if err != nil {
+		return Permanent(fmt.Errorf("failed to marshal confirmation to JSON: %v", err))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.URL, bytes.NewReader(payload))
+	if err != nil {
+		return Permanent(fmt.Errorf("failed to create request: %v", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := t.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed POST to %s: %v", t.URL, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode/100 == 2:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode/100 == 5:
+		return fmt.Errorf("failed POST to %s: got %d", t.URL, resp.StatusCode)
+	}
+	return Permanent(fmt.Errorf("failed POST to %s: got %d", t.URL, resp.StatusCode))
+}
+
+// FileTransport appends confirmations to the file at a path, one JSON
+// object per line, instead of sending them.
+type FileTransport struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileTransport returns a transport that appends confirmations to the
+// file at path, which it creates if needed.
+func NewFileTransport(path string) *FileTransport {
+	return &FileTransport{path: path}
+}
+
+func (*FileTransport) Name() string { return "file" }
+
+func (t *FileTransport) Send(_ context.Context, m Message) error {
+	line, err := json.Marshal(map[string]interface{}{
+		"email":     m.To,
+		"orderId":   m.Order.GetOrderId(),
+		"subject":   m.Subject,
+		"htmlBody":  m.HTML,
+		"textBody":  m.Text,
+		"writtenAt": time.Now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return Permanent(fmt.Errorf("failed to marshal confirmation to JSON: %v", err))
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	f, err := os.OpenFile(t.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return Permanent(fmt.Errorf("failed to open %s: %v", t.path, err))
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return Permanent(fmt.Errorf("failed to write to %s: %v", t.path, err))
+	}
+	return f.Close()
+}
+
+// Sender sends confirmations with Transport, in up to Attempts attempts. It
+// waits Backoff before the second attempt, and twice as long as the last
+// time before each next one. Permanent errors are not retried.
+type Sender struct {
+	Transport Transport
+	Attempts  int
+	Backoff   time.Duration
+}
+
+// Send sends m, and returns the number of attempts it made and the error of
+// the last one.
+func (s Sender) Send(ctx context.Context, m Message) (int, error) {
+	wait := s.Backoff
+	for attempt := 1; ; attempt++ {
+		err := s.Transport.Send(ctx, m)
+		if err == nil || IsPermanent(err) || attempt >= s.Attempts {
+			return attempt, err
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return attempt, fmt.Errorf("%w (gave up retrying: %v)", err, ctx.Err())
+		}
+		wait *= 2
+	}
+}
@@ -0,0 +1,204 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/card"
+	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/money"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/policy"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/risk"
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/rpcerr"
+)
+
+// errorDomain is the ErrorInfo domain of the errors returned by checkout.
+const errorDomain = "checkout.oteldemo"
+
+// ErrorInfo reasons of the errors returned by checkout.
+const (
+	reasonInvalidRequest    = "INVALID_REQUEST"
+	reasonOrderIDFailed     = "ORDER_ID_FAILED"
+	reasonPreparationFailed = "ORDER_PREPARATION_FAILED"
+	reasonPolicyViolated    = "ORDER_POLICY_VIOLATED"
+	reasonRiskDenied        = "RISK_DENIED"
+	reasonPaymentFailed     = "PAYMENT_FAILED"
+	reasonShippingFailed    = "SHIPPING_FAILED"
+	reasonOrderNotOnHold    = "ORDER_NOT_ON_HOLD"
+	reasonHoldExpired       = "ORDER_HOLD_EXPIRED"
+	reasonCaptureFailed     = "PAYMENT_CAPTURE_FAILED"
+	reasonPaymentDeclined   = "PAYMENT_INSTRUMENTS_DECLINED"
+	reasonQuoteExpired      = "QUOTE_EXPIRED"
+	reasonQuoteMismatch     = "QUOTE_MISMATCH"
+	reasonQuoteUsed         = "QUOTE_ALREADY_USED"
+	reasonOrderQueueFull    = "ORDER_QUEUE_FULL"
+	reasonOrderNotSubmitted = "ORDER_NOT_SUBMITTED"
+)
+
+// orderError returns the status error of a failed PlaceOrder stage, with an
+// ErrorInfo detail naming the reason.
+func orderError(ctx context.Context, code codes.Code, reason string, err error) error {
+	return rpcerr.Error(ctx, code, err.Error(), rpcerr.ErrorInfo(errorDomain, reason))
+}
+
+// validatePlaceOrderRequest returns the fields of req that are missing or
+// malformed at now.
+func validatePlaceOrderRequest(req *pb.PlaceOrderRequest, now time.Time) []rpcerr.FieldViolation {
+	var violations []rpcerr.FieldViolation
+	if req.GetUserId() == "" {
+		violations = append(violations, rpcerr.FieldViolation{Field: "user_id", Description: "is required"})
+	}
+	if c := req.GetUserCurrency(); len(c) != 3 || strings.ToUpper(c) != c {
+		violations = append(violations, rpcerr.FieldViolation{Field: "user_currency", Description: "must be a three letter ISO 4217 currency code"})
+	}
+	if req.GetEmail() == "" {
+		violations = append(violations, rpcerr.FieldViolation{Field: "email", Description: "is required"})
+	}
+	if req.GetAddress() == nil {
+		violations = append(violations, rpcerr.FieldViolation{Field: "address", Description: "is required"})
+	}
+	violations = append(violations, validateShippingMethod(req.GetShippingMethod())...)
+	instruments := req.GetPaymentInstruments()
+	switch {
+	case req.GetCreditCard() != nil && len(instruments) > 0:
+		violations = append(violations, rpcerr.FieldViolation{Field: "credit_card", Description: "must not be set with payment_instruments"})
+	case req.GetCreditCard() != nil:
+		violations = append(violations, card.Validate(req.GetCreditCard(), now)...)
+	case len(instruments) == 0:
+		violations = append(violations, rpcerr.FieldViolation{Field: "credit_card", Description: "is required without payment_instruments"})
+	}
+	for i, pi := range instruments {
+		violations = append(violations, validatePaymentInstrument(fmt.Sprintf("payment_instruments[%d]", i), pi, req.GetUserCurrency(), now)...)
+	}
+	return violations
+}
+
+// validatePreviewOrderRequest returns the fields of req that are missing or
+// malformed.
+func validatePreviewOrderRequest(req *pb.PreviewOrderRequest) []rpcerr.FieldViolation {
+	var violations []rpcerr.FieldViolation
+	if req.GetUserId() == "" {
+		violations = append(violations, rpcerr.FieldViolation{Field: "user_id", Description: "is required"})
+	}
+	if c := req.GetUserCurrency(); len(c) != 3 || strings.ToUpper(c) != c {
+		violations = append(violations, rpcerr.FieldViolation{Field: "user_currency", Description: "must be a three letter ISO 4217 currency code"})
+	}
+	if req.GetAddress() == nil {
+		violations = append(violations, rpcerr.FieldViolation{Field: "address", Description: "is required"})
+	}
+	return append(violations, validateShippingMethod(req.GetShippingMethod())...)
+}
+
+// validateShippingMethod returns the violation of the shipping_method field
+// of a request set to method, if it is not a shipping method.
+func validateShippingMethod(method string) []rpcerr.FieldViolation {
+	if _, ok := findShippingMethod(method); ok {
+		return nil
+	}
+	return []rpcerr.FieldViolation{{Field: "shipping_method", Description: "must be one of " + shippingMethodNames()}}
+}
+
+// validatePaymentInstrument returns the fields of the payment instrument pi
+// of a request, named field, that are missing or malformed at now.
+func validatePaymentInstrument(field string, pi *pb.PaymentInstrument, currency string, now time.Time) []rpcerr.FieldViolation {
+	var violations []rpcerr.FieldViolation
+	switch in := pi.GetInstrument().(type) {
+	case *pb.PaymentInstrument_CreditCard:
+		for _, v := range card.Validate(in.CreditCard, now) {
+			v.Field = field + "." + v.Field
+			violations = append(violations, v)
+		}
+	case *pb.PaymentInstrument_GiftCard:
+		if in.GiftCard.GetCode() == "" {
+			violations = append(violations, rpcerr.FieldViolation{Field: field + ".gift_card.code", Description: "is required"})
+		}
+	case *pb.PaymentInstrument_StoreCredit:
+	default:
+		violations = append(violations, rpcerr.FieldViolation{Field: field, Description: "must be a credit card, gift card or store credit"})
+	}
+	if m := pi.GetMaxAmount(); m != nil && (!money.IsValid(m) || money.IsNegative(m) || m.GetCurrencyCode() != currency) {
+		violations = append(violations, rpcerr.FieldViolation{Field: field + ".max_amount", Description: "must be a non-negative amount in the user currency"})
+	}
+	return violations
+}
+
+// invalidRequestError returns the error of a request with violations.
+func invalidRequestError(ctx context.Context, violations []rpcerr.FieldViolation) error {
+	fields := make([]string, len(violations))
+	for i, v := range violations {
+		fields[i] = v.Field
+	}
+	return rpcerr.Error(ctx, codes.InvalidArgument,
+		fmt.Sprintf("invalid order: %s", strings.Join(fields, ", ")),
+		rpcerr.ErrorInfo(errorDomain, reasonInvalidRequest),
+		rpcerr.BadRequest(violations...),
+	)
+}
+
+// riskDeniedError returns the error of an order denied for its fraud risk.
+// The signals are named but not described, so as not to tell fraudsters
+// which rules they tripped.
+func riskDeniedError(ctx context.Context, assessment risk.Assessment) error {
+	return rpcerr.Error(ctx, codes.FailedPrecondition, "Order was declined by fraud screening",
+		rpcerr.ErrorInfo(errorDomain, reasonRiskDenied,
+			"risk_score", strconv.FormatFloat(assessment.Score, 'f', 0, 64),
+			"signals", strings.Join(assessment.SignalNames(), ","),
+		),
+	)
+}
+
+// paymentDeclinedError returns the error of an order that its payment
+// instruments cannot pay for, for the reasons in violations.
+func paymentDeclinedError(ctx context.Context, violations []rpcerr.PreconditionViolation) error {
+	msgs := make([]string, len(violations))
+	for i, v := range violations {
+		msgs[i] = v.Description
+	}
+	return rpcerr.Error(ctx, codes.FailedPrecondition,
+		fmt.Sprintf("Payment instruments cannot pay for the order: %s", strings.Join(msgs, "; ")),
+		rpcerr.ErrorInfo(errorDomain, reasonPaymentDeclined),
+		rpcerr.PreconditionFailure(violations...),
+	)
+}
+
+// policyError is returned for orders that break rules of the order policy.
+type policyError struct {
+	violations []policy.Violation
+	// hold is set if the policy places the order on hold rather than
+	// rejecting it.
+	hold bool
+}
+
+func (e *policyError) Error() string {
+	msgs := make([]string, len(e.violations))
+	for i, v := range e.violations {
+		msgs[i] = v.Description
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// status returns the status error of e, which lists the violations as
+// precondition violations typed by rule.
+func (e *policyError) status(ctx context.Context) error {
+	violations := make([]rpcerr.PreconditionViolation, len(e.violations))
+	var rules []string
+	for i, v := range e.violations {
+		violations[i] = rpcerr.PreconditionViolation{Type: v.Rule, Subject: v.Subject, Description: v.Description}
+		if !slices.Contains(rules, v.Rule) {
+			rules = append(rules, v.Rule)
+		}
+	}
+	return rpcerr.Error(ctx, codes.FailedPrecondition,
+		fmt.Sprintf("Order violates checkout policy: %v", e),
+		rpcerr.ErrorInfo(errorDomain, reasonPolicyViolated, "rules", strings.Join(rules, ",")),
+		rpcerr.PreconditionFailure(violations...),
+	)
+}
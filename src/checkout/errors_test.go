@@ -0,0 +1,111 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/policy"
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/rpcerr"
+)
+
+func TestValidatePlaceOrderRequest(t *testing.T) {
+	valid := &pb.PlaceOrderRequest{
+		UserId:       "user-1",
+		UserCurrency: "USD",
+		Email:        "someone@example.com",
+		Address:      &pb.Address{},
+		CreditCard: &pb.CreditCardInfo{
+			CreditCardNumber:          "4432-8015-6152-0454",
+			CreditCardCvv:             672,
+			CreditCardExpirationYear:  2039,
+			CreditCardExpirationMonth: 1,
+		},
+	}
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	if v := validatePlaceOrderRequest(valid, now); len(v) != 0 {
+		t.Errorf("valid request: violations = %+v", v)
+	}
+
+	err := invalidRequestError(context.Background(), validatePlaceOrderRequest(&pb.PlaceOrderRequest{UserCurrency: "usd"}, now))
+	d, ok := rpcerr.Decode(err)
+	if !ok || d.Code != codes.InvalidArgument || d.Reason != reasonInvalidRequest || d.Domain != errorDomain {
+		t.Fatalf("Decode() = %+v, %t", d, ok)
+	}
+	var fields []string
+	for _, v := range d.FieldViolations {
+		fields = append(fields, v.Field)
+	}
+	if got := strings.Join(fields, ","); got != "user_id,user_currency,email,address,credit_card" {
+		t.Errorf("field violations = %s", got)
+	}
+}
+
+func TestValidatePaymentInstruments(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	req := &pb.PlaceOrderRequest{
+		UserId:       "user-1",
+		UserCurrency: "USD",
+		Email:        "someone@example.com",
+		Address:      &pb.Address{},
+		PaymentInstruments: []*pb.PaymentInstrument{
+			{Instrument: &pb.PaymentInstrument_GiftCard{GiftCard: &pb.GiftCard{Code: "GC-1"}}, MaxAmount: &pb.Money{CurrencyCode: "USD", Units: 10}},
+			{Instrument: &pb.PaymentInstrument_StoreCredit{StoreCredit: &pb.StoreCredit{}}},
+			{Instrument: &pb.PaymentInstrument_CreditCard{CreditCard: &pb.CreditCardInfo{
+				CreditCardNumber: "4432-8015-6152-0454", CreditCardCvv: 672, CreditCardExpirationYear: 2039, CreditCardExpirationMonth: 1,
+			}}},
+		},
+	}
+	if v := validatePlaceOrderRequest(req, now); len(v) != 0 {
+		t.Errorf("valid request: violations = %+v", v)
+	}
+
+	req.PaymentInstruments = []*pb.PaymentInstrument{
+		{Instrument: &pb.PaymentInstrument_GiftCard{GiftCard: &pb.GiftCard{}}, MaxAmount: &pb.Money{CurrencyCode: "EUR", Units: 10}},
+		{},
+		{Instrument: &pb.PaymentInstrument_CreditCard{CreditCard: &pb.CreditCardInfo{
+			CreditCardNumber: "4432-8015-6152-0455", CreditCardCvv: 672, CreditCardExpirationYear: 2039, CreditCardExpirationMonth: 1,
+		}}},
+	}
+	req.CreditCard = &pb.CreditCardInfo{}
+	req.ShippingMethod = "teleport"
+	var fields []string
+	for _, v := range validatePlaceOrderRequest(req, now) {
+		fields = append(fields, v.Field)
+	}
+	want := "shipping_method,credit_card,payment_instruments[0].gift_card.code,payment_instruments[0].max_amount,payment_instruments[1]," +
+		"payment_instruments[2].credit_card.credit_card_number"
+	if got := strings.Join(fields, ","); got != want {
+		t.Errorf("field violations = %s, want %s", got, want)
+	}
+}
+
+func TestPolicyError(t *testing.T) {
+	e := &policyError{violations: []policy.Violation{
+		{Rule: policy.RulePriceLimit, Subject: "66VCHSJNUP", Description: "item 66VCHSJNUP costs $349.95 which exceeds the threshold of $25"},
+		{Rule: policy.RuleBlockedCountry, Subject: "Atlantis", Description: "orders cannot be shipped to Atlantis"},
+		{Rule: policy.RulePriceLimit, Subject: "OLJCESPC7Z", Description: "item OLJCESPC7Z costs $101.96 which exceeds the threshold of $25"},
+	}}
+	d, ok := rpcerr.Decode(e.status(context.Background()))
+	if !ok {
+		t.Fatal("Decode() = false")
+	}
+	if d.Code != codes.FailedPrecondition || d.Reason != reasonPolicyViolated {
+		t.Errorf("Decode() = %+v", d)
+	}
+	if !strings.HasPrefix(d.Message, "Order violates checkout policy: item 66VCHSJNUP costs $349.95 which exceeds the threshold of $25; orders cannot") {
+		t.Errorf("message = %q", d.Message)
+	}
+	if d.Metadata["rules"] != "PRICE_LIMIT,BLOCKED_COUNTRY" {
+		t.Errorf("metadata = %v", d.Metadata)
+	}
+	if len(d.PreconditionViolations) != 3 || d.PreconditionViolations[2].Subject != "OLJCESPC7Z" || d.PreconditionViolations[1].Type != policy.RuleBlockedCountry {
+		t.Errorf("precondition violations = %+v", d.PreconditionViolations)
+	}
+}
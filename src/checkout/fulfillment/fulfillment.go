@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package fulfillment plans the shipments of orders. Warehouses stock the
+// products of some categories, or some products, and every item of an order
+// ships from the first warehouse that stocks it, or from the default
+// warehouse if none does. Each warehouse ships its items of an order in one
+// shipment. The warehouses are configured with a Config, which is read from
+// a JSON or YAML file.
+package fulfillment
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultWarehouse is the default warehouse of the Configs that do not name
+// one.
+const DefaultWarehouse = "main"
+
+// Config configures the warehouses orders ship from. The zero Config ships
+// every order from DefaultWarehouse in one shipment.
+type Config struct {
+	// DefaultWarehouse ships the items no warehouse stocks.
+	DefaultWarehouse string      `json:"defaultWarehouse,omitempty" yaml:"defaultWarehouse,omitempty"`
+	Warehouses       []Warehouse `json:"warehouses,omitempty" yaml:"warehouses,omitempty"`
+}
+
+// Warehouse stocks the products of Categories and the products in
+// ProductIDs.
+type Warehouse struct {
+	Name       string   `json:"name" yaml:"name"`
+	Categories []string `json:"categories,omitempty" yaml:"categories,omitempty"`
+	ProductIDs []string `json:"productIds,omitempty" yaml:"productIds,omitempty"`
+}
+
+// Load reads the config in the file at path, which is parsed as YAML if its
+// extension is .yaml or .yml and as JSON otherwise.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read fulfillment config: %v", err)
+	}
+	var c Config
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &c)
+	default:
+		err = json.Unmarshal(data, &c)
+	}
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to parse fulfillment config %s: %v", path, err)
+	}
+	return c, c.validate()
+}
+
+func (c Config) validate() error {
+	names := make(map[string]bool, len(c.Warehouses))
+	for i, w := range c.Warehouses {
+		switch {
+		case w.Name == "":
+			return fmt.Errorf("warehouse %d has no name", i)
+		case names[w.Name]:
+			return fmt.Errorf("warehouse %q is configured twice", w.Name)
+		case len(w.Categories) == 0 && len(w.ProductIDs) == 0:
+			return fmt.Errorf("warehouse %q stocks no categories or products", w.Name)
+		}
+		names[w.Name] = true
+	}
+	return nil
+}
+
+// Item is a line of an order.
+type Item struct {
+	ProductID  string
+	Categories []string
+}
+
+// Shipment is the part of an order shipped from Warehouse. Items are the
+// indexes of its items in the order.
+type Shipment struct {
+	Warehouse string
+	Items     []int
+}
+
+// Plan groups items into shipments, in the order of their first item.
+func (c Config) Plan(items []Item) []Shipment {
+	var shipments []Shipment
+	index := make(map[string]int)
+	for i, it := range items {
+		w := c.warehouseOf(it)
+		n, ok := index[w]
+		if !ok {
+			n = len(shipments)
+			index[w] = n
+			shipments = append(shipments, Shipment{Warehouse: w})
+		}
+		shipments[n].Items = append(shipments[n].Items, i)
+	}
+	return shipments
+}
+
+// warehouseOf returns the name of the warehouse it ships from.
+func (c Config) warehouseOf(it Item) string {
+	for _, w := range c.Warehouses {
+		if slices.Contains(w.ProductIDs, it.ProductID) {
+			return w.Name
+		}
+		for _, category := range it.Categories {
+			if slices.Contains(w.Categories, category) {
+				return w.Name
+			}
+		}
+	}
+	if c.DefaultWarehouse == "" {
+		return DefaultWarehouse
+	}
+	return c.DefaultWarehouse
+}
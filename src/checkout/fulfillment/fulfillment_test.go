@@ -0,0 +1,112 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package fulfillment
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPlan(t *testing.T) {
+	telescope := Item{ProductID: "66VCHSJNUP", Categories: []string{"telescopes"}}
+	lens := Item{ProductID: "OLJCESPC7Z", Categories: []string{"accessories", "binoculars"}}
+	book := Item{ProductID: "HQTGWGPNH4", Categories: []string{"books"}}
+	flashlight := Item{ProductID: "9SIQT8TOJO", Categories: []string{"flashlights"}}
+
+	warehouses := Config{
+		DefaultWarehouse: "hub",
+		Warehouses: []Warehouse{
+			{Name: "optics", Categories: []string{"telescopes", "binoculars"}},
+			{Name: "books", ProductIDs: []string{"HQTGWGPNH4"}},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		config Config
+		items  []Item
+		want   []Shipment
+	}{
+		{
+			name:  "zero config",
+			items: []Item{telescope, lens, book},
+			want:  []Shipment{{Warehouse: DefaultWarehouse, Items: []int{0, 1, 2}}},
+		},
+		{
+			name:   "no items",
+			config: warehouses,
+		},
+		{
+			name:   "by category and product",
+			config: warehouses,
+			items:  []Item{book, flashlight, telescope, lens},
+			want: []Shipment{
+				{Warehouse: "books", Items: []int{0}},
+				{Warehouse: "hub", Items: []int{1}},
+				{Warehouse: "optics", Items: []int{2, 3}},
+			},
+		},
+		{
+			name: "first warehouse that stocks the item",
+			config: Config{Warehouses: []Warehouse{
+				{Name: "accessories", Categories: []string{"accessories"}},
+				{Name: "optics", Categories: []string{"binoculars"}},
+			}},
+			items: []Item{lens, flashlight},
+			want: []Shipment{
+				{Warehouse: "accessories", Items: []int{0}},
+				{Warehouse: DefaultWarehouse, Items: []int{1}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.Plan(tt.items); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Plan() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	want := Config{
+		DefaultWarehouse: "hub",
+		Warehouses: []Warehouse{
+			{Name: "optics", Categories: []string{"telescopes", "binoculars"}},
+			{Name: "books", ProductIDs: []string{"HQTGWGPNH4"}},
+		},
+	}
+	files := map[string]string{
+		"fulfillment.json": `{"defaultWarehouse": "hub", "warehouses": [{"name": "optics", "categories": ["telescopes", "binoculars"]},
+			{"name": "books", "productIds": ["HQTGWGPNH4"]}]}`,
+		"fulfillment.yaml": "defaultWarehouse: hub\nwarehouses:\n  - name: optics\n    categories: [telescopes, binoculars]\n" +
+			"  - name: books\n    productIds: [HQTGWGPNH4]\n",
+		"duplicate.json":  `{"warehouses": [{"name": "optics", "categories": ["telescopes"]}, {"name": "optics", "categories": ["binoculars"]}]}`,
+		"empty.json":      `{"warehouses": [{"name": "optics"}]}`,
+		"unnamed.json":    `{"warehouses": [{"categories": ["telescopes"]}]}`,
+		"unparsable.yaml": "warehouses: {",
+	}
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, name := range []string{"fulfillment.json", "fulfillment.yaml"} {
+		got, err := Load(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("Load(%s) error = %v", name, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Load(%s) = %+v, want %+v", name, got, want)
+		}
+	}
+	for _, name := range []string{"duplicate.json", "empty.json", "unnamed.json", "unparsable.yaml", "missing.json"} {
+		if _, err := Load(filepath.Join(dir, name)); err == nil {
+			t.Errorf("Load(%s) error = nil", name)
+		}
+	}
+}
@@ -0,0 +1,364 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/money"
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/rpcerr"
+)
+
+// Statuses of the orders returned by checkout.
+const (
+	orderStatusPlaced   = "PLACED"
+	orderStatusOnHold   = "ON_HOLD"
+	orderStatusRejected = "REJECTED"
+	orderStatusExpired  = "EXPIRED"
+)
+
+// defaultHoldTTL is how long orders stay on hold when CHECKOUT_HOLD_TTL is
+// not set.
+const defaultHoldTTL = 24 * time.Hour
+
+// The reversal of the payment of an expired order is retried after
+// expiryRetryDelay, doubled after every failure up to maxExpiryRetryDelay.
+const (
+	expiryRetryDelay    = time.Minute
+	maxExpiryRetryDelay = time.Hour
+)
+
+// heldOrder is an order on hold, whose cards are authorized but not charged,
+// and whose gift cards and store credit are debited.
+type heldOrder struct {
+	*pendingOrder
+	expiresAt time.Time
+	// placed is the span of the PlaceOrder call, which the spans that
+	// resolve the hold link to.
+	placed trace.SpanContext
+	timer  *time.Timer
+	// retryDelay is set once the hold expired and the reversal of the
+	// payment failed, to the delay of the next reversal. An expired order
+	// stays on hold until its payment is reversed, and can then only be
+	// rejected.
+	retryDelay time.Duration
+}
+
+// expired reports whether the hold of o expired.
+func (o *heldOrder) expired() bool {
+	return o.retryDelay > 0
+}
+
+// orderHolds holds the orders awaiting approval in memory, and expires them
+// after their TTL.
+type orderHolds struct {
+	mu     sync.Mutex
+	orders map[string]*heldOrder
+	// expire is called, in its own goroutine, with the orders that are still
+	// on hold when they expire.
+	expire func(*heldOrder)
+}
+
+func newOrderHolds(expire func(*heldOrder)) *orderHolds {
+	return &orderHolds{orders: make(map[string]*heldOrder), expire: expire}
+}
+
+// put holds o until it is taken or expires.
+func (h *orderHolds) put(o *heldOrder) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.orders[o.id] = o
+	o.timer = time.AfterFunc(time.Until(o.expiresAt), func() {
+		if expired, ok := h.take(o.id); ok {
+			h.expire(expired)
+		}
+	})
+}
+
+// drain removes all orders from hold and returns them.
+func (h *orderHolds) drain() []*heldOrder {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	orders := make([]*heldOrder, 0, len(h.orders))
+	for id, o := range h.orders {
+		o.timer.Stop()
+		delete(h.orders, id)
+		orders = append(orders, o)
+	}
+	return orders
+}
+
+// take removes the order with id from hold, and reports whether it was on
+// hold.
+func (h *orderHolds) take(id string) (*heldOrder, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	o, ok := h.orders[id]
+	if !ok {
+		return nil, false
+	}
+	o.timer.Stop()
+	delete(h.orders, id)
+	return o, true
+}
+
+// holdOrder authorizes the payment of an order that violates the order
+// policy or needs a risk review, for reasons, and places it on hold until an
+// approver approves or rejects it.
+func (cs *checkout) holdOrder(ctx context.Context, order *pendingOrder, reasons []string) (*pb.OrderResult, error) {
+	span := trace.SpanFromContext(ctx)
+
+	if err := cs.chargeTenders(ctx, order, true); err != nil {
+		err = orderError(ctx, codes.Internal, reasonPaymentFailed, fmt.Errorf("failed to authorize payment: %+v", err))
+		cs.metrics.orderFailed(ctx, stagePayment, err)
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(cs.holdTTL)
+	order.hold = &pb.OrderHold{Reasons: reasons, ExpiresAt: expiresAt.Unix()}
+	cs.holds.put(&heldOrder{pendingOrder: order, expiresAt: expiresAt, placed: span.SpanContext()})
+	_ = cs.emptyUserCart(ctx, order.req.UserId)
+
+	span.SetAttributes(
+		attribute.String("app.order.id", order.id),
+		attribute.String("app.order.status", orderStatusOnHold),
+		attribute.Float64("app.order.amount", money.ToFloat64(order.total)),
+	)
+	span.AddEvent("held", trace.WithAttributes(
+		attribute.String("failure.reason", strings.Join(reasons, "; ")),
+		attribute.String("app.order.hold.expires_at", expiresAt.Format(time.RFC3339)),
+	))
+	cs.metrics.orderHeld(ctx)
+	log.WithContext(ctx).Infof("order %s is on hold until %s: %s", order.id, expiresAt.Format(time.RFC3339), strings.Join(reasons, "; "))
+
+	if err := cs.notifyApprover(ctx, order); err != nil {
+		log.WithContext(ctx).Warnf("failed to notify the approver of order %s: %+v", order.id, err)
+	}
+	return order.result(orderStatusOnHold), nil
+}
+
+// notifyApprover posts the order on hold to CHECKOUT_APPROVER_WEBHOOK_URL,
+// if set.
+func (cs *checkout) notifyApprover(ctx context.Context, order *pendingOrder) error {
+	if cs.approverWebhookURL == "" {
+		return nil
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"orderId":   order.id,
+		"userId":    order.req.UserId,
+		"email":     order.req.Email,
+		"total":     money.ToFloat64(order.total),
+		"currency":  order.total.GetCurrencyCode(),
+		"reasons":   order.hold.Reasons,
+		"expiresAt": time.Unix(order.hold.ExpiresAt, 0).UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal order to JSON: %+v", err)
+	}
+
+	start := time.Now()
+	resp, err := otelhttp.Post(ctx, cs.approverWebhookURL, "application/json", bytes.NewBuffer(payload))
+	if err != nil {
+		cs.metrics.dependencyCall(ctx, dependencyApprover, start, err)
+		return fmt.Errorf("failed POST to approver webhook: %+v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		err = fmt.Errorf("failed POST to approver webhook: got %d", resp.StatusCode)
+	}
+	cs.metrics.dependencyCall(ctx, dependencyApprover, start, err)
+	return err
+}
+
+// validateHoldDecision returns the missing fields of an approval or a
+// rejection.
+func validateHoldDecision(orderID, approver string) []rpcerr.FieldViolation {
+	var violations []rpcerr.FieldViolation
+	if orderID == "" {
+		violations = append(violations, rpcerr.FieldViolation{Field: "order_id", Description: "is required"})
+	}
+	if approver == "" {
+		violations = append(violations, rpcerr.FieldViolation{Field: "approver", Description: "is required"})
+	}
+	return violations
+}
+
+// takeHeldOrder removes the order with id from hold for the call in ctx,
+// whose span it links to the PlaceOrder span of the order.
+func (cs *checkout) takeHeldOrder(ctx context.Context, id string) (*heldOrder, error) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.String("app.order.id", id))
+	o, ok := cs.holds.take(id)
+	if !ok {
+		return nil, rpcerr.Error(ctx, codes.NotFound, fmt.Sprintf("order %s is not on hold", id),
+			rpcerr.ErrorInfo(errorDomain, reasonOrderNotOnHold))
+	}
+	span.AddLink(trace.Link{SpanContext: o.placed})
+	return o, nil
+}
+
+// ApproveOrder captures the card payments of an order on hold and
+// completes it.
+func (cs *checkout) ApproveOrder(ctx context.Context, req *pb.ApproveOrderRequest) (*pb.ApproveOrderResponse, error) {
+	if violations := validateHoldDecision(req.GetOrderId(), req.GetApprover()); len(violations) > 0 {
+		return nil, invalidRequestError(ctx, violations)
+	}
+	o, err := cs.takeHeldOrder(ctx, req.GetOrderId())
+	if err != nil {
+		return nil, err
+	}
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.String("app.order.approver", req.GetApprover()))
+
+	if o.expired() {
+		cs.holds.put(o)
+		return nil, rpcerr.Error(ctx, codes.FailedPrecondition, fmt.Sprintf("hold of order %s expired, reject it to reverse its payment", o.id),
+			rpcerr.ErrorInfo(errorDomain, reasonHoldExpired))
+	}
+	if o.reversed() {
+		// A previous approval failed to ship the order, and to reverse all of
+		// its payment.
+		cs.holds.put(o)
+		return nil, rpcerr.Error(ctx, codes.FailedPrecondition, fmt.Sprintf("order %s failed to ship, reject it to reverse its payment", o.id),
+			rpcerr.ErrorInfo(errorDomain, reasonShippingFailed))
+	}
+	if err := cs.captureTenders(ctx, o.pendingOrder); err != nil {
+		// Keep the order on hold so that the approval can be retried.
+		cs.holds.put(o)
+		err = orderError(ctx, codes.Unavailable, reasonCaptureFailed, fmt.Errorf("failed to capture payment: %+v", err))
+		cs.metrics.orderFailed(ctx, stagePayment, err)
+		return nil, err
+	}
+
+	o.hold.Approver, o.hold.Comment = req.GetApprover(), req.GetComment()
+	cs.metrics.holdResolved(ctx, orderStatusPlaced)
+	log.WithContext(ctx).Infof("order %s was approved by %s", o.id, req.GetApprover())
+
+	result, err := cs.completeOrder(ctx, o.pendingOrder)
+	if err != nil {
+		// completeOrder reverses the payment of an order that does not ship.
+		// If that fails, the order goes back on hold, so that rejecting it or
+		// letting it expire retries the reversal.
+		if o.charged() {
+			log.WithContext(ctx).Warnf("order %s is back on hold until its payment is reversed", o.id)
+			cs.holds.put(o)
+			return nil, err
+		}
+		cs.releaseQuote(o.quote)
+		cs.orderStatuses.update(orderStatusUpdate(o.id, orderStatusFailed, nil, err))
+		return nil, err
+	}
+	cs.orderStatuses.update(orderStatusUpdate(o.id, orderStatusPlaced, result, nil))
+	return &pb.ApproveOrderResponse{Order: result}, nil
+}
+
+// RejectOrder reverses the payment of an order on hold.
+func (cs *checkout) RejectOrder(ctx context.Context, req *pb.RejectOrderRequest) (*pb.RejectOrderResponse, error) {
+	if violations := validateHoldDecision(req.GetOrderId(), req.GetApprover()); len(violations) > 0 {
+		return nil, invalidRequestError(ctx, violations)
+	}
+	o, err := cs.takeHeldOrder(ctx, req.GetOrderId())
+	if err != nil {
+		return nil, err
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("app.order.approver", req.GetApprover()))
+
+	if err := cs.reverseTenders(ctx, o.pendingOrder); err != nil {
+		// Keep the order on hold so that the rejection can be retried.
+		cs.holds.put(o)
+		return nil, orderError(ctx, codes.Unavailable, reasonPaymentFailed, fmt.Errorf("failed to reverse payment: %+v", err))
+	}
+
+	o.hold.Approver, o.hold.Comment = req.GetApprover(), req.GetReason()
+	cs.releaseQuote(o.quote)
+	cs.metrics.holdResolved(ctx, orderStatusRejected)
+	log.WithContext(ctx).Infof("order %s was rejected by %s: %s", o.id, req.GetApprover(), req.GetReason())
+	result := o.result(orderStatusRejected)
+	cs.orderStatuses.update(orderStatusUpdate(o.id, orderStatusRejected, result, nil))
+	return &pb.RejectOrderResponse{Order: result}, nil
+}
+
+// expireHold reverses the payment of an order whose hold expired. If that
+// fails, the order goes back on hold and the reversal is retried with
+// backoff; the order only expires once its payment is reversed.
+func (cs *checkout) expireHold(o *heldOrder) {
+	ctx, span := tracer.Start(context.Background(), "expireOrderHold",
+		trace.WithLinks(trace.Link{SpanContext: o.placed}),
+		trace.WithAttributes(attribute.String("app.order.id", o.id)),
+	)
+	defer span.End()
+
+	if err := cs.reverseTenders(ctx, o.pendingOrder); err != nil {
+		if o.retryDelay == 0 {
+			o.retryDelay = expiryRetryDelay
+		} else {
+			o.retryDelay = min(2*o.retryDelay, maxExpiryRetryDelay)
+		}
+		o.expiresAt = time.Now().Add(o.retryDelay)
+		cs.holds.put(o)
+		span.SetStatus(otelcodes.Error, err.Error())
+		log.WithContext(ctx).Warnf("failed to reverse the payment of expired order %s, retrying in %s: %+v", o.id, o.retryDelay, err)
+		return
+	}
+	cs.expireOrder(ctx, o)
+	log.WithContext(ctx).Infof("hold of order %s expired", o.id)
+}
+
+// expireOrder records that the hold of o, whose payment is reversed,
+// expired.
+func (cs *checkout) expireOrder(ctx context.Context, o *heldOrder) {
+	cs.releaseQuote(o.quote)
+	cs.metrics.holdResolved(ctx, orderStatusExpired)
+	cs.orderStatuses.update(orderStatusUpdate(o.id, orderStatusExpired, o.result(orderStatusExpired), nil))
+}
+
+// releaseHolds reverses the payment of the orders on hold, which are lost
+// when checkout stops, and expires them. It is called on shutdown.
+func (cs *checkout) releaseHolds(ctx context.Context) error {
+	var errs []error
+	for _, o := range cs.holds.drain() {
+		if err := cs.reverseTenders(ctx, o.pendingOrder); err != nil {
+			errs = append(errs, fmt.Errorf("failed to reverse the payment of order %s: %w", o.id, err))
+			continue
+		}
+		cs.expireOrder(ctx, o)
+		log.WithContext(ctx).Infof("hold of order %s was released on shutdown", o.id)
+	}
+	return errors.Join(errs...)
+}
+
+// capturePayment charges the card of the authorization txID.
+func (cs *checkout) capturePayment(ctx context.Context, txID string) error {
+	start := time.Now()
+	_, err := cs.paymentSvcClient.Capture(ctx, &pb.CaptureRequest{TransactionId: txID})
+	cs.metrics.dependencyCall(ctx, dependencyPayment, start, err)
+	return err
+}
+
+// voidPayment releases the authorization txID. Authorizations the payment
+// service does not know of are already released.
+func (cs *checkout) voidPayment(ctx context.Context, txID string) error {
+	start := time.Now()
+	_, err := cs.paymentSvcClient.Void(ctx, &pb.VoidRequest{TransactionId: txID})
+	cs.metrics.dependencyCall(ctx, dependencyPayment, start, err)
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+
+	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/ledger"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/money"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/quote"
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/rpcerr"
+)
+
+func TestOrderHolds(t *testing.T) {
+	expired := make(chan string, 1)
+	holds := newOrderHolds(func(o *heldOrder) { expired <- o.id })
+
+	held := &heldOrder{pendingOrder: &pendingOrder{id: "approved"}, expiresAt: time.Now().Add(time.Hour)}
+	holds.put(held)
+	holds.put(&heldOrder{pendingOrder: &pendingOrder{id: "expired"}, expiresAt: time.Now().Add(10 * time.Millisecond)})
+
+	if o, ok := holds.take("approved"); !ok || o != held {
+		t.Fatalf("take(approved) = %v, %v", o, ok)
+	}
+	if _, ok := holds.take("approved"); ok {
+		t.Error("take(approved) twice = true")
+	}
+
+	select {
+	case id := <-expired:
+		if id != "expired" {
+			t.Errorf("expired %q, want expired", id)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("hold did not expire")
+	}
+	if _, ok := holds.take("expired"); ok {
+		t.Error("take(expired) after expiry = true")
+	}
+
+	// Orders put back on hold keep their expiry.
+	holds.put(held)
+	if o, ok := holds.take("approved"); !ok || o.expiresAt != held.expiresAt {
+		t.Errorf("take(approved) after put = %v, %v", o, ok)
+	}
+}
+
+func TestApproveOrderShippingFails(t *testing.T) {
+	// hold places an order that fails to ship on hold.
+	hold := func(t *testing.T) (*checkout, *fakePayment, *pendingOrder) {
+		cs, payment, order := newShippedOrder(t, "telescope", "book")
+		cs.holds = newOrderHolds(func(*heldOrder) {})
+		if err := cs.chargeTenders(context.Background(), order, true); err != nil {
+			t.Fatalf("chargeTenders() error = %v", err)
+		}
+		order.hold = &pb.OrderHold{Reasons: []string{"order over 50.00"}}
+		cs.holds.put(&heldOrder{pendingOrder: order, expiresAt: time.Now().Add(time.Hour)})
+		return cs, payment, order
+	}
+	approve := &pb.ApproveOrderRequest{OrderId: "order-1", Approver: "bob"}
+
+	t.Run("payment reversed", func(t *testing.T) {
+		cs, payment, _ := hold(t)
+		_, err := cs.ApproveOrder(context.Background(), approve)
+		if d, _ := rpcerr.Decode(err); d.Reason != reasonShippingFailed {
+			t.Fatalf("ApproveOrder() error = %v, want %s", err, reasonShippingFailed)
+		}
+		if balance, _ := cs.ledger.Balance(ledger.GiftCardAccount("GC-1")); !money.AreEquals(balance, usd(30, 0)) {
+			t.Errorf("gift card balance = %v, want it credited back", balance)
+		}
+		if !money.IsZero(payment.collected) {
+			t.Errorf("collected %v, want the capture refunded", payment.collected)
+		}
+		if _, ok := cs.holds.take("order-1"); ok {
+			t.Error("order is still on hold")
+		}
+	})
+
+	t.Run("reversal fails", func(t *testing.T) {
+		cs, payment, _ := hold(t)
+		payment.failRefunds = true
+		if _, err := cs.ApproveOrder(context.Background(), approve); err == nil {
+			t.Fatal("ApproveOrder() succeeded")
+		}
+		// The order is back on hold, and cannot be approved again.
+		_, err := cs.ApproveOrder(context.Background(), approve)
+		if d, _ := rpcerr.Decode(err); d.Reason != reasonShippingFailed {
+			t.Fatalf("ApproveOrder() again error = %v, want %s", err, reasonShippingFailed)
+		}
+
+		// Rejecting it retries the reversal.
+		payment.failRefunds = false
+		if _, err := cs.RejectOrder(context.Background(), &pb.RejectOrderRequest{OrderId: "order-1", Approver: "bob"}); err != nil {
+			t.Fatalf("RejectOrder() error = %v", err)
+		}
+		if !money.IsZero(payment.collected) {
+			t.Errorf("collected %v, want the capture refunded", payment.collected)
+		}
+		if balance, _ := cs.ledger.Balance(ledger.GiftCardAccount("GC-1")); !money.AreEquals(balance, usd(30, 0)) {
+			t.Errorf("gift card balance = %v, want it credited back", balance)
+		}
+	})
+}
+
+// newHeldOrder places an order with a redeemed quote on hold, and tracks its
+// status.
+func newHeldOrder(t *testing.T) (*checkout, *fakePayment, *heldOrder) {
+	// expireHold starts its own span.
+	tracer = otel.Tracer("checkout")
+	cs, payment, order := newShippedOrder(t)
+	cs.holds = newOrderHolds(func(*heldOrder) {})
+	cs.orderStatuses = newOrderTracker(time.Minute)
+	cs.orderStatuses.track(order.id)
+	cs.quoteRedemptions = quote.NewRedemptions()
+	order.quote = quote.Quote{ID: "quote-1", ExpiresAt: time.Now().Add(time.Hour).Unix()}
+	cs.quoteRedemptions.Redeem(order.quote, time.Now())
+	if err := cs.chargeTenders(context.Background(), order, true); err != nil {
+		t.Fatalf("chargeTenders() error = %v", err)
+	}
+	order.hold = &pb.OrderHold{Reasons: []string{"order over 50.00"}}
+	held := &heldOrder{pendingOrder: order, expiresAt: time.Now().Add(time.Hour)}
+	cs.holds.put(held)
+	return cs, payment, held
+}
+
+// checkReversed fails t unless the payment of the held order of cs was
+// reversed, its quote released, and its last status is want.
+func checkReversed(t *testing.T, cs *checkout, payment *fakePayment, want string) {
+	t.Helper()
+	if balance, _ := cs.ledger.Balance(ledger.GiftCardAccount("GC-1")); !money.AreEquals(balance, usd(30, 0)) {
+		t.Errorf("gift card balance = %v, want it credited back", balance)
+	}
+	if len(payment.authorized) != 0 {
+		t.Errorf("authorized %v, want the authorization voided", payment.authorized)
+	}
+	if !cs.quoteRedemptions.Redeem(quote.Quote{ID: "quote-1", ExpiresAt: time.Now().Add(time.Hour).Unix()}, time.Now()) {
+		t.Error("the quote of the order was not released")
+	}
+	updates, _, _, _ := cs.orderStatuses.since("order-1", 0)
+	if got := updates[len(updates)-1].Status; got != want {
+		t.Errorf("status = %s, want %s", got, want)
+	}
+}
+
+func TestExpireHold(t *testing.T) {
+	cs, payment, held := newHeldOrder(t)
+	ctx := context.Background()
+	cs.holds.take(held.id)
+
+	// While the authorization cannot be voided, the order stays on hold and
+	// the reversal is retried with backoff.
+	payment.failVoids = true
+	for _, delay := range []time.Duration{expiryRetryDelay, 2 * expiryRetryDelay} {
+		cs.expireHold(held)
+		o, ok := cs.holds.take(held.id)
+		if !ok {
+			t.Fatal("expired order is not back on hold")
+		}
+		if o.retryDelay != delay || time.Until(o.expiresAt) > delay {
+			t.Errorf("retry in %s, want %s", time.Until(o.expiresAt), delay)
+		}
+	}
+	if updates, _, _, _ := cs.orderStatuses.since(held.id, 0); len(updates) != 1 {
+		t.Errorf("status updates = %v, want none before the payment is reversed", updates)
+	}
+
+	// The order can no longer be approved.
+	cs.holds.put(held)
+	_, err := cs.ApproveOrder(ctx, &pb.ApproveOrderRequest{OrderId: held.id, Approver: "bob"})
+	if d, _ := rpcerr.Decode(err); d.Reason != reasonHoldExpired {
+		t.Fatalf("ApproveOrder() error = %v, want %s", err, reasonHoldExpired)
+	}
+	cs.holds.take(held.id)
+
+	payment.failVoids = false
+	cs.expireHold(held)
+	if _, ok := cs.holds.take(held.id); ok {
+		t.Error("order is still on hold")
+	}
+	checkReversed(t, cs, payment, orderStatusExpired)
+}
+
+func TestRejectOrderReleasesQuote(t *testing.T) {
+	cs, payment, held := newHeldOrder(t)
+	if _, err := cs.RejectOrder(context.Background(), &pb.RejectOrderRequest{OrderId: held.id, Approver: "bob"}); err != nil {
+		t.Fatalf("RejectOrder() error = %v", err)
+	}
+	checkReversed(t, cs, payment, orderStatusRejected)
+}
+
+func TestReleaseHolds(t *testing.T) {
+	cs, payment, held := newHeldOrder(t)
+
+	payment.failVoids = true
+	if err := cs.releaseHolds(context.Background()); err == nil {
+		t.Fatal("releaseHolds() error = nil, want the failed void")
+	}
+
+	payment.failVoids = false
+	cs.holds.put(held)
+	if err := cs.releaseHolds(context.Background()); err != nil {
+		t.Fatalf("releaseHolds() error = %v", err)
+	}
+	if _, ok := cs.holds.take(held.id); ok {
+		t.Error("order is still on hold")
+	}
+	checkReversed(t, cs, payment, orderStatusExpired)
+}
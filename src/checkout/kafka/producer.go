@@ -3,6 +3,11 @@
 package kafka
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
 	"github.com/IBM/sarama"
 	"github.com/sirupsen/logrus"
 )
@@ -12,7 +17,23 @@ var (
 	ProtocolVersion = sarama.V3_0_0_0
 )
 
-func CreateKafkaProducer(brokers []string, log *logrus.Logger) (sarama.AsyncProducer, error) {
+// Producer is an async producer and the client it publishes with, whose
+// broker connections tell whether Kafka can be reached.
+type Producer struct {
+	sarama.AsyncProducer
+	client sarama.Client
+
+	mu      sync.Mutex
+	refresh *refresh // the metadata refresh in flight, if any
+}
+
+// refresh is a metadata refresh, whose err is set once done is closed.
+type refresh struct {
+	done chan struct{}
+	err  error
+}
+
+func CreateKafkaProducer(brokers []string, log *logrus.Logger) (*Producer, error) {
 	sarama.Logger = log
 
 	saramaConfig := sarama.NewConfig()
@@ -28,10 +49,15 @@ func CreateKafkaProducer(brokers []string, log *logrus.Logger) (sarama.AsyncProd
 	// So we can know the partition and offset of messages.
 	saramaConfig.Producer.Return.Successes = true
 
-	producer, err := sarama.NewAsyncProducer(brokers, saramaConfig)
+	client, err := sarama.NewClient(brokers, saramaConfig)
 	if err != nil {
 		return nil, err
 	}
+	producer, err := sarama.NewAsyncProducerFromClient(client)
+	if err != nil {
+		_ = client.Close()
+		return nil, err
+	}
 
 	// We will log to STDOUT if we're not able to produce messages.
 	go func() {
@@ -39,5 +65,58 @@ func CreateKafkaProducer(brokers []string, log *logrus.Logger) (sarama.AsyncProd
 			log.Errorf("Failed to write message: %+v", err)
 		}
 	}()
-	return producer, nil
+	return &Producer{AsyncProducer: producer, client: client}, nil
+}
+
+// Close flushes the messages still buffered in the producer, then closes
+// its client.
+func (p *Producer) Close() error {
+	return errors.Join(p.AsyncProducer.Close(), p.client.Close())
+}
+
+// Check returns an error unless the producer can reach a broker. It is used
+// as a readiness check, so it only dials Kafka while none of the broker
+// connections of the client is open: the producer closes the connections
+// that fail to publish, and a metadata refresh opens one that later checks
+// reuse. The refresh does not take a context, so Check stops waiting for it
+// when ctx is done and later checks wait for the same refresh instead of
+// starting another.
+func (p *Producer) Check(ctx context.Context) error {
+	if p.client.Closed() {
+		return errors.New("kafka client is closed")
+	}
+	for _, b := range p.client.Brokers() {
+		if connected, _ := b.Connected(); connected {
+			return nil
+		}
+	}
+	r := p.refreshMetadata()
+	select {
+	case <-r.done:
+		if r.err != nil {
+			return fmt.Errorf("no kafka broker reachable: %v", r.err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("no kafka broker reachable: %w", ctx.Err())
+	}
+}
+
+// refreshMetadata starts a metadata refresh unless one is in flight, and
+// returns it.
+func (p *Producer) refreshMetadata() *refresh {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.refresh == nil {
+		r := &refresh{done: make(chan struct{})}
+		p.refresh = r
+		go func() {
+			r.err = p.client.RefreshMetadata()
+			p.mu.Lock()
+			p.refresh = nil
+			p.mu.Unlock()
+			close(r.done)
+		}()
+	}
+	return p.refresh
 }
@@ -0,0 +1,176 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ledger keeps the balances of the gift cards and store credit that
+// orders can be paid with. Balances are held in memory, opened from a JSON
+// or YAML file, and every debit can be reversed with a credit of the same
+// amount.
+package ledger
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/money"
+)
+
+var (
+	ErrUnknownAccount    = errors.New("unknown account")
+	ErrInsufficientFunds = errors.New("insufficient funds")
+)
+
+// GiftCardAccount returns the account of the gift card with code.
+func GiftCardAccount(code string) string { return "gift_card:" + code }
+
+// StoreCreditAccount returns the store credit account of the user with id.
+func StoreCreditAccount(userID string) string { return "store_credit:" + userID }
+
+// Balance is the balance of an account in a ledger file.
+type Balance struct {
+	CurrencyCode string `json:"currencyCode" yaml:"currencyCode"`
+	Units        int64  `json:"units" yaml:"units"`
+	Nanos        int32  `json:"nanos" yaml:"nanos"`
+}
+
+// Accounts are the contents of a ledger file.
+type Accounts struct {
+	// GiftCards are the balances of gift cards, by code.
+	GiftCards map[string]Balance `json:"giftCards" yaml:"giftCards"`
+	// StoreCredit are the store credit balances of users, by user ID.
+	StoreCredit map[string]Balance `json:"storeCredit" yaml:"storeCredit"`
+}
+
+// Ledger holds the balances of accounts. It is safe for concurrent use.
+type Ledger struct {
+	mu       sync.Mutex
+	balances map[string]*pb.Money
+}
+
+// New returns a Ledger without accounts.
+func New() *Ledger {
+	return &Ledger{balances: make(map[string]*pb.Money)}
+}
+
+// Load returns a Ledger with the accounts of the JSON or YAML file at path,
+// which is parsed as YAML if its extension is .yaml or .yml.
+func Load(path string) (*Ledger, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read ledger: %v", err)
+	}
+	var a Accounts
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &a)
+	default:
+		err = json.Unmarshal(data, &a)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ledger %s: %v", path, err)
+	}
+
+	l := New()
+	open := func(account string, b Balance) error {
+		balance := &pb.Money{CurrencyCode: b.CurrencyCode, Units: b.Units, Nanos: b.Nanos}
+		if len(b.CurrencyCode) != 3 || !money.IsValid(balance) || money.IsNegative(balance) {
+			return fmt.Errorf("invalid balance of %s: %+v", account, b)
+		}
+		l.Open(account, balance)
+		return nil
+	}
+	for code, b := range a.GiftCards {
+		if err := open(GiftCardAccount(code), b); err != nil {
+			return nil, err
+		}
+	}
+	for userID, b := range a.StoreCredit {
+		if err := open(StoreCreditAccount(userID), b); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// Open sets the balance of account, opening it if needed.
+func (l *Ledger) Open(account string, balance *pb.Money) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.balances[account] = clone(balance)
+}
+
+// Len returns the number of accounts in l.
+func (l *Ledger) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return len(l.balances)
+}
+
+// Balance returns the balance of account, and reports whether it is open.
+func (l *Ledger) Balance(account string) (*pb.Money, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.balances[account]
+	if !ok {
+		return nil, false
+	}
+	return clone(b), true
+}
+
+// Debit takes amount from the balance of account, and returns the ID of
+// the transaction.
+func (l *Ledger) Debit(account string, amount *pb.Money) (string, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.balances[account]
+	if !ok {
+		return "", ErrUnknownAccount
+	}
+	if money.IsNegative(amount) {
+		return "", money.ErrInvalidValue
+	}
+	balance, err := money.Sum(b, money.Negate(amount))
+	if err != nil {
+		return "", err
+	}
+	if money.IsNegative(balance) {
+		return "", ErrInsufficientFunds
+	}
+	l.balances[account] = balance
+
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+	return "ldg_" + hex.EncodeToString(id), nil
+}
+
+// Credit adds amount to the balance of account, such as to reverse a debit.
+func (l *Ledger) Credit(account string, amount *pb.Money) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	b, ok := l.balances[account]
+	if !ok {
+		return ErrUnknownAccount
+	}
+	if money.IsNegative(amount) {
+		return money.ErrInvalidValue
+	}
+	balance, err := money.Sum(b, amount)
+	if err != nil {
+		return err
+	}
+	l.balances[account] = balance
+	return nil
+}
+
+func clone(m *pb.Money) *pb.Money {
+	return &pb.Money{CurrencyCode: m.GetCurrencyCode(), Units: m.GetUnits(), Nanos: m.GetNanos()}
+}
@@ -0,0 +1,88 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package ledger
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/money"
+)
+
+func usd(units int64, nanos int32) *pb.Money {
+	return &pb.Money{CurrencyCode: "USD", Units: units, Nanos: nanos}
+}
+
+func TestLedger(t *testing.T) {
+	l := New()
+	account := GiftCardAccount("GC-1")
+	l.Open(account, usd(50, 0))
+
+	steps := []struct {
+		name    string
+		op      func() error
+		want    *pb.Money
+		wantErr error
+	}{
+		{"debit", func() error { _, err := l.Debit(account, usd(20, 250000000)); return err }, usd(29, 750000000), nil},
+		{"debit more than the balance", func() error { _, err := l.Debit(account, usd(30, 0)); return err }, usd(29, 750000000), ErrInsufficientFunds},
+		{"debit the balance", func() error { _, err := l.Debit(account, usd(29, 750000000)); return err }, usd(0, 0), nil},
+		{"credit", func() error { return l.Credit(account, usd(5, 500000000)) }, usd(5, 500000000), nil},
+		{"debit in another currency", func() error {
+			_, err := l.Debit(account, &pb.Money{CurrencyCode: "EUR", Units: 1})
+			return err
+		}, usd(5, 500000000), money.ErrMismatchingCurrency},
+		{"debit a negative amount", func() error { _, err := l.Debit(account, usd(-1, 0)); return err }, usd(5, 500000000), money.ErrInvalidValue},
+	}
+	for _, s := range steps {
+		if err := s.op(); !errors.Is(err, s.wantErr) {
+			t.Errorf("%s: error = %v, want %v", s.name, err, s.wantErr)
+		}
+		if got, _ := l.Balance(account); !money.AreEquals(got, s.want) {
+			t.Errorf("%s: balance = %v, want %v", s.name, got, s.want)
+		}
+	}
+
+	if _, err := l.Debit(StoreCreditAccount("nobody"), usd(1, 0)); err != ErrUnknownAccount {
+		t.Errorf("Debit() of an unknown account error = %v", err)
+	}
+	if _, ok := l.Balance(StoreCreditAccount("nobody")); ok {
+		t.Error("Balance() of an unknown account = true")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	files := map[string]string{
+		"ledger.json": `{"giftCards": {"GC-1": {"currencyCode": "USD", "units": 50}},
+			"storeCredit": {"alice": {"currencyCode": "EUR", "units": 12, "nanos": 500000000}}}`,
+		"ledger.yaml": "giftCards:\n  GC-1: {currencyCode: USD, units: 50}\n" +
+			"storeCredit:\n  alice: {currencyCode: EUR, units: 12, nanos: 500000000}\n",
+	}
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		l, err := Load(path)
+		if err != nil {
+			t.Fatalf("Load(%s) error = %v", name, err)
+		}
+		gift, _ := l.Balance(GiftCardAccount("GC-1"))
+		credit, _ := l.Balance(StoreCreditAccount("alice"))
+		if l.Len() != 2 || !money.AreEquals(gift, usd(50, 0)) || !money.AreEquals(credit, &pb.Money{CurrencyCode: "EUR", Units: 12, Nanos: 500000000}) {
+			t.Errorf("Load(%s) = %d accounts, gift card %v, store credit %v", name, l.Len(), gift, credit)
+		}
+	}
+
+	path := filepath.Join(dir, "negative.json")
+	if err := os.WriteFile(path, []byte(`{"giftCards": {"GC-2": {"currencyCode": "USD", "units": -5}}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("Load() of a negative balance succeeded")
+	}
+}
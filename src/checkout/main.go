@@ -3,16 +3,14 @@
 package main
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
+	"crypto/rand"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
 	"strconv"
 	"strings"
-	"sync"
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
@@ -27,39 +25,51 @@ import (
 	"github.com/sirupsen/logrus"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
-	"go.opentelemetry.io/contrib/instrumentation/runtime"
 	"go.opentelemetry.io/otel"
 	otelcodes "go.opentelemetry.io/otel/codes"
-	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/propagation"
-	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
-	sdkresource "go.opentelemetry.io/otel/sdk/resource"
-	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/card"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/confirmation"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/fulfillment"
 	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
 	"github.com/open-telemetry/opentelemetry-demo/src/checkout/kafka"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/ledger"
 	"github.com/open-telemetry/opentelemetry-demo/src/checkout/money"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/policy"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/quote"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/risk"
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/health"
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/lifecycle"
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/logging"
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/telemetry"
 )
 
 //go:generate go install google.golang.org/protobuf/cmd/protoc-gen-go
 //go:generate go install google.golang.org/grpc/cmd/protoc-gen-go-grpc
 //go:generate protoc --go_out=./ --go-grpc_out=./ --proto_path=../../pb ../../pb/demo.proto
 
+// defaultConfirmationAttempts and confirmationBackoff pace the retries of
+// order confirmations, which are sent before PlaceOrder returns.
+const (
+	defaultConfirmationAttempts = 3
+	confirmationBackoff         = 200 * time.Millisecond
+)
+
+// cardVaultTTL bounds how long the card of an order stays in the vault,
+// should the order not forget it.
+const cardVaultTTL = 5 * time.Minute
+
 var log *logrus.Logger
 var tracer trace.Tracer
-var resource *sdkresource.Resource
-var initResourcesOnce sync.Once
 
 func init() {
 	log = logrus.New()
-	log.Level = logrus.DebugLevel
 	log.Formatter = &logrus.JSONFormatter{
 		FieldMap: logrus.FieldMap{
 			logrus.FieldKeyTime:  "timestamp",
@@ -69,55 +79,9 @@ func init() {
 		TimestampFormat: time.RFC3339Nano,
 	}
 	log.Out = os.Stdout
-}
-
-func initResource() *sdkresource.Resource {
-	initResourcesOnce.Do(func() {
-		extraResources, _ := sdkresource.New(
-			context.Background(),
-			sdkresource.WithOS(),
-			sdkresource.WithProcess(),
-			sdkresource.WithContainer(),
-			sdkresource.WithHost(),
-		)
-		resource, _ = sdkresource.Merge(
-			sdkresource.Default(),
-			extraResources,
-		)
-	})
-	return resource
-}
-
-func initTracerProvider() *sdktrace.TracerProvider {
-	ctx := context.Background()
-
-	exporter, err := otlptracegrpc.New(ctx)
-	if err != nil {
-		log.Fatalf("new otlp trace grpc exporter failed: %v", err)
-	}
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
-		sdktrace.WithResource(initResource()),
-	)
-	otel.SetTracerProvider(tp)
-	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
-	return tp
-}
-
-func initMeterProvider() *sdkmetric.MeterProvider {
-	ctx := context.Background()
-
-	exporter, err := otlpmetricgrpc.New(ctx)
-	if err != nil {
-		log.Fatalf("new otlp metric grpc exporter failed: %v", err)
-	}
-
-	mp := sdkmetric.NewMeterProvider(
-		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)),
-		sdkmetric.WithResource(initResource()),
-	)
-	otel.SetMeterProvider(mp)
-	return mp
+	// Added first, so that card numbers are masked before entries are
+	// exported by the hooks logging.Setup adds.
+	log.AddHook(card.RedactHook{})
 }
 
 type checkout struct {
@@ -129,74 +93,219 @@ type checkout struct {
 	paymentSvcAddr        string
 	kafkaBrokerSvcAddr    string
 	pb.UnimplementedCheckoutServiceServer
-	KafkaProducerClient     sarama.AsyncProducer
+	KafkaProducerClient     *kafka.Producer
 	shippingSvcClient       pb.ShippingServiceClient
 	productCatalogSvcClient pb.ProductCatalogServiceClient
 	cartSvcClient           pb.CartServiceClient
 	currencySvcClient       pb.CurrencyServiceClient
 	emailSvcClient          pb.EmailServiceClient
 	paymentSvcClient        pb.PaymentServiceClient
+	metrics                 *checkoutMetrics
+	// orderPolicy is the policy read from CHECKOUT_POLICY_FILE, which the
+	// checkoutPolicy and checkoutFailureThreshold flags refine per order.
+	orderPolicy policy.Policy
+	// holds are the orders awaiting approval, which expire after holdTTL.
+	holds              *orderHolds
+	holdTTL            time.Duration
+	approverWebhookURL string
+	// riskScorer assesses orders before they are charged, if set.
+	riskScorer risk.Scorer
+	// cards holds the cards of the orders being placed.
+	cards *card.Vault
+	// ledger holds the balances of gift cards and store credit, read from
+	// CHECKOUT_LEDGER_FILE.
+	ledger *ledger.Ledger
+	// quotes signs the quotes of PreviewOrder and verifies those of
+	// PlaceOrder, and quoteRedemptions records the quotes orders were
+	// placed with.
+	quotes           *quote.Signer
+	quoteRedemptions *quote.Redemptions
+	// fulfillment is the config read from CHECKOUT_FULFILLMENT_FILE, which
+	// splits orders into shipments.
+	fulfillment fulfillment.Config
+	// orderQueue holds the orders of SubmitOrder until a worker places them,
+	// and orderStatuses their status updates for WatchOrder.
+	orderQueue    *orderQueue
+	orderStatuses *orderTracker
+	// confirmations sends the order confirmations with the transport of
+	// CHECKOUT_CONFIRMATION_TRANSPORT.
+	confirmations confirmation.Sender
 }
 
 func main() {
 	var port string
 	mustMapEnv(&port, "CHECKOUT_PORT")
 
-	tp := initTracerProvider()
-	defer func() {
-		if err := tp.Shutdown(context.Background()); err != nil {
-			log.Printf("Error shutting down tracer provider: %v", err)
-		}
-	}()
-
-	mp := initMeterProvider()
-	defer func() {
-		if err := mp.Shutdown(context.Background()); err != nil {
-			log.Printf("Error shutting down meter provider: %v", err)
-		}
-	}()
+	lm := lifecycle.New(log, lifecycle.DefaultTimeout)
 
-	err := runtime.Start(runtime.WithMinimumReadMemStatsInterval(time.Second))
+	tel, shutdownTelemetry, err := telemetry.Setup(context.Background(), telemetry.Config{ServiceName: "checkout"})
+	lm.OnShutdown(lifecycle.StageTelemetry, "telemetry", shutdownTelemetry)
 	if err != nil {
 		log.Fatal(err)
 	}
+	if err := logging.Setup(log, "checkout", tel.LoggerProvider, logrus.DebugLevel); err != nil {
+		log.Fatal(err)
+	}
 
 	openfeature.SetProvider(flagd.NewProvider())
 	openfeature.AddHooks(otelhooks.NewTracesHook())
+	lm.OnShutdown(lifecycle.StageClients, "flagd provider", func(context.Context) error {
+		openfeature.Shutdown()
+		return nil
+	})
 
-	tracer = tp.Tracer("checkout")
+	tracer = tel.Tracer("checkout")
 
 	svc := new(checkout)
+	svc.cards = card.NewVault(cardVaultTTL)
+
+	hs := health.NewServer(log, health.DefaultInterval)
+	meter := tel.MeterProvider.Meter("checkout")
+	if err := hs.RegisterMetrics(meter); err != nil {
+		log.Fatal(err)
+	}
+	if svc.metrics, err = newCheckoutMetrics(meter); err != nil {
+		log.Fatal(err)
+	}
+	checkoutService := pb.CheckoutService_ServiceDesc.ServiceName
 
 	mustMapEnv(&svc.shippingSvcAddr, "SHIPPING_ADDR")
 	c := mustCreateClient(svc.shippingSvcAddr)
 	svc.shippingSvcClient = pb.NewShippingServiceClient(c)
-	defer c.Close()
+	hs.AddCheck(checkoutService, "shipping", health.ConnCheck(c))
+	lm.OnShutdownClose("shipping client", c)
 
 	mustMapEnv(&svc.productCatalogSvcAddr, "PRODUCT_CATALOG_ADDR")
 	c = mustCreateClient(svc.productCatalogSvcAddr)
 	svc.productCatalogSvcClient = pb.NewProductCatalogServiceClient(c)
-	defer c.Close()
+	hs.AddCheck(checkoutService, "product-catalog", health.ConnCheck(c))
+	lm.OnShutdownClose("product-catalog client", c)
 
 	mustMapEnv(&svc.cartSvcAddr, "CART_ADDR")
 	c = mustCreateClient(svc.cartSvcAddr)
 	svc.cartSvcClient = pb.NewCartServiceClient(c)
-	defer c.Close()
+	hs.AddCheck(checkoutService, "cart", health.ConnCheck(c))
+	lm.OnShutdownClose("cart client", c)
 
 	mustMapEnv(&svc.currencySvcAddr, "CURRENCY_ADDR")
 	c = mustCreateClient(svc.currencySvcAddr)
 	svc.currencySvcClient = pb.NewCurrencyServiceClient(c)
-	defer c.Close()
-
-	mustMapEnv(&svc.emailSvcAddr, "EMAIL_ADDR")
-	c = mustCreateClient(svc.emailSvcAddr)
-	svc.emailSvcClient = pb.NewEmailServiceClient(c)
-	defer c.Close()
+	hs.AddCheck(checkoutService, "currency", health.ConnCheck(c))
+	lm.OnShutdownClose("currency client", c)
 
 	mustMapEnv(&svc.paymentSvcAddr, "PAYMENT_ADDR")
 	c = mustCreateClient(svc.paymentSvcAddr)
 	svc.paymentSvcClient = pb.NewPaymentServiceClient(c)
-	defer c.Close()
+	hs.AddCheck(checkoutService, "payment", health.ConnCheck(c))
+	lm.OnShutdownClose("payment client", c)
+
+	if path := os.Getenv("CHECKOUT_POLICY_FILE"); path != "" {
+		if svc.orderPolicy, err = policy.Load(path); err != nil {
+			log.Fatal(err)
+		}
+		log.Infof("loaded order policy from %s with %d rules", path, len(svc.orderPolicy.Rules()))
+	}
+
+	if path := os.Getenv("CHECKOUT_FULFILLMENT_FILE"); path != "" {
+		if svc.fulfillment, err = fulfillment.Load(path); err != nil {
+			log.Fatal(err)
+		}
+		log.Infof("loaded fulfillment config from %s with %d warehouses", path, len(svc.fulfillment.Warehouses))
+	}
+
+	svc.confirmations = confirmation.Sender{Attempts: defaultConfirmationAttempts, Backoff: confirmationBackoff}
+	switch transport := os.Getenv("CHECKOUT_CONFIRMATION_TRANSPORT"); transport {
+	case "", "grpc":
+		mustMapEnv(&svc.emailSvcAddr, "EMAIL_GRPC_ADDR")
+		c = mustCreateClient(svc.emailSvcAddr)
+		svc.emailSvcClient = pb.NewEmailServiceClient(c)
+		lm.OnShutdownClose("email client", c)
+		svc.confirmations.Transport = emailTransport{metrics: svc.metrics, Transport: confirmation.GRPCTransport{Client: svc.emailSvcClient}}
+	case "http":
+		mustMapEnv(&svc.emailSvcAddr, "EMAIL_ADDR")
+		svc.confirmations.Transport = emailTransport{metrics: svc.metrics, Transport: confirmation.HTTPTransport{
+			URL:    svc.emailSvcAddr + "/send_order_confirmation",
+			Client: &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)},
+		}}
+	case "file":
+		path := os.Getenv("CHECKOUT_CONFIRMATION_FILE")
+		if path == "" {
+			log.Fatal("CHECKOUT_CONFIRMATION_FILE must be set for the file confirmation transport")
+		}
+		svc.confirmations.Transport = confirmation.NewFileTransport(path)
+	default:
+		log.Fatalf("unknown CHECKOUT_CONFIRMATION_TRANSPORT %q, want \"grpc\", \"http\" or \"file\"", transport)
+	}
+	if v := os.Getenv("CHECKOUT_CONFIRMATION_ATTEMPTS"); v != "" {
+		if svc.confirmations.Attempts, err = strconv.Atoi(v); err != nil || svc.confirmations.Attempts < 1 {
+			log.Fatalf("invalid CHECKOUT_CONFIRMATION_ATTEMPTS %q, want a positive number", v)
+		}
+	}
+
+	svc.ledger = ledger.New()
+	if path := os.Getenv("CHECKOUT_LEDGER_FILE"); path != "" {
+		if svc.ledger, err = ledger.Load(path); err != nil {
+			log.Fatal(err)
+		}
+		log.Infof("loaded %d gift card and store credit accounts from %s", svc.ledger.Len(), path)
+	}
+
+	quoteTTL := defaultQuoteTTL
+	if v := os.Getenv("CHECKOUT_QUOTE_TTL"); v != "" {
+		if quoteTTL, err = time.ParseDuration(v); err != nil {
+			log.Fatalf("invalid CHECKOUT_QUOTE_TTL %q: %v", v, err)
+		}
+	}
+	quoteKey := []byte(os.Getenv("CHECKOUT_QUOTE_KEY"))
+	if len(quoteKey) == 0 {
+		// Quotes then only hold until checkout restarts, and are not
+		// honored by other replicas.
+		quoteKey = make([]byte, 32)
+		if _, err := rand.Read(quoteKey); err != nil {
+			log.Fatal(err)
+		}
+	}
+	svc.quotes = quote.NewSigner(quoteKey, quoteTTL)
+	svc.quoteRedemptions = quote.NewRedemptions()
+
+	svc.holds = newOrderHolds(svc.expireHold)
+	svc.holdTTL = defaultHoldTTL
+	if v := os.Getenv("CHECKOUT_HOLD_TTL"); v != "" {
+		if svc.holdTTL, err = time.ParseDuration(v); err != nil {
+			log.Fatalf("invalid CHECKOUT_HOLD_TTL %q: %v", v, err)
+		}
+	}
+	svc.approverWebhookURL = os.Getenv("CHECKOUT_APPROVER_WEBHOOK_URL")
+
+	switch scorer := os.Getenv("CHECKOUT_RISK_SCORER"); scorer {
+	case "", "off":
+	case "rules":
+		svc.riskScorer = risk.NewRuleScorer(risk.DefaultRules())
+	default:
+		log.Fatalf("unknown CHECKOUT_RISK_SCORER %q, want \"rules\" or \"off\"", scorer)
+	}
+
+	workers, queueSize := defaultOrderWorkers, defaultOrderQueueSize
+	if v := os.Getenv("CHECKOUT_ORDER_WORKERS"); v != "" {
+		if workers, err = strconv.Atoi(v); err != nil || workers < 1 {
+			log.Fatalf("invalid CHECKOUT_ORDER_WORKERS %q, want a positive number", v)
+		}
+	}
+	if v := os.Getenv("CHECKOUT_ORDER_QUEUE_SIZE"); v != "" {
+		if queueSize, err = strconv.Atoi(v); err != nil || queueSize < 0 {
+			log.Fatalf("invalid CHECKOUT_ORDER_QUEUE_SIZE %q, want a number", v)
+		}
+	}
+	svc.orderStatuses = newOrderTracker(orderStatusRetention)
+	svc.orderQueue = newOrderQueue(queueSize)
+	svc.orderQueue.start(workers, svc.processOrder)
+	// Registered before the Kafka producer, so that the orders still queued
+	// are placed before it is closed.
+	lm.OnShutdown(lifecycle.StageFlush, "order queue", svc.orderQueue.close)
+	// Holds are kept in memory, so the payment of the orders still on hold,
+	// including those the queue just placed on hold, is reversed before the
+	// payment client is closed.
+	lm.OnShutdown(lifecycle.StageFlush, "order holds", svc.releaseHolds)
 
 	svc.kafkaBrokerSvcAddr = os.Getenv("KAFKA_ADDR")
 
@@ -205,9 +314,15 @@ func main() {
 		if err != nil {
 			log.Fatal(err)
 		}
+		hs.AddCheck(checkoutService, "kafka", svc.KafkaProducerClient.Check)
+		lm.OnShutdown(lifecycle.StageFlush, "kafka producer", func(context.Context) error {
+			// Close flushes messages still buffered in the async producer.
+			return svc.KafkaProducerClient.Close()
+		})
 	}
 
-	log.Infof("service config: %+v", svc)
+	log.Infof("service config: cart=%s currency=%s email=%s payment=%s product-catalog=%s shipping=%s kafka=%q",
+		svc.cartSvcAddr, svc.currencySvcAddr, svc.emailSvcAddr, svc.paymentSvcAddr, svc.productCatalogSvcAddr, svc.shippingSvcAddr, svc.kafkaBrokerSvcAddr)
 
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
 	if err != nil {
@@ -218,10 +333,13 @@ func main() {
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 	)
 	pb.RegisterCheckoutServiceServer(srv, svc)
-	healthpb.RegisterHealthServer(srv, svc)
+	healthpb.RegisterHealthServer(srv, hs)
+	hs.Start(context.Background())
+	lm.SetHealth(hs)
 	log.Infof("starting to listen on tcp: %q", lis.Addr().String())
-	err = srv.Serve(lis)
-	log.Fatal(err)
+	if err := lm.Serve(context.Background(), srv, lis); err != nil {
+		log.Fatal(err)
+	}
 }
 
 func mustMapEnv(target *string, envKey string) {
@@ -232,21 +350,57 @@ func mustMapEnv(target *string, envKey string) {
 	*target = v
 }
 
-func (cs *checkout) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
-	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
-}
+func (cs *checkout) PlaceOrder(ctx context.Context, req *pb.PlaceOrderRequest) (*pb.PlaceOrderResponse, error) {
+	span := trace.SpanFromContext(ctx)
+	log.WithContext(ctx).Infof("[PlaceOrder] user_id=%q user_currency=%q", req.UserId, req.UserCurrency)
 
-func (cs *checkout) Watch(req *healthpb.HealthCheckRequest, ws healthpb.Health_WatchServer) error {
-	return status.Errorf(codes.Unimplemented, "health check via Watch not implemented")
+	orderID, tenders, err := cs.acceptOrder(ctx, req)
+	if err != nil {
+		span.AddEvent("error", trace.WithAttributes(semconv.ExceptionMessageKey.String(err.Error())))
+		return nil, err
+	}
+	defer cs.forgetCards(tenders)
+
+	result, err := cs.placeOrder(ctx, orderID, req, tenders)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.PlaceOrderResponse{Order: result}, nil
 }
 
-func (cs *checkout) PlaceOrder(ctx context.Context, req *pb.PlaceOrderRequest) (*pb.PlaceOrderResponse, error) {
-	span := trace.SpanFromContext(ctx)
-	span.SetAttributes(
+// acceptOrder validates req, exchanges its cards for tokens, which the
+// caller must forget, and returns the ID of its order.
+func (cs *checkout) acceptOrder(ctx context.Context, req *pb.PlaceOrderRequest) (string, []*tender, error) {
+	trace.SpanFromContext(ctx).SetAttributes(
 		attribute.String("app.user.id", req.UserId),
 		attribute.String("app.user.currency", req.UserCurrency),
 	)
-	log.Infof("[PlaceOrder] user_id=%q user_currency=%q", req.UserId, req.UserCurrency)
+
+	if violations := validatePlaceOrderRequest(req, time.Now()); len(violations) > 0 {
+		err := invalidRequestError(ctx, violations)
+		cs.metrics.orderFailed(ctx, stageValidation, err)
+		return "", nil, err
+	}
+
+	orderID, err := uuid.NewUUID()
+	if err != nil {
+		err = orderError(ctx, codes.Internal, reasonOrderIDFailed, fmt.Errorf("failed to generate order uuid"))
+		cs.metrics.orderFailed(ctx, stagePrepare, err)
+		return "", nil, err
+	}
+
+	// From here on cards are only known by their tokens, and the request no
+	// longer holds their numbers.
+	tenders := cs.tokenizeTenders(req)
+	req.CreditCard, req.PaymentInstruments = nil, nil
+	return orderID.String(), tenders, nil
+}
+
+// placeOrder places the order with orderID of the accepted request req, paid
+// with tenders: it prices, validates and charges the order, then ships it or
+// places it on hold.
+func (cs *checkout) placeOrder(ctx context.Context, orderID string, req *pb.PlaceOrderRequest, tenders []*tender) (*pb.OrderResult, error) {
+	span := trace.SpanFromContext(ctx)
 
 	var err error
 	defer func() {
@@ -255,99 +409,250 @@ func (cs *checkout) PlaceOrder(ctx context.Context, req *pb.PlaceOrderRequest) (
 		}
 	}()
 
-	orderID, err := uuid.NewUUID()
+	prep, err := cs.prepareOrderItemsAndShippingQuoteFromCart(ctx, req.UserId, req.UserCurrency, req.Address, req.ShippingMethod)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to generate order uuid")
+		err = orderError(ctx, codes.Internal, reasonPreparationFailed, err)
+		cs.metrics.orderFailed(ctx, stagePrepare, err)
+		return nil, err
+	}
+	log.WithContext(ctx).Debugf("Order preparation completed: %d items prepared", len(prep.orderItems))
+	span.AddEvent("prepared")
+
+	var q quote.Quote
+	if req.GetQuoteToken() != "" {
+		if q, err = cs.applyQuote(ctx, req, &prep); err != nil {
+			cs.metrics.orderFailed(ctx, stageValidation, err)
+			return nil, err
+		}
+		// A failed order is not paid for, so its quote can be used again.
+		defer func() {
+			if err != nil {
+				cs.releaseQuote(q)
+			}
+		}()
+		span.AddEvent("quote_applied")
 	}
 
-	prep, err := cs.prepareOrderItemsAndShippingQuoteFromCart(ctx, req.UserId, req.UserCurrency, req.Address)
+	total := prep.total(req.UserCurrency)
+	order := &pendingOrder{id: orderID, req: req, tenders: tenders, prep: prep, total: total, quote: q}
+	methods := make([]string, len(tenders))
+	for i, t := range tenders {
+		methods[i] = t.method
+	}
+	span.SetAttributes(attribute.StringSlice("app.payment.methods", methods))
+	if c, ok := order.firstCard(); ok {
+		span.SetAttributes(
+			attribute.String("app.payment.card_type", string(c.Brand)),
+			attribute.String("app.payment.card_last_four", c.LastFour),
+		)
+	}
+
+	declined, err := cs.allocateTenders(ctx, order)
+	if err == nil && len(declined) > 0 {
+		err = paymentDeclinedError(ctx, declined)
+	} else if err != nil {
+		err = orderError(ctx, codes.Internal, reasonPaymentFailed, err)
+	}
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, err.Error())
+		cs.metrics.orderFailed(ctx, stagePayment, err)
+		return nil, err
 	}
-	log.Infof("[DEBUG] Order preparation completed: %d items prepared", len(prep.orderItems))
-	span.AddEvent("prepared")
 
-	// Check for expensive items if feature flag is enabled
-	log.Infof("[DEBUG] About to call checkExpensiveItems with %d items", len(prep.orderItems))
-	if err := cs.checkExpensiveItems(ctx, prep.orderItems); err != nil {
-		span.AddEvent("checkout_failed_expensive_items", trace.WithAttributes(
-			attribute.String("failure.reason", err.Error()),
-		))
+	var holdReasons []string
+	if violated := cs.checkOrderPolicy(ctx, req.Address, prep); violated != nil {
+		if !violated.hold {
+			span.AddEvent("checkout_failed_policy", trace.WithAttributes(
+				attribute.String("failure.reason", violated.Error()),
+			))
+			err = violated.status(ctx)
+			cs.metrics.orderFailed(ctx, stageValidation, err)
+			return nil, err
+		}
+		for _, v := range violated.violations {
+			holdReasons = append(holdReasons, v.Description)
+		}
+	}
 
-		// Create detailed technical error for bug reporting
-		technicalDetails := cs.generateTechnicalErrorDetails(ctx, prep, err)
+	assessment := cs.assessRisk(ctx, order)
+	switch assessment.Decision {
+	case risk.Deny:
+		err = riskDeniedError(ctx, assessment)
+		cs.metrics.orderFailed(ctx, stageRisk, err)
+		return nil, err
+	case risk.Review:
+		for _, s := range assessment.Signals {
+			holdReasons = append(holdReasons, s.Description)
+		}
+	}
 
-		return nil, status.Errorf(codes.FailedPrecondition, "Order contains expensive items: %v\n\nTECHNICAL_DETAILS:%s", err, technicalDetails)
+	if len(holdReasons) > 0 {
+		var result *pb.OrderResult
+		result, err = cs.holdOrder(ctx, order, holdReasons)
+		return result, err
 	}
 
-	total := &pb.Money{CurrencyCode: req.UserCurrency,
-		Units: 0,
-		Nanos: 0}
-	total = money.Must(money.Sum(total, prep.shippingCostLocalized))
-	for _, it := range prep.orderItems {
-		multPrice := money.MultiplySlow(it.Cost, uint32(it.GetItem().GetQuantity()))
-		total = money.Must(money.Sum(total, multPrice))
+	if err = cs.chargeTenders(ctx, order, false); err != nil {
+		err = orderError(ctx, codes.Internal, reasonPaymentFailed, err)
+		cs.metrics.orderFailed(ctx, stagePayment, err)
+		return nil, err
 	}
 
-	txID, err := cs.chargeCard(ctx, total, req.CreditCard)
+	orderResult, err := cs.completeOrder(ctx, order)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to charge card: %+v", err)
+		return nil, err
 	}
-	log.Infof("payment went through (transaction_id: %s)", txID)
-	span.AddEvent("charged",
-		trace.WithAttributes(attribute.String("app.payment.transaction.id", txID)))
+	_ = cs.emptyUserCart(ctx, req.UserId)
+	return orderResult, nil
+}
 
-	shippingTrackingID, err := cs.shipOrder(ctx, req.Address, prep.cartItems)
-	if err != nil {
-		return nil, status.Errorf(codes.Unavailable, "shipping error: %+v", err)
+// pendingOrder is an order that passed validation and is about to be, or
+// has been, paid for.
+type pendingOrder struct {
+	id  string
+	req *pb.PlaceOrderRequest
+	// tenders are the payment instruments of the order, which pay its total
+	// in turn. Cards are only authorized for orders on hold.
+	tenders []*tender
+	prep    orderPrep
+	total   *pb.Money
+	hold    *pb.OrderHold
+	// quote is the quote the order was placed with, if any.
+	quote quote.Quote
+}
+
+// releaseQuote forgets the redemption of q, the quote of an order that is
+// not paid for, so that it can be used again.
+func (cs *checkout) releaseQuote(q quote.Quote) {
+	if q.ID != "" {
+		cs.quoteRedemptions.Release(q)
 	}
-	shippingTrackingAttribute := attribute.String("app.shipping.tracking.id", shippingTrackingID)
-	span.AddEvent("shipped", trace.WithAttributes(shippingTrackingAttribute))
+}
 
-	_ = cs.emptyUserCart(ctx, req.UserId)
+// completeOrder ships the paid order, confirms it to the customer and
+// publishes it. The order is placed as long as one of its shipments ships;
+// the others are returned as failed, and their share of the total is
+// refunded. If no shipment ships, the payment of the order is reversed.
+func (cs *checkout) completeOrder(ctx context.Context, order *pendingOrder) (*pb.OrderResult, error) {
+	span := trace.SpanFromContext(ctx)
+	req, prep := order.req, order.prep
 
-	orderResult := &pb.OrderResult{
-		OrderId:            orderID.String(),
-		ShippingTrackingId: shippingTrackingID,
-		ShippingCost:       prep.shippingCostLocalized,
-		ShippingAddress:    req.Address,
-		Items:              prep.orderItems,
+	shipped := cs.shipShipments(ctx, order)
+	if shipped == 0 && len(prep.shipments) > 0 {
+		failures := make([]string, len(prep.shipments))
+		for i, s := range prep.shipments {
+			failures[i] = s.failure
+		}
+		if err := cs.reverseTenders(ctx, order); err != nil {
+			log.WithContext(ctx).Errorf("failed to reverse the payments of unshipped order %s: %+v", order.id, err)
+		}
+		err := orderError(ctx, codes.Unavailable, reasonShippingFailed, fmt.Errorf("shipping error: %s", strings.Join(failures, "; ")))
+		cs.metrics.orderFailed(ctx, stageShipping, err)
+		return nil, err
+	}
+	if shipped < len(prep.shipments) {
+		refund := &pb.Money{CurrencyCode: order.total.GetCurrencyCode()}
+		for _, s := range prep.shipments {
+			if s.status() == shipmentFailed {
+				refund = money.Must(money.Sum(refund, prep.shipmentAmount(s)))
+			}
+		}
+		if left, err := cs.refundTenders(ctx, order, refund); err != nil {
+			log.WithContext(ctx).Errorf("failed to refund %s of %s for the failed shipments of order %s: %+v",
+				money.Format(left), money.Format(refund), order.id, err)
+		}
 	}
 
-	shippingCostFloat, _ := strconv.ParseFloat(fmt.Sprintf("%d.%02d", prep.shippingCostLocalized.GetUnits(), prep.shippingCostLocalized.GetNanos()/1000000000), 64)
-	totalPriceFloat, _ := strconv.ParseFloat(fmt.Sprintf("%d.%02d", total.GetUnits(), total.GetNanos()/1000000000), 64)
+	orderResult := order.result(orderStatusPlaced)
 
 	span.SetAttributes(
-		attribute.String("app.order.id", orderID.String()),
-		attribute.Float64("app.shipping.amount", shippingCostFloat),
-		attribute.Float64("app.order.amount", totalPriceFloat),
+		attribute.String("app.order.id", order.id),
+		attribute.Float64("app.shipping.amount", money.ToFloat64(prep.shippingCostLocalized)),
+		attribute.Float64("app.order.amount", money.ToFloat64(order.total)),
 		attribute.Int("app.order.items.count", len(prep.orderItems)),
-		shippingTrackingAttribute,
+		attribute.String("app.shipping.tracking.id", orderResult.ShippingTrackingId),
+		attribute.Int("app.shipping.shipments.count", len(prep.shipments)),
+		attribute.Int("app.shipping.shipments.failed", len(prep.shipments)-shipped),
 	)
+	cs.metrics.orderPlaced(ctx, money.ToFloat64(prep.totalUSD), prep.itemCount())
 
-	if err := cs.sendOrderConfirmation(ctx, req.Email, orderResult); err != nil {
-		log.Warnf("failed to send order confirmation to %q: %+v", req.Email, err)
+	orderResult.Confirmation = cs.sendOrderConfirmation(ctx, req.Email, orderResult)
+	if c := orderResult.Confirmation; c.Status == confirmationFailed {
+		log.WithContext(ctx).Warnf("failed to send order confirmation to %q after %d attempts: %s", req.Email, c.Attempts, c.Error)
 	} else {
-		log.Infof("order confirmation email sent to %q", req.Email)
+		log.WithContext(ctx).Infof("order confirmation sent to %q with the %s transport", req.Email, c.Transport)
 	}
 
 	// send to kafka only if kafka broker address is set
 	if cs.kafkaBrokerSvcAddr != "" {
-		log.Infof("sending to postProcessor")
+		log.WithContext(ctx).Infof("sending to postProcessor")
 		cs.sendToPostProcessor(ctx, orderResult)
 	}
+	return orderResult, nil
+}
 
-	resp := &pb.PlaceOrderResponse{Order: orderResult}
-	return resp, nil
+// result returns the OrderResult of the order with status.
+func (o *pendingOrder) result(status string) *pb.OrderResult {
+	shipments, trackingID := o.prep.shipmentResults()
+	return &pb.OrderResult{
+		OrderId:            o.id,
+		ShippingTrackingId: trackingID,
+		ShippingCost:       o.prep.shippingCostLocalized,
+		ShippingAddress:    o.req.Address,
+		Items:              o.prep.orderItems,
+		Status:             status,
+		Hold:               o.hold,
+		Payments:           o.payments(),
+		ShippingMethod:     o.prep.shippingMethod,
+		Shipments:          shipments,
+	}
 }
 
 type orderPrep struct {
-	orderItems            []*pb.OrderItem
-	cartItems             []*pb.CartItem
+	orderItems []*pb.OrderItem
+	cartItems  []*pb.CartItem
+	// products are the catalog entries of orderItems, in the same order.
+	products []*pb.Product
+	// shippingMethod is the shipping option of the order, whose cost is
+	// shippingCostLocalized.
+	shippingMethod        string
+	shippingOptions       []*pb.ShippingOption
 	shippingCostLocalized *pb.Money
+	// shipments split the order by warehouse, and their costs add up to
+	// shippingCostLocalized.
+	shipments []*shipment
+	// totalUSD is the order total including shipping before conversion to
+	// the user currency, so that order values are comparable across users.
+	totalUSD *pb.Money
+}
+
+// subtotal returns the cost of the items in the order, in currency.
+func (p orderPrep) subtotal(currency string) *pb.Money {
+	subtotal := &pb.Money{CurrencyCode: currency}
+	for _, it := range p.orderItems {
+		multPrice := money.MultiplySlow(it.Cost, uint32(it.GetItem().GetQuantity()))
+		subtotal = money.Must(money.Sum(subtotal, multPrice))
+	}
+	return subtotal
 }
 
-func (cs *checkout) prepareOrderItemsAndShippingQuoteFromCart(ctx context.Context, userID, userCurrency string, address *pb.Address) (orderPrep, error) {
+// total returns the total of the order including shipping, in currency.
+func (p orderPrep) total(currency string) *pb.Money {
+	return money.Must(money.Sum(p.subtotal(currency), p.shippingCostLocalized))
+}
+
+// itemCount returns the number of items in the order, counting quantities.
+func (p orderPrep) itemCount() int {
+	var n int
+	for _, ci := range p.cartItems {
+		n += int(ci.GetQuantity())
+	}
+	return n
+}
+
+// prepareOrderItemsAndShippingQuoteFromCart prices the cart of the user and
+// its shipping to address with shippingMethod, a valid method name or "".
+// Every shipment of the order is quoted separately.
+func (cs *checkout) prepareOrderItemsAndShippingQuoteFromCart(ctx context.Context, userID, userCurrency string, address *pb.Address, shippingMethod string) (orderPrep, error) {
 
 	ctx, span := tracer.Start(ctx, "prepareOrderItemsAndShippingQuoteFromCart")
 	defer span.End()
@@ -357,32 +662,36 @@ func (cs *checkout) prepareOrderItemsAndShippingQuoteFromCart(ctx context.Contex
 	if err != nil {
 		return out, fmt.Errorf("cart failure: %+v", err)
 	}
-	orderItems, err := cs.prepOrderItems(ctx, cartItems, userCurrency)
+	orderItems, products, subtotalUSD, err := cs.prepOrderItems(ctx, cartItems, userCurrency)
 	if err != nil {
 		return out, fmt.Errorf("failed to prepare order: %+v", err)
 	}
-	shippingUSD, err := cs.quoteShipping(ctx, address, cartItems)
+	method, _ := findShippingMethod(shippingMethod)
+	shipments, shippingUSD, standardPrice, err := cs.planShipments(ctx, address, cartItems, products, userCurrency, method)
 	if err != nil {
-		return out, fmt.Errorf("shipping quote failure: %+v", err)
+		return out, err
 	}
-	shippingPrice, err := cs.convertCurrency(ctx, shippingUSD, userCurrency)
+	shippingPrice := method.cost(standardPrice)
+
+	totalUSD, err := money.Sum(subtotalUSD, method.cost(shippingUSD))
 	if err != nil {
-		return out, fmt.Errorf("failed to convert shipping cost to currency: %+v", err)
+		return out, fmt.Errorf("failed to compute order total in USD: %+v", err)
 	}
 
+	out.shippingMethod = method.name
+	out.shippingOptions = shippingOptions(standardPrice)
 	out.shippingCostLocalized = shippingPrice
+	out.shipments = shipments
 	out.cartItems = cartItems
 	out.orderItems = orderItems
-
-	var totalCart int32
-	for _, ci := range cartItems {
-		totalCart += ci.Quantity
-	}
-	shippingCostFloat, _ := strconv.ParseFloat(fmt.Sprintf("%d.%02d", shippingPrice.GetUnits(), shippingPrice.GetNanos()/1000000000), 64)
+	out.products = products
+	out.totalUSD = totalUSD
 
 	span.SetAttributes(
-		attribute.Float64("app.shipping.amount", shippingCostFloat),
-		attribute.Int("app.cart.items.count", int(totalCart)),
+		attribute.Float64("app.shipping.amount", money.ToFloat64(shippingPrice)),
+		attribute.String("app.shipping.method", method.name),
+		attribute.Int("app.shipping.shipments.count", len(shipments)),
+		attribute.Int("app.cart.items.count", out.itemCount()),
 		attribute.Int("app.order.items.count", len(orderItems)),
 	)
 	return out, nil
@@ -401,10 +710,12 @@ func mustCreateClient(svcAddr string) *grpc.ClientConn {
 }
 
 func (cs *checkout) quoteShipping(ctx context.Context, address *pb.Address, items []*pb.CartItem) (*pb.Money, error) {
+	start := time.Now()
 	shippingQuote, err := cs.shippingSvcClient.
 		GetQuote(ctx, &pb.GetQuoteRequest{
 			Address: address,
 			Items:   items})
+	cs.metrics.dependencyCall(ctx, dependencyShipping, start, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get shipping quote: %+v", err)
 	}
@@ -412,7 +723,9 @@ func (cs *checkout) quoteShipping(ctx context.Context, address *pb.Address, item
 }
 
 func (cs *checkout) getUserCart(ctx context.Context, userID string) ([]*pb.CartItem, error) {
+	start := time.Now()
 	cart, err := cs.cartSvcClient.GetCart(ctx, &pb.GetCartRequest{UserId: userID})
+	cs.metrics.dependencyCall(ctx, dependencyCart, start, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user cart during checkout: %+v", err)
 	}
@@ -420,42 +733,61 @@ func (cs *checkout) getUserCart(ctx context.Context, userID string) ([]*pb.CartI
 }
 
 func (cs *checkout) emptyUserCart(ctx context.Context, userID string) error {
-	if _, err := cs.cartSvcClient.EmptyCart(ctx, &pb.EmptyCartRequest{UserId: userID}); err != nil {
+	start := time.Now()
+	_, err := cs.cartSvcClient.EmptyCart(ctx, &pb.EmptyCartRequest{UserId: userID})
+	cs.metrics.dependencyCall(ctx, dependencyCart, start, err)
+	if err != nil {
 		return fmt.Errorf("failed to empty user cart during checkout: %+v", err)
 	}
 	return nil
 }
 
-func (cs *checkout) prepOrderItems(ctx context.Context, items []*pb.CartItem, userCurrency string) ([]*pb.OrderItem, error) {
+// prepOrderItems looks up and converts the price of every cart item. It also
+// returns the products of the items and their subtotal in USD.
+func (cs *checkout) prepOrderItems(ctx context.Context, items []*pb.CartItem, userCurrency string) ([]*pb.OrderItem, []*pb.Product, *pb.Money, error) {
 	out := make([]*pb.OrderItem, len(items))
+	products := make([]*pb.Product, len(items))
+	subtotalUSD := &pb.Money{CurrencyCode: "USD"}
 
 	for i, item := range items {
+		start := time.Now()
 		product, err := cs.productCatalogSvcClient.GetProduct(ctx, &pb.GetProductRequest{Id: item.GetProductId()})
+		cs.metrics.dependencyCall(ctx, dependencyProductCatalog, start, err)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get product #%q", item.GetProductId())
+			return nil, nil, nil, fmt.Errorf("failed to get product #%q", item.GetProductId())
 		}
 		price, err := cs.convertCurrency(ctx, product.GetPriceUsd(), userCurrency)
 		if err != nil {
-			return nil, fmt.Errorf("failed to convert price of %q to %s", item.GetProductId(), userCurrency)
+			return nil, nil, nil, fmt.Errorf("failed to convert price of %q to %s", item.GetProductId(), userCurrency)
 		}
 		out[i] = &pb.OrderItem{
 			Item: item,
 			Cost: price}
+		products[i] = product
+
+		subtotalUSD, err = money.Sum(subtotalUSD, money.MultiplySlow(product.GetPriceUsd(), uint32(item.GetQuantity())))
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to add price of %q to the order subtotal: %+v", item.GetProductId(), err)
+		}
 	}
-	return out, nil
+	return out, products, subtotalUSD, nil
 }
 
 func (cs *checkout) convertCurrency(ctx context.Context, from *pb.Money, toCurrency string) (*pb.Money, error) {
+	start := time.Now()
 	result, err := cs.currencySvcClient.Convert(ctx, &pb.CurrencyConversionRequest{
 		From:   from,
 		ToCode: toCurrency})
+	cs.metrics.dependencyCall(ctx, dependencyCurrency, start, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to convert currency: %+v", err)
 	}
 	return result, err
 }
 
-func (cs *checkout) chargeCard(ctx context.Context, amount *pb.Money, paymentInfo *pb.CreditCardInfo) (string, error) {
+// chargeCard charges amount to the tokenized card c, or only authorizes it
+// if authorizeOnly is set.
+func (cs *checkout) chargeCard(ctx context.Context, amount *pb.Money, c card.Card, authorizeOnly bool) (string, error) {
 	paymentService := cs.paymentSvcClient
 	if cs.isFeatureFlagEnabled(ctx, "paymentUnreachable") {
 		badAddress := "badAddress:50051"
@@ -463,41 +795,74 @@ func (cs *checkout) chargeCard(ctx context.Context, amount *pb.Money, paymentInf
 		paymentService = pb.NewPaymentServiceClient(c)
 	}
 
+	start := time.Now()
 	paymentResp, err := paymentService.Charge(ctx, &pb.ChargeRequest{
-		Amount:     amount,
-		CreditCard: paymentInfo})
+		Amount:        amount,
+		Card:          c.PaymentCard(),
+		AuthorizeOnly: authorizeOnly})
+	cs.metrics.dependencyCall(ctx, dependencyPayment, start, err)
 	if err != nil {
 		return "", fmt.Errorf("could not charge the card: %+v", err)
 	}
 	return paymentResp.GetTransactionId(), nil
 }
 
-func (cs *checkout) sendOrderConfirmation(ctx context.Context, email string, order *pb.OrderResult) error {
-	emailPayload, err := json.Marshal(map[string]interface{}{
-		"email": email,
-		"order": order,
-	})
-	if err != nil {
-		return fmt.Errorf("failed to marshal order to JSON: %+v", err)
+// Statuses of the deliveries of order confirmations.
+const (
+	confirmationSent   = "SENT"
+	confirmationFailed = "FAILED"
+)
+
+// sendOrderConfirmation renders the confirmation of order and sends it to
+// email with the confirmation transport, retrying failed attempts. It
+// returns the delivery of the confirmation, which it records as a
+// confirmation_sent or confirmation_failed span event.
+func (cs *checkout) sendOrderConfirmation(ctx context.Context, email string, order *pb.OrderResult) *pb.ConfirmationDelivery {
+	delivery := &pb.ConfirmationDelivery{Transport: cs.confirmations.Transport.Name()}
+	m, err := confirmation.Render(email, order)
+	if err == nil {
+		var attempts int
+		attempts, err = cs.confirmations.Send(ctx, m)
+		delivery.Attempts = int32(attempts)
 	}
 
-	resp, err := otelhttp.Post(ctx, cs.emailSvcAddr+"/send_order_confirmation", "application/json", bytes.NewBuffer(emailPayload))
+	attrs := []attribute.KeyValue{
+		attribute.String("app.confirmation.transport", delivery.Transport),
+		attribute.Int("app.confirmation.attempts", int(delivery.Attempts)),
+	}
 	if err != nil {
-		return fmt.Errorf("failed POST to email service: %+v", err)
+		delivery.Status, delivery.Error = confirmationFailed, err.Error()
+		attrs = append(attrs, attribute.String("failure.reason", delivery.Error))
+		trace.SpanFromContext(ctx).AddEvent("confirmation_failed", trace.WithAttributes(attrs...))
+	} else {
+		delivery.Status, delivery.SentAt = confirmationSent, time.Now().Unix()
+		trace.SpanFromContext(ctx).AddEvent("confirmation_sent", trace.WithAttributes(attrs...))
 	}
-	defer resp.Body.Close()
+	cs.metrics.confirmationDelivered(ctx, delivery.Transport, delivery.Status)
+	return delivery
+}
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed POST to email service: expected 200, got %d", resp.StatusCode)
-	}
+// emailTransport records the calls of a transport to the email service as
+// dependency calls.
+type emailTransport struct {
+	confirmation.Transport
+	metrics *checkoutMetrics
+}
 
+func (t emailTransport) Send(ctx context.Context, m confirmation.Message) error {
+	start := time.Now()
+	err := t.Transport.Send(ctx, m)
+	t.metrics.dependencyCall(ctx, dependencyEmail, start, err)
 	return err
 }
 
-func (cs *checkout) shipOrder(ctx context.Context, address *pb.Address, items []*pb.CartItem) (string, error) {
+func (cs *checkout) shipOrder(ctx context.Context, address *pb.Address, items []*pb.CartItem, method string) (string, error) {
+	start := time.Now()
 	resp, err := cs.shippingSvcClient.ShipOrder(ctx, &pb.ShipOrderRequest{
-		Address: address,
-		Items:   items})
+		Address:        address,
+		Items:          items,
+		ShippingMethod: method})
+	cs.metrics.dependencyCall(ctx, dependencyShipping, start, err)
 	if err != nil {
 		return "", fmt.Errorf("shipment failed: %+v", err)
 	}
@@ -507,7 +872,7 @@ func (cs *checkout) shipOrder(ctx context.Context, address *pb.Address, items []
 func (cs *checkout) sendToPostProcessor(ctx context.Context, result *pb.OrderResult) {
 	message, err := proto.Marshal(result)
 	if err != nil {
-		log.Errorf("Failed to marshal message to protobuf: %+v", err)
+		log.WithContext(ctx).Errorf("Failed to marshal message to protobuf: %+v", err)
 		return
 	}
 
@@ -524,7 +889,7 @@ func (cs *checkout) sendToPostProcessor(ctx context.Context, result *pb.OrderRes
 	startTime := time.Now()
 	select {
 	case cs.KafkaProducerClient.Input() <- &msg:
-		log.Infof("Message sent to Kafka: %v", msg)
+		log.WithContext(ctx).Infof("Message sent to Kafka: %v", msg)
 		select {
 		case successMsg := <-cs.KafkaProducerClient.Successes():
 			span.SetAttributes(
@@ -532,21 +897,24 @@ func (cs *checkout) sendToPostProcessor(ctx context.Context, result *pb.OrderRes
 				attribute.Int("messaging.kafka.producer.duration_ms", int(time.Since(startTime).Milliseconds())),
 				attribute.KeyValue(semconv.MessagingKafkaMessageOffset(int(successMsg.Offset))),
 			)
-			log.Infof("Successful to write message. offset: %v, duration: %v", successMsg.Offset, time.Since(startTime))
+			cs.metrics.kafkaPublished(ctx, publishSuccess)
+			log.WithContext(ctx).Infof("Successful to write message. offset: %v, duration: %v", successMsg.Offset, time.Since(startTime))
 		case errMsg := <-cs.KafkaProducerClient.Errors():
 			span.SetAttributes(
 				attribute.Bool("messaging.kafka.producer.success", false),
 				attribute.Int("messaging.kafka.producer.duration_ms", int(time.Since(startTime).Milliseconds())),
 			)
 			span.SetStatus(otelcodes.Error, errMsg.Err.Error())
-			log.Errorf("Failed to write message: %v", errMsg.Err)
+			cs.metrics.kafkaPublished(ctx, publishError)
+			log.WithContext(ctx).Errorf("Failed to write message: %v", errMsg.Err)
 		case <-ctx.Done():
 			span.SetAttributes(
 				attribute.Bool("messaging.kafka.producer.success", false),
 				attribute.Int("messaging.kafka.producer.duration_ms", int(time.Since(startTime).Milliseconds())),
 			)
 			span.SetStatus(otelcodes.Error, "Context cancelled: "+ctx.Err().Error())
-			log.Warnf("Context canceled before success message received: %v", ctx.Err())
+			cs.metrics.kafkaPublished(ctx, publishTimeout)
+			log.WithContext(ctx).Warnf("Context canceled before success message received: %v", ctx.Err())
 		}
 	case <-ctx.Done():
 		span.SetAttributes(
@@ -554,20 +922,21 @@ func (cs *checkout) sendToPostProcessor(ctx context.Context, result *pb.OrderRes
 			attribute.Int("messaging.kafka.producer.duration_ms", int(time.Since(startTime).Milliseconds())),
 		)
 		span.SetStatus(otelcodes.Error, "Failed to send: "+ctx.Err().Error())
-		log.Errorf("Failed to send message to Kafka within context deadline: %v", ctx.Err())
+		cs.metrics.kafkaPublished(ctx, publishTimeout)
+		log.WithContext(ctx).Errorf("Failed to send message to Kafka within context deadline: %v", ctx.Err())
 		return
 	}
 
 	ffValue := cs.getIntFeatureFlag(ctx, "kafkaQueueProblems")
 	if ffValue > 0 {
-		log.Infof("Warning: FeatureFlag 'kafkaQueueProblems' is activated, overloading queue now.")
+		log.WithContext(ctx).Infof("Warning: FeatureFlag 'kafkaQueueProblems' is activated, overloading queue now.")
 		for i := 0; i < ffValue; i++ {
 			go func(i int) {
 				cs.KafkaProducerClient.Input() <- &msg
 				_ = <-cs.KafkaProducerClient.Successes()
 			}(i)
 		}
-		log.Infof("Done with #%d messages for overload simulation.", ffValue)
+		log.WithContext(ctx).Infof("Done with #%d messages for overload simulation.", ffValue)
 	}
 }
 
@@ -625,152 +994,91 @@ func (cs *checkout) getIntFeatureFlag(ctx context.Context, featureFlagName strin
 	return int(featureFlagValue)
 }
 
-func (cs *checkout) checkExpensiveItems(ctx context.Context, orderItems []*pb.OrderItem) error {
-	// Get the price threshold from feature flag
-	priceThreshold := cs.getIntFeatureFlag(ctx, "checkoutFailureThreshold")
+// policyFor returns the policy an order is validated with: the
+// checkoutPolicy flag object if it is not empty, or the policy of
+// CHECKOUT_POLICY_FILE otherwise, with the checkoutFailureThreshold flag as
+// the price limit of every category.
+func (cs *checkout) policyFor(ctx context.Context) policy.Policy {
+	p := cs.orderPolicy
+	client := openfeature.NewClient("checkout")
+	v, _ := client.ObjectValue(ctx, "checkoutPolicy", nil, openfeature.EvaluationContext{})
+	if obj, ok := v.(map[string]any); ok && len(obj) > 0 {
+		if fp, err := policy.FromObject(obj); err != nil {
+			log.WithContext(ctx).Warnf("Ignoring invalid checkoutPolicy flag: %v", err)
+		} else {
+			p = fp
+		}
+	}
+	if threshold := cs.getIntFeatureFlag(ctx, "checkoutFailureThreshold"); threshold > 0 {
+		p = p.WithPriceLimit(policy.AnyCategory, float64(threshold))
+	}
+	return p
+}
 
-	log.Infof("[DEBUG] checkExpensiveItems called with %d items, threshold: %d", len(orderItems), priceThreshold)
+// checkOrderPolicy evaluates the order policy against the order in prep,
+// shipped to address, and returns all of its violations, or nil if there
+// are none.
+func (cs *checkout) checkOrderPolicy(ctx context.Context, address *pb.Address, prep orderPrep) *policyError {
+	order := policy.Order{
+		Items:    make([]policy.Item, len(prep.orderItems)),
+		TotalUSD: money.ToFloat64(prep.totalUSD),
+		Country:  address.GetCountry(),
+	}
+	for i, it := range prep.orderItems {
+		product := prep.products[i]
+		order.Items[i] = policy.Item{
+			ProductID:  it.GetItem().GetProductId(),
+			Categories: product.GetCategories(),
+			Quantity:   int(it.GetItem().GetQuantity()),
+			PriceUSD:   money.ToFloat64(product.GetPriceUsd()),
+		}
+	}
 
-	// If threshold is 0, feature is disabled
-	if priceThreshold == 0 {
-		log.Infof("[DEBUG] Feature disabled (threshold=0), skipping expensive items check")
+	p := cs.policyFor(ctx)
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("app.checkout.policy.rules", len(p.Rules())))
+	violations := p.Evaluate(ctx, order)
+	log.WithContext(ctx).Debugf("Order policy evaluated %d rules with %d violations", len(p.Rules()), len(violations))
+	if len(violations) == 0 {
 		return nil
 	}
+	return &policyError{violations: violations, hold: p.OnViolation == policy.ActionHold}
+}
+
+// assessRisk scores the fraud risk of order with the risk scorer, and
+// records the assessment on the span in ctx. Orders are allowed if there is
+// no scorer or it fails.
+func (cs *checkout) assessRisk(ctx context.Context, order *pendingOrder) risk.Assessment {
+	req := order.req
+	if cs.riskScorer == nil {
+		return risk.Assessment{Decision: risk.Allow}
+	}
+	paymentCard, _ := order.firstCard()
+	assessment, err := cs.riskScorer.Score(ctx, risk.Order{
+		UserID:          req.GetUserId(),
+		CardFingerprint: paymentCard.Fingerprint,
+		ShippingCountry: req.GetAddress().GetCountry(),
+		BillingCountry:  req.GetBillingAddress().GetCountry(),
+		TotalUSD:        money.ToFloat64(order.prep.totalUSD),
+	})
+	if err != nil {
+		log.WithContext(ctx).Warnf("Failed to score order risk, allowing the order: %v", err)
+		return risk.Assessment{Decision: risk.Allow}
+	}
 
 	span := trace.SpanFromContext(ctx)
 	span.SetAttributes(
-		attribute.Int("app.checkout.price_threshold", priceThreshold),
+		attribute.Float64("app.risk.score", assessment.Score),
+		attribute.String("app.risk.decision", string(assessment.Decision)),
+		attribute.StringSlice("app.risk.signals", assessment.SignalNames()),
 	)
-
-	for _, item := range orderItems {
-		// Convert price to USD for comparison (threshold is in USD)
-		priceUSD, err := cs.currencySvcClient.Convert(ctx, &pb.CurrencyConversionRequest{
-			From:   item.Cost,
-			ToCode: "USD",
-		})
-		if err != nil {
-			log.Warnf("Failed to convert price to USD for threshold check: %v", err)
-			// Continue with original price if conversion fails
-			priceUSD = item.Cost
-		}
-
-		// Calculate total price in USD (units + nanos)
-		totalPriceUSD := float64(priceUSD.Units) + float64(priceUSD.Nanos)/1e9
-
-		if totalPriceUSD > float64(priceThreshold) {
-			span.AddEvent("expensive_item_detected", trace.WithAttributes(
-				attribute.String("app.product.id", item.Item.ProductId),
-				attribute.Float64("app.product.price_usd", totalPriceUSD),
-				attribute.Int("app.checkout.threshold", priceThreshold),
-			))
-
-			return fmt.Errorf("item %s costs $%.2f which exceeds the threshold of $%d",
-				item.Item.ProductId, totalPriceUSD, priceThreshold)
-		}
+	for _, s := range assessment.Signals {
+		span.AddEvent("risk_signal", trace.WithAttributes(
+			attribute.String("app.risk.signal", s.Name),
+			attribute.Float64("app.risk.signal.score", s.Score),
+			attribute.String("app.risk.signal.description", s.Description),
+		))
 	}
-
-	return nil
-}
-
-func (cs *checkout) generateTechnicalErrorDetails(ctx context.Context, prep orderPrep, originalErr error) string {
-	span := trace.SpanFromContext(ctx)
-
-	// Get feature flag configuration
-	priceThreshold := cs.getIntFeatureFlag(ctx, "checkoutFailureThreshold")
-
-	// Build comprehensive technical report
-	var details strings.Builder
-
-	details.WriteString("\n\n=== TECHNICAL BUG REPORT ===\n")
-	details.WriteString("ISSUE: Checkout failure due to expensive items blocking order completion\n")
-	details.WriteString("SEVERITY: HIGH - Business Logic Error\n")
-	details.WriteString("COMPONENT: Checkout Service (src/checkout/main.go)\n\n")
-
-	// Error Analysis
-	details.WriteString("ERROR ANALYSIS:\n")
-	details.WriteString(fmt.Sprintf("- Original Error: %s\n", originalErr.Error()))
-	details.WriteString(fmt.Sprintf("- Error Location: checkExpensiveItems() function, line ~650\n"))
-	details.WriteString(fmt.Sprintf("- Failure Point: PlaceOrder() method, line ~270\n"))
-	details.WriteString(fmt.Sprintf("- Feature Flag: checkoutFailureThreshold = %d\n", priceThreshold))
-	details.WriteString("- Error Type: Business logic validation failure\n\n")
-
-	// System Context
-	details.WriteString("SYSTEM CONTEXT:\n")
-	details.WriteString(fmt.Sprintf("- Service: checkout-service\n"))
-	details.WriteString(fmt.Sprintf("- Method: PlaceOrder (gRPC)\n"))
-	details.WriteString(fmt.Sprintf("- Trace ID: %s\n", span.SpanContext().TraceID().String()))
-	details.WriteString(fmt.Sprintf("- Span ID: %s\n", span.SpanContext().SpanID().String()))
-	details.WriteString(fmt.Sprintf("- User Currency: %s\n", prep.orderItems[0].Cost.CurrencyCode))
-	details.WriteString(fmt.Sprintf("- Total Items: %d\n", len(prep.orderItems)))
-
-	// Detailed Item Analysis
-	details.WriteString("\nITEM ANALYSIS:\n")
-	for i, item := range prep.orderItems {
-		priceUSD := float64(item.Cost.Units) + float64(item.Cost.Nanos)/1e9
-		details.WriteString(fmt.Sprintf("- Item %d: %s\n", i+1, item.Item.ProductId))
-		details.WriteString(fmt.Sprintf("  * Price: $%.2f USD\n", priceUSD))
-		details.WriteString(fmt.Sprintf("  * Quantity: %d\n", item.Item.Quantity))
-		details.WriteString(fmt.Sprintf("  * Exceeds Threshold: %t (threshold: $%d)\n", priceUSD > float64(priceThreshold), priceThreshold))
-	}
-
-	// Stack Trace Simulation
-	details.WriteString("\nCALL STACK:\n")
-	details.WriteString("1. frontend/pages/api/checkout.ts:25 - POST /api/checkout\n")
-	details.WriteString("2. frontend/gateways/Api.gateway.ts:45 - ApiGateway.placeOrder()\n")
-	details.WriteString("3. checkout/main.go:250 - PlaceOrder() gRPC method\n")
-	details.WriteString("4. checkout/main.go:270 - checkExpensiveItems() validation\n")
-	details.WriteString("5. checkout/main.go:650 - Price threshold comparison\n")
-
-	// Root Cause Analysis
-	details.WriteString("\nROOT CAUSE ANALYSIS:\n")
-	details.WriteString("The checkout service implements a business rule that prevents orders\n")
-	details.WriteString("containing items above a configurable price threshold. This is controlled\n")
-	details.WriteString("by the 'checkoutFailureThreshold' feature flag.\n\n")
-	details.WriteString("BUSINESS IMPACT:\n")
-	details.WriteString("- Customer cannot complete purchase of expensive items\n")
-	details.WriteString("- Revenue loss for high-value transactions\n")
-	details.WriteString("- Poor user experience with unclear error handling\n")
-	details.WriteString("- No alternative checkout flow for expensive items\n\n")
-
-	// Suggested Fixes
-	details.WriteString("SUGGESTED FIXES:\n")
-	details.WriteString("1. IMMEDIATE (Low Risk):\n")
-	details.WriteString("   - Increase checkoutFailureThreshold feature flag value\n")
-	details.WriteString("   - Add better error messaging for users\n")
-	details.WriteString("   - Implement 'Request Manager Approval' workflow\n\n")
-	details.WriteString("2. SHORT TERM (Medium Risk):\n")
-	details.WriteString("   - Add item-category based thresholds\n")
-	details.WriteString("   - Implement split-payment options\n")
-	details.WriteString("   - Add admin override capabilities\n\n")
-	details.WriteString("3. LONG TERM (High Risk):\n")
-	details.WriteString("   - Redesign checkout flow for enterprise customers\n")
-	details.WriteString("   - Implement approval workflows\n")
-	details.WriteString("   - Add payment plan options\n\n")
-
-	// Code References
-	details.WriteString("CODE REFERENCES:\n")
-	details.WriteString("- Main Logic: src/checkout/main.go:621-663 (checkExpensiveItems)\n")
-	details.WriteString("- Feature Flag: src/checkout/main.go:600-620 (getIntFeatureFlag)\n")
-	details.WriteString("- Error Handling: src/checkout/main.go:268-278 (PlaceOrder)\n")
-	details.WriteString("- Frontend: src/frontend/pages/api/checkout.ts\n")
-	details.WriteString("- Support Integration: src/support/main.go:240-350\n\n")
-
-	// Configuration Details
-	details.WriteString("CONFIGURATION:\n")
-	details.WriteString("- Feature Flag Service: Connected\n")
-	details.WriteString(fmt.Sprintf("- Current Threshold: $%d USD\n", priceThreshold))
-	details.WriteString("- Currency Service: Connected\n")
-	details.WriteString("- Support Service: Connected\n\n")
-
-	// Reproduction Steps
-	details.WriteString("REPRODUCTION STEPS:\n")
-	details.WriteString("1. Add item with price > $100 to cart\n")
-	details.WriteString("2. Proceed to checkout\n")
-	details.WriteString("3. Fill in payment/shipping details\n")
-	details.WriteString("4. Click 'Place Order'\n")
-	details.WriteString("5. Observe error: 'Order contains expensive items'\n\n")
-
-	details.WriteString("=== END TECHNICAL REPORT ===\n")
-
-	return details.String()
+	cs.metrics.riskAssessed(ctx, assessment)
+	log.WithContext(ctx).Debugf("Order risk score %.0f (%s): %v", assessment.Score, assessment.Decision, assessment.SignalNames())
+	return assessment
 }
@@ -0,0 +1,245 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc/status"
+
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/risk"
+)
+
+// Failure stages of PlaceOrder, used as the app.order.failure.stage attribute.
+const (
+	stagePrepare    = "prepare"
+	stageValidation = "validation"
+	stageRisk       = "risk"
+	stagePayment    = "payment"
+	stageShipping   = "shipping"
+)
+
+// Downstream dependencies, used as the app.dependency attribute.
+const (
+	dependencyCart           = "cart"
+	dependencyProductCatalog = "product-catalog"
+	dependencyCurrency       = "currency"
+	dependencyShipping       = "shipping"
+	dependencyPayment        = "payment"
+	dependencyEmail          = "email"
+	dependencyApprover       = "approver"
+)
+
+// Outcomes of publishing an order to Kafka, used as the
+// messaging.kafka.publish.outcome attribute.
+const (
+	publishSuccess = "success"
+	publishError   = "error"
+	publishTimeout = "timeout"
+)
+
+// checkoutMetrics holds the business metric instruments of the checkout
+// service. All attributes take values from the fixed sets above (or gRPC
+// status codes) so their cardinality stays bounded. A nil *checkoutMetrics
+// records nothing.
+type checkoutMetrics struct {
+	orders             metric.Int64Counter
+	orderValue         metric.Float64Histogram
+	orderItems         metric.Int64Histogram
+	dependencyDuration metric.Float64Histogram
+	kafkaPublishes     metric.Int64Counter
+	holds              metric.Int64Counter
+	riskScore          metric.Float64Histogram
+	riskSignals        metric.Int64Counter
+	tenders            metric.Int64Counter
+	shipments          metric.Int64Counter
+	confirmations      metric.Int64Counter
+}
+
+func newCheckoutMetrics(meter metric.Meter) (*checkoutMetrics, error) {
+	var m checkoutMetrics
+	var err error
+
+	if m.orders, err = meter.Int64Counter("app.checkout.orders",
+		metric.WithDescription("Orders handled by PlaceOrder, by outcome and failure stage"),
+		metric.WithUnit("{order}"),
+	); err != nil {
+		return nil, err
+	}
+	if m.orderValue, err = meter.Float64Histogram("app.checkout.order.value",
+		metric.WithDescription("Total value of placed orders, including shipping, in USD"),
+		metric.WithUnit("USD"),
+		metric.WithExplicitBucketBoundaries(10, 25, 50, 100, 250, 500, 1000, 2500, 5000),
+	); err != nil {
+		return nil, err
+	}
+	if m.orderItems, err = meter.Int64Histogram("app.checkout.order.items",
+		metric.WithDescription("Number of items (sum of quantities) in placed orders"),
+		metric.WithUnit("{item}"),
+		metric.WithExplicitBucketBoundaries(1, 2, 3, 5, 10, 20, 50),
+	); err != nil {
+		return nil, err
+	}
+	if m.dependencyDuration, err = meter.Float64Histogram("app.checkout.dependency.duration",
+		metric.WithDescription("Duration of calls from checkout to downstream dependencies"),
+		metric.WithUnit("s"),
+	); err != nil {
+		return nil, err
+	}
+	if m.kafkaPublishes, err = meter.Int64Counter("app.checkout.kafka.publishes",
+		metric.WithDescription("Order events published to Kafka, by outcome"),
+		metric.WithUnit("{message}"),
+	); err != nil {
+		return nil, err
+	}
+	if m.holds, err = meter.Int64Counter("app.checkout.order.holds",
+		metric.WithDescription("Orders on hold that were resolved, by resolution"),
+		metric.WithUnit("{order}"),
+	); err != nil {
+		return nil, err
+	}
+	if m.riskScore, err = meter.Float64Histogram("app.checkout.risk.score",
+		metric.WithDescription("Fraud risk score of orders, by decision"),
+		metric.WithUnit("1"),
+		metric.WithExplicitBucketBoundaries(10, 20, 30, 40, 50, 60, 70, 80, 90),
+	); err != nil {
+		return nil, err
+	}
+	if m.riskSignals, err = meter.Int64Counter("app.checkout.risk.signals",
+		metric.WithDescription("Fraud risk signals found in orders, by signal"),
+		metric.WithUnit("{signal}"),
+	); err != nil {
+		return nil, err
+	}
+	if m.tenders, err = meter.Int64Counter("app.checkout.payment.tenders",
+		metric.WithDescription("Payment instruments charged for orders, by method and outcome"),
+		metric.WithUnit("{payment}"),
+	); err != nil {
+		return nil, err
+	}
+	if m.shipments, err = meter.Int64Counter("app.checkout.shipments",
+		metric.WithDescription("Shipments of placed orders, by warehouse and status"),
+		metric.WithUnit("{shipment}"),
+	); err != nil {
+		return nil, err
+	}
+	if m.confirmations, err = meter.Int64Counter("app.checkout.confirmations",
+		metric.WithDescription("Order confirmations sent to customers, by transport and status"),
+		metric.WithUnit("{message}"),
+	); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// orderPlaced records a successful order of valueUSD with itemCount items.
+func (m *checkoutMetrics) orderPlaced(ctx context.Context, valueUSD float64, itemCount int) {
+	if m == nil {
+		return
+	}
+	m.orders.Add(ctx, 1, metric.WithAttributes(attribute.String("app.order.outcome", "placed")))
+	m.orderValue.Record(ctx, valueUSD)
+	m.orderItems.Record(ctx, int64(itemCount))
+}
+
+// orderHeld records an order placed on hold.
+func (m *checkoutMetrics) orderHeld(ctx context.Context) {
+	if m == nil {
+		return
+	}
+	m.orders.Add(ctx, 1, metric.WithAttributes(attribute.String("app.order.outcome", "held")))
+}
+
+// holdResolved records an order on hold that was approved, rejected or
+// expired, as the order status it ended with.
+func (m *checkoutMetrics) holdResolved(ctx context.Context, status string) {
+	if m == nil {
+		return
+	}
+	m.holds.Add(ctx, 1, metric.WithAttributes(attribute.String("app.order.status", status)))
+}
+
+// riskAssessed records the risk score of an order and its signals.
+func (m *checkoutMetrics) riskAssessed(ctx context.Context, assessment risk.Assessment) {
+	if m == nil {
+		return
+	}
+	m.riskScore.Record(ctx, assessment.Score, metric.WithAttributes(attribute.String("app.risk.decision", string(assessment.Decision))))
+	for _, s := range assessment.Signals {
+		m.riskSignals.Add(ctx, 1, metric.WithAttributes(attribute.String("app.risk.signal", s.Name)))
+	}
+}
+
+// tenderPaid records the outcome of paying with a payment instrument of
+// method.
+func (m *checkoutMetrics) tenderPaid(ctx context.Context, method, outcome string) {
+	if m == nil {
+		return
+	}
+	m.tenders.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("app.payment.method", method),
+		attribute.String("app.payment.outcome", outcome),
+	))
+}
+
+// shipmentShipped records a shipment from warehouse that ended with status.
+func (m *checkoutMetrics) shipmentShipped(ctx context.Context, warehouse, status string) {
+	if m == nil {
+		return
+	}
+	m.shipments.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("app.shipping.warehouse", warehouse),
+		attribute.String("app.shipping.status", status),
+	))
+}
+
+// confirmationDelivered records an order confirmation sent with transport
+// that ended with status.
+func (m *checkoutMetrics) confirmationDelivered(ctx context.Context, transport, status string) {
+	if m == nil {
+		return
+	}
+	m.confirmations.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("app.confirmation.transport", transport),
+		attribute.String("app.confirmation.status", status),
+	))
+}
+
+// orderFailed records an order that failed at stage with the gRPC error err.
+func (m *checkoutMetrics) orderFailed(ctx context.Context, stage string, err error) {
+	if m == nil {
+		return
+	}
+	m.orders.Add(ctx, 1, metric.WithAttributes(
+		attribute.String("app.order.outcome", "failed"),
+		attribute.String("app.order.failure.stage", stage),
+		attribute.String("rpc.grpc.status_code", status.Code(err).String()),
+	))
+}
+
+// dependencyCall records the duration of a call to dependency that started
+// at start and returned err.
+func (m *checkoutMetrics) dependencyCall(ctx context.Context, dependency string, start time.Time, err error) {
+	if m == nil {
+		return
+	}
+	outcome := "ok"
+	if err != nil {
+		outcome = "error"
+	}
+	m.dependencyDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(
+		attribute.String("app.dependency", dependency),
+		attribute.String("app.dependency.outcome", outcome),
+	))
+}
+
+// kafkaPublished records the outcome of publishing an order event.
+func (m *checkoutMetrics) kafkaPublished(ctx context.Context, outcome string) {
+	if m == nil {
+		return
+	}
+	m.kafkaPublishes.Add(ctx, 1, metric.WithAttributes(attribute.String("messaging.kafka.publish.outcome", outcome)))
+}
@@ -0,0 +1,180 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// recordMetrics records metrics with record, and returns those collected by
+// name.
+func recordMetrics(t *testing.T, record func(context.Context, *checkoutMetrics)) map[string]metricdata.Metrics {
+	t.Helper()
+	reader := sdkmetric.NewManualReader()
+	m, err := newCheckoutMetrics(sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)).Meter("checkout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	record(context.Background(), m)
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	metrics := make(map[string]metricdata.Metrics)
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			metrics[m.Name] = m
+		}
+	}
+	return metrics
+}
+
+// counted returns the value of the counter name, in unit, for attrs.
+func counted(t *testing.T, metrics map[string]metricdata.Metrics, name, unit string, attrs ...attribute.KeyValue) int64 {
+	t.Helper()
+	m, ok := metrics[name]
+	if !ok {
+		t.Fatalf("%s was not recorded", name)
+	}
+	if m.Unit != unit {
+		t.Errorf("%s unit = %q, want %q", name, m.Unit, unit)
+	}
+	sum, ok := m.Data.(metricdata.Sum[int64])
+	if !ok {
+		t.Fatalf("%s is a %T, want an int64 counter", name, m.Data)
+	}
+	want := attribute.NewSet(attrs...)
+	for _, dp := range sum.DataPoints {
+		if dp.Attributes.Equals(&want) {
+			return dp.Value
+		}
+	}
+	t.Errorf("%s has no data point with %v", name, want.ToSlice())
+	return 0
+}
+
+// histogram returns the data point of the histogram name, in unit, for
+// attrs.
+func histogram[N int64 | float64](t *testing.T, metrics map[string]metricdata.Metrics, name, unit string, attrs ...attribute.KeyValue) metricdata.HistogramDataPoint[N] {
+	t.Helper()
+	m, ok := metrics[name]
+	if !ok {
+		t.Fatalf("%s was not recorded", name)
+	}
+	if m.Unit != unit {
+		t.Errorf("%s unit = %q, want %q", name, m.Unit, unit)
+	}
+	hist, ok := m.Data.(metricdata.Histogram[N])
+	if !ok {
+		t.Fatalf("%s is a %T, want a histogram", name, m.Data)
+	}
+	want := attribute.NewSet(attrs...)
+	for _, dp := range hist.DataPoints {
+		if dp.Attributes.Equals(&want) {
+			return dp
+		}
+	}
+	t.Fatalf("%s has no data point with %v", name, want.ToSlice())
+	return metricdata.HistogramDataPoint[N]{}
+}
+
+func TestOrderMetrics(t *testing.T) {
+	t.Run("placed", func(t *testing.T) {
+		metrics := recordMetrics(t, func(ctx context.Context, m *checkoutMetrics) {
+			m.orderPlaced(ctx, 60.5, 3)
+		})
+		if n := counted(t, metrics, "app.checkout.orders", "{order}", attribute.String("app.order.outcome", "placed")); n != 1 {
+			t.Errorf("placed orders = %d, want 1", n)
+		}
+		if dp := histogram[float64](t, metrics, "app.checkout.order.value", "USD"); dp.Count != 1 || dp.Sum != 60.5 {
+			t.Errorf("order value: count %d, sum %v, want one order of 60.5", dp.Count, dp.Sum)
+		}
+		if dp := histogram[int64](t, metrics, "app.checkout.order.items", "{item}"); dp.Count != 1 || dp.Sum != 3 {
+			t.Errorf("order items: count %d, sum %v, want one order of 3 items", dp.Count, dp.Sum)
+		}
+	})
+
+	t.Run("failed", func(t *testing.T) {
+		metrics := recordMetrics(t, func(ctx context.Context, m *checkoutMetrics) {
+			m.orderFailed(ctx, stagePayment, status.Error(codes.FailedPrecondition, "declined"))
+			m.orderFailed(ctx, stagePayment, status.Error(codes.FailedPrecondition, "declined"))
+			m.orderFailed(ctx, stageShipping, errors.New("no status"))
+		})
+		if n := counted(t, metrics, "app.checkout.orders", "{order}",
+			attribute.String("app.order.outcome", "failed"),
+			attribute.String("app.order.failure.stage", stagePayment),
+			attribute.String("rpc.grpc.status_code", "FailedPrecondition"),
+		); n != 2 {
+			t.Errorf("orders failed at payment = %d, want 2", n)
+		}
+		if n := counted(t, metrics, "app.checkout.orders", "{order}",
+			attribute.String("app.order.outcome", "failed"),
+			attribute.String("app.order.failure.stage", stageShipping),
+			attribute.String("rpc.grpc.status_code", "Unknown"),
+		); n != 1 {
+			t.Errorf("orders failed at shipping = %d, want 1", n)
+		}
+		if _, ok := metrics["app.checkout.order.value"]; ok {
+			t.Error("failed orders recorded an order value")
+		}
+	})
+
+	t.Run("held", func(t *testing.T) {
+		metrics := recordMetrics(t, func(ctx context.Context, m *checkoutMetrics) {
+			m.orderHeld(ctx)
+			m.holdResolved(ctx, orderStatusExpired)
+			m.holdResolved(ctx, orderStatusPlaced)
+			m.holdResolved(ctx, orderStatusPlaced)
+		})
+		if n := counted(t, metrics, "app.checkout.orders", "{order}", attribute.String("app.order.outcome", "held")); n != 1 {
+			t.Errorf("held orders = %d, want 1", n)
+		}
+		for status, want := range map[string]int64{orderStatusPlaced: 2, orderStatusExpired: 1} {
+			if n := counted(t, metrics, "app.checkout.order.holds", "{order}", attribute.String("app.order.status", status)); n != want {
+				t.Errorf("holds resolved as %s = %d, want %d", status, n, want)
+			}
+		}
+	})
+}
+
+func TestDependencyCallMetrics(t *testing.T) {
+	metrics := recordMetrics(t, func(ctx context.Context, m *checkoutMetrics) {
+		m.dependencyCall(ctx, dependencyPayment, time.Now().Add(-50*time.Millisecond), nil)
+		m.dependencyCall(ctx, dependencyPayment, time.Now(), errors.New("unavailable"))
+	})
+
+	ok := histogram[float64](t, metrics, "app.checkout.dependency.duration", "s",
+		attribute.String("app.dependency", dependencyPayment),
+		attribute.String("app.dependency.outcome", "ok"),
+	)
+	if ok.Count != 1 || ok.Sum < 0.05 || ok.Sum > 1 {
+		t.Errorf("successful calls: count %d, sum %vs, want one call of about 0.05s", ok.Count, ok.Sum)
+	}
+	failed := histogram[float64](t, metrics, "app.checkout.dependency.duration", "s",
+		attribute.String("app.dependency", dependencyPayment),
+		attribute.String("app.dependency.outcome", "error"),
+	)
+	if failed.Count != 1 || failed.Sum >= 0.05 {
+		t.Errorf("failed calls: count %d, sum %vs, want one immediate call", failed.Count, failed.Sum)
+	}
+}
+
+func TestNilMetrics(t *testing.T) {
+	var m *checkoutMetrics
+	ctx := context.Background()
+	m.orderPlaced(ctx, 1, 1)
+	m.orderHeld(ctx)
+	m.orderFailed(ctx, stagePayment, nil)
+	m.holdResolved(ctx, orderStatusRejected)
+	m.dependencyCall(ctx, dependencyCart, time.Now(), nil)
+}
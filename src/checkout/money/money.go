@@ -4,6 +4,7 @@ package money
 
 import (
 	"errors"
+	"fmt"
 
 	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
 )
@@ -87,7 +88,7 @@ func Sum(l, r *pb.Money) (*pb.Money, error) {
 	units := l.GetUnits() + r.GetUnits()
 	nanos := l.GetNanos() + r.GetNanos()
 
-	if (units == 0 && nanos == 0) || (units > 0 && nanos >= 0) || (units < 0 && nanos <= 0) {
+	if units == 0 || (units > 0 && nanos >= 0) || (units < 0 && nanos <= 0) {
 		// same sign <units, nanos>
 		units += int64(nanos / nanosMod)
 		nanos = nanos % nanosMod
@@ -108,6 +109,53 @@ func Sum(l, r *pb.Money) (*pb.Money, error) {
 		CurrencyCode: l.GetCurrencyCode()}, nil
 }
 
+// Compare returns -1, 0 or +1 as l is less than, equal to or greater than
+// r. Returns an error if one of the values are invalid or currency codes
+// are not matching.
+func Compare(l, r *pb.Money) (int, error) {
+	d, err := Sum(l, Negate(r))
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case IsNegative(d):
+		return -1, nil
+	case IsZero(d):
+		return 0, nil
+	}
+	return 1, nil
+}
+
+// Allocate splits total across parts in turn, each taking as much of what
+// remains as its limit allows; a nil limit takes all that remains. It
+// returns the amount of every part and the remainder that no part took.
+// Limits must not be negative and must be in the currency of total.
+func Allocate(total *pb.Money, limits []*pb.Money) ([]*pb.Money, *pb.Money, error) {
+	remaining := &pb.Money{CurrencyCode: total.GetCurrencyCode(), Units: total.GetUnits(), Nanos: total.GetNanos()}
+	parts := make([]*pb.Money, len(limits))
+	for i, limit := range limits {
+		part := remaining
+		if limit != nil {
+			if IsNegative(limit) {
+				return nil, nil, ErrInvalidValue
+			}
+			c, err := Compare(limit, remaining)
+			if err != nil {
+				return nil, nil, err
+			}
+			if c < 0 {
+				part = limit
+			}
+		}
+		parts[i] = &pb.Money{CurrencyCode: total.GetCurrencyCode(), Units: part.GetUnits(), Nanos: part.GetNanos()}
+		var err error
+		if remaining, err = Sum(remaining, Negate(parts[i])); err != nil {
+			return nil, nil, err
+		}
+	}
+	return parts, remaining, nil
+}
+
 // MultiplySlow is a slow multiplication operation done through adding the value
 // to itself n-1 times.
 func MultiplySlow(m *pb.Money, n uint32) *pb.Money {
@@ -118,3 +166,25 @@ func MultiplySlow(m *pb.Money, n uint32) *pb.Money {
 	}
 	return out
 }
+
+// ToFloat64 returns the value as a float64 in units of the currency. It is
+// meant for telemetry and display, not for further arithmetic.
+func ToFloat64(m *pb.Money) float64 {
+	return float64(m.GetUnits()) + float64(m.GetNanos())/nanosMod
+}
+
+// Format formats m for people, in units of the currency rounded to the cent,
+// such as "24.99 USD". Unlike ToFloat64, it does not lose precision on large
+// amounts.
+func Format(m *pb.Money) string {
+	units, nanos := m.GetUnits(), int64(m.GetNanos())
+	sign := ""
+	if units < 0 || nanos < 0 {
+		sign, units, nanos = "-", -units, -nanos
+	}
+	cents := (nanos + nanosMod/200) / (nanosMod / 100)
+	if cents == 100 {
+		units, cents = units+1, 0
+	}
+	return fmt.Sprintf("%s%d.%02d %s", sign, units, cents, m.GetCurrencyCode())
+}
@@ -218,6 +218,8 @@ func TestSum(t *testing.T) {
 		{"mixed (larger negative, with borrow)", args{mm(-11, -100000000), mm(2, 9000000 /*.09*/)}, mm(-9, -91000000 /*.091*/), nil},
 		{"0+negative", args{mm(0, 0), mm(-2, -100000000)}, mm(-2, -100000000), nil},
 		{"negative+0", args{mm(-2, -100000000), mm(0, 0)}, mm(-2, -100000000), nil},
+		{"mixed (units cancel, negative nanos)", args{mm(2, 400000000), mm(-2, -500000000)}, mm(0, -100000000), nil},
+		{"mixed (units cancel, positive nanos)", args{mm(-2, -400000000), mm(2, 500000000)}, mm(0, 100000000), nil},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -231,3 +233,127 @@ func TestSum(t *testing.T) {
 		})
 	}
 }
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name    string
+		l, r    *pb.Money
+		want    int
+		wantErr error
+	}{
+		{"equal", mmc(2, 500000000, "USD"), mmc(2, 500000000, "USD"), 0, nil},
+		{"less by nanos", mmc(2, 400000000, "USD"), mmc(2, 500000000, "USD"), -1, nil},
+		{"greater by units", mmc(3, 0, "USD"), mmc(2, 999999999, "USD"), 1, nil},
+		{"negative", mmc(-1, 0, "USD"), mmc(0, 0, "USD"), -1, nil},
+		{"Error: currency code mismatch", mmc(1, 0, "USD"), mmc(1, 0, "EUR"), 0, ErrMismatchingCurrency},
+		{"Error: invalid", mmc(1, -1, "USD"), mmc(1, 0, "USD"), 0, ErrInvalidValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Compare(tt.l, tt.r)
+			if err != tt.wantErr {
+				t.Errorf("Compare([%v],[%v]): expected err=\"%v\" got=\"%v\"", tt.l, tt.r, tt.wantErr, err)
+			}
+			if got != tt.want {
+				t.Errorf("Compare([%v],[%v]) = %v, want %v", tt.l, tt.r, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAllocate(t *testing.T) {
+	usd := func(u int64, n int32) *pb.Money { return mmc(u, n, "USD") }
+	tests := []struct {
+		name          string
+		total         *pb.Money
+		limits        []*pb.Money
+		want          []*pb.Money
+		wantRemainder *pb.Money
+		wantErr       error
+	}{
+		{"single unlimited", usd(59, 990000000), []*pb.Money{nil}, []*pb.Money{usd(59, 990000000)}, usd(0, 0), nil},
+		{
+			"limits then unlimited",
+			usd(59, 990000000),
+			[]*pb.Money{usd(25, 0), usd(10, 500000000), nil},
+			[]*pb.Money{usd(25, 0), usd(10, 500000000), usd(24, 490000000)},
+			usd(0, 0),
+			nil,
+		},
+		{
+			"first limit covers the total",
+			usd(20, 0),
+			[]*pb.Money{usd(50, 0), nil},
+			[]*pb.Money{usd(20, 0), usd(0, 0)},
+			usd(0, 0),
+			nil,
+		},
+		{
+			"limits fall short",
+			usd(100, 0),
+			[]*pb.Money{usd(30, 0), usd(45, 250000000)},
+			[]*pb.Money{usd(30, 0), usd(45, 250000000)},
+			usd(24, 750000000),
+			nil,
+		},
+		{"no parts", usd(5, 0), nil, []*pb.Money{}, usd(5, 0), nil},
+		{"Error: negative limit", usd(5, 0), []*pb.Money{usd(-1, 0)}, nil, nil, ErrInvalidValue},
+		{"Error: currency code mismatch", usd(5, 0), []*pb.Money{mmc(1, 0, "EUR")}, nil, nil, ErrMismatchingCurrency},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, remainder, err := Allocate(tt.total, tt.limits)
+			if err != tt.wantErr {
+				t.Fatalf("Allocate(): expected err=\"%v\" got=\"%v\"", tt.wantErr, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) || !reflect.DeepEqual(remainder, tt.wantRemainder) {
+				t.Errorf("Allocate() = %v, %v, want %v, %v", got, remainder, tt.want, tt.wantRemainder)
+			}
+		})
+	}
+}
+
+func TestToFloat64(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *pb.Money
+		want float64
+	}{
+		{"zero", mm(0, 0), 0},
+		{"units only", mm(12, 0), 12},
+		{"with nanos", mm(349, 950000000), 349.95},
+		{"negative", mm(-1, -500000000), -1.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ToFloat64(tt.in); got != tt.want {
+				t.Errorf("ToFloat64(%v) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		name string
+		in   *pb.Money
+		want string
+	}{
+		{"zero", mmc(0, 0, "USD"), "0.00 USD"},
+		{"units only", mmc(12, 0, "EUR"), "12.00 EUR"},
+		{"with nanos", mmc(349, 950000000, "USD"), "349.95 USD"},
+		{"rounded down", mmc(8, 494999999, "USD"), "8.49 USD"},
+		{"rounded up", mmc(8, 495000000, "USD"), "8.50 USD"},
+		{"rounded up to units", mmc(9, 999000000, "USD"), "10.00 USD"},
+		{"negative", mmc(-1, -500000000, "JPY"), "-1.50 JPY"},
+		{"negative nanos only", mmc(0, -10000000, "USD"), "-0.01 USD"},
+		{"large", mmc(9007199254740993, 10000000, "USD"), "9007199254740993.01 USD"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Format(tt.in); got != tt.want {
+				t.Errorf("Format(%v) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
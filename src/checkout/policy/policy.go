@@ -0,0 +1,344 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package policy evaluates the declarative rules an order must satisfy
+// before it is charged, such as price limits per product category or
+// destinations a product cannot be shipped to. Rules are configured with a
+// Policy, which is read from a JSON or YAML file or from a feature flag
+// object, and every rule of the policy is evaluated so that all the
+// violations of an order are reported at once.
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/yaml.v3"
+)
+
+// AnyCategory is the PriceLimitsUSD key of the limit that applies to the
+// products of every category.
+const AnyCategory = "*"
+
+// Names of the rules, which are also the types of their violations.
+const (
+	RulePriceLimit     = "PRICE_LIMIT"
+	RuleQuantityLimit  = "QUANTITY_LIMIT"
+	RuleOrderTotal     = "ORDER_TOTAL_LIMIT"
+	RuleBlockedCountry = "BLOCKED_COUNTRY"
+	RuleEmbargo        = "EMBARGO"
+)
+
+// Actions taken on the orders that violate a policy.
+const (
+	// ActionReject fails the order.
+	ActionReject = "reject"
+	// ActionHold places the order on hold until it is approved.
+	ActionHold = "hold"
+)
+
+// Policy configures the rules orders are validated with. Zero fields
+// disable their rule.
+type Policy struct {
+	// OnViolation is the action taken on orders that violate the policy,
+	// ActionReject if empty.
+	OnViolation string `json:"onViolation,omitempty" yaml:"onViolation,omitempty"`
+	// PriceLimitsUSD maps product categories, or AnyCategory, to the
+	// highest unit price in USD of the products in them. The lowest limit
+	// of the categories of a product applies.
+	PriceLimitsUSD map[string]float64 `json:"priceLimitsUsd,omitempty" yaml:"priceLimitsUsd,omitempty"`
+	// MaxQuantityPerSKU is the highest quantity of a single product in an
+	// order.
+	MaxQuantityPerSKU int `json:"maxQuantityPerSku,omitempty" yaml:"maxQuantityPerSku,omitempty"`
+	// MaxOrderTotalUSD is the highest order total in USD, shipping
+	// included.
+	MaxOrderTotalUSD float64 `json:"maxOrderTotalUsd,omitempty" yaml:"maxOrderTotalUsd,omitempty"`
+	// BlockedCountries are the countries orders cannot be shipped to.
+	BlockedCountries []string `json:"blockedCountries,omitempty" yaml:"blockedCountries,omitempty"`
+	// Embargoes are the products that cannot be shipped to some countries.
+	Embargoes []Embargo `json:"embargoes,omitempty" yaml:"embargoes,omitempty"`
+}
+
+// Embargo forbids shipping a product, or the products of a category, to
+// Countries.
+type Embargo struct {
+	ProductID string   `json:"productId,omitempty" yaml:"productId,omitempty"`
+	Category  string   `json:"category,omitempty" yaml:"category,omitempty"`
+	Countries []string `json:"countries" yaml:"countries"`
+}
+
+// Load reads the policy in the file at path, which is parsed as YAML if its
+// extension is .yaml or .yml and as JSON otherwise.
+func Load(path string) (Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Policy{}, fmt.Errorf("failed to read policy: %v", err)
+	}
+	var p Policy
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &p)
+	default:
+		err = json.Unmarshal(data, &p)
+	}
+	if err != nil {
+		return Policy{}, fmt.Errorf("failed to parse policy %s: %v", path, err)
+	}
+	return p, p.validate()
+}
+
+// FromObject returns the policy in v, the value of a feature flag object.
+func FromObject(v any) (Policy, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return Policy{}, fmt.Errorf("failed to encode policy: %v", err)
+	}
+	var p Policy
+	if err := json.Unmarshal(data, &p); err != nil {
+		return Policy{}, fmt.Errorf("failed to parse policy: %v", err)
+	}
+	return p, p.validate()
+}
+
+func (p Policy) validate() error {
+	switch p.OnViolation {
+	case "", ActionReject, ActionHold:
+	default:
+		return fmt.Errorf("unknown onViolation action %q, want %q or %q", p.OnViolation, ActionReject, ActionHold)
+	}
+	for category, limit := range p.PriceLimitsUSD {
+		if limit <= 0 {
+			return fmt.Errorf("price limit of %q must be positive", category)
+		}
+	}
+	for i, e := range p.Embargoes {
+		if (e.ProductID == "") == (e.Category == "") {
+			return fmt.Errorf("embargo %d must have either a product ID or a category", i)
+		}
+		if len(e.Countries) == 0 {
+			return fmt.Errorf("embargo %d has no countries", i)
+		}
+	}
+	return nil
+}
+
+// WithPriceLimit returns a copy of p in which the products of category cost
+// at most limitUSD, unless p already has a lower limit for it.
+func (p Policy) WithPriceLimit(category string, limitUSD float64) Policy {
+	limits := make(map[string]float64, len(p.PriceLimitsUSD)+1)
+	for c, l := range p.PriceLimitsUSD {
+		limits[c] = l
+	}
+	if l, ok := limits[category]; !ok || limitUSD < l {
+		limits[category] = limitUSD
+	}
+	p.PriceLimitsUSD = limits
+	return p
+}
+
+// Item is a line of an order.
+type Item struct {
+	ProductID  string
+	Categories []string
+	Quantity   int
+	// PriceUSD is the unit price of the product in USD.
+	PriceUSD float64
+}
+
+// Order is an order as seen by the rules.
+type Order struct {
+	Items []Item
+	// TotalUSD is the order total in USD, shipping included.
+	TotalUSD float64
+	// Country is the destination of the order.
+	Country string
+}
+
+// Violation is a rule an order breaks. Subject is the product ID for the
+// rules about products, and the country or "order" otherwise.
+type Violation struct {
+	Rule        string
+	Subject     string
+	Description string
+}
+
+// Rule validates orders.
+type Rule interface {
+	Name() string
+	Evaluate(order Order) []Violation
+}
+
+// Rules returns the rules p enables.
+func (p Policy) Rules() []Rule {
+	var rules []Rule
+	if len(p.PriceLimitsUSD) > 0 {
+		rules = append(rules, priceLimitRule(p.PriceLimitsUSD))
+	}
+	if p.MaxQuantityPerSKU > 0 {
+		rules = append(rules, quantityLimitRule(p.MaxQuantityPerSKU))
+	}
+	if p.MaxOrderTotalUSD > 0 {
+		rules = append(rules, orderTotalRule(p.MaxOrderTotalUSD))
+	}
+	if len(p.BlockedCountries) > 0 {
+		rules = append(rules, blockedCountryRule(p.BlockedCountries))
+	}
+	if len(p.Embargoes) > 0 {
+		rules = append(rules, embargoRule(p.Embargoes))
+	}
+	return rules
+}
+
+// Evaluate evaluates every rule of p against order and returns all the
+// violations. Each evaluation is recorded as an event of the span in ctx.
+func (p Policy) Evaluate(ctx context.Context, order Order) []Violation {
+	span := trace.SpanFromContext(ctx)
+	var violations []Violation
+	for _, rule := range p.Rules() {
+		found := rule.Evaluate(order)
+		subjects := make([]string, len(found))
+		for i, v := range found {
+			subjects[i] = v.Subject
+		}
+		span.AddEvent("order_rule_evaluated", trace.WithAttributes(
+			attribute.String("app.checkout.rule.name", rule.Name()),
+			attribute.Bool("app.checkout.rule.passed", len(found) == 0),
+			attribute.Int("app.checkout.rule.violations", len(found)),
+			attribute.StringSlice("app.checkout.rule.subjects", subjects),
+		))
+		violations = append(violations, found...)
+	}
+	return violations
+}
+
+type priceLimitRule map[string]float64
+
+func (r priceLimitRule) Name() string { return RulePriceLimit }
+
+func (r priceLimitRule) Evaluate(order Order) []Violation {
+	var violations []Violation
+	for _, item := range order.Items {
+		limit, ok := r[AnyCategory]
+		for _, c := range item.Categories {
+			if l, found := r[c]; found && (!ok || l < limit) {
+				limit, ok = l, true
+			}
+		}
+		if ok && item.PriceUSD > limit {
+			violations = append(violations, Violation{
+				Rule:        RulePriceLimit,
+				Subject:     item.ProductID,
+				Description: fmt.Sprintf("item %s costs $%.2f which exceeds the threshold of $%s", item.ProductID, item.PriceUSD, formatUSD(limit)),
+			})
+		}
+	}
+	return violations
+}
+
+type quantityLimitRule int
+
+func (r quantityLimitRule) Name() string { return RuleQuantityLimit }
+
+func (r quantityLimitRule) Evaluate(order Order) []Violation {
+	quantities := make(map[string]int)
+	var ids []string
+	for _, item := range order.Items {
+		if _, ok := quantities[item.ProductID]; !ok {
+			ids = append(ids, item.ProductID)
+		}
+		quantities[item.ProductID] += item.Quantity
+	}
+	var violations []Violation
+	for _, id := range ids {
+		if q := quantities[id]; q > int(r) {
+			violations = append(violations, Violation{
+				Rule:        RuleQuantityLimit,
+				Subject:     id,
+				Description: fmt.Sprintf("item %s is ordered %d times which exceeds the limit of %d", id, q, int(r)),
+			})
+		}
+	}
+	return violations
+}
+
+type orderTotalRule float64
+
+func (r orderTotalRule) Name() string { return RuleOrderTotal }
+
+func (r orderTotalRule) Evaluate(order Order) []Violation {
+	if order.TotalUSD <= float64(r) {
+		return nil
+	}
+	return []Violation{{
+		Rule:        RuleOrderTotal,
+		Subject:     "order",
+		Description: fmt.Sprintf("order total of $%.2f exceeds the limit of $%s", order.TotalUSD, formatUSD(float64(r))),
+	}}
+}
+
+type blockedCountryRule []string
+
+func (r blockedCountryRule) Name() string { return RuleBlockedCountry }
+
+func (r blockedCountryRule) Evaluate(order Order) []Violation {
+	if !containsCountry(r, order.Country) {
+		return nil
+	}
+	return []Violation{{
+		Rule:        RuleBlockedCountry,
+		Subject:     order.Country,
+		Description: fmt.Sprintf("orders cannot be shipped to %s", order.Country),
+	}}
+}
+
+type embargoRule []Embargo
+
+func (r embargoRule) Name() string { return RuleEmbargo }
+
+func (r embargoRule) Evaluate(order Order) []Violation {
+	var violations []Violation
+	for _, item := range order.Items {
+		for _, e := range r {
+			if e.ProductID != "" && e.ProductID != item.ProductID {
+				continue
+			}
+			if e.Category != "" && !slices.Contains(item.Categories, e.Category) {
+				continue
+			}
+			if containsCountry(e.Countries, order.Country) {
+				violations = append(violations, Violation{
+					Rule:        RuleEmbargo,
+					Subject:     item.ProductID,
+					Description: fmt.Sprintf("item %s cannot be shipped to %s", item.ProductID, order.Country),
+				})
+				break
+			}
+		}
+	}
+	return violations
+}
+
+// containsCountry reports whether countries contains country, ignoring case
+// since addresses are free text.
+func containsCountry(countries []string, country string) bool {
+	country = strings.TrimSpace(country)
+	return slices.ContainsFunc(countries, func(c string) bool {
+		return strings.EqualFold(c, country)
+	})
+}
+
+// formatUSD formats whole dollar limits without cents, as they are usually
+// configured.
+func formatUSD(v float64) string {
+	if v == float64(int64(v)) {
+		return fmt.Sprintf("%d", int64(v))
+	}
+	return fmt.Sprintf("%.2f", v)
+}
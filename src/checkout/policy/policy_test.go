@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package policy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestEvaluate(t *testing.T) {
+	telescope := Item{ProductID: "66VCHSJNUP", Categories: []string{"telescopes"}, Quantity: 1, PriceUSD: 349.95}
+	lens := Item{ProductID: "OLJCESPC7Z", Categories: []string{"accessories", "binoculars"}, Quantity: 3, PriceUSD: 101.96}
+	book := Item{ProductID: "HQTGWGPNH4", Categories: []string{"books"}, Quantity: 2, PriceUSD: 9.99}
+
+	tests := []struct {
+		name   string
+		policy Policy
+		order  Order
+		want   []Violation
+	}{
+		{
+			name:  "empty policy",
+			order: Order{Items: []Item{telescope, lens}, TotalUSD: 655.83, Country: "Germany"},
+		},
+		{
+			name:   "price limit of every category",
+			policy: Policy{PriceLimitsUSD: map[string]float64{AnyCategory: 100}},
+			order:  Order{Items: []Item{telescope, lens, book}},
+			want: []Violation{
+				{RulePriceLimit, "66VCHSJNUP", "item 66VCHSJNUP costs $349.95 which exceeds the threshold of $100"},
+				{RulePriceLimit, "OLJCESPC7Z", "item OLJCESPC7Z costs $101.96 which exceeds the threshold of $100"},
+			},
+		},
+		{
+			name:   "lowest category price limit applies",
+			policy: Policy{PriceLimitsUSD: map[string]float64{AnyCategory: 500, "binoculars": 50.5, "accessories": 200}},
+			order:  Order{Items: []Item{telescope, lens}},
+			want: []Violation{
+				{RulePriceLimit, "OLJCESPC7Z", "item OLJCESPC7Z costs $101.96 which exceeds the threshold of $50.50"},
+			},
+		},
+		{
+			name:   "quantity summed per product",
+			policy: Policy{MaxQuantityPerSKU: 2},
+			order:  Order{Items: []Item{book, lens, book}},
+			want: []Violation{
+				{RuleQuantityLimit, "HQTGWGPNH4", "item HQTGWGPNH4 is ordered 4 times which exceeds the limit of 2"},
+				{RuleQuantityLimit, "OLJCESPC7Z", "item OLJCESPC7Z is ordered 3 times which exceeds the limit of 2"},
+			},
+		},
+		{
+			name:   "order total",
+			policy: Policy{MaxOrderTotalUSD: 500},
+			order:  Order{Items: []Item{telescope, lens}, TotalUSD: 655.83},
+			want: []Violation{
+				{RuleOrderTotal, "order", "order total of $655.83 exceeds the limit of $500"},
+			},
+		},
+		{
+			name:   "blocked country ignores case",
+			policy: Policy{BlockedCountries: []string{"Atlantis"}},
+			order:  Order{Items: []Item{book}, Country: " atlantis"},
+			want: []Violation{
+				{RuleBlockedCountry, " atlantis", "orders cannot be shipped to  atlantis"},
+			},
+		},
+		{
+			name: "embargoed products and categories",
+			policy: Policy{Embargoes: []Embargo{
+				{ProductID: "66VCHSJNUP", Countries: []string{"Narnia"}},
+				{Category: "binoculars", Countries: []string{"Narnia", "Oz"}},
+			}},
+			order: Order{Items: []Item{telescope, lens, book}, Country: "Narnia"},
+			want: []Violation{
+				{RuleEmbargo, "66VCHSJNUP", "item 66VCHSJNUP cannot be shipped to Narnia"},
+				{RuleEmbargo, "OLJCESPC7Z", "item OLJCESPC7Z cannot be shipped to Narnia"},
+			},
+		},
+		{
+			name: "all violations at once",
+			policy: Policy{
+				PriceLimitsUSD:   map[string]float64{"telescopes": 300},
+				MaxOrderTotalUSD: 300,
+				BlockedCountries: []string{"Oz"},
+			},
+			order: Order{Items: []Item{telescope}, TotalUSD: 359.94, Country: "Oz"},
+			want: []Violation{
+				{RulePriceLimit, "66VCHSJNUP", "item 66VCHSJNUP costs $349.95 which exceeds the threshold of $300"},
+				{RuleOrderTotal, "order", "order total of $359.94 exceeds the limit of $300"},
+				{RuleBlockedCountry, "Oz", "orders cannot be shipped to Oz"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.policy.Evaluate(context.Background(), tt.order); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Evaluate() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWithPriceLimit(t *testing.T) {
+	p := Policy{PriceLimitsUSD: map[string]float64{AnyCategory: 50}}
+	if got := p.WithPriceLimit(AnyCategory, 25).PriceLimitsUSD[AnyCategory]; got != 25 {
+		t.Errorf("lower limit = %v, want 25", got)
+	}
+	if got := p.WithPriceLimit(AnyCategory, 75).PriceLimitsUSD[AnyCategory]; got != 50 {
+		t.Errorf("higher limit = %v, want 50", got)
+	}
+	if p.PriceLimitsUSD[AnyCategory] != 50 {
+		t.Errorf("WithPriceLimit() modified the policy: %v", p.PriceLimitsUSD)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	want := Policy{
+		OnViolation:       ActionHold,
+		PriceLimitsUSD:    map[string]float64{"telescopes": 300},
+		MaxQuantityPerSKU: 5,
+		BlockedCountries:  []string{"Oz"},
+		Embargoes:         []Embargo{{Category: "binoculars", Countries: []string{"Narnia"}}},
+	}
+	files := map[string]string{
+		"policy.json": `{"onViolation": "hold", "priceLimitsUsd": {"telescopes": 300}, "maxQuantityPerSku": 5, "blockedCountries": ["Oz"],
+			"embargoes": [{"category": "binoculars", "countries": ["Narnia"]}]}`,
+		"policy.yaml": "onViolation: hold\npriceLimitsUsd:\n  telescopes: 300\nmaxQuantityPerSku: 5\nblockedCountries: [Oz]\n" +
+			"embargoes:\n  - category: binoculars\n    countries: [Narnia]\n",
+	}
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		got, err := Load(path)
+		if err != nil {
+			t.Fatalf("Load(%s) error = %v", name, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Load(%s) = %+v, want %+v", name, got, want)
+		}
+	}
+
+	fromFlag, err := FromObject(map[string]any{"maxQuantityPerSku": 5.0, "priceLimitsUsd": map[string]any{"telescopes": 300}})
+	if err != nil || fromFlag.MaxQuantityPerSKU != 5 || fromFlag.PriceLimitsUSD["telescopes"] != 300 {
+		t.Errorf("FromObject() = %+v, %v", fromFlag, err)
+	}
+
+	for name, obj := range map[string]any{
+		"negative limit":       map[string]any{"priceLimitsUsd": map[string]any{"books": -1}},
+		"embargo without item": map[string]any{"embargoes": []any{map[string]any{"countries": []any{"Oz"}}}},
+		"embargo without land": map[string]any{"embargoes": []any{map[string]any{"productId": "X"}}},
+		"unknown action":       map[string]any{"onViolation": "ignore"},
+	} {
+		if _, err := FromObject(obj); err == nil {
+			t.Errorf("FromObject(%s) error = nil", name)
+		}
+	}
+}
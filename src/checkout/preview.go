@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/money"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/quote"
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/rpcerr"
+)
+
+// defaultQuoteTTL is how long quotes are honored when CHECKOUT_QUOTE_TTL is
+// not set.
+const defaultQuoteTTL = 15 * time.Minute
+
+// PreviewOrder prices the cart of a user as PlaceOrder would, without
+// charging, shipping or emptying it, and signs the prices in a quote token.
+func (cs *checkout) PreviewOrder(ctx context.Context, req *pb.PreviewOrderRequest) (*pb.PreviewOrderResponse, error) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.String("app.user.id", req.GetUserId()),
+		attribute.String("app.user.currency", req.GetUserCurrency()),
+	)
+	if violations := validatePreviewOrderRequest(req); len(violations) > 0 {
+		return nil, invalidRequestError(ctx, violations)
+	}
+
+	prep, err := cs.prepareOrderItemsAndShippingQuoteFromCart(ctx, req.UserId, req.UserCurrency, req.Address, req.ShippingMethod)
+	if err != nil {
+		return nil, orderError(ctx, codes.Internal, reasonPreparationFailed, err)
+	}
+
+	var holdReasons []string
+	if violated := cs.checkOrderPolicy(ctx, req.Address, prep); violated != nil {
+		if !violated.hold {
+			return nil, violated.status(ctx)
+		}
+		for _, v := range violated.violations {
+			holdReasons = append(holdReasons, v.Description)
+		}
+	}
+
+	q := quote.Quote{
+		UserID:   req.UserId,
+		Currency: req.UserCurrency,
+		Address:  addressKey(req.Address),
+		Items:    make([]quote.Item, len(prep.orderItems)),
+		Shipping: make(map[string]quote.Amount, len(prep.shippingOptions)),
+	}
+	for _, o := range prep.shippingOptions {
+		q.Shipping[o.GetMethod()] = quote.AmountOf(o.GetCost())
+	}
+	for i, it := range prep.orderItems {
+		q.Items[i] = quote.Item{
+			ProductID: it.GetItem().GetProductId(),
+			Quantity:  it.GetItem().GetQuantity(),
+			Cost:      quote.AmountOf(it.GetCost()),
+		}
+	}
+	token, expiresAt, err := cs.quotes.Sign(q, time.Now())
+	if err != nil {
+		return nil, orderError(ctx, codes.Internal, reasonPreparationFailed, fmt.Errorf("failed to sign quote: %+v", err))
+	}
+
+	// Checkout applies no adjustments yet, so the total is the subtotal
+	// plus shipping.
+	total := prep.total(req.UserCurrency)
+	span.SetAttributes(
+		attribute.Float64("app.order.amount", money.ToFloat64(total)),
+		attribute.String("app.quote.expires_at", expiresAt.Format(time.RFC3339)),
+	)
+	log.WithContext(ctx).Infof("[PreviewOrder] quoted %s for %d items until %s", money.Format(total), prep.itemCount(), expiresAt.Format(time.RFC3339))
+
+	shipments, _ := prep.shipmentResults()
+	return &pb.PreviewOrderResponse{
+		Items:           prep.orderItems,
+		Subtotal:        prep.subtotal(req.UserCurrency),
+		ShippingCost:    prep.shippingCostLocalized,
+		ShippingMethod:  prep.shippingMethod,
+		ShippingOptions: prep.shippingOptions,
+		Total:           total,
+		QuoteToken:      token,
+		ExpiresAt:       expiresAt.Unix(),
+		HoldReasons:     holdReasons,
+		Shipments:       shipments,
+	}, nil
+}
+
+// applyQuote checks that the order in prep, placed with req, is the one
+// quoted by the quote token of req, and prices it as quoted, in the user
+// currency and in USD. It redeems the quote, which the caller releases if
+// the order fails.
+func (cs *checkout) applyQuote(ctx context.Context, req *pb.PlaceOrderRequest, prep *orderPrep) (quote.Quote, error) {
+	now := time.Now()
+	q, err := cs.quotes.Verify(req.GetQuoteToken(), now)
+	switch {
+	case errors.Is(err, quote.ErrExpired):
+		return q, rpcerr.Error(ctx, codes.FailedPrecondition, "Quote expired, preview the order again",
+			rpcerr.ErrorInfo(errorDomain, reasonQuoteExpired, "expired_at", time.Unix(q.ExpiresAt, 0).UTC().Format(time.RFC3339)))
+	case err != nil:
+		return q, invalidRequestError(ctx, []rpcerr.FieldViolation{{Field: "quote_token", Description: "is not a valid quote token"}})
+	}
+	if mismatch := quoteMismatch(q, req, *prep); mismatch != "" {
+		return q, rpcerr.Error(ctx, codes.FailedPrecondition, fmt.Sprintf("Order differs from its quote: %s, preview the order again", mismatch),
+			rpcerr.ErrorInfo(errorDomain, reasonQuoteMismatch))
+	}
+
+	quoted := *prep
+	quoted.orderItems = make([]*pb.OrderItem, len(prep.orderItems))
+	for i, it := range prep.orderItems {
+		quoted.orderItems[i] = &pb.OrderItem{Item: it.GetItem(), Cost: q.Items[i].Cost.Money(q.Currency)}
+	}
+	quoted.shipments = make([]*shipment, len(prep.shipments))
+	for i, s := range prep.shipments {
+		c := *s
+		quoted.shipments[i] = &c
+	}
+	quoted.shippingCostLocalized = q.Shipping[prep.shippingMethod].Money(q.Currency)
+	if err := quoted.allocateShipping(); err != nil {
+		return q, orderError(ctx, codes.Internal, reasonPreparationFailed, err)
+	}
+	// The order policy and risk scoring see the quoted total.
+	quoted.totalUSD = quoted.total(q.Currency)
+	if q.Currency != "USD" {
+		if quoted.totalUSD, err = cs.convertCurrency(ctx, quoted.totalUSD, "USD"); err != nil {
+			return q, orderError(ctx, codes.Internal, reasonPreparationFailed, fmt.Errorf("failed to convert quoted total to USD: %+v", err))
+		}
+	}
+
+	if !cs.quoteRedemptions.Redeem(q, now) {
+		return q, rpcerr.Error(ctx, codes.FailedPrecondition, "Quote was already used for an order, preview the order again",
+			rpcerr.ErrorInfo(errorDomain, reasonQuoteUsed))
+	}
+	*prep = quoted
+	trace.SpanFromContext(ctx).SetAttributes(attribute.String("app.quote.expires_at", time.Unix(q.ExpiresAt, 0).UTC().Format(time.RFC3339)))
+	return q, nil
+}
+
+// quoteMismatch returns how the order in prep, placed with req, differs
+// from q, or "" if it does not.
+func quoteMismatch(q quote.Quote, req *pb.PlaceOrderRequest, prep orderPrep) string {
+	switch {
+	case q.UserID != req.GetUserId():
+		return "the quote is for another user"
+	case q.Currency != req.GetUserCurrency():
+		return fmt.Sprintf("the quote is in %s", q.Currency)
+	case q.Address != addressKey(req.GetAddress()):
+		return "the shipping address changed"
+	case len(q.Items) != len(prep.orderItems):
+		return "the cart changed"
+	}
+	if _, ok := q.Shipping[prep.shippingMethod]; !ok {
+		return fmt.Sprintf("the quote has no %s shipping", prep.shippingMethod)
+	}
+	for i, it := range prep.orderItems {
+		if q.Items[i].ProductID != it.GetItem().GetProductId() || q.Items[i].Quantity != it.GetItem().GetQuantity() {
+			return "the cart changed"
+		}
+	}
+	return ""
+}
+
+// addressKey identifies address in quotes without revealing it.
+func addressKey(a *pb.Address) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		a.GetStreetAddress(), a.GetCity(), a.GetState(), a.GetCountry(), a.GetZipCode(),
+	}, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+
+	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/money"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/quote"
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/rpcerr"
+)
+
+func TestApplyQuote(t *testing.T) {
+	address := &pb.Address{StreetAddress: "1600 Amphitheatre Parkway", City: "Mountain View", Country: "United States", ZipCode: "94043"}
+	newPrep := func(quantity int32, method string) orderPrep {
+		return orderPrep{
+			orderItems: []*pb.OrderItem{
+				{Item: &pb.CartItem{ProductId: "OLJCESPC7Z", Quantity: quantity}, Cost: usd(101, 960000000)},
+				{Item: &pb.CartItem{ProductId: "66VCHSJNUP", Quantity: 1}, Cost: usd(349, 950000000)},
+			},
+			shippingMethod:        method,
+			shippingCostLocalized: usd(17, 980000000),
+		}
+	}
+	cs := &checkout{quotes: quote.NewSigner([]byte("secret"), time.Minute), quoteRedemptions: quote.NewRedemptions()}
+
+	// The quote was priced before the prices of the first item and of
+	// shipping went up.
+	quoted := quote.Quote{
+		UserID:   "alice",
+		Currency: "USD",
+		Address:  addressKey(address),
+		Items: []quote.Item{
+			{ProductID: "OLJCESPC7Z", Quantity: 2, Cost: quote.Amount{Units: 99, Nanos: 990000000}},
+			{ProductID: "66VCHSJNUP", Quantity: 1, Cost: quote.Amount{Units: 349, Nanos: 950000000}},
+		},
+		Shipping: map[string]quote.Amount{shippingStandard: {Units: 2, Nanos: 745000000}, shippingExpress: {Units: 5, Nanos: 490000000}},
+	}
+	token, _, err := cs.quotes.Sign(quoted, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired, _, _ := quote.NewSigner([]byte("secret"), -time.Minute).Sign(quoted, time.Now())
+
+	prep := newPrep(2, shippingExpress)
+	req := &pb.PlaceOrderRequest{ShippingMethod: shippingExpress, UserId: "alice", UserCurrency: "USD", Address: address, QuoteToken: token}
+	q, err := cs.applyQuote(context.Background(), req, &prep)
+	if err != nil {
+		t.Fatalf("applyQuote() error = %v", err)
+	}
+	if total := prep.total("USD"); !money.AreEquals(total, usd(555, 420000000)) {
+		t.Errorf("total = %v, want the quoted 555.42", total)
+	}
+	if !money.AreEquals(prep.totalUSD, usd(555, 420000000)) {
+		t.Errorf("total in USD = %v, want the quoted 555.42", prep.totalUSD)
+	}
+
+	// The quote pays for a single order, unless that order fails.
+	replayed := newPrep(2, shippingExpress)
+	_, err = cs.applyQuote(context.Background(), req, &replayed)
+	if d, _ := rpcerr.Decode(err); d.Code != codes.FailedPrecondition || d.Reason != reasonQuoteUsed {
+		t.Errorf("applyQuote() replayed = %v, want %s", err, reasonQuoteUsed)
+	}
+	if !money.AreEquals(replayed.orderItems[0].Cost, usd(101, 960000000)) {
+		t.Errorf("item cost = %v, want it unchanged", replayed.orderItems[0].Cost)
+	}
+	cs.quoteRedemptions.Release(q)
+	if _, err := cs.applyQuote(context.Background(), req, &replayed); err != nil {
+		t.Errorf("applyQuote() after the order failed = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		req        *pb.PlaceOrderRequest
+		quantity   int32
+		method     string
+		wantCode   codes.Code
+		wantReason string
+	}{
+		{"other user", &pb.PlaceOrderRequest{UserId: "bob", UserCurrency: "USD", Address: address, QuoteToken: token}, 2, shippingExpress, codes.FailedPrecondition, reasonQuoteMismatch},
+		{"other currency", &pb.PlaceOrderRequest{UserId: "alice", UserCurrency: "EUR", Address: address, QuoteToken: token}, 2, shippingExpress, codes.FailedPrecondition, reasonQuoteMismatch},
+		{"other address", &pb.PlaceOrderRequest{UserId: "alice", UserCurrency: "USD", Address: &pb.Address{Country: "Germany"}, QuoteToken: token}, 2, shippingExpress, codes.FailedPrecondition, reasonQuoteMismatch},
+		{"cart changed", req, 3, shippingExpress, codes.FailedPrecondition, reasonQuoteMismatch},
+		{"shipping not quoted", &pb.PlaceOrderRequest{UserId: "alice", UserCurrency: "USD", Address: address, QuoteToken: token}, 2, shippingOvernight, codes.FailedPrecondition, reasonQuoteMismatch},
+		{"expired", &pb.PlaceOrderRequest{UserId: "alice", UserCurrency: "USD", Address: address, QuoteToken: expired}, 2, shippingExpress, codes.FailedPrecondition, reasonQuoteExpired},
+		{"forged", &pb.PlaceOrderRequest{UserId: "alice", UserCurrency: "USD", Address: address, QuoteToken: token + "x"}, 2, shippingExpress, codes.InvalidArgument, reasonInvalidRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prep := newPrep(tt.quantity, tt.method)
+			_, err := cs.applyQuote(context.Background(), tt.req, &prep)
+			d, ok := rpcerr.Decode(err)
+			if !ok || d.Code != tt.wantCode || d.Reason != tt.wantReason {
+				t.Errorf("applyQuote() = %+v, %t, want %s %s", d, ok, tt.wantCode, tt.wantReason)
+			}
+			if !money.AreEquals(prep.orderItems[0].Cost, usd(101, 960000000)) {
+				t.Errorf("item cost = %v, want it unchanged", prep.orderItems[0].Cost)
+			}
+		})
+	}
+}
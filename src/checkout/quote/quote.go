@@ -0,0 +1,164 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package quote signs the price quotes of orders, so that an order placed
+// with the token of a quote is charged the quoted prices. Tokens carry the
+// quote itself, signed with HMAC-SHA256, and expire after the TTL of the
+// Signer that issued them. Redemptions make each quote pay for a single
+// order.
+package quote
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
+)
+
+var (
+	ErrInvalid = errors.New("quote token is malformed or was not signed by checkout")
+	ErrExpired = errors.New("quote token expired")
+)
+
+// Amount is an amount of money in the currency of its quote.
+type Amount struct {
+	Units int64 `json:"u,omitempty"`
+	Nanos int32 `json:"n,omitempty"`
+}
+
+// AmountOf returns the amount of m.
+func AmountOf(m *pb.Money) Amount {
+	return Amount{Units: m.GetUnits(), Nanos: m.GetNanos()}
+}
+
+// Money returns a in currency.
+func (a Amount) Money(currency string) *pb.Money {
+	return &pb.Money{CurrencyCode: currency, Units: a.Units, Nanos: a.Nanos}
+}
+
+// Item is a quoted cart item.
+type Item struct {
+	ProductID string `json:"p"`
+	Quantity  int32  `json:"q"`
+	// Cost is the unit price of the product.
+	Cost Amount `json:"c"`
+}
+
+// Quote is the priced cart of a user.
+type Quote struct {
+	// ID is set by Sign, and identifies the quote in Redemptions.
+	ID       string `json:"id"`
+	UserID   string `json:"uid"`
+	Currency string `json:"cur"`
+	// Address identifies the shipping address the shipping cost was quoted
+	// for.
+	Address string `json:"addr"`
+	Items   []Item `json:"items"`
+	// Shipping are the costs of the shipping options, by method.
+	Shipping map[string]Amount `json:"ship"`
+	// ExpiresAt is set by Sign, in Unix seconds.
+	ExpiresAt int64 `json:"exp"`
+}
+
+// Signer signs and verifies quote tokens.
+type Signer struct {
+	key []byte
+	ttl time.Duration
+}
+
+// NewSigner returns a Signer of tokens that expire after ttl.
+func NewSigner(key []byte, ttl time.Duration) *Signer {
+	return &Signer{key: key, ttl: ttl}
+}
+
+// Sign returns the token of q at now, and the time it expires.
+func (s *Signer) Sign(q Quote, now time.Time) (string, time.Time, error) {
+	id := make([]byte, 12)
+	if _, err := rand.Read(id); err != nil {
+		return "", time.Time{}, err
+	}
+	q.ID = base64.RawURLEncoding.EncodeToString(id)
+	expiresAt := now.Add(s.ttl).Truncate(time.Second)
+	q.ExpiresAt = expiresAt.Unix()
+	payload, err := json.Marshal(q)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(s.mac(encoded)), expiresAt, nil
+}
+
+// Verify returns the quote of token if it was signed by s and did not
+// expire at now.
+func (s *Signer) Verify(token string, now time.Time) (Quote, error) {
+	encoded, sig, ok := strings.Cut(token, ".")
+	if !ok {
+		return Quote{}, ErrInvalid
+	}
+	mac, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil || !hmac.Equal(mac, s.mac(encoded)) {
+		return Quote{}, ErrInvalid
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Quote{}, ErrInvalid
+	}
+	var q Quote
+	if err := json.Unmarshal(payload, &q); err != nil {
+		return Quote{}, ErrInvalid
+	}
+	if now.Unix() >= q.ExpiresAt {
+		return q, ErrExpired
+	}
+	return q, nil
+}
+
+func (s *Signer) mac(encoded string) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(encoded))
+	return h.Sum(nil)
+}
+
+// Redemptions records the quotes that orders were placed with until they
+// expire, so that a quote cannot be replayed.
+type Redemptions struct {
+	mu sync.Mutex
+	// expiresAt are the expiry times of the redeemed quotes, by ID.
+	expiresAt map[string]int64
+}
+
+func NewRedemptions() *Redemptions {
+	return &Redemptions{expiresAt: make(map[string]int64)}
+}
+
+// Redeem records the redemption of q at now, and reports whether q was not
+// redeemed yet. The quotes that expired by now are forgotten.
+func (r *Redemptions) Redeem(q Quote, now time.Time) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, expiresAt := range r.expiresAt {
+		if now.Unix() >= expiresAt {
+			delete(r.expiresAt, id)
+		}
+	}
+	if _, ok := r.expiresAt[q.ID]; ok {
+		return false
+	}
+	r.expiresAt[q.ID] = q.ExpiresAt
+	return true
+}
+
+// Release forgets the redemption of q, so that it can be redeemed again. It
+// is used when the order placed with q fails.
+func (r *Redemptions) Release(q Quote) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.expiresAt, q.ID)
+}
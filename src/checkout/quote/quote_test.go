@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package quote
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSigner(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	s := NewSigner([]byte("secret"), 15*time.Minute)
+	q := Quote{
+		UserID:   "alice",
+		Currency: "EUR",
+		Address:  "a1b2",
+		Items:    []Item{{ProductID: "OLJCESPC7Z", Quantity: 2, Cost: Amount{Units: 93, Nanos: 990000000}}},
+		Shipping: map[string]Amount{"standard": {Units: 8, Nanos: 990000000}, "pickup": {}},
+	}
+	token, expiresAt, err := s.Sign(q, now)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+	if !expiresAt.Equal(now.Add(15 * time.Minute)) {
+		t.Errorf("Sign() expires at %v", expiresAt)
+	}
+
+	got, err := s.Verify(token, now.Add(14*time.Minute))
+	q.ID, q.ExpiresAt = got.ID, expiresAt.Unix()
+	if err != nil || got.ID == "" || !reflect.DeepEqual(got, q) {
+		t.Errorf("Verify() = %+v, %v, want %+v", got, err, q)
+	}
+
+	payload, sig, _ := strings.Cut(token, ".")
+	tampered, _, _ := NewSigner([]byte("secret"), time.Hour).Sign(Quote{UserID: "mallory"}, now)
+	other := NewSigner([]byte("other"), 15*time.Minute)
+	tests := map[string]struct {
+		signer *Signer
+		token  string
+		at     time.Time
+		want   error
+	}{
+		"expired":         {s, token, now.Add(15 * time.Minute), ErrExpired},
+		"other key":       {other, token, now, ErrInvalid},
+		"swapped payload": {s, strings.SplitN(tampered, ".", 2)[0] + "." + sig, now, ErrInvalid},
+		"no signature":    {s, payload, now, ErrInvalid},
+		"garbage":         {s, "not.a-token", now, ErrInvalid},
+		"garbage payload": {s, "!!!." + sig, now, ErrInvalid},
+	}
+	for name, tt := range tests {
+		if _, err := tt.signer.Verify(tt.token, tt.at); err != tt.want {
+			t.Errorf("%s: Verify() error = %v, want %v", name, err, tt.want)
+		}
+	}
+}
+
+func TestRedemptions(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	s := NewSigner([]byte("secret"), 15*time.Minute)
+	sign := func(at time.Time) Quote {
+		token, _, err := s.Sign(Quote{UserID: "alice"}, at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		q, err := s.Verify(token, at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return q
+	}
+	first, second := sign(now), sign(now)
+
+	r := NewRedemptions()
+	if !r.Redeem(first, now) {
+		t.Fatal("Redeem() = false, want the first redemption to succeed")
+	}
+	if r.Redeem(first, now.Add(time.Minute)) {
+		t.Error("Redeem() twice = true")
+	}
+	if !r.Redeem(second, now) {
+		t.Error("Redeem() of another quote of the same cart = false")
+	}
+
+	// Released quotes can be redeemed again.
+	r.Release(first)
+	if !r.Redeem(first, now.Add(time.Minute)) {
+		t.Error("Redeem() after Release() = false")
+	}
+
+	// Expired quotes are forgotten.
+	later := sign(now.Add(15 * time.Minute))
+	r.Redeem(later, now.Add(15*time.Minute))
+	if _, ok := r.expiresAt[first.ID]; ok || len(r.expiresAt) != 1 {
+		t.Errorf("redemptions after expiry = %v", r.expiresAt)
+	}
+}
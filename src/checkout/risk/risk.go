@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package risk assesses the fraud risk of an order before its card is
+// charged. A Scorer turns an order into a score between 0 and 100, the
+// signals that contributed to it and the resulting decision: orders are
+// allowed, sent to manual review or denied.
+package risk
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Decision is what to do with an order given its risk.
+type Decision string
+
+// Decisions, from the least to the most risky.
+const (
+	Allow  Decision = "allow"
+	Review Decision = "review"
+	Deny   Decision = "deny"
+)
+
+// Names of the signals of the RuleScorer.
+const (
+	SignalUserVelocity     = "user_velocity"
+	SignalCardVelocity     = "card_velocity"
+	SignalCountryMismatch  = "country_mismatch"
+	SignalHighTotal        = "high_total"
+	SignalNewUserHighValue = "new_user_high_value"
+)
+
+// Order is an order as seen by a Scorer.
+type Order struct {
+	UserID string
+	// CardFingerprint identifies the card without its number.
+	CardFingerprint string
+	ShippingCountry string
+	// BillingCountry is empty if the order has no billing address.
+	BillingCountry string
+	TotalUSD       float64
+	// At is when the order was placed, now if zero.
+	At time.Time
+}
+
+// Signal is an indicator of fraud found in an order, which adds Score to
+// the score of the order.
+type Signal struct {
+	Name        string
+	Score       float64
+	Description string
+}
+
+// Assessment is the risk of an order.
+type Assessment struct {
+	Score    float64
+	Decision Decision
+	Signals  []Signal
+}
+
+// SignalNames returns the names of the signals of a.
+func (a Assessment) SignalNames() []string {
+	names := make([]string, len(a.Signals))
+	for i, s := range a.Signals {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// Scorer assesses the risk of orders.
+type Scorer interface {
+	Score(ctx context.Context, order Order) (Assessment, error)
+}
+
+// Rules configures the RuleScorer. Zero thresholds disable their signal.
+type Rules struct {
+	// VelocityWindow is the period over which orders are counted for the
+	// velocity signals.
+	VelocityWindow time.Duration
+	// MaxOrdersPerUser and MaxOrdersPerCard are the number of orders in
+	// VelocityWindow above which a user or a card is suspicious.
+	MaxOrdersPerUser int
+	MaxOrdersPerCard int
+	// HighTotalUSD is the order total above which an order is suspicious.
+	HighTotalUSD float64
+	// NewUserWindow is how long users are remembered. Users without orders
+	// in that period are new, and suspicious above NewUserHighValueUSD.
+	NewUserWindow       time.Duration
+	NewUserHighValueUSD float64
+	// Weights are the scores of the signals, by name.
+	Weights map[string]float64
+	// ReviewScore and DenyScore are the scores from which orders are
+	// reviewed and denied.
+	ReviewScore float64
+	DenyScore   float64
+}
+
+// DefaultRules are the rules of a RuleScorer tuned for a web shop.
+func DefaultRules() Rules {
+	return Rules{
+		VelocityWindow:      time.Hour,
+		MaxOrdersPerUser:    5,
+		MaxOrdersPerCard:    5,
+		HighTotalUSD:        2000,
+		NewUserWindow:       30 * 24 * time.Hour,
+		NewUserHighValueUSD: 500,
+		Weights: map[string]float64{
+			SignalUserVelocity:     40,
+			SignalCardVelocity:     50,
+			SignalCountryMismatch:  30,
+			SignalHighTotal:        30,
+			SignalNewUserHighValue: 25,
+		},
+		ReviewScore: 40,
+		DenyScore:   80,
+	}
+}
+
+// RuleScorer scores orders with fixed rules over the recent orders kept in
+// a Window. Every scored order is added to the window, whatever the
+// decision, so that repeated attempts raise the velocity signals.
+type RuleScorer struct {
+	rules  Rules
+	window *Window
+}
+
+// NewRuleScorer returns a RuleScorer with rules.
+func NewRuleScorer(rules Rules) *RuleScorer {
+	retention := max(rules.VelocityWindow, rules.NewUserWindow)
+	return &RuleScorer{rules: rules, window: NewWindow(retention)}
+}
+
+// Score implements Scorer.
+func (s *RuleScorer) Score(_ context.Context, order Order) (Assessment, error) {
+	at := order.At
+	if at.IsZero() {
+		at = time.Now()
+	}
+	r := s.rules
+	userKey, cardKey := "user:"+order.UserID, "card:"+order.CardFingerprint
+
+	var signals []Signal
+	add := func(name, description string) {
+		if w := r.Weights[name]; w > 0 {
+			signals = append(signals, Signal{Name: name, Score: w, Description: description})
+		}
+	}
+
+	if n := s.window.Count(userKey, at.Add(-r.VelocityWindow), at) + 1; r.MaxOrdersPerUser > 0 && n > r.MaxOrdersPerUser {
+		add(SignalUserVelocity, fmt.Sprintf("user placed %d orders in %s", n, r.VelocityWindow))
+	}
+	if n := s.window.Count(cardKey, at.Add(-r.VelocityWindow), at) + 1; order.CardFingerprint != "" && r.MaxOrdersPerCard > 0 && n > r.MaxOrdersPerCard {
+		add(SignalCardVelocity, fmt.Sprintf("card was used for %d orders in %s", n, r.VelocityWindow))
+	}
+	if order.BillingCountry != "" && !strings.EqualFold(strings.TrimSpace(order.BillingCountry), strings.TrimSpace(order.ShippingCountry)) {
+		add(SignalCountryMismatch, fmt.Sprintf("billing country %s differs from shipping country %s", order.BillingCountry, order.ShippingCountry))
+	}
+	if r.HighTotalUSD > 0 && order.TotalUSD > r.HighTotalUSD {
+		add(SignalHighTotal, fmt.Sprintf("order total of $%.2f exceeds $%.2f", order.TotalUSD, r.HighTotalUSD))
+	}
+	if r.NewUserHighValueUSD > 0 && order.TotalUSD > r.NewUserHighValueUSD && s.window.Count(userKey, at.Add(-r.NewUserWindow), at) == 0 {
+		add(SignalNewUserHighValue, fmt.Sprintf("new user placed an order of $%.2f", order.TotalUSD))
+	}
+
+	s.window.Add(userKey, at)
+	if order.CardFingerprint != "" {
+		s.window.Add(cardKey, at)
+	}
+
+	a := Assessment{Decision: Allow, Signals: signals}
+	for _, sig := range signals {
+		a.Score += sig.Score
+	}
+	a.Score = min(a.Score, 100)
+	switch {
+	case r.DenyScore > 0 && a.Score >= r.DenyScore:
+		a.Decision = Deny
+	case r.ReviewScore > 0 && a.Score >= r.ReviewScore:
+		a.Decision = Review
+	}
+	return a, nil
+}
+
+// Window keeps the times of the events of each key for a retention period,
+// so that the events in any window within that period can be counted. It is
+// safe for concurrent use.
+type Window struct {
+	retention time.Duration
+	mu        sync.Mutex
+	events    map[string][]time.Time
+	lastPrune time.Time
+}
+
+// pruneInterval is how often a Window drops old events.
+const pruneInterval = time.Minute
+
+// NewWindow returns a Window that keeps events for retention.
+func NewWindow(retention time.Duration) *Window {
+	return &Window{retention: retention, events: make(map[string][]time.Time)}
+}
+
+// Add records an event of key at. Events older than the retention period
+// are dropped.
+func (w *Window) Add(key string, at time.Time) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.events[key] = append(w.events[key], at)
+	if at.Sub(w.lastPrune) > pruneInterval {
+		w.prune(at)
+		w.lastPrune = at
+	}
+}
+
+// Count returns the number of events of key in [from, to].
+func (w *Window) Count(key string, from, to time.Time) int {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var n int
+	for _, at := range w.events[key] {
+		if !at.Before(from) && !at.After(to) {
+			n++
+		}
+	}
+	return n
+}
+
+// prune drops the events older than the retention period before now, and
+// the keys left without events.
+func (w *Window) prune(now time.Time) {
+	cutoff := now.Add(-w.retention)
+	for key, events := range w.events {
+		i := 0
+		for i < len(events) && events[i].Before(cutoff) {
+			i++
+		}
+		if i == len(events) {
+			delete(w.events, key)
+		} else if i > 0 {
+			w.events[key] = events[i:]
+		}
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package risk
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRuleScorer(t *testing.T) {
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	rules := Rules{
+		VelocityWindow:      time.Hour,
+		MaxOrdersPerUser:    2,
+		MaxOrdersPerCard:    2,
+		HighTotalUSD:        1000,
+		NewUserWindow:       24 * time.Hour,
+		NewUserHighValueUSD: 300,
+		Weights: map[string]float64{
+			SignalUserVelocity:     40,
+			SignalCardVelocity:     50,
+			SignalCountryMismatch:  30,
+			SignalHighTotal:        30,
+			SignalNewUserHighValue: 25,
+		},
+		ReviewScore: 40,
+		DenyScore:   80,
+	}
+
+	tests := []struct {
+		name         string
+		order        Order
+		wantScore    float64
+		wantDecision Decision
+		wantSignals  []string
+	}{
+		{
+			name:         "new user with a small order",
+			order:        Order{UserID: "alice", CardFingerprint: "card-a", ShippingCountry: "Germany", TotalUSD: 50, At: start},
+			wantDecision: Allow,
+			wantSignals:  []string{},
+		},
+		{
+			name:         "new user with a large order",
+			order:        Order{UserID: "bob", CardFingerprint: "card-b", ShippingCountry: "Germany", TotalUSD: 400, At: start},
+			wantScore:    25,
+			wantDecision: Allow,
+			wantSignals:  []string{SignalNewUserHighValue},
+		},
+		{
+			name:         "known user ships abroad",
+			order:        Order{UserID: "alice", CardFingerprint: "card-a", ShippingCountry: "Germany", BillingCountry: "france", TotalUSD: 400, At: start.Add(time.Minute)},
+			wantScore:    30,
+			wantDecision: Allow,
+			wantSignals:  []string{SignalCountryMismatch},
+		},
+		{
+			name:         "user velocity and high total",
+			order:        Order{UserID: "alice", CardFingerprint: "card-c", ShippingCountry: "Germany", TotalUSD: 1500, At: start.Add(2 * time.Minute)},
+			wantScore:    70,
+			wantDecision: Review,
+			wantSignals:  []string{SignalUserVelocity, SignalHighTotal},
+		},
+		{
+			name:         "velocity window slides",
+			order:        Order{UserID: "alice", CardFingerprint: "card-a", ShippingCountry: "Germany", TotalUSD: 50, At: start.Add(2 * time.Hour)},
+			wantDecision: Allow,
+			wantSignals:  []string{},
+		},
+		{
+			name:         "card shared by users",
+			order:        Order{UserID: "carol", CardFingerprint: "card-a", ShippingCountry: "Germany", BillingCountry: "Germany", TotalUSD: 50, At: start.Add(2*time.Hour + time.Minute)},
+			wantDecision: Allow,
+			wantSignals:  []string{},
+		},
+		{
+			name:         "card velocity and new user with a large order",
+			order:        Order{UserID: "dave", CardFingerprint: "card-a", ShippingCountry: "Germany", BillingCountry: "Spain", TotalUSD: 350, At: start.Add(2*time.Hour + 2*time.Minute)},
+			wantScore:    100,
+			wantDecision: Deny,
+			wantSignals:  []string{SignalCardVelocity, SignalCountryMismatch, SignalNewUserHighValue},
+		},
+	}
+
+	// The cases share the scorer, so that each sees the orders before it.
+	s := NewRuleScorer(rules)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := s.Score(context.Background(), tt.order)
+			if err != nil {
+				t.Fatalf("Score() error = %v", err)
+			}
+			if got.Score != tt.wantScore || got.Decision != tt.wantDecision || !reflect.DeepEqual(got.SignalNames(), tt.wantSignals) {
+				t.Errorf("Score() = %v %s %v, want %v %s %v", got.Score, got.Decision, got.SignalNames(), tt.wantScore, tt.wantDecision, tt.wantSignals)
+			}
+		})
+	}
+}
+
+func TestWindow(t *testing.T) {
+	start := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	w := NewWindow(time.Hour)
+	w.Add("a", start)
+	w.Add("a", start.Add(30*time.Minute))
+	w.Add("b", start.Add(30*time.Minute))
+
+	if n := w.Count("a", start, start.Add(time.Hour)); n != 2 {
+		t.Errorf("Count(a) = %d, want 2", n)
+	}
+	if n := w.Count("a", start.Add(time.Minute), start.Add(time.Hour)); n != 1 {
+		t.Errorf("Count(a) after the first event = %d, want 1", n)
+	}
+
+	// Adding an event after the retention period drops the older ones.
+	w.Add("c", start.Add(2*time.Hour))
+	if n := w.Count("a", start, start.Add(3*time.Hour)); n != 0 {
+		t.Errorf("Count(a) after retention = %d, want 0", n)
+	}
+	if _, ok := w.events["b"]; ok {
+		t.Error("key without events was not dropped")
+	}
+}
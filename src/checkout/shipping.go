@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/fulfillment"
+	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/money"
+)
+
+// Shipping methods orders can be placed with.
+const (
+	shippingStandard  = "standard"
+	shippingExpress   = "express"
+	shippingOvernight = "overnight"
+	shippingPickup    = "pickup"
+)
+
+// shippingMethod is priced off the quote of the shipping service, which is
+// the cost of standard shipping.
+type shippingMethod struct {
+	name string
+	// rate multiplies the cost of standard shipping. Methods with a rate of
+	// 0 are free.
+	rate             uint32
+	minDays, maxDays int32
+}
+
+// shippingMethods are the methods offered for every order.
+var shippingMethods = []shippingMethod{
+	{name: shippingStandard, rate: 1, minDays: 5, maxDays: 7},
+	{name: shippingExpress, rate: 2, minDays: 2, maxDays: 3},
+	{name: shippingOvernight, rate: 4, minDays: 1, maxDays: 1},
+	{name: shippingPickup, rate: 0, minDays: 1, maxDays: 2},
+}
+
+// findShippingMethod returns the shipping method called name, or standard
+// shipping if name is empty, and reports whether there is one.
+func findShippingMethod(name string) (shippingMethod, bool) {
+	if name == "" {
+		name = shippingStandard
+	}
+	for _, m := range shippingMethods {
+		if m.name == name {
+			return m, true
+		}
+	}
+	return shippingMethod{}, false
+}
+
+// shippingMethodNames returns the names of the shipping methods, for
+// errors.
+func shippingMethodNames() string {
+	names := make([]string, len(shippingMethods))
+	for i, m := range shippingMethods {
+		names[i] = m.name
+	}
+	return strings.Join(names, ", ")
+}
+
+// cost returns the cost of m for an order whose standard shipping costs
+// standard.
+func (m shippingMethod) cost(standard *pb.Money) *pb.Money {
+	if m.rate == 0 {
+		return &pb.Money{CurrencyCode: standard.GetCurrencyCode()}
+	}
+	return money.MultiplySlow(standard, m.rate)
+}
+
+// shippingOptions returns the shipping options of an order whose standard
+// shipping costs standard.
+func shippingOptions(standard *pb.Money) []*pb.ShippingOption {
+	options := make([]*pb.ShippingOption, len(shippingMethods))
+	for i, m := range shippingMethods {
+		options[i] = &pb.ShippingOption{
+			Method:          m.name,
+			Cost:            m.cost(standard),
+			MinDeliveryDays: m.minDays,
+			MaxDeliveryDays: m.maxDays,
+		}
+	}
+	return options
+}
+
+// Statuses of the shipments of orders.
+const (
+	shipmentPlanned = "PLANNED"
+	shipmentShipped = "SHIPPED"
+	shipmentFailed  = "FAILED"
+)
+
+// shipment is the part of an order shipped from one warehouse.
+type shipment struct {
+	warehouse string
+	items     []*pb.CartItem
+	// cost is the part of the shipping cost of the order the shipment
+	// accounts for, in the currency of the order.
+	cost       *pb.Money
+	trackingID string
+	// failure is why the shipment could not be shipped, if it failed.
+	failure string
+}
+
+func (s *shipment) status() string {
+	switch {
+	case s.trackingID != "":
+		return shipmentShipped
+	case s.failure != "":
+		return shipmentFailed
+	}
+	return shipmentPlanned
+}
+
+// planShipments groups items, whose catalog entries are products, into the
+// shipments of the fulfillment config, and quotes each to address. The
+// shipments cost method in currency. It also returns the cost of standard
+// shipping of all the shipments, in USD and in currency, which the other
+// methods are priced off.
+func (cs *checkout) planShipments(ctx context.Context, address *pb.Address, items []*pb.CartItem, products []*pb.Product, currency string, method shippingMethod) ([]*shipment, *pb.Money, *pb.Money, error) {
+	planned := make([]fulfillment.Item, len(items))
+	for i, it := range items {
+		planned[i] = fulfillment.Item{ProductID: it.GetProductId(), Categories: products[i].GetCategories()}
+	}
+
+	var shipments []*shipment
+	standardUSD, standard := &pb.Money{CurrencyCode: "USD"}, &pb.Money{CurrencyCode: currency}
+	for _, p := range cs.fulfillment.Plan(planned) {
+		s := &shipment{warehouse: p.Warehouse, items: make([]*pb.CartItem, len(p.Items))}
+		for i, n := range p.Items {
+			s.items[i] = items[n]
+		}
+		costUSD, err := cs.quoteShipping(ctx, address, s.items)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("shipping quote failure for warehouse %s: %+v", s.warehouse, err)
+		}
+		cost, err := cs.convertCurrency(ctx, costUSD, currency)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to convert shipping cost to currency: %+v", err)
+		}
+		if standardUSD, err = money.Sum(standardUSD, costUSD); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to add shipping cost of warehouse %s: %+v", s.warehouse, err)
+		}
+		if standard, err = money.Sum(standard, cost); err != nil {
+			return nil, nil, nil, fmt.Errorf("failed to add shipping cost of warehouse %s: %+v", s.warehouse, err)
+		}
+		s.cost = method.cost(cost)
+		shipments = append(shipments, s)
+	}
+	return shipments, standardUSD, standard, nil
+}
+
+// allocateShipping splits the shipping cost of p across its shipments in
+// turn, each taking at most its own cost and the last one all that remains.
+// It is used once the shipping cost of p is set from a quote.
+func (p *orderPrep) allocateShipping() error {
+	if len(p.shipments) == 0 {
+		return nil
+	}
+	limits := make([]*pb.Money, len(p.shipments))
+	for i, s := range p.shipments[:len(p.shipments)-1] {
+		limits[i] = s.cost
+	}
+	costs, _, err := money.Allocate(p.shippingCostLocalized, limits)
+	if err != nil {
+		return fmt.Errorf("failed to allocate %s across shipments: %+v", money.Format(p.shippingCostLocalized), err)
+	}
+	for i, s := range p.shipments {
+		s.cost = costs[i]
+	}
+	return nil
+}
+
+// shipShipments ships the shipments of order that have not shipped, each
+// recorded as a shipped or shipment_failed span event. A shipment that
+// fails does not stop the others, and is marked as failed. It returns the
+// number of shipments that shipped.
+func (cs *checkout) shipShipments(ctx context.Context, order *pendingOrder) int {
+	span := trace.SpanFromContext(ctx)
+	var shipped int
+	for _, s := range order.prep.shipments {
+		if s.trackingID != "" {
+			shipped++
+			continue
+		}
+		attrs := []attribute.KeyValue{
+			attribute.String("app.shipping.warehouse", s.warehouse),
+			attribute.Int("app.shipping.items.count", len(s.items)),
+			attribute.Float64("app.shipping.amount", money.ToFloat64(s.cost)),
+		}
+		trackingID, err := cs.shipOrder(ctx, order.req.Address, s.items, order.prep.shippingMethod)
+		if err != nil {
+			s.failure = err.Error()
+			cs.metrics.shipmentShipped(ctx, s.warehouse, shipmentFailed)
+			span.AddEvent("shipment_failed", trace.WithAttributes(append(attrs, attribute.String("failure.reason", s.failure))...))
+			log.WithContext(ctx).Warnf("shipment of order %s from warehouse %s failed: %+v", order.id, s.warehouse, err)
+			continue
+		}
+		s.trackingID, s.failure = trackingID, ""
+		shipped++
+		cs.metrics.shipmentShipped(ctx, s.warehouse, shipmentShipped)
+		span.AddEvent("shipped", trace.WithAttributes(append(attrs, attribute.String("app.shipping.tracking.id", trackingID))...))
+	}
+	return shipped
+}
+
+// shipmentAmount returns the part of the total of p that s accounts for:
+// the cost of its items and its part of the shipping cost.
+func (p orderPrep) shipmentAmount(s *shipment) *pb.Money {
+	prices := make(map[string]*pb.Money, len(p.orderItems))
+	for _, it := range p.orderItems {
+		prices[it.GetItem().GetProductId()] = it.GetCost()
+	}
+	amount := s.cost
+	for _, it := range s.items {
+		amount = money.Must(money.Sum(amount, money.MultiplySlow(prices[it.GetProductId()], uint32(it.GetQuantity()))))
+	}
+	return amount
+}
+
+// shipmentResults returns the shipments of p, and the tracking ID of the
+// first one that shipped.
+func (p orderPrep) shipmentResults() ([]*pb.OrderShipment, string) {
+	results := make([]*pb.OrderShipment, len(p.shipments))
+	var trackingID string
+	for i, s := range p.shipments {
+		results[i] = &pb.OrderShipment{
+			Warehouse:     s.warehouse,
+			Items:         s.items,
+			Cost:          s.cost,
+			TrackingId:    s.trackingID,
+			Status:        s.status(),
+			FailureReason: s.failure,
+		}
+		if trackingID == "" {
+			trackingID = s.trackingID
+		}
+	}
+	return results, trackingID
+}
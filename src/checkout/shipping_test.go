@@ -0,0 +1,150 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/money"
+)
+
+func TestShippingOptions(t *testing.T) {
+	want := map[string]*pb.Money{
+		shippingStandard:  {CurrencyCode: "EUR", Units: 8, Nanos: 490000000},
+		shippingExpress:   {CurrencyCode: "EUR", Units: 16, Nanos: 980000000},
+		shippingOvernight: {CurrencyCode: "EUR", Units: 33, Nanos: 960000000},
+		shippingPickup:    {CurrencyCode: "EUR"},
+	}
+	options := shippingOptions(&pb.Money{CurrencyCode: "EUR", Units: 8, Nanos: 490000000})
+	if len(options) != len(want) {
+		t.Fatalf("shippingOptions() = %d options, want %d", len(options), len(want))
+	}
+	for _, o := range options {
+		if !money.AreEquals(o.GetCost(), want[o.GetMethod()]) {
+			t.Errorf("cost of %s = %v, want %v", o.GetMethod(), o.GetCost(), want[o.GetMethod()])
+		}
+		if o.GetMinDeliveryDays() > o.GetMaxDeliveryDays() {
+			t.Errorf("%s delivers in %d to %d days", o.GetMethod(), o.GetMinDeliveryDays(), o.GetMaxDeliveryDays())
+		}
+	}
+
+	if m, ok := findShippingMethod(""); !ok || m.name != shippingStandard {
+		t.Errorf(`findShippingMethod("") = %v, %t, want standard`, m, ok)
+	}
+	if _, ok := findShippingMethod("teleport"); ok {
+		t.Error(`findShippingMethod("teleport") = true`)
+	}
+}
+
+func TestAllocateShipping(t *testing.T) {
+	eur := func(units int64, nanos int32) *pb.Money {
+		return &pb.Money{CurrencyCode: "EUR", Units: units, Nanos: nanos}
+	}
+	tests := []struct {
+		name  string
+		costs []*pb.Money
+		total *pb.Money
+		want  []*pb.Money
+	}{
+		{
+			name:  "quoted as planned",
+			costs: []*pb.Money{eur(8, 490000000), eur(4, 0)},
+			total: eur(12, 490000000),
+			want:  []*pb.Money{eur(8, 490000000), eur(4, 0)},
+		},
+		{
+			name:  "quoted for more",
+			costs: []*pb.Money{eur(8, 490000000), eur(4, 0), eur(2, 0)},
+			total: eur(15, 0),
+			want:  []*pb.Money{eur(8, 490000000), eur(4, 0), eur(2, 510000000)},
+		},
+		{
+			name:  "quoted for less",
+			costs: []*pb.Money{eur(8, 490000000), eur(4, 0)},
+			total: eur(5, 0),
+			want:  []*pb.Money{eur(5, 0), eur(0, 0)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prep := orderPrep{shippingCostLocalized: tt.total}
+			for _, c := range tt.costs {
+				prep.shipments = append(prep.shipments, &shipment{cost: c})
+			}
+			if err := prep.allocateShipping(); err != nil {
+				t.Fatalf("allocateShipping() error = %v", err)
+			}
+			for i, s := range prep.shipments {
+				if !money.AreEquals(s.cost, tt.want[i]) {
+					t.Errorf("cost of shipment %d = %v, want %v", i, s.cost, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+// fakeShipping is a shipping service that fails to ship the orders of the
+// products in fail.
+type fakeShipping struct {
+	pb.ShippingServiceClient
+	fail map[string]bool
+}
+
+func (s *fakeShipping) ShipOrder(_ context.Context, req *pb.ShipOrderRequest, _ ...grpc.CallOption) (*pb.ShipOrderResponse, error) {
+	for _, it := range req.GetItems() {
+		if s.fail[it.GetProductId()] {
+			return nil, errors.New("warehouse closed")
+		}
+	}
+	return &pb.ShipOrderResponse{TrackingId: "track-" + req.GetItems()[0].GetProductId()}, nil
+}
+
+func TestShipShipments(t *testing.T) {
+	newOrder := func() *pendingOrder {
+		return &pendingOrder{id: "order-1", req: &pb.PlaceOrderRequest{}, prep: orderPrep{shipments: []*shipment{
+			{warehouse: "optics", items: []*pb.CartItem{{ProductId: "telescope", Quantity: 1}}},
+			{warehouse: "books", items: []*pb.CartItem{{ProductId: "book", Quantity: 2}}},
+		}}}
+	}
+
+	t.Run("one shipment fails", func(t *testing.T) {
+		shipping := &fakeShipping{fail: map[string]bool{"book": true}}
+		cs := &checkout{shippingSvcClient: shipping}
+		order := newOrder()
+		if shipped := cs.shipShipments(context.Background(), order); shipped != 1 {
+			t.Fatalf("shipShipments() = %d, want 1", shipped)
+		}
+		results, trackingID := order.prep.shipmentResults()
+		if trackingID != "track-telescope" {
+			t.Errorf("tracking ID = %q, want track-telescope", trackingID)
+		}
+		if results[0].Status != shipmentShipped || results[1].Status != shipmentFailed || results[1].FailureReason == "" {
+			t.Errorf("shipments = %v", results)
+		}
+
+		// Shipping again retries the failed shipment only.
+		shipping.fail = map[string]bool{"telescope": true}
+		if shipped := cs.shipShipments(context.Background(), order); shipped != 2 {
+			t.Fatalf("shipShipments() = %d, want 2", shipped)
+		}
+		if s := order.prep.shipments[1]; s.status() != shipmentShipped || s.trackingID != "track-book" {
+			t.Errorf("retried shipment = %+v", s)
+		}
+	})
+
+	t.Run("all shipments fail", func(t *testing.T) {
+		cs := &checkout{shippingSvcClient: &fakeShipping{fail: map[string]bool{"telescope": true, "book": true}}}
+		order := newOrder()
+		if shipped := cs.shipShipments(context.Background(), order); shipped != 0 {
+			t.Fatalf("shipShipments() = %d, want 0", shipped)
+		}
+		if _, trackingID := order.prep.shipmentResults(); trackingID != "" {
+			t.Errorf("tracking ID = %q, want none", trackingID)
+		}
+	})
+}
@@ -0,0 +1,355 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/card"
+	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/ledger"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/money"
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/rpcerr"
+)
+
+// Payment methods of the instruments orders are paid with, used as the
+// app.payment.method attribute.
+const (
+	methodCreditCard  = "credit_card"
+	methodGiftCard    = "gift_card"
+	methodStoreCredit = "store_credit"
+)
+
+// Outcomes of paying with a tender, used as the app.payment.outcome
+// attribute.
+const (
+	tenderCharged  = "charged"
+	tenderFailed   = "failed"
+	tenderReversed = "reversed"
+	tenderRefunded = "refunded"
+)
+
+// Types of the precondition violations of orders that their payment
+// instruments cannot pay for.
+const (
+	violationAccountNotFound   = "ACCOUNT_NOT_FOUND"
+	violationCurrencyMismatch  = "CURRENCY_MISMATCH"
+	violationInsufficientFunds = "INSUFFICIENT_FUNDS"
+)
+
+// tender is a payment instrument of an order and the part of the total it
+// pays.
+type tender struct {
+	method string
+	// field is the request field of the instrument, for errors.
+	field string
+	// description names the instrument without revealing it.
+	description string
+	// card is the tokenized card of credit card tenders.
+	card card.Card
+	// account is the ledger account of gift card and store credit tenders.
+	account   string
+	maxAmount *pb.Money
+	amount    *pb.Money
+	// txID is the transaction that paid amount: a payment transaction for
+	// cards, a ledger transaction otherwise. It is empty until paid.
+	txID string
+	// authorized is set while the payment transaction of a card is an
+	// authorization that was not captured.
+	authorized bool
+	reversed   bool
+	// refunded is the part of amount paid back for failed shipments, if any.
+	refunded *pb.Money
+}
+
+// paid reports whether t was paid and not reversed.
+func (t *tender) paid() bool { return t.txID != "" && !t.reversed }
+
+// kept returns the part of the amount of t that was not refunded.
+func (t *tender) kept() *pb.Money {
+	if t.refunded == nil {
+		return t.amount
+	}
+	return money.Must(money.Sum(t.amount, money.Negate(t.refunded)))
+}
+
+// tokenizeTenders returns the tenders of the payment instruments of req, or
+// of its credit card, whose cards it exchanges for tokens. req must be
+// valid.
+func (cs *checkout) tokenizeTenders(req *pb.PlaceOrderRequest) []*tender {
+	instruments := req.GetPaymentInstruments()
+	if len(instruments) == 0 {
+		c := cs.cards.Tokenize(req.GetCreditCard())
+		return []*tender{{method: methodCreditCard, field: "credit_card", description: c.String(), card: c}}
+	}
+
+	tenders := make([]*tender, len(instruments))
+	for i, pi := range instruments {
+		t := &tender{field: fmt.Sprintf("payment_instruments[%d]", i), maxAmount: pi.GetMaxAmount()}
+		switch in := pi.GetInstrument().(type) {
+		case *pb.PaymentInstrument_CreditCard:
+			t.method, t.card = methodCreditCard, cs.cards.Tokenize(in.CreditCard)
+			t.description = t.card.String()
+		case *pb.PaymentInstrument_GiftCard:
+			code := in.GiftCard.GetCode()
+			t.method, t.account = methodGiftCard, ledger.GiftCardAccount(code)
+			t.description = "gift card ending in " + code[max(0, len(code)-4):]
+		case *pb.PaymentInstrument_StoreCredit:
+			t.method, t.account = methodStoreCredit, ledger.StoreCreditAccount(req.GetUserId())
+			t.description = "store credit"
+		}
+		tenders[i] = t
+	}
+	return tenders
+}
+
+// forgetCards removes the cards of tenders from the card vault.
+func (cs *checkout) forgetCards(tenders []*tender) {
+	for _, t := range tenders {
+		if t.card.Token != "" {
+			cs.cards.Forget(t.card.Token)
+		}
+	}
+}
+
+// allocateTenders splits the total of order across its tenders in turn.
+// Gift cards and store credit pay at most their balance, which must be in
+// the currency of the order. It returns the reasons the tenders cannot pay
+// for the order, if any.
+func (cs *checkout) allocateTenders(ctx context.Context, order *pendingOrder) ([]rpcerr.PreconditionViolation, error) {
+	currency := order.total.GetCurrencyCode()
+	var violations []rpcerr.PreconditionViolation
+	limits := make([]*pb.Money, len(order.tenders))
+	for i, t := range order.tenders {
+		limits[i] = t.maxAmount
+		if t.account == "" {
+			continue
+		}
+		balance, ok := cs.ledger.Balance(t.account)
+		switch {
+		case !ok:
+			violations = append(violations, rpcerr.PreconditionViolation{Type: violationAccountNotFound, Subject: t.field,
+				Description: fmt.Sprintf("%s was not found", t.description)})
+			limits[i] = &pb.Money{CurrencyCode: currency}
+		case balance.GetCurrencyCode() != currency:
+			violations = append(violations, rpcerr.PreconditionViolation{Type: violationCurrencyMismatch, Subject: t.field,
+				Description: fmt.Sprintf("%s is in %s, not %s", t.description, balance.GetCurrencyCode(), currency)})
+			limits[i] = &pb.Money{CurrencyCode: currency}
+		case limits[i] == nil || lessThan(balance, limits[i]):
+			limits[i] = balance
+		}
+	}
+
+	amounts, remainder, err := money.Allocate(order.total, limits)
+	if err != nil {
+		return nil, fmt.Errorf("failed to allocate %s across payment instruments: %+v", money.Format(order.total), err)
+	}
+	for i, t := range order.tenders {
+		t.amount = amounts[i]
+	}
+	if money.IsPositive(remainder) {
+		violations = append(violations, rpcerr.PreconditionViolation{Type: violationInsufficientFunds, Subject: "payment_instruments",
+			Description: fmt.Sprintf("payment instruments cover %s of %s",
+				money.Format(money.Must(money.Sum(order.total, money.Negate(remainder)))), money.Format(order.total))})
+	}
+	log.WithContext(ctx).Debugf("Allocated %s across %d payment instruments", money.Format(order.total), len(order.tenders))
+	return violations, nil
+}
+
+// chargeTenders pays for order with its tenders in turn, authorizing rather
+// than charging cards if authorizeOnly is set. If a tender fails, the
+// tenders paid before it are reversed.
+func (cs *checkout) chargeTenders(ctx context.Context, order *pendingOrder, authorizeOnly bool) error {
+	span := trace.SpanFromContext(ctx)
+	for _, t := range order.tenders {
+		if money.IsZero(t.amount) {
+			continue
+		}
+		var err error
+		if t.account != "" {
+			t.txID, err = cs.ledger.Debit(t.account, t.amount)
+		} else {
+			t.txID, err = cs.chargeCard(ctx, t.amount, t.card, authorizeOnly)
+			t.authorized = authorizeOnly
+		}
+		if err != nil {
+			cs.metrics.tenderPaid(ctx, t.method, tenderFailed)
+			if rerr := cs.reverseTenders(ctx, order); rerr != nil {
+				log.WithContext(ctx).Errorf("failed to reverse the payments of order %s: %+v", order.id, rerr)
+			}
+			return fmt.Errorf("failed to charge %s: %+v", t.description, err)
+		}
+		cs.metrics.tenderPaid(ctx, t.method, tenderCharged)
+		span.AddEvent("charged", trace.WithAttributes(tenderAttributes(t)...))
+		log.WithContext(ctx).Infof("payment of %s with %s went through (transaction_id: %s)", money.Format(t.amount), t.description, t.txID)
+	}
+	return nil
+}
+
+// captureTenders charges the cards of order whose payment was only
+// authorized. Cards already captured are skipped, so that a failed capture
+// can be retried.
+func (cs *checkout) captureTenders(ctx context.Context, order *pendingOrder) error {
+	span := trace.SpanFromContext(ctx)
+	for _, t := range order.tenders {
+		if !t.authorized || !t.paid() {
+			continue
+		}
+		if err := cs.capturePayment(ctx, t.txID); err != nil {
+			return fmt.Errorf("failed to capture %s: %+v", t.description, err)
+		}
+		t.authorized = false
+		span.AddEvent("charged", trace.WithAttributes(tenderAttributes(t)...))
+	}
+	return nil
+}
+
+// reverseTenders reverses the paid tenders of order, the last paid first:
+// gift cards and store credit are credited back, card authorizations voided
+// and card charges refunded, less what was already refunded. Tenders that
+// fail to be reversed stay paid, so that the reversal can be retried.
+func (cs *checkout) reverseTenders(ctx context.Context, order *pendingOrder) error {
+	span := trace.SpanFromContext(ctx)
+	var errs []error
+	for i := len(order.tenders) - 1; i >= 0; i-- {
+		t := order.tenders[i]
+		if !t.paid() {
+			continue
+		}
+		var err error
+		switch {
+		case t.authorized:
+			err = cs.voidPayment(ctx, t.txID)
+		case money.IsZero(t.kept()):
+		case t.account != "":
+			err = cs.ledger.Credit(t.account, t.kept())
+		default:
+			err = cs.refundPayment(ctx, t.txID, t.kept())
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to reverse the payment with %s: %+v", t.description, err))
+			continue
+		}
+		t.reversed = true
+		cs.metrics.tenderPaid(ctx, t.method, tenderReversed)
+		span.AddEvent("reversed", trace.WithAttributes(tenderAttributes(t)...))
+		log.WithContext(ctx).Infof("payment of %s with %s was reversed (transaction_id: %s)", money.Format(t.amount), t.description, t.txID)
+	}
+	return errors.Join(errs...)
+}
+
+// refundTenders pays amount of the charged total of order back, with the
+// tenders that paid it, the last paid first, each up to what it kept. It
+// returns the part of amount that it failed to refund.
+func (cs *checkout) refundTenders(ctx context.Context, order *pendingOrder, amount *pb.Money) (*pb.Money, error) {
+	span := trace.SpanFromContext(ctx)
+	var errs []error
+	for i := len(order.tenders) - 1; i >= 0 && money.IsPositive(amount); i-- {
+		t := order.tenders[i]
+		if !t.paid() || t.authorized || money.IsZero(t.kept()) {
+			continue
+		}
+		part := amount
+		if lessThan(t.kept(), part) {
+			part = t.kept()
+		}
+		var err error
+		if t.account != "" {
+			err = cs.ledger.Credit(t.account, part)
+		} else {
+			err = cs.refundPayment(ctx, t.txID, part)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to refund %s to %s: %+v", money.Format(part), t.description, err))
+			continue
+		}
+		if t.refunded == nil {
+			t.refunded = part
+		} else {
+			t.refunded = money.Must(money.Sum(t.refunded, part))
+		}
+		amount = money.Must(money.Sum(amount, money.Negate(part)))
+		cs.metrics.tenderPaid(ctx, t.method, tenderRefunded)
+		span.AddEvent("refunded", trace.WithAttributes(append(tenderAttributes(t), attribute.Float64("app.payment.refund.amount", money.ToFloat64(part)))...))
+		log.WithContext(ctx).Infof("%s of the payment with %s was refunded (transaction_id: %s)", money.Format(part), t.description, t.txID)
+	}
+	return amount, errors.Join(errs...)
+}
+
+// refundPayment refunds amount of the card charge txID.
+func (cs *checkout) refundPayment(ctx context.Context, txID string, amount *pb.Money) error {
+	start := time.Now()
+	_, err := cs.paymentSvcClient.Refund(ctx, &pb.RefundRequest{TransactionId: txID, Amount: amount})
+	cs.metrics.dependencyCall(ctx, dependencyPayment, start, err)
+	return err
+}
+
+// charged reports whether a tender of o is paid.
+func (o *pendingOrder) charged() bool {
+	for _, t := range o.tenders {
+		if t.paid() {
+			return true
+		}
+	}
+	return false
+}
+
+// reversed reports whether a tender of o was reversed.
+func (o *pendingOrder) reversed() bool {
+	for _, t := range o.tenders {
+		if t.reversed {
+			return true
+		}
+	}
+	return false
+}
+
+// payments returns the tenders of o that pay part of its total.
+func (o *pendingOrder) payments() []*pb.OrderPayment {
+	var payments []*pb.OrderPayment
+	for _, t := range o.tenders {
+		if t.amount == nil || money.IsZero(t.amount) {
+			continue
+		}
+		payments = append(payments, &pb.OrderPayment{
+			Method:        t.method,
+			Description:   t.description,
+			Amount:        t.amount,
+			TransactionId: t.txID,
+			Refunded:      t.refunded,
+		})
+	}
+	return payments
+}
+
+// firstCard returns the first card o is paid with, and reports whether it
+// is paid with a card.
+func (o *pendingOrder) firstCard() (card.Card, bool) {
+	for _, t := range o.tenders {
+		if t.method == methodCreditCard {
+			return t.card, true
+		}
+	}
+	return card.Card{}, false
+}
+
+func tenderAttributes(t *tender) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("app.payment.method", t.method),
+		attribute.Float64("app.payment.amount", money.ToFloat64(t.amount)),
+		attribute.String("app.payment.transaction.id", t.txID),
+	}
+}
+
+// lessThan reports whether l is less than r, which are valid amounts of the
+// same currency.
+func lessThan(l, r *pb.Money) bool {
+	c, err := money.Compare(l, r)
+	return err == nil && c < 0
+}
@@ -0,0 +1,316 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+package main
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/card"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/confirmation"
+	pb "github.com/open-telemetry/opentelemetry-demo/src/checkout/genproto/oteldemo"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/ledger"
+	"github.com/open-telemetry/opentelemetry-demo/src/checkout/money"
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/rpcerr"
+)
+
+func usd(units int64, nanos int32) *pb.Money {
+	return &pb.Money{CurrencyCode: "USD", Units: units, Nanos: nanos}
+}
+
+// fakePayment is a payment service that declines the cards in decline, fails
+// refunds while failRefunds is set and voids while failVoids is set, and
+// records the calls it receives and the money it collects.
+type fakePayment struct {
+	pb.PaymentServiceClient
+	decline     map[string]bool
+	failRefunds bool
+	failVoids   bool
+	calls       []string
+	// collected is the amount charged or captured, less refunds, in USD.
+	collected  *pb.Money
+	authorized map[string]*pb.Money
+}
+
+func (p *fakePayment) collect(amount *pb.Money) {
+	if p.collected == nil {
+		p.collected = usd(0, 0)
+	}
+	p.collected = money.Must(money.Sum(p.collected, amount))
+}
+
+func (p *fakePayment) Charge(_ context.Context, req *pb.ChargeRequest, _ ...grpc.CallOption) (*pb.ChargeResponse, error) {
+	if p.decline[req.GetCard().GetToken()] {
+		p.calls = append(p.calls, "decline "+req.GetCard().GetToken())
+		return nil, errors.New("card declined")
+	}
+	p.calls = append(p.calls, "charge "+req.GetCard().GetToken())
+	txID := "tx-" + req.GetCard().GetToken()
+	if req.GetAuthorizeOnly() {
+		if p.authorized == nil {
+			p.authorized = make(map[string]*pb.Money)
+		}
+		p.authorized[txID] = req.GetAmount()
+	} else {
+		p.collect(req.GetAmount())
+	}
+	return &pb.ChargeResponse{TransactionId: txID}, nil
+}
+
+func (p *fakePayment) Capture(_ context.Context, req *pb.CaptureRequest, _ ...grpc.CallOption) (*pb.ChargeResponse, error) {
+	p.calls = append(p.calls, "capture "+req.GetTransactionId())
+	p.collect(p.authorized[req.GetTransactionId()])
+	delete(p.authorized, req.GetTransactionId())
+	return &pb.ChargeResponse{TransactionId: req.GetTransactionId()}, nil
+}
+
+func (p *fakePayment) Refund(_ context.Context, req *pb.RefundRequest, _ ...grpc.CallOption) (*pb.Empty, error) {
+	if p.failRefunds {
+		p.calls = append(p.calls, "failed refund "+req.GetTransactionId())
+		return nil, errors.New("payment service unavailable")
+	}
+	p.calls = append(p.calls, "refund "+req.GetTransactionId())
+	p.collect(money.Negate(req.GetAmount()))
+	return &pb.Empty{}, nil
+}
+
+func (p *fakePayment) Void(_ context.Context, req *pb.VoidRequest, _ ...grpc.CallOption) (*pb.Empty, error) {
+	if p.failVoids {
+		p.calls = append(p.calls, "failed void "+req.GetTransactionId())
+		return nil, errors.New("payment service unavailable")
+	}
+	p.calls = append(p.calls, "void "+req.GetTransactionId())
+	delete(p.authorized, req.GetTransactionId())
+	return &pb.Empty{}, nil
+}
+
+func TestAllocateTenders(t *testing.T) {
+	l := ledger.New()
+	l.Open(ledger.GiftCardAccount("GC-1"), usd(25, 0))
+	l.Open(ledger.GiftCardAccount("GC-EUR"), &pb.Money{CurrencyCode: "EUR", Units: 25})
+	l.Open(ledger.StoreCreditAccount("alice"), usd(10, 500000000))
+	cs := &checkout{ledger: l}
+
+	giftCard := func(code string, maxAmount *pb.Money) *tender {
+		return &tender{method: methodGiftCard, field: code, account: ledger.GiftCardAccount(code), maxAmount: maxAmount}
+	}
+	storeCredit := &tender{method: methodStoreCredit, field: "credit", account: ledger.StoreCreditAccount("alice")}
+	creditCard := func(maxAmount *pb.Money) *tender {
+		return &tender{method: methodCreditCard, field: "card", maxAmount: maxAmount}
+	}
+
+	tests := []struct {
+		name           string
+		tenders        []*tender
+		wantAmounts    []*pb.Money
+		wantViolations []string
+	}{
+		{
+			name:        "gift card, store credit and card",
+			tenders:     []*tender{giftCard("GC-1", nil), storeCredit, creditCard(nil)},
+			wantAmounts: []*pb.Money{usd(25, 0), usd(10, 500000000), usd(24, 490000000)},
+		},
+		{
+			name:        "capped gift card",
+			tenders:     []*tender{giftCard("GC-1", usd(5, 0)), creditCard(nil)},
+			wantAmounts: []*pb.Money{usd(5, 0), usd(54, 990000000)},
+		},
+		{
+			name:        "gift card covers the total",
+			tenders:     []*tender{giftCard("GC-1", nil), creditCard(nil)},
+			wantAmounts: []*pb.Money{usd(25, 0), usd(34, 990000000)},
+		},
+		{
+			name:           "balances fall short",
+			tenders:        []*tender{giftCard("GC-1", nil), storeCredit},
+			wantAmounts:    []*pb.Money{usd(25, 0), usd(10, 500000000)},
+			wantViolations: []string{violationInsufficientFunds},
+		},
+		{
+			name:           "unknown and foreign gift cards",
+			tenders:        []*tender{giftCard("GC-0", nil), giftCard("GC-EUR", nil), creditCard(usd(50, 0))},
+			wantAmounts:    []*pb.Money{usd(0, 0), usd(0, 0), usd(50, 0)},
+			wantViolations: []string{violationAccountNotFound, violationCurrencyMismatch, violationInsufficientFunds},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &pendingOrder{tenders: tt.tenders, total: usd(59, 990000000)}
+			violations, err := cs.allocateTenders(context.Background(), order)
+			if err != nil {
+				t.Fatalf("allocateTenders() error = %v", err)
+			}
+			var types []string
+			for _, v := range violations {
+				types = append(types, v.Type)
+			}
+			if !reflect.DeepEqual(types, tt.wantViolations) {
+				t.Errorf("violations = %v, want %v", types, tt.wantViolations)
+			}
+			for i, tender := range order.tenders {
+				if !money.AreEquals(tender.amount, tt.wantAmounts[i]) {
+					t.Errorf("amount of %s = %v, want %v", tender.field, tender.amount, tt.wantAmounts[i])
+				}
+			}
+		})
+	}
+}
+
+func TestChargeTenders(t *testing.T) {
+	newOrder := func(l *ledger.Ledger, declined string) (*checkout, *fakePayment, *pendingOrder) {
+		payment := &fakePayment{decline: map[string]bool{declined: true}}
+		cs := &checkout{ledger: l, paymentSvcClient: payment}
+		order := &pendingOrder{id: "order-1", total: usd(60, 0), tenders: []*tender{
+			{method: methodGiftCard, account: ledger.GiftCardAccount("GC-1"), amount: usd(25, 0)},
+			{method: methodCreditCard, card: card.Card{Token: "tok_a"}, amount: usd(20, 0)},
+			{method: methodCreditCard, card: card.Card{Token: "tok_b"}, amount: usd(15, 0)},
+		}}
+		return cs, payment, order
+	}
+
+	t.Run("all paid", func(t *testing.T) {
+		l := ledger.New()
+		l.Open(ledger.GiftCardAccount("GC-1"), usd(30, 0))
+		cs, payment, order := newOrder(l, "")
+		if err := cs.chargeTenders(context.Background(), order, false); err != nil {
+			t.Fatalf("chargeTenders() error = %v", err)
+		}
+		if balance, _ := l.Balance(ledger.GiftCardAccount("GC-1")); !money.AreEquals(balance, usd(5, 0)) {
+			t.Errorf("gift card balance = %v", balance)
+		}
+		if want := []string{"charge tok_a", "charge tok_b"}; !reflect.DeepEqual(payment.calls, want) {
+			t.Errorf("payment calls = %v, want %v", payment.calls, want)
+		}
+		if payments := order.payments(); len(payments) != 3 || payments[2].TransactionId != "tx-tok_b" {
+			t.Errorf("payments = %v", payments)
+		}
+	})
+
+	t.Run("last card declined", func(t *testing.T) {
+		l := ledger.New()
+		l.Open(ledger.GiftCardAccount("GC-1"), usd(30, 0))
+		cs, payment, order := newOrder(l, "tok_b")
+		if err := cs.chargeTenders(context.Background(), order, false); err == nil {
+			t.Fatal("chargeTenders() succeeded with a declined card")
+		}
+		if balance, _ := l.Balance(ledger.GiftCardAccount("GC-1")); !money.AreEquals(balance, usd(30, 0)) {
+			t.Errorf("gift card balance after reversal = %v", balance)
+		}
+		if want := []string{"charge tok_a", "decline tok_b", "refund tx-tok_a"}; !reflect.DeepEqual(payment.calls, want) {
+			t.Errorf("payment calls = %v, want %v", payment.calls, want)
+		}
+	})
+
+	t.Run("authorizations reversed", func(t *testing.T) {
+		l := ledger.New()
+		l.Open(ledger.GiftCardAccount("GC-1"), usd(30, 0))
+		cs, payment, order := newOrder(l, "")
+		ctx := context.Background()
+		if err := cs.chargeTenders(ctx, order, true); err != nil {
+			t.Fatalf("chargeTenders() error = %v", err)
+		}
+		if err := cs.reverseTenders(ctx, order); err != nil {
+			t.Fatalf("reverseTenders() error = %v", err)
+		}
+		// Reversing twice is a no-op.
+		if err := cs.reverseTenders(ctx, order); err != nil {
+			t.Fatalf("reverseTenders() error = %v", err)
+		}
+		if want := []string{"charge tok_a", "charge tok_b", "void tx-tok_b", "void tx-tok_a"}; !reflect.DeepEqual(payment.calls, want) {
+			t.Errorf("payment calls = %v, want %v", payment.calls, want)
+		}
+		if balance, _ := l.Balance(ledger.GiftCardAccount("GC-1")); !money.AreEquals(balance, usd(30, 0)) {
+			t.Errorf("gift card balance after reversal = %v", balance)
+		}
+	})
+}
+
+// newShippedOrder returns an order of a telescope and two books, shipped
+// from two warehouses and paid with a gift card and a card, and a checkout
+// that fails to ship the products in fail.
+func newShippedOrder(t *testing.T, fail ...string) (*checkout, *fakePayment, *pendingOrder) {
+	l := ledger.New()
+	l.Open(ledger.GiftCardAccount("GC-1"), usd(30, 0))
+	payment := &fakePayment{}
+	shipping := &fakeShipping{fail: make(map[string]bool)}
+	for _, id := range fail {
+		shipping.fail[id] = true
+	}
+	cs := &checkout{
+		ledger:            l,
+		paymentSvcClient:  payment,
+		shippingSvcClient: shipping,
+		confirmations:     confirmation.Sender{Transport: confirmation.NewFileTransport(filepath.Join(t.TempDir(), "confirmations.jsonl"))},
+	}
+
+	telescope := &pb.CartItem{ProductId: "telescope", Quantity: 1}
+	books := &pb.CartItem{ProductId: "book", Quantity: 2}
+	order := &pendingOrder{
+		id:    "order-1",
+		req:   &pb.PlaceOrderRequest{UserId: "alice", Email: "alice@example.com", Address: &pb.Address{Country: "Germany"}},
+		total: usd(60, 0),
+		prep: orderPrep{
+			orderItems:            []*pb.OrderItem{{Item: telescope, Cost: usd(40, 0)}, {Item: books, Cost: usd(5, 0)}},
+			cartItems:             []*pb.CartItem{telescope, books},
+			shippingMethod:        shippingStandard,
+			shippingCostLocalized: usd(10, 0),
+			totalUSD:              usd(60, 0),
+			shipments: []*shipment{
+				{warehouse: "optics", items: []*pb.CartItem{telescope}, cost: usd(6, 0)},
+				{warehouse: "books", items: []*pb.CartItem{books}, cost: usd(4, 0)},
+			},
+		},
+		tenders: []*tender{
+			{method: methodGiftCard, account: ledger.GiftCardAccount("GC-1"), amount: usd(25, 0)},
+			{method: methodCreditCard, card: card.Card{Token: "tok_a"}, amount: usd(35, 0)},
+		},
+	}
+	return cs, payment, order
+}
+
+func TestCompleteOrderRefunds(t *testing.T) {
+	t.Run("no shipment ships", func(t *testing.T) {
+		cs, payment, order := newShippedOrder(t, "telescope", "book")
+		ctx := context.Background()
+		if err := cs.chargeTenders(ctx, order, false); err != nil {
+			t.Fatalf("chargeTenders() error = %v", err)
+		}
+		_, err := cs.completeOrder(ctx, order)
+		if d, _ := rpcerr.Decode(err); d.Reason != reasonShippingFailed {
+			t.Fatalf("completeOrder() error = %v, want %s", err, reasonShippingFailed)
+		}
+		if balance, _ := cs.ledger.Balance(ledger.GiftCardAccount("GC-1")); !money.AreEquals(balance, usd(30, 0)) {
+			t.Errorf("gift card balance = %v, want it credited back", balance)
+		}
+		if !money.IsZero(payment.collected) {
+			t.Errorf("collected %v, want the card refunded", payment.collected)
+		}
+	})
+
+	t.Run("one shipment fails", func(t *testing.T) {
+		cs, payment, order := newShippedOrder(t, "book")
+		ctx := context.Background()
+		if err := cs.chargeTenders(ctx, order, false); err != nil {
+			t.Fatalf("chargeTenders() error = %v", err)
+		}
+		result, err := cs.completeOrder(ctx, order)
+		if err != nil {
+			t.Fatalf("completeOrder() error = %v", err)
+		}
+		// The books and their shipping, 14.00, are refunded to the card.
+		if !money.AreEquals(payment.collected, usd(21, 0)) {
+			t.Errorf("collected %v, want 21.00", payment.collected)
+		}
+		if balance, _ := cs.ledger.Balance(ledger.GiftCardAccount("GC-1")); !money.AreEquals(balance, usd(5, 0)) {
+			t.Errorf("gift card balance = %v, want 5.00", balance)
+		}
+		if p := result.GetPayments(); len(p) != 2 || p[0].GetRefunded() != nil || !money.AreEquals(p[1].GetRefunded(), usd(14, 0)) {
+			t.Errorf("payments = %v", p)
+		}
+	})
+}
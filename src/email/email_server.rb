@@ -1,6 +1,7 @@
 # Copyright The OpenTelemetry Authors
 # SPDX-License-Identifier: Apache-2.0
 
+require "erb"
 require "ostruct"
 require "pony"
 require "sinatra"
@@ -9,12 +10,18 @@ require "opentelemetry/sdk"
 require "opentelemetry/exporter/otlp"
 require "opentelemetry/instrumentation/sinatra"
 
+# The gRPC code of demo.proto is generated into ./proto, see the Dockerfile.
+$LOAD_PATH.unshift(File.join(__dir__, "proto"))
+require "demo_services_pb"
+
 set :port, ENV["EMAIL_PORT"]
 
 OpenTelemetry::SDK.configure do |c|
   c.use "OpenTelemetry::Instrumentation::Sinatra"
 end
 
+CONFIRMATION_TEMPLATE = ERB.new(File.read(File.join(__dir__, "views", "confirmation.erb")))
+
 post "/send_order_confirmation" do
   data = JSON.parse(request.body.read, object_class: OpenStruct)
 
@@ -32,22 +39,65 @@ error do
   OpenTelemetry::Trace.current_span.record_exception(env['sinatra.error'])
 end
 
+# EmailService serves SendOrderConfirmation over gRPC, for the checkout
+# confirmation transport "grpc".
+class EmailService < Oteldemo::EmailService::Service
+  def send_order_confirmation(request, call)
+    # continue the trace of checkout, whose context is in the call metadata
+    context = OpenTelemetry.propagation.extract(call.metadata)
+    tracer = OpenTelemetry.tracer_provider.tracer('email')
+    tracer.in_span(
+      "oteldemo.EmailService/SendOrderConfirmation",
+      kind: :server,
+      with_parent: context,
+      attributes: {
+        "rpc.system" => "grpc",
+        "rpc.service" => "oteldemo.EmailService",
+        "rpc.method" => "SendOrderConfirmation",
+        "app.order.id" => request.order&.order_id.to_s,
+      }
+    ) do |span|
+      begin
+        send_email(request)
+      rescue StandardError => e
+        span.record_exception(e)
+        span.status = OpenTelemetry::Trace::Status.error(e.message)
+        raise GRPC::Unavailable.new(e.message)
+      end
+    end
+    Oteldemo::Empty.new
+  end
+end
+
+grpc_server = GRPC::RpcServer.new
+grpc_server.add_http2_port("0.0.0.0:#{ENV["EMAIL_GRPC_PORT"]}", :this_port_is_insecure)
+grpc_server.handle(EmailService)
+Thread.new { grpc_server.run }
+
 def send_email(data)
   # create and start a manual span
   tracer = OpenTelemetry.tracer_provider.tracer('email')
   tracer.in_span("send_email") do |span|
+    # checkout sends the bodies it rendered, if any
+    if data.html_body.to_s.empty?
+      body, html_body = CONFIRMATION_TEMPLATE.result_with_hash(order: data.order), nil
+    else
+      body, html_body = data.text_body, data.html_body
+    end
+    subject = data.subject.to_s
     Pony.mail(
-      to:       data.email,
-      from:     "noreply@example.com",
-      subject:  "Your confirmation email",
-      body:     erb(:confirmation, locals: { order: data.order }),
-      via:      :test
+      to:        data.email,
+      from:      "noreply@example.com",
+      subject:   subject.empty? ? "Your confirmation email" : subject,
+      body:      body,
+      html_body: html_body,
+      via:       :test
     )
     span.set_attribute("app.email.recipient", data.email)
     puts "Order confirmation email sent to: #{data.email}"
   end
   # manually created spans need to be ended
   # in Ruby, the method `in_span` ends it automatically
-  # check out the OpenTelemetry Ruby docs at: 
-  # https://opentelemetry.io/docs/instrumentation/ruby/manual/#creating-new-spans 
+  # check out the OpenTelemetry Ruby docs at:
+  # https://opentelemetry.io/docs/instrumentation/ruby/manual/#creating-new-spans
 end
@@ -12,6 +12,8 @@ import { useCart } from '../../providers/Cart.provider';
 import { useCurrency } from '../../providers/Currency.provider';
 import { CreateSupportRequestRequest } from '../../protos/demo';
 import * as S from '../../styles/Cart.styled';
+import { IErrorDetails } from '../../utils/ErrorDetails';
+import { RequestError } from '../../utils/Request';
 
 const { userId } = SessionGateway.getSession();
 
@@ -24,6 +26,7 @@ const CartDetail = () => {
   const { selectedCurrency } = useCurrency();
   const { push } = useRouter();
   const [checkoutError, setCheckoutError] = useState<string | null>(null);
+  const [checkoutErrorDetails, setCheckoutErrorDetails] = useState<IErrorDetails | undefined>(undefined);
   const [isSubmitting, setIsSubmitting] = useState(false);
 
   const onPlaceOrder = useCallback(
@@ -41,6 +44,7 @@ const CartDetail = () => {
     }: IFormData) => {
       setIsSubmitting(true);
       setCheckoutError(null);
+      setCheckoutErrorDetails(undefined);
 
       try {
         const order = await placeOrder({
@@ -60,6 +64,10 @@ const CartDetail = () => {
             creditCardExpirationYear,
             creditCardNumber,
           },
+          billingAddress: undefined,
+          paymentInstruments: [],
+          quoteToken: '',
+          shippingMethod: '',
         });
 
         console.log('Order response:', order);
@@ -95,6 +103,9 @@ const CartDetail = () => {
         }
 
         setCheckoutError(errorMessage);
+        if (error instanceof RequestError) {
+          setCheckoutErrorDetails(error.details);
+        }
       } finally {
         setIsSubmitting(false);
       }
@@ -104,6 +115,11 @@ const CartDetail = () => {
 
   const onSupportRequest = useCallback(
     async (data: ISupportRequestData) => {
+      // Only report the items checkout rejected, if it said which ones.
+      const rejectedIds = new Set((checkoutErrorDetails?.preconditionViolations || []).map(({ subject }) => subject));
+      const rejectedItems = items.filter(({ productId }) => rejectedIds.has(productId));
+      const failedItems = rejectedItems.length ? rejectedItems : items;
+
       try {
         const supportRequest: CreateSupportRequestRequest = {
           userId,
@@ -111,7 +127,7 @@ const CartDetail = () => {
           subject: data.subject,
           description: data.description,
           errorMessage: checkoutError || '',
-          failedItems: items.map(item => ({
+          failedItems: failedItems.map(item => ({
             item: {
               productId: item.productId,
               quantity: item.quantity,
@@ -123,6 +139,7 @@ const CartDetail = () => {
             },
           })),
           shippingAddress: undefined, // TODO: Get from form if available
+          service: '',
         };
 
         const response = await fetch('/api/support', {
@@ -137,6 +154,7 @@ const CartDetail = () => {
           const result = await response.json();
           alert(`Support ticket created successfully! Ticket ID: ${result.supportRequest?.jiraTicketId || result.supportRequest?.id}`);
           setCheckoutError(null);
+          setCheckoutErrorDetails(undefined);
         } else {
           throw new Error('Failed to create support ticket');
         }
@@ -145,7 +163,7 @@ const CartDetail = () => {
         alert('Failed to create support ticket. Please try again.');
       }
     },
-    [selectedCurrency, items, checkoutError, userId]
+    [selectedCurrency, items, checkoutError, checkoutErrorDetails, userId]
   );
 
   return (
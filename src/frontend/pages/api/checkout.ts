@@ -7,8 +7,9 @@ import CheckoutGateway from '../../gateways/rpc/Checkout.gateway';
 import { Empty, PlaceOrderRequest } from '../../protos/demo';
 import { IProductCheckoutItem, IProductCheckout } from '../../types/Cart';
 import ProductCatalogService from '../../services/ProductCatalog.service';
+import { decodeErrorDetails, IErrorDetails } from '../../utils/ErrorDetails';
 
-type TResponse = IProductCheckout | Empty | { error: string };
+type TResponse = IProductCheckout | Empty | { error: string; details?: IErrorDetails };
 
 const handler = async ({ method, body, query }: NextApiRequest, res: NextApiResponse<TResponse>) => {
   switch (method) {
@@ -47,7 +48,7 @@ const handler = async ({ method, body, query }: NextApiRequest, res: NextApiResp
 
         // Ensure we set the status code before sending the response
         res.status(400);
-        return res.json({ error: errorMessage });
+        return res.json({ error: errorMessage, details: decodeErrorDetails(error) });
       }
     }
 
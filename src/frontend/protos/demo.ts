@@ -11,7 +11,9 @@ import {
   ChannelCredentials,
   Client,
   type ClientOptions,
+  type ClientReadableStream,
   type ClientUnaryCall,
+  type handleServerStreamingCall,
   type handleUnaryCall,
   makeGenericClientConstructor,
   Metadata,
@@ -99,6 +101,23 @@ export interface GetQuoteResponse {
 export interface ShipOrderRequest {
   address: Address | undefined;
   items: CartItem[];
+  /** shipping_method is standard, express, overnight or pickup. */
+  shippingMethod: string;
+}
+
+/** ShippingOption is a shipping method offered for an order. */
+export interface ShippingOption {
+  /** method is standard, express, overnight or pickup. */
+  method: string;
+  cost:
+    | Money
+    | undefined;
+  /**
+   * The order is delivered, or ready for pickup, within
+   * min_delivery_days to max_delivery_days.
+   */
+  minDeliveryDays: number;
+  maxDeliveryDays: number;
 }
 
 export interface ShipOrderResponse {
@@ -155,7 +174,41 @@ export interface CreditCardInfo {
 
 export interface ChargeRequest {
   amount: Money | undefined;
-  creditCard: CreditCardInfo | undefined;
+  creditCard:
+    | CreditCardInfo
+    | undefined;
+  /**
+   * authorize_only authorizes the amount without charging the card. The
+   * returned transaction is then captured or voided.
+   */
+  authorizeOnly: boolean;
+  /**
+   * card is a tokenized card, which clients that keep card numbers to
+   * themselves send instead of credit_card.
+   */
+  card: PaymentCard | undefined;
+}
+
+/** PaymentCard is a card known by its token, without its number. */
+export interface PaymentCard {
+  token: string;
+  brand: string;
+  lastFour: string;
+  expirationYear: number;
+  expirationMonth: number;
+}
+
+export interface CaptureRequest {
+  transactionId: string;
+}
+
+export interface VoidRequest {
+  transactionId: string;
+}
+
+export interface RefundRequest {
+  transactionId: string;
+  amount: Money | undefined;
 }
 
 export interface ChargeResponse {
@@ -173,11 +226,100 @@ export interface OrderResult {
   shippingCost: Money | undefined;
   shippingAddress: Address | undefined;
   items: OrderItem[];
+  /**
+   * status is PLACED, or ON_HOLD for orders awaiting approval, which are
+   * then PLACED, REJECTED or EXPIRED.
+   */
+  status: string;
+  hold:
+    | OrderHold
+    | undefined;
+  /**
+   * payments are the parts of the total paid with each payment
+   * instrument, in the order they were charged.
+   */
+  payments: OrderPayment[];
+  shippingMethod: string;
+  /**
+   * shipments are the parts of the order shipped separately, each from one
+   * warehouse. shipping_tracking_id is the tracking ID of the first
+   * shipment that shipped.
+   */
+  shipments: OrderShipment[];
+  /**
+   * confirmation is the delivery of the order confirmation to the
+   * customer, once it was attempted.
+   */
+  confirmation: ConfirmationDelivery | undefined;
+}
+
+export interface ConfirmationDelivery {
+  /** status is SENT, or FAILED if every attempt failed. */
+  status: string;
+  /** transport is grpc, http or file. */
+  transport: string;
+  attempts: number;
+  /** error is the error of the last attempt of a FAILED delivery. */
+  error: string;
+  /** sent_at is when a SENT confirmation was sent, in Unix seconds. */
+  sentAt: number;
+}
+
+export interface OrderShipment {
+  warehouse: string;
+  items: CartItem[];
+  /**
+   * cost is the part of the shipping cost of the order the shipment
+   * accounts for.
+   */
+  cost: Money | undefined;
+  trackingId: string;
+  /**
+   * status is PLANNED until the order ships, then SHIPPED, or FAILED if
+   * the shipping service could not ship it.
+   */
+  status: string;
+  /** failure_reason is why a FAILED shipment could not be shipped. */
+  failureReason: string;
+}
+
+export interface OrderPayment {
+  /** method is credit_card, gift_card or store_credit. */
+  method: string;
+  /**
+   * description names the instrument without revealing it, such as
+   * "visa ending in 0454".
+   */
+  description: string;
+  amount: Money | undefined;
+  transactionId: string;
+  /**
+   * refunded is the part of amount that was paid back for the shipments
+   * of the order that failed.
+   */
+  refunded: Money | undefined;
+}
+
+/** OrderHold describes why an order is on hold and how it was resolved. */
+export interface OrderHold {
+  reasons: string[];
+  expiresAt: number;
+  approver: string;
+  comment: string;
 }
 
 export interface SendOrderConfirmationRequest {
   email: string;
-  order: OrderResult | undefined;
+  order:
+    | OrderResult
+    | undefined;
+  /**
+   * subject, html_body and text_body are the confirmation rendered by
+   * checkout, if it renders them.
+   */
+  subject: string;
+  htmlBody: string;
+  textBody: string;
 }
 
 export interface PlaceOrderRequest {
@@ -185,13 +327,158 @@ export interface PlaceOrderRequest {
   userCurrency: string;
   address: Address | undefined;
   email: string;
-  creditCard: CreditCardInfo | undefined;
+  creditCard:
+    | CreditCardInfo
+    | undefined;
+  /**
+   * billing_address is the address of the card holder, if it differs
+   * from the shipping address.
+   */
+  billingAddress:
+    | Address
+    | undefined;
+  /**
+   * payment_instruments pay for the order in turn, each as much of what
+   * remains as it can, instead of credit_card.
+   */
+  paymentInstruments: PaymentInstrument[];
+  /**
+   * quote_token is the token of a PreviewOrder response, whose prices the
+   * order is charged if the cart and address did not change.
+   */
+  quoteToken: string;
+  /**
+   * shipping_method is one of the shipping options of the order, standard
+   * if unset.
+   */
+  shippingMethod: string;
+}
+
+export interface PaymentInstrument {
+  creditCard?: CreditCardInfo | undefined;
+  giftCard?: GiftCard | undefined;
+  storeCredit?:
+    | StoreCredit
+    | undefined;
+  /**
+   * max_amount caps the amount paid with the instrument, in the user
+   * currency. Without it, cards pay all that remains and gift cards and
+   * store credit their whole balance.
+   */
+  maxAmount: Money | undefined;
+}
+
+export interface GiftCard {
+  code: string;
+}
+
+/** StoreCredit is the store credit balance of the user placing the order. */
+export interface StoreCredit {
 }
 
 export interface PlaceOrderResponse {
   order: OrderResult | undefined;
 }
 
+export interface PreviewOrderRequest {
+  userId: string;
+  userCurrency: string;
+  address:
+    | Address
+    | undefined;
+  /**
+   * shipping_method is the shipping option the total is computed with,
+   * standard if unset.
+   */
+  shippingMethod: string;
+}
+
+export interface PreviewOrderResponse {
+  items: OrderItem[];
+  subtotal: Money | undefined;
+  shippingCost: Money | undefined;
+  adjustments: OrderAdjustment[];
+  total: Money | undefined;
+  quoteToken: string;
+  /** expires_at is when quote_token expires, in Unix seconds. */
+  expiresAt: number;
+  /**
+   * hold_reasons are the reasons an order of the cart would be placed on
+   * hold for approval, if any.
+   */
+  holdReasons: string[];
+  /**
+   * shipping_options are the shipping methods the order can be placed
+   * with, at the prices of the quote.
+   */
+  shippingOptions: ShippingOption[];
+  /** shipping_method is the option shipping_cost is the cost of. */
+  shippingMethod: string;
+  /** shipments are the shipments the order would be split into. */
+  shipments: OrderShipment[];
+}
+
+/**
+ * OrderAdjustment is an amount added to, or taken off if negative, the
+ * total of an order.
+ */
+export interface OrderAdjustment {
+  description: string;
+  amount: Money | undefined;
+}
+
+export interface SubmitOrderResponse {
+  orderId: string;
+}
+
+export interface WatchOrderRequest {
+  orderId: string;
+}
+
+export interface OrderStatusUpdate {
+  orderId: string;
+  /**
+   * status is SUBMITTED, PROCESSING, then PLACED, ON_HOLD or FAILED. Orders
+   * on hold are then PLACED, REJECTED, EXPIRED or FAILED. PLACED,
+   * REJECTED, EXPIRED and FAILED are final.
+   */
+  status: string;
+  /** updated_at is when the order reached status, in Unix milliseconds. */
+  updatedAt: number;
+  /** order is set once the order is placed or on hold. */
+  order:
+    | OrderResult
+    | undefined;
+  /**
+   * error_code, error_reason and error_message are the gRPC code, the
+   * ErrorInfo reason and the message of the error PlaceOrder would have
+   * returned for a FAILED order.
+   */
+  errorCode: string;
+  errorReason: string;
+  errorMessage: string;
+}
+
+export interface ApproveOrderRequest {
+  orderId: string;
+  approver: string;
+  comment: string;
+}
+
+export interface ApproveOrderResponse {
+  order: OrderResult | undefined;
+}
+
+export interface RejectOrderRequest {
+  orderId: string;
+  approver: string;
+  reason: string;
+}
+
+export interface RejectOrderResponse {
+  order: OrderResult | undefined;
+}
+
 export interface AdRequest {
   /** List of important key words from the current page describing the context. */
   contextKeys: string[];
@@ -266,6 +553,59 @@ export interface SupportRequest {
   status: string;
   jiraTicketId: string;
   createdAt: number;
+  updatedAt: number;
+  history: SupportRequestStatusChange[];
+  /**
+   * ticket_status is PENDING while the ticket is being filed in the
+   * background, then CREATED or FAILED. It is empty when no ticket provider
+   * is configured.
+   */
+  ticketStatus: string;
+  ticketUrl: string;
+  /**
+   * Matching requests received within the deduplication window are grouped
+   * into one incident, which shares the ticket of its first request.
+   */
+  incidentId: string;
+  fingerprint: string;
+  service: string;
+  /**
+   * dora_incident_id is the DORA metrics incident recorded for the request
+   * that opened an incident. It is resolved when that request is resolved.
+   */
+  doraIncidentId: string;
+  /** trace_id and span_id identify the span the request was created in. */
+  traceId: string;
+  spanId: string;
+}
+
+/** SupportIncident groups the support requests that report the same failure. */
+export interface SupportIncident {
+  id: string;
+  fingerprint: string;
+  /**
+   * support_request_ids lists the grouped requests, oldest first. The
+   * first one filed the ticket; the others were added to it as comments.
+   */
+  supportRequestIds: string[];
+  affectedUserCount: number;
+  ticketStatus: string;
+  ticketKey: string;
+  ticketUrl: string;
+  firstSeenAt: number;
+  lastSeenAt: number;
+}
+
+/**
+ * SupportRequestStatusChange records one transition of a support request's
+ * status. Status values are CREATED, TRIAGED, IN_PROGRESS, RESOLVED and CLOSED.
+ */
+export interface SupportRequestStatusChange {
+  fromStatus: string;
+  toStatus: string;
+  actor: string;
+  reason: string;
+  changedAt: number;
 }
 
 export interface CreateSupportRequestRequest {
@@ -275,7 +615,14 @@ export interface CreateSupportRequestRequest {
   description: string;
   errorMessage: string;
   failedItems: OrderItem[];
-  shippingAddress: Address | undefined;
+  shippingAddress:
+    | Address
+    | undefined;
+  /**
+   * service is the service that failed. Defaults to the service the error
+   * message blames, or checkout.
+   */
+  service: string;
 }
 
 export interface CreateSupportRequestResponse {
@@ -286,6 +633,57 @@ export interface GetSupportRequestRequest {
   id: string;
 }
 
+export interface ListSupportRequestsRequest {
+  /**
+   * All filters are optional. created_after and created_before are Unix
+   * seconds and bound created_at inclusively.
+   */
+  userId: string;
+  status: string;
+  createdAfter: number;
+  createdBefore: number;
+}
+
+export interface ListSupportRequestsResponse {
+  supportRequests: SupportRequest[];
+}
+
+export interface UpdateSupportRequestStatusRequest {
+  id: string;
+  status: string;
+  actor: string;
+  reason: string;
+}
+
+export interface GetSupportIncidentRequest {
+  id: string;
+}
+
+export interface SupportJob {
+  id: string;
+  kind: string;
+  supportRequestId: string;
+  target: string;
+  state: string;
+  attempts: number;
+  maxAttempts: number;
+  lastError: string;
+  createdAt: number;
+  updatedAt: number;
+  nextAttemptAt: number;
+}
+
+export interface ListDeadJobsRequest {
+}
+
+export interface ListDeadJobsResponse {
+  jobs: SupportJob[];
+}
+
+export interface ReplayDeadJobRequest {
+  id: string;
+}
+
 function createBaseCartItem(): CartItem {
   return { productId: "", quantity: 0 };
 }
@@ -1332,7 +1730,7 @@ export const GetQuoteResponse: MessageFns<GetQuoteResponse> = {
 };
 
 function createBaseShipOrderRequest(): ShipOrderRequest {
-  return { address: undefined, items: [] };
+  return { address: undefined, items: [], shippingMethod: "" };
 }
 
 export const ShipOrderRequest: MessageFns<ShipOrderRequest> = {
@@ -1343,6 +1741,9 @@ export const ShipOrderRequest: MessageFns<ShipOrderRequest> = {
     for (const v of message.items) {
       CartItem.encode(v!, writer.uint32(18).fork()).join();
     }
+    if (message.shippingMethod !== "") {
+      writer.uint32(26).string(message.shippingMethod);
+    }
     return writer;
   },
 
@@ -1369,6 +1770,14 @@ export const ShipOrderRequest: MessageFns<ShipOrderRequest> = {
           message.items.push(CartItem.decode(reader, reader.uint32()));
           continue;
         }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.shippingMethod = reader.string();
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -1382,6 +1791,7 @@ export const ShipOrderRequest: MessageFns<ShipOrderRequest> = {
     return {
       address: isSet(object.address) ? Address.fromJSON(object.address) : undefined,
       items: globalThis.Array.isArray(object?.items) ? object.items.map((e: any) => CartItem.fromJSON(e)) : [],
+      shippingMethod: isSet(object.shippingMethod) ? globalThis.String(object.shippingMethod) : "",
     };
   },
 
@@ -1393,6 +1803,9 @@ export const ShipOrderRequest: MessageFns<ShipOrderRequest> = {
     if (message.items?.length) {
       obj.items = message.items.map((e) => CartItem.toJSON(e));
     }
+    if (message.shippingMethod !== "") {
+      obj.shippingMethod = message.shippingMethod;
+    }
     return obj;
   },
 
@@ -1405,26 +1818,36 @@ export const ShipOrderRequest: MessageFns<ShipOrderRequest> = {
       ? Address.fromPartial(object.address)
       : undefined;
     message.items = object.items?.map((e) => CartItem.fromPartial(e)) || [];
+    message.shippingMethod = object.shippingMethod ?? "";
     return message;
   },
 };
 
-function createBaseShipOrderResponse(): ShipOrderResponse {
-  return { trackingId: "" };
+function createBaseShippingOption(): ShippingOption {
+  return { method: "", cost: undefined, minDeliveryDays: 0, maxDeliveryDays: 0 };
 }
 
-export const ShipOrderResponse: MessageFns<ShipOrderResponse> = {
-  encode(message: ShipOrderResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    if (message.trackingId !== "") {
-      writer.uint32(10).string(message.trackingId);
+export const ShippingOption: MessageFns<ShippingOption> = {
+  encode(message: ShippingOption, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.method !== "") {
+      writer.uint32(10).string(message.method);
+    }
+    if (message.cost !== undefined) {
+      Money.encode(message.cost, writer.uint32(18).fork()).join();
+    }
+    if (message.minDeliveryDays !== 0) {
+      writer.uint32(24).int32(message.minDeliveryDays);
+    }
+    if (message.maxDeliveryDays !== 0) {
+      writer.uint32(32).int32(message.maxDeliveryDays);
     }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): ShipOrderResponse {
+  decode(input: BinaryReader | Uint8Array, length?: number): ShippingOption {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseShipOrderResponse();
+    const message = createBaseShippingOption();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -1433,7 +1856,31 @@ export const ShipOrderResponse: MessageFns<ShipOrderResponse> = {
             break;
           }
 
-          message.trackingId = reader.string();
+          message.method = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.cost = Money.decode(reader, reader.uint32());
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.minDeliveryDays = reader.int32();
+          continue;
+        }
+        case 4: {
+          if (tag !== 32) {
+            break;
+          }
+
+          message.maxDeliveryDays = reader.int32();
           continue;
         }
       }
@@ -1445,44 +1892,119 @@ export const ShipOrderResponse: MessageFns<ShipOrderResponse> = {
     return message;
   },
 
-  fromJSON(object: any): ShipOrderResponse {
-    return { trackingId: isSet(object.trackingId) ? globalThis.String(object.trackingId) : "" };
+  fromJSON(object: any): ShippingOption {
+    return {
+      method: isSet(object.method) ? globalThis.String(object.method) : "",
+      cost: isSet(object.cost) ? Money.fromJSON(object.cost) : undefined,
+      minDeliveryDays: isSet(object.minDeliveryDays) ? globalThis.Number(object.minDeliveryDays) : 0,
+      maxDeliveryDays: isSet(object.maxDeliveryDays) ? globalThis.Number(object.maxDeliveryDays) : 0,
+    };
   },
 
-  toJSON(message: ShipOrderResponse): unknown {
+  toJSON(message: ShippingOption): unknown {
     const obj: any = {};
-    if (message.trackingId !== "") {
-      obj.trackingId = message.trackingId;
+    if (message.method !== "") {
+      obj.method = message.method;
+    }
+    if (message.cost !== undefined) {
+      obj.cost = Money.toJSON(message.cost);
+    }
+    if (message.minDeliveryDays !== 0) {
+      obj.minDeliveryDays = Math.round(message.minDeliveryDays);
+    }
+    if (message.maxDeliveryDays !== 0) {
+      obj.maxDeliveryDays = Math.round(message.maxDeliveryDays);
     }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<ShipOrderResponse>, I>>(base?: I): ShipOrderResponse {
-    return ShipOrderResponse.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<ShippingOption>, I>>(base?: I): ShippingOption {
+    return ShippingOption.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<ShipOrderResponse>, I>>(object: I): ShipOrderResponse {
-    const message = createBaseShipOrderResponse();
-    message.trackingId = object.trackingId ?? "";
+  fromPartial<I extends Exact<DeepPartial<ShippingOption>, I>>(object: I): ShippingOption {
+    const message = createBaseShippingOption();
+    message.method = object.method ?? "";
+    message.cost = (object.cost !== undefined && object.cost !== null) ? Money.fromPartial(object.cost) : undefined;
+    message.minDeliveryDays = object.minDeliveryDays ?? 0;
+    message.maxDeliveryDays = object.maxDeliveryDays ?? 0;
     return message;
   },
 };
 
-function createBaseAddress(): Address {
-  return { streetAddress: "", city: "", state: "", country: "", zipCode: "" };
+function createBaseShipOrderResponse(): ShipOrderResponse {
+  return { trackingId: "" };
 }
 
-export const Address: MessageFns<Address> = {
-  encode(message: Address, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    if (message.streetAddress !== "") {
-      writer.uint32(10).string(message.streetAddress);
-    }
-    if (message.city !== "") {
-      writer.uint32(18).string(message.city);
-    }
-    if (message.state !== "") {
-      writer.uint32(26).string(message.state);
+export const ShipOrderResponse: MessageFns<ShipOrderResponse> = {
+  encode(message: ShipOrderResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.trackingId !== "") {
+      writer.uint32(10).string(message.trackingId);
     }
-    if (message.country !== "") {
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): ShipOrderResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseShipOrderResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.trackingId = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ShipOrderResponse {
+    return { trackingId: isSet(object.trackingId) ? globalThis.String(object.trackingId) : "" };
+  },
+
+  toJSON(message: ShipOrderResponse): unknown {
+    const obj: any = {};
+    if (message.trackingId !== "") {
+      obj.trackingId = message.trackingId;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<ShipOrderResponse>, I>>(base?: I): ShipOrderResponse {
+    return ShipOrderResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<ShipOrderResponse>, I>>(object: I): ShipOrderResponse {
+    const message = createBaseShipOrderResponse();
+    message.trackingId = object.trackingId ?? "";
+    return message;
+  },
+};
+
+function createBaseAddress(): Address {
+  return { streetAddress: "", city: "", state: "", country: "", zipCode: "" };
+}
+
+export const Address: MessageFns<Address> = {
+  encode(message: Address, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.streetAddress !== "") {
+      writer.uint32(10).string(message.streetAddress);
+    }
+    if (message.city !== "") {
+      writer.uint32(18).string(message.city);
+    }
+    if (message.state !== "") {
+      writer.uint32(26).string(message.state);
+    }
+    if (message.country !== "") {
       writer.uint32(34).string(message.country);
     }
     if (message.zipCode !== "") {
@@ -1936,7 +2458,7 @@ export const CreditCardInfo: MessageFns<CreditCardInfo> = {
 };
 
 function createBaseChargeRequest(): ChargeRequest {
-  return { amount: undefined, creditCard: undefined };
+  return { amount: undefined, creditCard: undefined, authorizeOnly: false, card: undefined };
 }
 
 export const ChargeRequest: MessageFns<ChargeRequest> = {
@@ -1947,6 +2469,12 @@ export const ChargeRequest: MessageFns<ChargeRequest> = {
     if (message.creditCard !== undefined) {
       CreditCardInfo.encode(message.creditCard, writer.uint32(18).fork()).join();
     }
+    if (message.authorizeOnly !== false) {
+      writer.uint32(24).bool(message.authorizeOnly);
+    }
+    if (message.card !== undefined) {
+      PaymentCard.encode(message.card, writer.uint32(34).fork()).join();
+    }
     return writer;
   },
 
@@ -1973,6 +2501,22 @@ export const ChargeRequest: MessageFns<ChargeRequest> = {
           message.creditCard = CreditCardInfo.decode(reader, reader.uint32());
           continue;
         }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.authorizeOnly = reader.bool();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.card = PaymentCard.decode(reader, reader.uint32());
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -1986,6 +2530,8 @@ export const ChargeRequest: MessageFns<ChargeRequest> = {
     return {
       amount: isSet(object.amount) ? Money.fromJSON(object.amount) : undefined,
       creditCard: isSet(object.creditCard) ? CreditCardInfo.fromJSON(object.creditCard) : undefined,
+      authorizeOnly: isSet(object.authorizeOnly) ? globalThis.Boolean(object.authorizeOnly) : false,
+      card: isSet(object.card) ? PaymentCard.fromJSON(object.card) : undefined,
     };
   },
 
@@ -1997,6 +2543,12 @@ export const ChargeRequest: MessageFns<ChargeRequest> = {
     if (message.creditCard !== undefined) {
       obj.creditCard = CreditCardInfo.toJSON(message.creditCard);
     }
+    if (message.authorizeOnly !== false) {
+      obj.authorizeOnly = message.authorizeOnly;
+    }
+    if (message.card !== undefined) {
+      obj.card = PaymentCard.toJSON(message.card);
+    }
     return obj;
   },
 
@@ -2011,26 +2563,154 @@ export const ChargeRequest: MessageFns<ChargeRequest> = {
     message.creditCard = (object.creditCard !== undefined && object.creditCard !== null)
       ? CreditCardInfo.fromPartial(object.creditCard)
       : undefined;
+    message.authorizeOnly = object.authorizeOnly ?? false;
+    message.card = (object.card !== undefined && object.card !== null)
+      ? PaymentCard.fromPartial(object.card)
+      : undefined;
     return message;
   },
 };
 
-function createBaseChargeResponse(): ChargeResponse {
+function createBasePaymentCard(): PaymentCard {
+  return { token: "", brand: "", lastFour: "", expirationYear: 0, expirationMonth: 0 };
+}
+
+export const PaymentCard: MessageFns<PaymentCard> = {
+  encode(message: PaymentCard, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.token !== "") {
+      writer.uint32(10).string(message.token);
+    }
+    if (message.brand !== "") {
+      writer.uint32(18).string(message.brand);
+    }
+    if (message.lastFour !== "") {
+      writer.uint32(26).string(message.lastFour);
+    }
+    if (message.expirationYear !== 0) {
+      writer.uint32(32).int32(message.expirationYear);
+    }
+    if (message.expirationMonth !== 0) {
+      writer.uint32(40).int32(message.expirationMonth);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): PaymentCard {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePaymentCard();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.token = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.brand = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.lastFour = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 32) {
+            break;
+          }
+
+          message.expirationYear = reader.int32();
+          continue;
+        }
+        case 5: {
+          if (tag !== 40) {
+            break;
+          }
+
+          message.expirationMonth = reader.int32();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): PaymentCard {
+    return {
+      token: isSet(object.token) ? globalThis.String(object.token) : "",
+      brand: isSet(object.brand) ? globalThis.String(object.brand) : "",
+      lastFour: isSet(object.lastFour) ? globalThis.String(object.lastFour) : "",
+      expirationYear: isSet(object.expirationYear) ? globalThis.Number(object.expirationYear) : 0,
+      expirationMonth: isSet(object.expirationMonth) ? globalThis.Number(object.expirationMonth) : 0,
+    };
+  },
+
+  toJSON(message: PaymentCard): unknown {
+    const obj: any = {};
+    if (message.token !== "") {
+      obj.token = message.token;
+    }
+    if (message.brand !== "") {
+      obj.brand = message.brand;
+    }
+    if (message.lastFour !== "") {
+      obj.lastFour = message.lastFour;
+    }
+    if (message.expirationYear !== 0) {
+      obj.expirationYear = Math.round(message.expirationYear);
+    }
+    if (message.expirationMonth !== 0) {
+      obj.expirationMonth = Math.round(message.expirationMonth);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<PaymentCard>, I>>(base?: I): PaymentCard {
+    return PaymentCard.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<PaymentCard>, I>>(object: I): PaymentCard {
+    const message = createBasePaymentCard();
+    message.token = object.token ?? "";
+    message.brand = object.brand ?? "";
+    message.lastFour = object.lastFour ?? "";
+    message.expirationYear = object.expirationYear ?? 0;
+    message.expirationMonth = object.expirationMonth ?? 0;
+    return message;
+  },
+};
+
+function createBaseCaptureRequest(): CaptureRequest {
   return { transactionId: "" };
 }
 
-export const ChargeResponse: MessageFns<ChargeResponse> = {
-  encode(message: ChargeResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+export const CaptureRequest: MessageFns<CaptureRequest> = {
+  encode(message: CaptureRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
     if (message.transactionId !== "") {
       writer.uint32(10).string(message.transactionId);
     }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): ChargeResponse {
+  decode(input: BinaryReader | Uint8Array, length?: number): CaptureRequest {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseChargeResponse();
+    const message = createBaseCaptureRequest();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -2051,11 +2731,11 @@ export const ChargeResponse: MessageFns<ChargeResponse> = {
     return message;
   },
 
-  fromJSON(object: any): ChargeResponse {
+  fromJSON(object: any): CaptureRequest {
     return { transactionId: isSet(object.transactionId) ? globalThis.String(object.transactionId) : "" };
   },
 
-  toJSON(message: ChargeResponse): unknown {
+  toJSON(message: CaptureRequest): unknown {
     const obj: any = {};
     if (message.transactionId !== "") {
       obj.transactionId = message.transactionId;
@@ -2063,35 +2743,32 @@ export const ChargeResponse: MessageFns<ChargeResponse> = {
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<ChargeResponse>, I>>(base?: I): ChargeResponse {
-    return ChargeResponse.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<CaptureRequest>, I>>(base?: I): CaptureRequest {
+    return CaptureRequest.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<ChargeResponse>, I>>(object: I): ChargeResponse {
-    const message = createBaseChargeResponse();
+  fromPartial<I extends Exact<DeepPartial<CaptureRequest>, I>>(object: I): CaptureRequest {
+    const message = createBaseCaptureRequest();
     message.transactionId = object.transactionId ?? "";
     return message;
   },
 };
 
-function createBaseOrderItem(): OrderItem {
-  return { item: undefined, cost: undefined };
+function createBaseVoidRequest(): VoidRequest {
+  return { transactionId: "" };
 }
 
-export const OrderItem: MessageFns<OrderItem> = {
-  encode(message: OrderItem, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    if (message.item !== undefined) {
-      CartItem.encode(message.item, writer.uint32(10).fork()).join();
-    }
-    if (message.cost !== undefined) {
-      Money.encode(message.cost, writer.uint32(18).fork()).join();
+export const VoidRequest: MessageFns<VoidRequest> = {
+  encode(message: VoidRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.transactionId !== "") {
+      writer.uint32(10).string(message.transactionId);
     }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): OrderItem {
+  decode(input: BinaryReader | Uint8Array, length?: number): VoidRequest {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseOrderItem();
+    const message = createBaseVoidRequest();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -2100,15 +2777,7 @@ export const OrderItem: MessageFns<OrderItem> = {
             break;
           }
 
-          message.item = CartItem.decode(reader, reader.uint32());
-          continue;
-        }
-        case 2: {
-          if (tag !== 18) {
-            break;
-          }
-
-          message.cost = Money.decode(reader, reader.uint32());
+          message.transactionId = reader.string();
           continue;
         }
       }
@@ -2120,63 +2789,47 @@ export const OrderItem: MessageFns<OrderItem> = {
     return message;
   },
 
-  fromJSON(object: any): OrderItem {
-    return {
-      item: isSet(object.item) ? CartItem.fromJSON(object.item) : undefined,
-      cost: isSet(object.cost) ? Money.fromJSON(object.cost) : undefined,
-    };
+  fromJSON(object: any): VoidRequest {
+    return { transactionId: isSet(object.transactionId) ? globalThis.String(object.transactionId) : "" };
   },
 
-  toJSON(message: OrderItem): unknown {
+  toJSON(message: VoidRequest): unknown {
     const obj: any = {};
-    if (message.item !== undefined) {
-      obj.item = CartItem.toJSON(message.item);
-    }
-    if (message.cost !== undefined) {
-      obj.cost = Money.toJSON(message.cost);
+    if (message.transactionId !== "") {
+      obj.transactionId = message.transactionId;
     }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<OrderItem>, I>>(base?: I): OrderItem {
-    return OrderItem.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<VoidRequest>, I>>(base?: I): VoidRequest {
+    return VoidRequest.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<OrderItem>, I>>(object: I): OrderItem {
-    const message = createBaseOrderItem();
-    message.item = (object.item !== undefined && object.item !== null) ? CartItem.fromPartial(object.item) : undefined;
-    message.cost = (object.cost !== undefined && object.cost !== null) ? Money.fromPartial(object.cost) : undefined;
+  fromPartial<I extends Exact<DeepPartial<VoidRequest>, I>>(object: I): VoidRequest {
+    const message = createBaseVoidRequest();
+    message.transactionId = object.transactionId ?? "";
     return message;
   },
 };
 
-function createBaseOrderResult(): OrderResult {
-  return { orderId: "", shippingTrackingId: "", shippingCost: undefined, shippingAddress: undefined, items: [] };
+function createBaseRefundRequest(): RefundRequest {
+  return { transactionId: "", amount: undefined };
 }
 
-export const OrderResult: MessageFns<OrderResult> = {
-  encode(message: OrderResult, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    if (message.orderId !== "") {
-      writer.uint32(10).string(message.orderId);
-    }
-    if (message.shippingTrackingId !== "") {
-      writer.uint32(18).string(message.shippingTrackingId);
-    }
-    if (message.shippingCost !== undefined) {
-      Money.encode(message.shippingCost, writer.uint32(26).fork()).join();
-    }
-    if (message.shippingAddress !== undefined) {
-      Address.encode(message.shippingAddress, writer.uint32(34).fork()).join();
+export const RefundRequest: MessageFns<RefundRequest> = {
+  encode(message: RefundRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.transactionId !== "") {
+      writer.uint32(10).string(message.transactionId);
     }
-    for (const v of message.items) {
-      OrderItem.encode(v!, writer.uint32(42).fork()).join();
+    if (message.amount !== undefined) {
+      Money.encode(message.amount, writer.uint32(18).fork()).join();
     }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): OrderResult {
+  decode(input: BinaryReader | Uint8Array, length?: number): RefundRequest {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseOrderResult();
+    const message = createBaseRefundRequest();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -2185,7 +2838,7 @@ export const OrderResult: MessageFns<OrderResult> = {
             break;
           }
 
-          message.orderId = reader.string();
+          message.transactionId = reader.string();
           continue;
         }
         case 2: {
@@ -2193,31 +2846,74 @@ export const OrderResult: MessageFns<OrderResult> = {
             break;
           }
 
-          message.shippingTrackingId = reader.string();
+          message.amount = Money.decode(reader, reader.uint32());
           continue;
         }
-        case 3: {
-          if (tag !== 26) {
-            break;
-          }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
 
-          message.shippingCost = Money.decode(reader, reader.uint32());
-          continue;
-        }
-        case 4: {
-          if (tag !== 34) {
-            break;
-          }
+  fromJSON(object: any): RefundRequest {
+    return {
+      transactionId: isSet(object.transactionId) ? globalThis.String(object.transactionId) : "",
+      amount: isSet(object.amount) ? Money.fromJSON(object.amount) : undefined,
+    };
+  },
 
-          message.shippingAddress = Address.decode(reader, reader.uint32());
-          continue;
-        }
-        case 5: {
-          if (tag !== 42) {
+  toJSON(message: RefundRequest): unknown {
+    const obj: any = {};
+    if (message.transactionId !== "") {
+      obj.transactionId = message.transactionId;
+    }
+    if (message.amount !== undefined) {
+      obj.amount = Money.toJSON(message.amount);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<RefundRequest>, I>>(base?: I): RefundRequest {
+    return RefundRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<RefundRequest>, I>>(object: I): RefundRequest {
+    const message = createBaseRefundRequest();
+    message.transactionId = object.transactionId ?? "";
+    message.amount = (object.amount !== undefined && object.amount !== null)
+      ? Money.fromPartial(object.amount)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseChargeResponse(): ChargeResponse {
+  return { transactionId: "" };
+}
+
+export const ChargeResponse: MessageFns<ChargeResponse> = {
+  encode(message: ChargeResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.transactionId !== "") {
+      writer.uint32(10).string(message.transactionId);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): ChargeResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseChargeResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
             break;
           }
 
-          message.items.push(OrderItem.decode(reader, reader.uint32()));
+          message.transactionId = reader.string();
           continue;
         }
       }
@@ -2229,73 +2925,47 @@ export const OrderResult: MessageFns<OrderResult> = {
     return message;
   },
 
-  fromJSON(object: any): OrderResult {
-    return {
-      orderId: isSet(object.orderId) ? globalThis.String(object.orderId) : "",
-      shippingTrackingId: isSet(object.shippingTrackingId) ? globalThis.String(object.shippingTrackingId) : "",
-      shippingCost: isSet(object.shippingCost) ? Money.fromJSON(object.shippingCost) : undefined,
-      shippingAddress: isSet(object.shippingAddress) ? Address.fromJSON(object.shippingAddress) : undefined,
-      items: globalThis.Array.isArray(object?.items) ? object.items.map((e: any) => OrderItem.fromJSON(e)) : [],
-    };
+  fromJSON(object: any): ChargeResponse {
+    return { transactionId: isSet(object.transactionId) ? globalThis.String(object.transactionId) : "" };
   },
 
-  toJSON(message: OrderResult): unknown {
+  toJSON(message: ChargeResponse): unknown {
     const obj: any = {};
-    if (message.orderId !== "") {
-      obj.orderId = message.orderId;
-    }
-    if (message.shippingTrackingId !== "") {
-      obj.shippingTrackingId = message.shippingTrackingId;
-    }
-    if (message.shippingCost !== undefined) {
-      obj.shippingCost = Money.toJSON(message.shippingCost);
-    }
-    if (message.shippingAddress !== undefined) {
-      obj.shippingAddress = Address.toJSON(message.shippingAddress);
-    }
-    if (message.items?.length) {
-      obj.items = message.items.map((e) => OrderItem.toJSON(e));
+    if (message.transactionId !== "") {
+      obj.transactionId = message.transactionId;
     }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<OrderResult>, I>>(base?: I): OrderResult {
-    return OrderResult.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<ChargeResponse>, I>>(base?: I): ChargeResponse {
+    return ChargeResponse.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<OrderResult>, I>>(object: I): OrderResult {
-    const message = createBaseOrderResult();
-    message.orderId = object.orderId ?? "";
-    message.shippingTrackingId = object.shippingTrackingId ?? "";
-    message.shippingCost = (object.shippingCost !== undefined && object.shippingCost !== null)
-      ? Money.fromPartial(object.shippingCost)
-      : undefined;
-    message.shippingAddress = (object.shippingAddress !== undefined && object.shippingAddress !== null)
-      ? Address.fromPartial(object.shippingAddress)
-      : undefined;
-    message.items = object.items?.map((e) => OrderItem.fromPartial(e)) || [];
+  fromPartial<I extends Exact<DeepPartial<ChargeResponse>, I>>(object: I): ChargeResponse {
+    const message = createBaseChargeResponse();
+    message.transactionId = object.transactionId ?? "";
     return message;
   },
 };
 
-function createBaseSendOrderConfirmationRequest(): SendOrderConfirmationRequest {
-  return { email: "", order: undefined };
+function createBaseOrderItem(): OrderItem {
+  return { item: undefined, cost: undefined };
 }
 
-export const SendOrderConfirmationRequest: MessageFns<SendOrderConfirmationRequest> = {
-  encode(message: SendOrderConfirmationRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    if (message.email !== "") {
-      writer.uint32(10).string(message.email);
+export const OrderItem: MessageFns<OrderItem> = {
+  encode(message: OrderItem, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.item !== undefined) {
+      CartItem.encode(message.item, writer.uint32(10).fork()).join();
     }
-    if (message.order !== undefined) {
-      OrderResult.encode(message.order, writer.uint32(18).fork()).join();
+    if (message.cost !== undefined) {
+      Money.encode(message.cost, writer.uint32(18).fork()).join();
     }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): SendOrderConfirmationRequest {
+  decode(input: BinaryReader | Uint8Array, length?: number): OrderItem {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseSendOrderConfirmationRequest();
+    const message = createBaseOrderItem();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -2304,7 +2974,7 @@ export const SendOrderConfirmationRequest: MessageFns<SendOrderConfirmationReque
             break;
           }
 
-          message.email = reader.string();
+          message.item = CartItem.decode(reader, reader.uint32());
           continue;
         }
         case 2: {
@@ -2312,7 +2982,7 @@ export const SendOrderConfirmationRequest: MessageFns<SendOrderConfirmationReque
             break;
           }
 
-          message.order = OrderResult.decode(reader, reader.uint32());
+          message.cost = Money.decode(reader, reader.uint32());
           continue;
         }
       }
@@ -2324,65 +2994,93 @@ export const SendOrderConfirmationRequest: MessageFns<SendOrderConfirmationReque
     return message;
   },
 
-  fromJSON(object: any): SendOrderConfirmationRequest {
+  fromJSON(object: any): OrderItem {
     return {
-      email: isSet(object.email) ? globalThis.String(object.email) : "",
-      order: isSet(object.order) ? OrderResult.fromJSON(object.order) : undefined,
+      item: isSet(object.item) ? CartItem.fromJSON(object.item) : undefined,
+      cost: isSet(object.cost) ? Money.fromJSON(object.cost) : undefined,
     };
   },
 
-  toJSON(message: SendOrderConfirmationRequest): unknown {
+  toJSON(message: OrderItem): unknown {
     const obj: any = {};
-    if (message.email !== "") {
-      obj.email = message.email;
+    if (message.item !== undefined) {
+      obj.item = CartItem.toJSON(message.item);
     }
-    if (message.order !== undefined) {
-      obj.order = OrderResult.toJSON(message.order);
+    if (message.cost !== undefined) {
+      obj.cost = Money.toJSON(message.cost);
     }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<SendOrderConfirmationRequest>, I>>(base?: I): SendOrderConfirmationRequest {
-    return SendOrderConfirmationRequest.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<OrderItem>, I>>(base?: I): OrderItem {
+    return OrderItem.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<SendOrderConfirmationRequest>, I>>(object: I): SendOrderConfirmationRequest {
-    const message = createBaseSendOrderConfirmationRequest();
-    message.email = object.email ?? "";
-    message.order = (object.order !== undefined && object.order !== null)
-      ? OrderResult.fromPartial(object.order)
-      : undefined;
+  fromPartial<I extends Exact<DeepPartial<OrderItem>, I>>(object: I): OrderItem {
+    const message = createBaseOrderItem();
+    message.item = (object.item !== undefined && object.item !== null) ? CartItem.fromPartial(object.item) : undefined;
+    message.cost = (object.cost !== undefined && object.cost !== null) ? Money.fromPartial(object.cost) : undefined;
     return message;
   },
 };
 
-function createBasePlaceOrderRequest(): PlaceOrderRequest {
-  return { userId: "", userCurrency: "", address: undefined, email: "", creditCard: undefined };
+function createBaseOrderResult(): OrderResult {
+  return {
+    orderId: "",
+    shippingTrackingId: "",
+    shippingCost: undefined,
+    shippingAddress: undefined,
+    items: [],
+    status: "",
+    hold: undefined,
+    payments: [],
+    shippingMethod: "",
+    shipments: [],
+    confirmation: undefined,
+  };
 }
 
-export const PlaceOrderRequest: MessageFns<PlaceOrderRequest> = {
-  encode(message: PlaceOrderRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    if (message.userId !== "") {
-      writer.uint32(10).string(message.userId);
+export const OrderResult: MessageFns<OrderResult> = {
+  encode(message: OrderResult, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.orderId !== "") {
+      writer.uint32(10).string(message.orderId);
     }
-    if (message.userCurrency !== "") {
-      writer.uint32(18).string(message.userCurrency);
+    if (message.shippingTrackingId !== "") {
+      writer.uint32(18).string(message.shippingTrackingId);
     }
-    if (message.address !== undefined) {
-      Address.encode(message.address, writer.uint32(26).fork()).join();
+    if (message.shippingCost !== undefined) {
+      Money.encode(message.shippingCost, writer.uint32(26).fork()).join();
     }
-    if (message.email !== "") {
-      writer.uint32(42).string(message.email);
+    if (message.shippingAddress !== undefined) {
+      Address.encode(message.shippingAddress, writer.uint32(34).fork()).join();
     }
-    if (message.creditCard !== undefined) {
-      CreditCardInfo.encode(message.creditCard, writer.uint32(50).fork()).join();
+    for (const v of message.items) {
+      OrderItem.encode(v!, writer.uint32(42).fork()).join();
+    }
+    if (message.status !== "") {
+      writer.uint32(50).string(message.status);
+    }
+    if (message.hold !== undefined) {
+      OrderHold.encode(message.hold, writer.uint32(58).fork()).join();
+    }
+    for (const v of message.payments) {
+      OrderPayment.encode(v!, writer.uint32(66).fork()).join();
+    }
+    if (message.shippingMethod !== "") {
+      writer.uint32(74).string(message.shippingMethod);
+    }
+    for (const v of message.shipments) {
+      OrderShipment.encode(v!, writer.uint32(82).fork()).join();
+    }
+    if (message.confirmation !== undefined) {
+      ConfirmationDelivery.encode(message.confirmation, writer.uint32(90).fork()).join();
     }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): PlaceOrderRequest {
+  decode(input: BinaryReader | Uint8Array, length?: number): OrderResult {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBasePlaceOrderRequest();
+    const message = createBaseOrderResult();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -2391,7 +3089,7 @@ export const PlaceOrderRequest: MessageFns<PlaceOrderRequest> = {
             break;
           }
 
-          message.userId = reader.string();
+          message.orderId = reader.string();
           continue;
         }
         case 2: {
@@ -2399,7 +3097,7 @@ export const PlaceOrderRequest: MessageFns<PlaceOrderRequest> = {
             break;
           }
 
-          message.userCurrency = reader.string();
+          message.shippingTrackingId = reader.string();
           continue;
         }
         case 3: {
@@ -2407,7 +3105,15 @@ export const PlaceOrderRequest: MessageFns<PlaceOrderRequest> = {
             break;
           }
 
-          message.address = Address.decode(reader, reader.uint32());
+          message.shippingCost = Money.decode(reader, reader.uint32());
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.shippingAddress = Address.decode(reader, reader.uint32());
           continue;
         }
         case 5: {
@@ -2415,7 +3121,7 @@ export const PlaceOrderRequest: MessageFns<PlaceOrderRequest> = {
             break;
           }
 
-          message.email = reader.string();
+          message.items.push(OrderItem.decode(reader, reader.uint32()));
           continue;
         }
         case 6: {
@@ -2423,7 +3129,47 @@ export const PlaceOrderRequest: MessageFns<PlaceOrderRequest> = {
             break;
           }
 
-          message.creditCard = CreditCardInfo.decode(reader, reader.uint32());
+          message.status = reader.string();
+          continue;
+        }
+        case 7: {
+          if (tag !== 58) {
+            break;
+          }
+
+          message.hold = OrderHold.decode(reader, reader.uint32());
+          continue;
+        }
+        case 8: {
+          if (tag !== 66) {
+            break;
+          }
+
+          message.payments.push(OrderPayment.decode(reader, reader.uint32()));
+          continue;
+        }
+        case 9: {
+          if (tag !== 74) {
+            break;
+          }
+
+          message.shippingMethod = reader.string();
+          continue;
+        }
+        case 10: {
+          if (tag !== 82) {
+            break;
+          }
+
+          message.shipments.push(OrderShipment.decode(reader, reader.uint32()));
+          continue;
+        }
+        case 11: {
+          if (tag !== 90) {
+            break;
+          }
+
+          message.confirmation = ConfirmationDelivery.decode(reader, reader.uint32());
           continue;
         }
       }
@@ -2435,70 +3181,118 @@ export const PlaceOrderRequest: MessageFns<PlaceOrderRequest> = {
     return message;
   },
 
-  fromJSON(object: any): PlaceOrderRequest {
+  fromJSON(object: any): OrderResult {
     return {
-      userId: isSet(object.userId) ? globalThis.String(object.userId) : "",
-      userCurrency: isSet(object.userCurrency) ? globalThis.String(object.userCurrency) : "",
-      address: isSet(object.address) ? Address.fromJSON(object.address) : undefined,
-      email: isSet(object.email) ? globalThis.String(object.email) : "",
-      creditCard: isSet(object.creditCard) ? CreditCardInfo.fromJSON(object.creditCard) : undefined,
-    };
-  },
+      orderId: isSet(object.orderId) ? globalThis.String(object.orderId) : "",
+      shippingTrackingId: isSet(object.shippingTrackingId) ? globalThis.String(object.shippingTrackingId) : "",
+      shippingCost: isSet(object.shippingCost) ? Money.fromJSON(object.shippingCost) : undefined,
+      shippingAddress: isSet(object.shippingAddress) ? Address.fromJSON(object.shippingAddress) : undefined,
+      items: globalThis.Array.isArray(object?.items) ? object.items.map((e: any) => OrderItem.fromJSON(e)) : [],
+      status: isSet(object.status) ? globalThis.String(object.status) : "",
+      hold: isSet(object.hold) ? OrderHold.fromJSON(object.hold) : undefined,
+      payments: globalThis.Array.isArray(object?.payments)
+        ? object.payments.map((e: any) => OrderPayment.fromJSON(e))
+        : [],
+      shippingMethod: isSet(object.shippingMethod) ? globalThis.String(object.shippingMethod) : "",
+      shipments: globalThis.Array.isArray(object?.shipments)
+        ? object.shipments.map((e: any) => OrderShipment.fromJSON(e))
+        : [],
+      confirmation: isSet(object.confirmation) ? ConfirmationDelivery.fromJSON(object.confirmation) : undefined,
+    };
+  },
 
-  toJSON(message: PlaceOrderRequest): unknown {
+  toJSON(message: OrderResult): unknown {
     const obj: any = {};
-    if (message.userId !== "") {
-      obj.userId = message.userId;
+    if (message.orderId !== "") {
+      obj.orderId = message.orderId;
     }
-    if (message.userCurrency !== "") {
-      obj.userCurrency = message.userCurrency;
+    if (message.shippingTrackingId !== "") {
+      obj.shippingTrackingId = message.shippingTrackingId;
     }
-    if (message.address !== undefined) {
-      obj.address = Address.toJSON(message.address);
+    if (message.shippingCost !== undefined) {
+      obj.shippingCost = Money.toJSON(message.shippingCost);
     }
-    if (message.email !== "") {
-      obj.email = message.email;
+    if (message.shippingAddress !== undefined) {
+      obj.shippingAddress = Address.toJSON(message.shippingAddress);
     }
-    if (message.creditCard !== undefined) {
-      obj.creditCard = CreditCardInfo.toJSON(message.creditCard);
+    if (message.items?.length) {
+      obj.items = message.items.map((e) => OrderItem.toJSON(e));
+    }
+    if (message.status !== "") {
+      obj.status = message.status;
+    }
+    if (message.hold !== undefined) {
+      obj.hold = OrderHold.toJSON(message.hold);
+    }
+    if (message.payments?.length) {
+      obj.payments = message.payments.map((e) => OrderPayment.toJSON(e));
+    }
+    if (message.shippingMethod !== "") {
+      obj.shippingMethod = message.shippingMethod;
+    }
+    if (message.shipments?.length) {
+      obj.shipments = message.shipments.map((e) => OrderShipment.toJSON(e));
+    }
+    if (message.confirmation !== undefined) {
+      obj.confirmation = ConfirmationDelivery.toJSON(message.confirmation);
     }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<PlaceOrderRequest>, I>>(base?: I): PlaceOrderRequest {
-    return PlaceOrderRequest.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<OrderResult>, I>>(base?: I): OrderResult {
+    return OrderResult.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<PlaceOrderRequest>, I>>(object: I): PlaceOrderRequest {
-    const message = createBasePlaceOrderRequest();
-    message.userId = object.userId ?? "";
-    message.userCurrency = object.userCurrency ?? "";
-    message.address = (object.address !== undefined && object.address !== null)
-      ? Address.fromPartial(object.address)
+  fromPartial<I extends Exact<DeepPartial<OrderResult>, I>>(object: I): OrderResult {
+    const message = createBaseOrderResult();
+    message.orderId = object.orderId ?? "";
+    message.shippingTrackingId = object.shippingTrackingId ?? "";
+    message.shippingCost = (object.shippingCost !== undefined && object.shippingCost !== null)
+      ? Money.fromPartial(object.shippingCost)
       : undefined;
-    message.email = object.email ?? "";
-    message.creditCard = (object.creditCard !== undefined && object.creditCard !== null)
-      ? CreditCardInfo.fromPartial(object.creditCard)
+    message.shippingAddress = (object.shippingAddress !== undefined && object.shippingAddress !== null)
+      ? Address.fromPartial(object.shippingAddress)
+      : undefined;
+    message.items = object.items?.map((e) => OrderItem.fromPartial(e)) || [];
+    message.status = object.status ?? "";
+    message.hold = (object.hold !== undefined && object.hold !== null) ? OrderHold.fromPartial(object.hold) : undefined;
+    message.payments = object.payments?.map((e) => OrderPayment.fromPartial(e)) || [];
+    message.shippingMethod = object.shippingMethod ?? "";
+    message.shipments = object.shipments?.map((e) => OrderShipment.fromPartial(e)) || [];
+    message.confirmation = (object.confirmation !== undefined && object.confirmation !== null)
+      ? ConfirmationDelivery.fromPartial(object.confirmation)
       : undefined;
     return message;
   },
 };
 
-function createBasePlaceOrderResponse(): PlaceOrderResponse {
-  return { order: undefined };
+function createBaseConfirmationDelivery(): ConfirmationDelivery {
+  return { status: "", transport: "", attempts: 0, error: "", sentAt: 0 };
 }
 
-export const PlaceOrderResponse: MessageFns<PlaceOrderResponse> = {
-  encode(message: PlaceOrderResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    if (message.order !== undefined) {
-      OrderResult.encode(message.order, writer.uint32(10).fork()).join();
+export const ConfirmationDelivery: MessageFns<ConfirmationDelivery> = {
+  encode(message: ConfirmationDelivery, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.status !== "") {
+      writer.uint32(10).string(message.status);
+    }
+    if (message.transport !== "") {
+      writer.uint32(18).string(message.transport);
+    }
+    if (message.attempts !== 0) {
+      writer.uint32(24).int32(message.attempts);
+    }
+    if (message.error !== "") {
+      writer.uint32(34).string(message.error);
+    }
+    if (message.sentAt !== 0) {
+      writer.uint32(40).int64(message.sentAt);
     }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): PlaceOrderResponse {
+  decode(input: BinaryReader | Uint8Array, length?: number): ConfirmationDelivery {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBasePlaceOrderResponse();
+    const message = createBaseConfirmationDelivery();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -2507,7 +3301,39 @@ export const PlaceOrderResponse: MessageFns<PlaceOrderResponse> = {
             break;
           }
 
-          message.order = OrderResult.decode(reader, reader.uint32());
+          message.status = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.transport = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.attempts = reader.int32();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.error = reader.string();
+          continue;
+        }
+        case 5: {
+          if (tag !== 40) {
+            break;
+          }
+
+          message.sentAt = longToNumber(reader.int64());
           continue;
         }
       }
@@ -2519,46 +3345,81 @@ export const PlaceOrderResponse: MessageFns<PlaceOrderResponse> = {
     return message;
   },
 
-  fromJSON(object: any): PlaceOrderResponse {
-    return { order: isSet(object.order) ? OrderResult.fromJSON(object.order) : undefined };
+  fromJSON(object: any): ConfirmationDelivery {
+    return {
+      status: isSet(object.status) ? globalThis.String(object.status) : "",
+      transport: isSet(object.transport) ? globalThis.String(object.transport) : "",
+      attempts: isSet(object.attempts) ? globalThis.Number(object.attempts) : 0,
+      error: isSet(object.error) ? globalThis.String(object.error) : "",
+      sentAt: isSet(object.sentAt) ? globalThis.Number(object.sentAt) : 0,
+    };
   },
 
-  toJSON(message: PlaceOrderResponse): unknown {
+  toJSON(message: ConfirmationDelivery): unknown {
     const obj: any = {};
-    if (message.order !== undefined) {
-      obj.order = OrderResult.toJSON(message.order);
+    if (message.status !== "") {
+      obj.status = message.status;
+    }
+    if (message.transport !== "") {
+      obj.transport = message.transport;
+    }
+    if (message.attempts !== 0) {
+      obj.attempts = Math.round(message.attempts);
+    }
+    if (message.error !== "") {
+      obj.error = message.error;
+    }
+    if (message.sentAt !== 0) {
+      obj.sentAt = Math.round(message.sentAt);
     }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<PlaceOrderResponse>, I>>(base?: I): PlaceOrderResponse {
-    return PlaceOrderResponse.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<ConfirmationDelivery>, I>>(base?: I): ConfirmationDelivery {
+    return ConfirmationDelivery.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<PlaceOrderResponse>, I>>(object: I): PlaceOrderResponse {
-    const message = createBasePlaceOrderResponse();
-    message.order = (object.order !== undefined && object.order !== null)
-      ? OrderResult.fromPartial(object.order)
-      : undefined;
+  fromPartial<I extends Exact<DeepPartial<ConfirmationDelivery>, I>>(object: I): ConfirmationDelivery {
+    const message = createBaseConfirmationDelivery();
+    message.status = object.status ?? "";
+    message.transport = object.transport ?? "";
+    message.attempts = object.attempts ?? 0;
+    message.error = object.error ?? "";
+    message.sentAt = object.sentAt ?? 0;
     return message;
   },
 };
 
-function createBaseAdRequest(): AdRequest {
-  return { contextKeys: [] };
+function createBaseOrderShipment(): OrderShipment {
+  return { warehouse: "", items: [], cost: undefined, trackingId: "", status: "", failureReason: "" };
 }
 
-export const AdRequest: MessageFns<AdRequest> = {
-  encode(message: AdRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    for (const v of message.contextKeys) {
-      writer.uint32(10).string(v!);
+export const OrderShipment: MessageFns<OrderShipment> = {
+  encode(message: OrderShipment, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.warehouse !== "") {
+      writer.uint32(10).string(message.warehouse);
+    }
+    for (const v of message.items) {
+      CartItem.encode(v!, writer.uint32(18).fork()).join();
+    }
+    if (message.cost !== undefined) {
+      Money.encode(message.cost, writer.uint32(26).fork()).join();
+    }
+    if (message.trackingId !== "") {
+      writer.uint32(34).string(message.trackingId);
+    }
+    if (message.status !== "") {
+      writer.uint32(42).string(message.status);
+    }
+    if (message.failureReason !== "") {
+      writer.uint32(50).string(message.failureReason);
     }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): AdRequest {
+  decode(input: BinaryReader | Uint8Array, length?: number): OrderShipment {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseAdRequest();
+    const message = createBaseOrderShipment();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -2567,7 +3428,47 @@ export const AdRequest: MessageFns<AdRequest> = {
             break;
           }
 
-          message.contextKeys.push(reader.string());
+          message.warehouse = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.items.push(CartItem.decode(reader, reader.uint32()));
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.cost = Money.decode(reader, reader.uint32());
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.trackingId = reader.string();
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.status = reader.string();
+          continue;
+        }
+        case 6: {
+          if (tag !== 50) {
+            break;
+          }
+
+          message.failureReason = reader.string();
           continue;
         }
       }
@@ -2579,48 +3480,83 @@ export const AdRequest: MessageFns<AdRequest> = {
     return message;
   },
 
-  fromJSON(object: any): AdRequest {
+  fromJSON(object: any): OrderShipment {
     return {
-      contextKeys: globalThis.Array.isArray(object?.contextKeys)
-        ? object.contextKeys.map((e: any) => globalThis.String(e))
-        : [],
+      warehouse: isSet(object.warehouse) ? globalThis.String(object.warehouse) : "",
+      items: globalThis.Array.isArray(object?.items) ? object.items.map((e: any) => CartItem.fromJSON(e)) : [],
+      cost: isSet(object.cost) ? Money.fromJSON(object.cost) : undefined,
+      trackingId: isSet(object.trackingId) ? globalThis.String(object.trackingId) : "",
+      status: isSet(object.status) ? globalThis.String(object.status) : "",
+      failureReason: isSet(object.failureReason) ? globalThis.String(object.failureReason) : "",
     };
   },
 
-  toJSON(message: AdRequest): unknown {
+  toJSON(message: OrderShipment): unknown {
     const obj: any = {};
-    if (message.contextKeys?.length) {
-      obj.contextKeys = message.contextKeys;
+    if (message.warehouse !== "") {
+      obj.warehouse = message.warehouse;
+    }
+    if (message.items?.length) {
+      obj.items = message.items.map((e) => CartItem.toJSON(e));
+    }
+    if (message.cost !== undefined) {
+      obj.cost = Money.toJSON(message.cost);
+    }
+    if (message.trackingId !== "") {
+      obj.trackingId = message.trackingId;
+    }
+    if (message.status !== "") {
+      obj.status = message.status;
+    }
+    if (message.failureReason !== "") {
+      obj.failureReason = message.failureReason;
     }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<AdRequest>, I>>(base?: I): AdRequest {
-    return AdRequest.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<OrderShipment>, I>>(base?: I): OrderShipment {
+    return OrderShipment.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<AdRequest>, I>>(object: I): AdRequest {
-    const message = createBaseAdRequest();
-    message.contextKeys = object.contextKeys?.map((e) => e) || [];
+  fromPartial<I extends Exact<DeepPartial<OrderShipment>, I>>(object: I): OrderShipment {
+    const message = createBaseOrderShipment();
+    message.warehouse = object.warehouse ?? "";
+    message.items = object.items?.map((e) => CartItem.fromPartial(e)) || [];
+    message.cost = (object.cost !== undefined && object.cost !== null) ? Money.fromPartial(object.cost) : undefined;
+    message.trackingId = object.trackingId ?? "";
+    message.status = object.status ?? "";
+    message.failureReason = object.failureReason ?? "";
     return message;
   },
 };
 
-function createBaseAdResponse(): AdResponse {
-  return { ads: [] };
+function createBaseOrderPayment(): OrderPayment {
+  return { method: "", description: "", amount: undefined, transactionId: "", refunded: undefined };
 }
 
-export const AdResponse: MessageFns<AdResponse> = {
-  encode(message: AdResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    for (const v of message.ads) {
-      Ad.encode(v!, writer.uint32(10).fork()).join();
+export const OrderPayment: MessageFns<OrderPayment> = {
+  encode(message: OrderPayment, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.method !== "") {
+      writer.uint32(10).string(message.method);
+    }
+    if (message.description !== "") {
+      writer.uint32(18).string(message.description);
+    }
+    if (message.amount !== undefined) {
+      Money.encode(message.amount, writer.uint32(26).fork()).join();
+    }
+    if (message.transactionId !== "") {
+      writer.uint32(34).string(message.transactionId);
+    }
+    if (message.refunded !== undefined) {
+      Money.encode(message.refunded, writer.uint32(42).fork()).join();
     }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): AdResponse {
+  decode(input: BinaryReader | Uint8Array, length?: number): OrderPayment {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseAdResponse();
+    const message = createBaseOrderPayment();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -2629,59 +3565,123 @@ export const AdResponse: MessageFns<AdResponse> = {
             break;
           }
 
-          message.ads.push(Ad.decode(reader, reader.uint32()));
+          message.method = reader.string();
           continue;
         }
-      }
-      if ((tag & 7) === 4 || tag === 0) {
-        break;
-      }
-      reader.skip(tag & 7);
-    }
-    return message;
-  },
-
-  fromJSON(object: any): AdResponse {
-    return { ads: globalThis.Array.isArray(object?.ads) ? object.ads.map((e: any) => Ad.fromJSON(e)) : [] };
-  },
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
 
-  toJSON(message: AdResponse): unknown {
-    const obj: any = {};
-    if (message.ads?.length) {
-      obj.ads = message.ads.map((e) => Ad.toJSON(e));
-    }
+          message.description = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.amount = Money.decode(reader, reader.uint32());
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.transactionId = reader.string();
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.refunded = Money.decode(reader, reader.uint32());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): OrderPayment {
+    return {
+      method: isSet(object.method) ? globalThis.String(object.method) : "",
+      description: isSet(object.description) ? globalThis.String(object.description) : "",
+      amount: isSet(object.amount) ? Money.fromJSON(object.amount) : undefined,
+      transactionId: isSet(object.transactionId) ? globalThis.String(object.transactionId) : "",
+      refunded: isSet(object.refunded) ? Money.fromJSON(object.refunded) : undefined,
+    };
+  },
+
+  toJSON(message: OrderPayment): unknown {
+    const obj: any = {};
+    if (message.method !== "") {
+      obj.method = message.method;
+    }
+    if (message.description !== "") {
+      obj.description = message.description;
+    }
+    if (message.amount !== undefined) {
+      obj.amount = Money.toJSON(message.amount);
+    }
+    if (message.transactionId !== "") {
+      obj.transactionId = message.transactionId;
+    }
+    if (message.refunded !== undefined) {
+      obj.refunded = Money.toJSON(message.refunded);
+    }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<AdResponse>, I>>(base?: I): AdResponse {
-    return AdResponse.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<OrderPayment>, I>>(base?: I): OrderPayment {
+    return OrderPayment.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<AdResponse>, I>>(object: I): AdResponse {
-    const message = createBaseAdResponse();
-    message.ads = object.ads?.map((e) => Ad.fromPartial(e)) || [];
+  fromPartial<I extends Exact<DeepPartial<OrderPayment>, I>>(object: I): OrderPayment {
+    const message = createBaseOrderPayment();
+    message.method = object.method ?? "";
+    message.description = object.description ?? "";
+    message.amount = (object.amount !== undefined && object.amount !== null)
+      ? Money.fromPartial(object.amount)
+      : undefined;
+    message.transactionId = object.transactionId ?? "";
+    message.refunded = (object.refunded !== undefined && object.refunded !== null)
+      ? Money.fromPartial(object.refunded)
+      : undefined;
     return message;
   },
 };
 
-function createBaseAd(): Ad {
-  return { redirectUrl: "", text: "" };
+function createBaseOrderHold(): OrderHold {
+  return { reasons: [], expiresAt: 0, approver: "", comment: "" };
 }
 
-export const Ad: MessageFns<Ad> = {
-  encode(message: Ad, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    if (message.redirectUrl !== "") {
-      writer.uint32(10).string(message.redirectUrl);
+export const OrderHold: MessageFns<OrderHold> = {
+  encode(message: OrderHold, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.reasons) {
+      writer.uint32(10).string(v!);
     }
-    if (message.text !== "") {
-      writer.uint32(18).string(message.text);
+    if (message.expiresAt !== 0) {
+      writer.uint32(16).int64(message.expiresAt);
+    }
+    if (message.approver !== "") {
+      writer.uint32(26).string(message.approver);
+    }
+    if (message.comment !== "") {
+      writer.uint32(34).string(message.comment);
     }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): Ad {
+  decode(input: BinaryReader | Uint8Array, length?: number): OrderHold {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseAd();
+    const message = createBaseOrderHold();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -2690,15 +3690,31 @@ export const Ad: MessageFns<Ad> = {
             break;
           }
 
-          message.redirectUrl = reader.string();
+          message.reasons.push(reader.string());
           continue;
         }
         case 2: {
-          if (tag !== 18) {
+          if (tag !== 16) {
             break;
           }
 
-          message.text = reader.string();
+          message.expiresAt = longToNumber(reader.int64());
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.approver = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.comment = reader.string();
           continue;
         }
       }
@@ -2710,57 +3726,73 @@ export const Ad: MessageFns<Ad> = {
     return message;
   },
 
-  fromJSON(object: any): Ad {
+  fromJSON(object: any): OrderHold {
     return {
-      redirectUrl: isSet(object.redirectUrl) ? globalThis.String(object.redirectUrl) : "",
-      text: isSet(object.text) ? globalThis.String(object.text) : "",
+      reasons: globalThis.Array.isArray(object?.reasons) ? object.reasons.map((e: any) => globalThis.String(e)) : [],
+      expiresAt: isSet(object.expiresAt) ? globalThis.Number(object.expiresAt) : 0,
+      approver: isSet(object.approver) ? globalThis.String(object.approver) : "",
+      comment: isSet(object.comment) ? globalThis.String(object.comment) : "",
     };
   },
 
-  toJSON(message: Ad): unknown {
+  toJSON(message: OrderHold): unknown {
     const obj: any = {};
-    if (message.redirectUrl !== "") {
-      obj.redirectUrl = message.redirectUrl;
+    if (message.reasons?.length) {
+      obj.reasons = message.reasons;
     }
-    if (message.text !== "") {
-      obj.text = message.text;
+    if (message.expiresAt !== 0) {
+      obj.expiresAt = Math.round(message.expiresAt);
+    }
+    if (message.approver !== "") {
+      obj.approver = message.approver;
+    }
+    if (message.comment !== "") {
+      obj.comment = message.comment;
     }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<Ad>, I>>(base?: I): Ad {
-    return Ad.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<OrderHold>, I>>(base?: I): OrderHold {
+    return OrderHold.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<Ad>, I>>(object: I): Ad {
-    const message = createBaseAd();
-    message.redirectUrl = object.redirectUrl ?? "";
-    message.text = object.text ?? "";
+  fromPartial<I extends Exact<DeepPartial<OrderHold>, I>>(object: I): OrderHold {
+    const message = createBaseOrderHold();
+    message.reasons = object.reasons?.map((e) => e) || [];
+    message.expiresAt = object.expiresAt ?? 0;
+    message.approver = object.approver ?? "";
+    message.comment = object.comment ?? "";
     return message;
   },
 };
 
-function createBaseFlag(): Flag {
-  return { name: "", description: "", enabled: false };
+function createBaseSendOrderConfirmationRequest(): SendOrderConfirmationRequest {
+  return { email: "", order: undefined, subject: "", htmlBody: "", textBody: "" };
 }
 
-export const Flag: MessageFns<Flag> = {
-  encode(message: Flag, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    if (message.name !== "") {
-      writer.uint32(10).string(message.name);
+export const SendOrderConfirmationRequest: MessageFns<SendOrderConfirmationRequest> = {
+  encode(message: SendOrderConfirmationRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.email !== "") {
+      writer.uint32(10).string(message.email);
     }
-    if (message.description !== "") {
-      writer.uint32(18).string(message.description);
+    if (message.order !== undefined) {
+      OrderResult.encode(message.order, writer.uint32(18).fork()).join();
     }
-    if (message.enabled !== false) {
-      writer.uint32(24).bool(message.enabled);
+    if (message.subject !== "") {
+      writer.uint32(26).string(message.subject);
+    }
+    if (message.htmlBody !== "") {
+      writer.uint32(34).string(message.htmlBody);
+    }
+    if (message.textBody !== "") {
+      writer.uint32(42).string(message.textBody);
     }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): Flag {
+  decode(input: BinaryReader | Uint8Array, length?: number): SendOrderConfirmationRequest {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseFlag();
+    const message = createBaseSendOrderConfirmationRequest();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -2769,7 +3801,7 @@ export const Flag: MessageFns<Flag> = {
             break;
           }
 
-          message.name = reader.string();
+          message.email = reader.string();
           continue;
         }
         case 2: {
@@ -2777,15 +3809,31 @@ export const Flag: MessageFns<Flag> = {
             break;
           }
 
-          message.description = reader.string();
+          message.order = OrderResult.decode(reader, reader.uint32());
           continue;
         }
         case 3: {
-          if (tag !== 24) {
+          if (tag !== 26) {
             break;
           }
 
-          message.enabled = reader.bool();
+          message.subject = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.htmlBody = reader.string();
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.textBody = reader.string();
           continue;
         }
       }
@@ -2797,65 +3845,2951 @@ export const Flag: MessageFns<Flag> = {
     return message;
   },
 
-  fromJSON(object: any): Flag {
+  fromJSON(object: any): SendOrderConfirmationRequest {
     return {
-      name: isSet(object.name) ? globalThis.String(object.name) : "",
-      description: isSet(object.description) ? globalThis.String(object.description) : "",
-      enabled: isSet(object.enabled) ? globalThis.Boolean(object.enabled) : false,
+      email: isSet(object.email) ? globalThis.String(object.email) : "",
+      order: isSet(object.order) ? OrderResult.fromJSON(object.order) : undefined,
+      subject: isSet(object.subject) ? globalThis.String(object.subject) : "",
+      htmlBody: isSet(object.htmlBody) ? globalThis.String(object.htmlBody) : "",
+      textBody: isSet(object.textBody) ? globalThis.String(object.textBody) : "",
     };
   },
 
-  toJSON(message: Flag): unknown {
+  toJSON(message: SendOrderConfirmationRequest): unknown {
     const obj: any = {};
-    if (message.name !== "") {
-      obj.name = message.name;
+    if (message.email !== "") {
+      obj.email = message.email;
     }
-    if (message.description !== "") {
-      obj.description = message.description;
+    if (message.order !== undefined) {
+      obj.order = OrderResult.toJSON(message.order);
     }
-    if (message.enabled !== false) {
-      obj.enabled = message.enabled;
+    if (message.subject !== "") {
+      obj.subject = message.subject;
+    }
+    if (message.htmlBody !== "") {
+      obj.htmlBody = message.htmlBody;
+    }
+    if (message.textBody !== "") {
+      obj.textBody = message.textBody;
     }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<Flag>, I>>(base?: I): Flag {
-    return Flag.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<SendOrderConfirmationRequest>, I>>(base?: I): SendOrderConfirmationRequest {
+    return SendOrderConfirmationRequest.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<Flag>, I>>(object: I): Flag {
-    const message = createBaseFlag();
-    message.name = object.name ?? "";
-    message.description = object.description ?? "";
-    message.enabled = object.enabled ?? false;
+  fromPartial<I extends Exact<DeepPartial<SendOrderConfirmationRequest>, I>>(object: I): SendOrderConfirmationRequest {
+    const message = createBaseSendOrderConfirmationRequest();
+    message.email = object.email ?? "";
+    message.order = (object.order !== undefined && object.order !== null)
+      ? OrderResult.fromPartial(object.order)
+      : undefined;
+    message.subject = object.subject ?? "";
+    message.htmlBody = object.htmlBody ?? "";
+    message.textBody = object.textBody ?? "";
     return message;
   },
 };
 
-function createBaseGetFlagRequest(): GetFlagRequest {
-  return { name: "" };
+function createBasePlaceOrderRequest(): PlaceOrderRequest {
+  return {
+    userId: "",
+    userCurrency: "",
+    address: undefined,
+    email: "",
+    creditCard: undefined,
+    billingAddress: undefined,
+    paymentInstruments: [],
+    quoteToken: "",
+    shippingMethod: "",
+  };
 }
 
-export const GetFlagRequest: MessageFns<GetFlagRequest> = {
-  encode(message: GetFlagRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    if (message.name !== "") {
-      writer.uint32(10).string(message.name);
-    }
-    return writer;
-  },
+export const PlaceOrderRequest: MessageFns<PlaceOrderRequest> = {
+  encode(message: PlaceOrderRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.userId !== "") {
+      writer.uint32(10).string(message.userId);
+    }
+    if (message.userCurrency !== "") {
+      writer.uint32(18).string(message.userCurrency);
+    }
+    if (message.address !== undefined) {
+      Address.encode(message.address, writer.uint32(26).fork()).join();
+    }
+    if (message.email !== "") {
+      writer.uint32(42).string(message.email);
+    }
+    if (message.creditCard !== undefined) {
+      CreditCardInfo.encode(message.creditCard, writer.uint32(50).fork()).join();
+    }
+    if (message.billingAddress !== undefined) {
+      Address.encode(message.billingAddress, writer.uint32(58).fork()).join();
+    }
+    for (const v of message.paymentInstruments) {
+      PaymentInstrument.encode(v!, writer.uint32(66).fork()).join();
+    }
+    if (message.quoteToken !== "") {
+      writer.uint32(74).string(message.quoteToken);
+    }
+    if (message.shippingMethod !== "") {
+      writer.uint32(82).string(message.shippingMethod);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): PlaceOrderRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePlaceOrderRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.userId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.userCurrency = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.address = Address.decode(reader, reader.uint32());
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.email = reader.string();
+          continue;
+        }
+        case 6: {
+          if (tag !== 50) {
+            break;
+          }
+
+          message.creditCard = CreditCardInfo.decode(reader, reader.uint32());
+          continue;
+        }
+        case 7: {
+          if (tag !== 58) {
+            break;
+          }
+
+          message.billingAddress = Address.decode(reader, reader.uint32());
+          continue;
+        }
+        case 8: {
+          if (tag !== 66) {
+            break;
+          }
+
+          message.paymentInstruments.push(PaymentInstrument.decode(reader, reader.uint32()));
+          continue;
+        }
+        case 9: {
+          if (tag !== 74) {
+            break;
+          }
+
+          message.quoteToken = reader.string();
+          continue;
+        }
+        case 10: {
+          if (tag !== 82) {
+            break;
+          }
+
+          message.shippingMethod = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): PlaceOrderRequest {
+    return {
+      userId: isSet(object.userId) ? globalThis.String(object.userId) : "",
+      userCurrency: isSet(object.userCurrency) ? globalThis.String(object.userCurrency) : "",
+      address: isSet(object.address) ? Address.fromJSON(object.address) : undefined,
+      email: isSet(object.email) ? globalThis.String(object.email) : "",
+      creditCard: isSet(object.creditCard) ? CreditCardInfo.fromJSON(object.creditCard) : undefined,
+      billingAddress: isSet(object.billingAddress) ? Address.fromJSON(object.billingAddress) : undefined,
+      paymentInstruments: globalThis.Array.isArray(object?.paymentInstruments)
+        ? object.paymentInstruments.map((e: any) => PaymentInstrument.fromJSON(e))
+        : [],
+      quoteToken: isSet(object.quoteToken) ? globalThis.String(object.quoteToken) : "",
+      shippingMethod: isSet(object.shippingMethod) ? globalThis.String(object.shippingMethod) : "",
+    };
+  },
+
+  toJSON(message: PlaceOrderRequest): unknown {
+    const obj: any = {};
+    if (message.userId !== "") {
+      obj.userId = message.userId;
+    }
+    if (message.userCurrency !== "") {
+      obj.userCurrency = message.userCurrency;
+    }
+    if (message.address !== undefined) {
+      obj.address = Address.toJSON(message.address);
+    }
+    if (message.email !== "") {
+      obj.email = message.email;
+    }
+    if (message.creditCard !== undefined) {
+      obj.creditCard = CreditCardInfo.toJSON(message.creditCard);
+    }
+    if (message.billingAddress !== undefined) {
+      obj.billingAddress = Address.toJSON(message.billingAddress);
+    }
+    if (message.paymentInstruments?.length) {
+      obj.paymentInstruments = message.paymentInstruments.map((e) => PaymentInstrument.toJSON(e));
+    }
+    if (message.quoteToken !== "") {
+      obj.quoteToken = message.quoteToken;
+    }
+    if (message.shippingMethod !== "") {
+      obj.shippingMethod = message.shippingMethod;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<PlaceOrderRequest>, I>>(base?: I): PlaceOrderRequest {
+    return PlaceOrderRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<PlaceOrderRequest>, I>>(object: I): PlaceOrderRequest {
+    const message = createBasePlaceOrderRequest();
+    message.userId = object.userId ?? "";
+    message.userCurrency = object.userCurrency ?? "";
+    message.address = (object.address !== undefined && object.address !== null)
+      ? Address.fromPartial(object.address)
+      : undefined;
+    message.email = object.email ?? "";
+    message.creditCard = (object.creditCard !== undefined && object.creditCard !== null)
+      ? CreditCardInfo.fromPartial(object.creditCard)
+      : undefined;
+    message.billingAddress = (object.billingAddress !== undefined && object.billingAddress !== null)
+      ? Address.fromPartial(object.billingAddress)
+      : undefined;
+    message.paymentInstruments = object.paymentInstruments?.map((e) => PaymentInstrument.fromPartial(e)) || [];
+    message.quoteToken = object.quoteToken ?? "";
+    message.shippingMethod = object.shippingMethod ?? "";
+    return message;
+  },
+};
+
+function createBasePaymentInstrument(): PaymentInstrument {
+  return { creditCard: undefined, giftCard: undefined, storeCredit: undefined, maxAmount: undefined };
+}
+
+export const PaymentInstrument: MessageFns<PaymentInstrument> = {
+  encode(message: PaymentInstrument, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.creditCard !== undefined) {
+      CreditCardInfo.encode(message.creditCard, writer.uint32(10).fork()).join();
+    }
+    if (message.giftCard !== undefined) {
+      GiftCard.encode(message.giftCard, writer.uint32(18).fork()).join();
+    }
+    if (message.storeCredit !== undefined) {
+      StoreCredit.encode(message.storeCredit, writer.uint32(26).fork()).join();
+    }
+    if (message.maxAmount !== undefined) {
+      Money.encode(message.maxAmount, writer.uint32(34).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): PaymentInstrument {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePaymentInstrument();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.creditCard = CreditCardInfo.decode(reader, reader.uint32());
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.giftCard = GiftCard.decode(reader, reader.uint32());
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.storeCredit = StoreCredit.decode(reader, reader.uint32());
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.maxAmount = Money.decode(reader, reader.uint32());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): PaymentInstrument {
+    return {
+      creditCard: isSet(object.creditCard) ? CreditCardInfo.fromJSON(object.creditCard) : undefined,
+      giftCard: isSet(object.giftCard) ? GiftCard.fromJSON(object.giftCard) : undefined,
+      storeCredit: isSet(object.storeCredit) ? StoreCredit.fromJSON(object.storeCredit) : undefined,
+      maxAmount: isSet(object.maxAmount) ? Money.fromJSON(object.maxAmount) : undefined,
+    };
+  },
+
+  toJSON(message: PaymentInstrument): unknown {
+    const obj: any = {};
+    if (message.creditCard !== undefined) {
+      obj.creditCard = CreditCardInfo.toJSON(message.creditCard);
+    }
+    if (message.giftCard !== undefined) {
+      obj.giftCard = GiftCard.toJSON(message.giftCard);
+    }
+    if (message.storeCredit !== undefined) {
+      obj.storeCredit = StoreCredit.toJSON(message.storeCredit);
+    }
+    if (message.maxAmount !== undefined) {
+      obj.maxAmount = Money.toJSON(message.maxAmount);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<PaymentInstrument>, I>>(base?: I): PaymentInstrument {
+    return PaymentInstrument.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<PaymentInstrument>, I>>(object: I): PaymentInstrument {
+    const message = createBasePaymentInstrument();
+    message.creditCard = (object.creditCard !== undefined && object.creditCard !== null)
+      ? CreditCardInfo.fromPartial(object.creditCard)
+      : undefined;
+    message.giftCard = (object.giftCard !== undefined && object.giftCard !== null)
+      ? GiftCard.fromPartial(object.giftCard)
+      : undefined;
+    message.storeCredit = (object.storeCredit !== undefined && object.storeCredit !== null)
+      ? StoreCredit.fromPartial(object.storeCredit)
+      : undefined;
+    message.maxAmount = (object.maxAmount !== undefined && object.maxAmount !== null)
+      ? Money.fromPartial(object.maxAmount)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseGiftCard(): GiftCard {
+  return { code: "" };
+}
+
+export const GiftCard: MessageFns<GiftCard> = {
+  encode(message: GiftCard, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.code !== "") {
+      writer.uint32(10).string(message.code);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GiftCard {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGiftCard();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.code = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GiftCard {
+    return { code: isSet(object.code) ? globalThis.String(object.code) : "" };
+  },
+
+  toJSON(message: GiftCard): unknown {
+    const obj: any = {};
+    if (message.code !== "") {
+      obj.code = message.code;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<GiftCard>, I>>(base?: I): GiftCard {
+    return GiftCard.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<GiftCard>, I>>(object: I): GiftCard {
+    const message = createBaseGiftCard();
+    message.code = object.code ?? "";
+    return message;
+  },
+};
+
+function createBaseStoreCredit(): StoreCredit {
+  return {};
+}
+
+export const StoreCredit: MessageFns<StoreCredit> = {
+  encode(_: StoreCredit, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): StoreCredit {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseStoreCredit();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(_: any): StoreCredit {
+    return {};
+  },
+
+  toJSON(_: StoreCredit): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<StoreCredit>, I>>(base?: I): StoreCredit {
+    return StoreCredit.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<StoreCredit>, I>>(_: I): StoreCredit {
+    const message = createBaseStoreCredit();
+    return message;
+  },
+};
+
+function createBasePlaceOrderResponse(): PlaceOrderResponse {
+  return { order: undefined };
+}
+
+export const PlaceOrderResponse: MessageFns<PlaceOrderResponse> = {
+  encode(message: PlaceOrderResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.order !== undefined) {
+      OrderResult.encode(message.order, writer.uint32(10).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): PlaceOrderResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePlaceOrderResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.order = OrderResult.decode(reader, reader.uint32());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): PlaceOrderResponse {
+    return { order: isSet(object.order) ? OrderResult.fromJSON(object.order) : undefined };
+  },
+
+  toJSON(message: PlaceOrderResponse): unknown {
+    const obj: any = {};
+    if (message.order !== undefined) {
+      obj.order = OrderResult.toJSON(message.order);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<PlaceOrderResponse>, I>>(base?: I): PlaceOrderResponse {
+    return PlaceOrderResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<PlaceOrderResponse>, I>>(object: I): PlaceOrderResponse {
+    const message = createBasePlaceOrderResponse();
+    message.order = (object.order !== undefined && object.order !== null)
+      ? OrderResult.fromPartial(object.order)
+      : undefined;
+    return message;
+  },
+};
+
+function createBasePreviewOrderRequest(): PreviewOrderRequest {
+  return { userId: "", userCurrency: "", address: undefined, shippingMethod: "" };
+}
+
+export const PreviewOrderRequest: MessageFns<PreviewOrderRequest> = {
+  encode(message: PreviewOrderRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.userId !== "") {
+      writer.uint32(10).string(message.userId);
+    }
+    if (message.userCurrency !== "") {
+      writer.uint32(18).string(message.userCurrency);
+    }
+    if (message.address !== undefined) {
+      Address.encode(message.address, writer.uint32(26).fork()).join();
+    }
+    if (message.shippingMethod !== "") {
+      writer.uint32(34).string(message.shippingMethod);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): PreviewOrderRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePreviewOrderRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.userId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.userCurrency = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.address = Address.decode(reader, reader.uint32());
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.shippingMethod = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): PreviewOrderRequest {
+    return {
+      userId: isSet(object.userId) ? globalThis.String(object.userId) : "",
+      userCurrency: isSet(object.userCurrency) ? globalThis.String(object.userCurrency) : "",
+      address: isSet(object.address) ? Address.fromJSON(object.address) : undefined,
+      shippingMethod: isSet(object.shippingMethod) ? globalThis.String(object.shippingMethod) : "",
+    };
+  },
+
+  toJSON(message: PreviewOrderRequest): unknown {
+    const obj: any = {};
+    if (message.userId !== "") {
+      obj.userId = message.userId;
+    }
+    if (message.userCurrency !== "") {
+      obj.userCurrency = message.userCurrency;
+    }
+    if (message.address !== undefined) {
+      obj.address = Address.toJSON(message.address);
+    }
+    if (message.shippingMethod !== "") {
+      obj.shippingMethod = message.shippingMethod;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<PreviewOrderRequest>, I>>(base?: I): PreviewOrderRequest {
+    return PreviewOrderRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<PreviewOrderRequest>, I>>(object: I): PreviewOrderRequest {
+    const message = createBasePreviewOrderRequest();
+    message.userId = object.userId ?? "";
+    message.userCurrency = object.userCurrency ?? "";
+    message.address = (object.address !== undefined && object.address !== null)
+      ? Address.fromPartial(object.address)
+      : undefined;
+    message.shippingMethod = object.shippingMethod ?? "";
+    return message;
+  },
+};
+
+function createBasePreviewOrderResponse(): PreviewOrderResponse {
+  return {
+    items: [],
+    subtotal: undefined,
+    shippingCost: undefined,
+    adjustments: [],
+    total: undefined,
+    quoteToken: "",
+    expiresAt: 0,
+    holdReasons: [],
+    shippingOptions: [],
+    shippingMethod: "",
+    shipments: [],
+  };
+}
+
+export const PreviewOrderResponse: MessageFns<PreviewOrderResponse> = {
+  encode(message: PreviewOrderResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.items) {
+      OrderItem.encode(v!, writer.uint32(10).fork()).join();
+    }
+    if (message.subtotal !== undefined) {
+      Money.encode(message.subtotal, writer.uint32(18).fork()).join();
+    }
+    if (message.shippingCost !== undefined) {
+      Money.encode(message.shippingCost, writer.uint32(26).fork()).join();
+    }
+    for (const v of message.adjustments) {
+      OrderAdjustment.encode(v!, writer.uint32(34).fork()).join();
+    }
+    if (message.total !== undefined) {
+      Money.encode(message.total, writer.uint32(42).fork()).join();
+    }
+    if (message.quoteToken !== "") {
+      writer.uint32(50).string(message.quoteToken);
+    }
+    if (message.expiresAt !== 0) {
+      writer.uint32(56).int64(message.expiresAt);
+    }
+    for (const v of message.holdReasons) {
+      writer.uint32(66).string(v!);
+    }
+    for (const v of message.shippingOptions) {
+      ShippingOption.encode(v!, writer.uint32(74).fork()).join();
+    }
+    if (message.shippingMethod !== "") {
+      writer.uint32(82).string(message.shippingMethod);
+    }
+    for (const v of message.shipments) {
+      OrderShipment.encode(v!, writer.uint32(90).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): PreviewOrderResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBasePreviewOrderResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.items.push(OrderItem.decode(reader, reader.uint32()));
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.subtotal = Money.decode(reader, reader.uint32());
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.shippingCost = Money.decode(reader, reader.uint32());
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.adjustments.push(OrderAdjustment.decode(reader, reader.uint32()));
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.total = Money.decode(reader, reader.uint32());
+          continue;
+        }
+        case 6: {
+          if (tag !== 50) {
+            break;
+          }
+
+          message.quoteToken = reader.string();
+          continue;
+        }
+        case 7: {
+          if (tag !== 56) {
+            break;
+          }
+
+          message.expiresAt = longToNumber(reader.int64());
+          continue;
+        }
+        case 8: {
+          if (tag !== 66) {
+            break;
+          }
+
+          message.holdReasons.push(reader.string());
+          continue;
+        }
+        case 9: {
+          if (tag !== 74) {
+            break;
+          }
+
+          message.shippingOptions.push(ShippingOption.decode(reader, reader.uint32()));
+          continue;
+        }
+        case 10: {
+          if (tag !== 82) {
+            break;
+          }
+
+          message.shippingMethod = reader.string();
+          continue;
+        }
+        case 11: {
+          if (tag !== 90) {
+            break;
+          }
+
+          message.shipments.push(OrderShipment.decode(reader, reader.uint32()));
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): PreviewOrderResponse {
+    return {
+      items: globalThis.Array.isArray(object?.items) ? object.items.map((e: any) => OrderItem.fromJSON(e)) : [],
+      subtotal: isSet(object.subtotal) ? Money.fromJSON(object.subtotal) : undefined,
+      shippingCost: isSet(object.shippingCost) ? Money.fromJSON(object.shippingCost) : undefined,
+      adjustments: globalThis.Array.isArray(object?.adjustments)
+        ? object.adjustments.map((e: any) => OrderAdjustment.fromJSON(e))
+        : [],
+      total: isSet(object.total) ? Money.fromJSON(object.total) : undefined,
+      quoteToken: isSet(object.quoteToken) ? globalThis.String(object.quoteToken) : "",
+      expiresAt: isSet(object.expiresAt) ? globalThis.Number(object.expiresAt) : 0,
+      holdReasons: globalThis.Array.isArray(object?.holdReasons)
+        ? object.holdReasons.map((e: any) => globalThis.String(e))
+        : [],
+      shippingOptions: globalThis.Array.isArray(object?.shippingOptions)
+        ? object.shippingOptions.map((e: any) => ShippingOption.fromJSON(e))
+        : [],
+      shippingMethod: isSet(object.shippingMethod) ? globalThis.String(object.shippingMethod) : "",
+      shipments: globalThis.Array.isArray(object?.shipments)
+        ? object.shipments.map((e: any) => OrderShipment.fromJSON(e))
+        : [],
+    };
+  },
+
+  toJSON(message: PreviewOrderResponse): unknown {
+    const obj: any = {};
+    if (message.items?.length) {
+      obj.items = message.items.map((e) => OrderItem.toJSON(e));
+    }
+    if (message.subtotal !== undefined) {
+      obj.subtotal = Money.toJSON(message.subtotal);
+    }
+    if (message.shippingCost !== undefined) {
+      obj.shippingCost = Money.toJSON(message.shippingCost);
+    }
+    if (message.adjustments?.length) {
+      obj.adjustments = message.adjustments.map((e) => OrderAdjustment.toJSON(e));
+    }
+    if (message.total !== undefined) {
+      obj.total = Money.toJSON(message.total);
+    }
+    if (message.quoteToken !== "") {
+      obj.quoteToken = message.quoteToken;
+    }
+    if (message.expiresAt !== 0) {
+      obj.expiresAt = Math.round(message.expiresAt);
+    }
+    if (message.holdReasons?.length) {
+      obj.holdReasons = message.holdReasons;
+    }
+    if (message.shippingOptions?.length) {
+      obj.shippingOptions = message.shippingOptions.map((e) => ShippingOption.toJSON(e));
+    }
+    if (message.shippingMethod !== "") {
+      obj.shippingMethod = message.shippingMethod;
+    }
+    if (message.shipments?.length) {
+      obj.shipments = message.shipments.map((e) => OrderShipment.toJSON(e));
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<PreviewOrderResponse>, I>>(base?: I): PreviewOrderResponse {
+    return PreviewOrderResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<PreviewOrderResponse>, I>>(object: I): PreviewOrderResponse {
+    const message = createBasePreviewOrderResponse();
+    message.items = object.items?.map((e) => OrderItem.fromPartial(e)) || [];
+    message.subtotal = (object.subtotal !== undefined && object.subtotal !== null)
+      ? Money.fromPartial(object.subtotal)
+      : undefined;
+    message.shippingCost = (object.shippingCost !== undefined && object.shippingCost !== null)
+      ? Money.fromPartial(object.shippingCost)
+      : undefined;
+    message.adjustments = object.adjustments?.map((e) => OrderAdjustment.fromPartial(e)) || [];
+    message.total = (object.total !== undefined && object.total !== null) ? Money.fromPartial(object.total) : undefined;
+    message.quoteToken = object.quoteToken ?? "";
+    message.expiresAt = object.expiresAt ?? 0;
+    message.holdReasons = object.holdReasons?.map((e) => e) || [];
+    message.shippingOptions = object.shippingOptions?.map((e) => ShippingOption.fromPartial(e)) || [];
+    message.shippingMethod = object.shippingMethod ?? "";
+    message.shipments = object.shipments?.map((e) => OrderShipment.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseOrderAdjustment(): OrderAdjustment {
+  return { description: "", amount: undefined };
+}
+
+export const OrderAdjustment: MessageFns<OrderAdjustment> = {
+  encode(message: OrderAdjustment, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.description !== "") {
+      writer.uint32(10).string(message.description);
+    }
+    if (message.amount !== undefined) {
+      Money.encode(message.amount, writer.uint32(18).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): OrderAdjustment {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseOrderAdjustment();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.description = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.amount = Money.decode(reader, reader.uint32());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): OrderAdjustment {
+    return {
+      description: isSet(object.description) ? globalThis.String(object.description) : "",
+      amount: isSet(object.amount) ? Money.fromJSON(object.amount) : undefined,
+    };
+  },
+
+  toJSON(message: OrderAdjustment): unknown {
+    const obj: any = {};
+    if (message.description !== "") {
+      obj.description = message.description;
+    }
+    if (message.amount !== undefined) {
+      obj.amount = Money.toJSON(message.amount);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<OrderAdjustment>, I>>(base?: I): OrderAdjustment {
+    return OrderAdjustment.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<OrderAdjustment>, I>>(object: I): OrderAdjustment {
+    const message = createBaseOrderAdjustment();
+    message.description = object.description ?? "";
+    message.amount = (object.amount !== undefined && object.amount !== null)
+      ? Money.fromPartial(object.amount)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseSubmitOrderResponse(): SubmitOrderResponse {
+  return { orderId: "" };
+}
+
+export const SubmitOrderResponse: MessageFns<SubmitOrderResponse> = {
+  encode(message: SubmitOrderResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.orderId !== "") {
+      writer.uint32(10).string(message.orderId);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): SubmitOrderResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSubmitOrderResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.orderId = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): SubmitOrderResponse {
+    return { orderId: isSet(object.orderId) ? globalThis.String(object.orderId) : "" };
+  },
+
+  toJSON(message: SubmitOrderResponse): unknown {
+    const obj: any = {};
+    if (message.orderId !== "") {
+      obj.orderId = message.orderId;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<SubmitOrderResponse>, I>>(base?: I): SubmitOrderResponse {
+    return SubmitOrderResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<SubmitOrderResponse>, I>>(object: I): SubmitOrderResponse {
+    const message = createBaseSubmitOrderResponse();
+    message.orderId = object.orderId ?? "";
+    return message;
+  },
+};
+
+function createBaseWatchOrderRequest(): WatchOrderRequest {
+  return { orderId: "" };
+}
+
+export const WatchOrderRequest: MessageFns<WatchOrderRequest> = {
+  encode(message: WatchOrderRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.orderId !== "") {
+      writer.uint32(10).string(message.orderId);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): WatchOrderRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseWatchOrderRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.orderId = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): WatchOrderRequest {
+    return { orderId: isSet(object.orderId) ? globalThis.String(object.orderId) : "" };
+  },
+
+  toJSON(message: WatchOrderRequest): unknown {
+    const obj: any = {};
+    if (message.orderId !== "") {
+      obj.orderId = message.orderId;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<WatchOrderRequest>, I>>(base?: I): WatchOrderRequest {
+    return WatchOrderRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<WatchOrderRequest>, I>>(object: I): WatchOrderRequest {
+    const message = createBaseWatchOrderRequest();
+    message.orderId = object.orderId ?? "";
+    return message;
+  },
+};
+
+function createBaseOrderStatusUpdate(): OrderStatusUpdate {
+  return { orderId: "", status: "", updatedAt: 0, order: undefined, errorCode: "", errorReason: "", errorMessage: "" };
+}
+
+export const OrderStatusUpdate: MessageFns<OrderStatusUpdate> = {
+  encode(message: OrderStatusUpdate, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.orderId !== "") {
+      writer.uint32(10).string(message.orderId);
+    }
+    if (message.status !== "") {
+      writer.uint32(18).string(message.status);
+    }
+    if (message.updatedAt !== 0) {
+      writer.uint32(24).int64(message.updatedAt);
+    }
+    if (message.order !== undefined) {
+      OrderResult.encode(message.order, writer.uint32(34).fork()).join();
+    }
+    if (message.errorCode !== "") {
+      writer.uint32(42).string(message.errorCode);
+    }
+    if (message.errorReason !== "") {
+      writer.uint32(50).string(message.errorReason);
+    }
+    if (message.errorMessage !== "") {
+      writer.uint32(58).string(message.errorMessage);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): OrderStatusUpdate {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseOrderStatusUpdate();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.orderId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.status = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.updatedAt = longToNumber(reader.int64());
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.order = OrderResult.decode(reader, reader.uint32());
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.errorCode = reader.string();
+          continue;
+        }
+        case 6: {
+          if (tag !== 50) {
+            break;
+          }
+
+          message.errorReason = reader.string();
+          continue;
+        }
+        case 7: {
+          if (tag !== 58) {
+            break;
+          }
+
+          message.errorMessage = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): OrderStatusUpdate {
+    return {
+      orderId: isSet(object.orderId) ? globalThis.String(object.orderId) : "",
+      status: isSet(object.status) ? globalThis.String(object.status) : "",
+      updatedAt: isSet(object.updatedAt) ? globalThis.Number(object.updatedAt) : 0,
+      order: isSet(object.order) ? OrderResult.fromJSON(object.order) : undefined,
+      errorCode: isSet(object.errorCode) ? globalThis.String(object.errorCode) : "",
+      errorReason: isSet(object.errorReason) ? globalThis.String(object.errorReason) : "",
+      errorMessage: isSet(object.errorMessage) ? globalThis.String(object.errorMessage) : "",
+    };
+  },
+
+  toJSON(message: OrderStatusUpdate): unknown {
+    const obj: any = {};
+    if (message.orderId !== "") {
+      obj.orderId = message.orderId;
+    }
+    if (message.status !== "") {
+      obj.status = message.status;
+    }
+    if (message.updatedAt !== 0) {
+      obj.updatedAt = Math.round(message.updatedAt);
+    }
+    if (message.order !== undefined) {
+      obj.order = OrderResult.toJSON(message.order);
+    }
+    if (message.errorCode !== "") {
+      obj.errorCode = message.errorCode;
+    }
+    if (message.errorReason !== "") {
+      obj.errorReason = message.errorReason;
+    }
+    if (message.errorMessage !== "") {
+      obj.errorMessage = message.errorMessage;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<OrderStatusUpdate>, I>>(base?: I): OrderStatusUpdate {
+    return OrderStatusUpdate.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<OrderStatusUpdate>, I>>(object: I): OrderStatusUpdate {
+    const message = createBaseOrderStatusUpdate();
+    message.orderId = object.orderId ?? "";
+    message.status = object.status ?? "";
+    message.updatedAt = object.updatedAt ?? 0;
+    message.order = (object.order !== undefined && object.order !== null)
+      ? OrderResult.fromPartial(object.order)
+      : undefined;
+    message.errorCode = object.errorCode ?? "";
+    message.errorReason = object.errorReason ?? "";
+    message.errorMessage = object.errorMessage ?? "";
+    return message;
+  },
+};
+
+function createBaseApproveOrderRequest(): ApproveOrderRequest {
+  return { orderId: "", approver: "", comment: "" };
+}
+
+export const ApproveOrderRequest: MessageFns<ApproveOrderRequest> = {
+  encode(message: ApproveOrderRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.orderId !== "") {
+      writer.uint32(10).string(message.orderId);
+    }
+    if (message.approver !== "") {
+      writer.uint32(18).string(message.approver);
+    }
+    if (message.comment !== "") {
+      writer.uint32(26).string(message.comment);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): ApproveOrderRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseApproveOrderRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.orderId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.approver = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.comment = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ApproveOrderRequest {
+    return {
+      orderId: isSet(object.orderId) ? globalThis.String(object.orderId) : "",
+      approver: isSet(object.approver) ? globalThis.String(object.approver) : "",
+      comment: isSet(object.comment) ? globalThis.String(object.comment) : "",
+    };
+  },
+
+  toJSON(message: ApproveOrderRequest): unknown {
+    const obj: any = {};
+    if (message.orderId !== "") {
+      obj.orderId = message.orderId;
+    }
+    if (message.approver !== "") {
+      obj.approver = message.approver;
+    }
+    if (message.comment !== "") {
+      obj.comment = message.comment;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<ApproveOrderRequest>, I>>(base?: I): ApproveOrderRequest {
+    return ApproveOrderRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<ApproveOrderRequest>, I>>(object: I): ApproveOrderRequest {
+    const message = createBaseApproveOrderRequest();
+    message.orderId = object.orderId ?? "";
+    message.approver = object.approver ?? "";
+    message.comment = object.comment ?? "";
+    return message;
+  },
+};
+
+function createBaseApproveOrderResponse(): ApproveOrderResponse {
+  return { order: undefined };
+}
+
+export const ApproveOrderResponse: MessageFns<ApproveOrderResponse> = {
+  encode(message: ApproveOrderResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.order !== undefined) {
+      OrderResult.encode(message.order, writer.uint32(10).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): ApproveOrderResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseApproveOrderResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.order = OrderResult.decode(reader, reader.uint32());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ApproveOrderResponse {
+    return { order: isSet(object.order) ? OrderResult.fromJSON(object.order) : undefined };
+  },
+
+  toJSON(message: ApproveOrderResponse): unknown {
+    const obj: any = {};
+    if (message.order !== undefined) {
+      obj.order = OrderResult.toJSON(message.order);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<ApproveOrderResponse>, I>>(base?: I): ApproveOrderResponse {
+    return ApproveOrderResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<ApproveOrderResponse>, I>>(object: I): ApproveOrderResponse {
+    const message = createBaseApproveOrderResponse();
+    message.order = (object.order !== undefined && object.order !== null)
+      ? OrderResult.fromPartial(object.order)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseRejectOrderRequest(): RejectOrderRequest {
+  return { orderId: "", approver: "", reason: "" };
+}
+
+export const RejectOrderRequest: MessageFns<RejectOrderRequest> = {
+  encode(message: RejectOrderRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.orderId !== "") {
+      writer.uint32(10).string(message.orderId);
+    }
+    if (message.approver !== "") {
+      writer.uint32(18).string(message.approver);
+    }
+    if (message.reason !== "") {
+      writer.uint32(26).string(message.reason);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): RejectOrderRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRejectOrderRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.orderId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.approver = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.reason = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): RejectOrderRequest {
+    return {
+      orderId: isSet(object.orderId) ? globalThis.String(object.orderId) : "",
+      approver: isSet(object.approver) ? globalThis.String(object.approver) : "",
+      reason: isSet(object.reason) ? globalThis.String(object.reason) : "",
+    };
+  },
+
+  toJSON(message: RejectOrderRequest): unknown {
+    const obj: any = {};
+    if (message.orderId !== "") {
+      obj.orderId = message.orderId;
+    }
+    if (message.approver !== "") {
+      obj.approver = message.approver;
+    }
+    if (message.reason !== "") {
+      obj.reason = message.reason;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<RejectOrderRequest>, I>>(base?: I): RejectOrderRequest {
+    return RejectOrderRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<RejectOrderRequest>, I>>(object: I): RejectOrderRequest {
+    const message = createBaseRejectOrderRequest();
+    message.orderId = object.orderId ?? "";
+    message.approver = object.approver ?? "";
+    message.reason = object.reason ?? "";
+    return message;
+  },
+};
+
+function createBaseRejectOrderResponse(): RejectOrderResponse {
+  return { order: undefined };
+}
+
+export const RejectOrderResponse: MessageFns<RejectOrderResponse> = {
+  encode(message: RejectOrderResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.order !== undefined) {
+      OrderResult.encode(message.order, writer.uint32(10).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): RejectOrderResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseRejectOrderResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.order = OrderResult.decode(reader, reader.uint32());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): RejectOrderResponse {
+    return { order: isSet(object.order) ? OrderResult.fromJSON(object.order) : undefined };
+  },
+
+  toJSON(message: RejectOrderResponse): unknown {
+    const obj: any = {};
+    if (message.order !== undefined) {
+      obj.order = OrderResult.toJSON(message.order);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<RejectOrderResponse>, I>>(base?: I): RejectOrderResponse {
+    return RejectOrderResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<RejectOrderResponse>, I>>(object: I): RejectOrderResponse {
+    const message = createBaseRejectOrderResponse();
+    message.order = (object.order !== undefined && object.order !== null)
+      ? OrderResult.fromPartial(object.order)
+      : undefined;
+    return message;
+  },
+};
+
+function createBaseAdRequest(): AdRequest {
+  return { contextKeys: [] };
+}
+
+export const AdRequest: MessageFns<AdRequest> = {
+  encode(message: AdRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.contextKeys) {
+      writer.uint32(10).string(v!);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): AdRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseAdRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.contextKeys.push(reader.string());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): AdRequest {
+    return {
+      contextKeys: globalThis.Array.isArray(object?.contextKeys)
+        ? object.contextKeys.map((e: any) => globalThis.String(e))
+        : [],
+    };
+  },
+
+  toJSON(message: AdRequest): unknown {
+    const obj: any = {};
+    if (message.contextKeys?.length) {
+      obj.contextKeys = message.contextKeys;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<AdRequest>, I>>(base?: I): AdRequest {
+    return AdRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<AdRequest>, I>>(object: I): AdRequest {
+    const message = createBaseAdRequest();
+    message.contextKeys = object.contextKeys?.map((e) => e) || [];
+    return message;
+  },
+};
+
+function createBaseAdResponse(): AdResponse {
+  return { ads: [] };
+}
+
+export const AdResponse: MessageFns<AdResponse> = {
+  encode(message: AdResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.ads) {
+      Ad.encode(v!, writer.uint32(10).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): AdResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseAdResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.ads.push(Ad.decode(reader, reader.uint32()));
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): AdResponse {
+    return { ads: globalThis.Array.isArray(object?.ads) ? object.ads.map((e: any) => Ad.fromJSON(e)) : [] };
+  },
+
+  toJSON(message: AdResponse): unknown {
+    const obj: any = {};
+    if (message.ads?.length) {
+      obj.ads = message.ads.map((e) => Ad.toJSON(e));
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<AdResponse>, I>>(base?: I): AdResponse {
+    return AdResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<AdResponse>, I>>(object: I): AdResponse {
+    const message = createBaseAdResponse();
+    message.ads = object.ads?.map((e) => Ad.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseAd(): Ad {
+  return { redirectUrl: "", text: "" };
+}
+
+export const Ad: MessageFns<Ad> = {
+  encode(message: Ad, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.redirectUrl !== "") {
+      writer.uint32(10).string(message.redirectUrl);
+    }
+    if (message.text !== "") {
+      writer.uint32(18).string(message.text);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): Ad {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseAd();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.redirectUrl = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.text = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Ad {
+    return {
+      redirectUrl: isSet(object.redirectUrl) ? globalThis.String(object.redirectUrl) : "",
+      text: isSet(object.text) ? globalThis.String(object.text) : "",
+    };
+  },
+
+  toJSON(message: Ad): unknown {
+    const obj: any = {};
+    if (message.redirectUrl !== "") {
+      obj.redirectUrl = message.redirectUrl;
+    }
+    if (message.text !== "") {
+      obj.text = message.text;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<Ad>, I>>(base?: I): Ad {
+    return Ad.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<Ad>, I>>(object: I): Ad {
+    const message = createBaseAd();
+    message.redirectUrl = object.redirectUrl ?? "";
+    message.text = object.text ?? "";
+    return message;
+  },
+};
+
+function createBaseFlag(): Flag {
+  return { name: "", description: "", enabled: false };
+}
+
+export const Flag: MessageFns<Flag> = {
+  encode(message: Flag, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.description !== "") {
+      writer.uint32(18).string(message.description);
+    }
+    if (message.enabled !== false) {
+      writer.uint32(24).bool(message.enabled);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): Flag {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseFlag();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.description = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.enabled = reader.bool();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): Flag {
+    return {
+      name: isSet(object.name) ? globalThis.String(object.name) : "",
+      description: isSet(object.description) ? globalThis.String(object.description) : "",
+      enabled: isSet(object.enabled) ? globalThis.Boolean(object.enabled) : false,
+    };
+  },
+
+  toJSON(message: Flag): unknown {
+    const obj: any = {};
+    if (message.name !== "") {
+      obj.name = message.name;
+    }
+    if (message.description !== "") {
+      obj.description = message.description;
+    }
+    if (message.enabled !== false) {
+      obj.enabled = message.enabled;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<Flag>, I>>(base?: I): Flag {
+    return Flag.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<Flag>, I>>(object: I): Flag {
+    const message = createBaseFlag();
+    message.name = object.name ?? "";
+    message.description = object.description ?? "";
+    message.enabled = object.enabled ?? false;
+    return message;
+  },
+};
+
+function createBaseGetFlagRequest(): GetFlagRequest {
+  return { name: "" };
+}
+
+export const GetFlagRequest: MessageFns<GetFlagRequest> = {
+  encode(message: GetFlagRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetFlagRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetFlagRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetFlagRequest {
+    return { name: isSet(object.name) ? globalThis.String(object.name) : "" };
+  },
+
+  toJSON(message: GetFlagRequest): unknown {
+    const obj: any = {};
+    if (message.name !== "") {
+      obj.name = message.name;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<GetFlagRequest>, I>>(base?: I): GetFlagRequest {
+    return GetFlagRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<GetFlagRequest>, I>>(object: I): GetFlagRequest {
+    const message = createBaseGetFlagRequest();
+    message.name = object.name ?? "";
+    return message;
+  },
+};
+
+function createBaseGetFlagResponse(): GetFlagResponse {
+  return { flag: undefined };
+}
+
+export const GetFlagResponse: MessageFns<GetFlagResponse> = {
+  encode(message: GetFlagResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.flag !== undefined) {
+      Flag.encode(message.flag, writer.uint32(10).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): GetFlagResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseGetFlagResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.flag = Flag.decode(reader, reader.uint32());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): GetFlagResponse {
+    return { flag: isSet(object.flag) ? Flag.fromJSON(object.flag) : undefined };
+  },
+
+  toJSON(message: GetFlagResponse): unknown {
+    const obj: any = {};
+    if (message.flag !== undefined) {
+      obj.flag = Flag.toJSON(message.flag);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<GetFlagResponse>, I>>(base?: I): GetFlagResponse {
+    return GetFlagResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<GetFlagResponse>, I>>(object: I): GetFlagResponse {
+    const message = createBaseGetFlagResponse();
+    message.flag = (object.flag !== undefined && object.flag !== null) ? Flag.fromPartial(object.flag) : undefined;
+    return message;
+  },
+};
+
+function createBaseCreateFlagRequest(): CreateFlagRequest {
+  return { name: "", description: "", enabled: false };
+}
+
+export const CreateFlagRequest: MessageFns<CreateFlagRequest> = {
+  encode(message: CreateFlagRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.description !== "") {
+      writer.uint32(18).string(message.description);
+    }
+    if (message.enabled !== false) {
+      writer.uint32(24).bool(message.enabled);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): CreateFlagRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCreateFlagRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.description = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
+
+          message.enabled = reader.bool();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CreateFlagRequest {
+    return {
+      name: isSet(object.name) ? globalThis.String(object.name) : "",
+      description: isSet(object.description) ? globalThis.String(object.description) : "",
+      enabled: isSet(object.enabled) ? globalThis.Boolean(object.enabled) : false,
+    };
+  },
+
+  toJSON(message: CreateFlagRequest): unknown {
+    const obj: any = {};
+    if (message.name !== "") {
+      obj.name = message.name;
+    }
+    if (message.description !== "") {
+      obj.description = message.description;
+    }
+    if (message.enabled !== false) {
+      obj.enabled = message.enabled;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<CreateFlagRequest>, I>>(base?: I): CreateFlagRequest {
+    return CreateFlagRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<CreateFlagRequest>, I>>(object: I): CreateFlagRequest {
+    const message = createBaseCreateFlagRequest();
+    message.name = object.name ?? "";
+    message.description = object.description ?? "";
+    message.enabled = object.enabled ?? false;
+    return message;
+  },
+};
+
+function createBaseCreateFlagResponse(): CreateFlagResponse {
+  return { flag: undefined };
+}
+
+export const CreateFlagResponse: MessageFns<CreateFlagResponse> = {
+  encode(message: CreateFlagResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.flag !== undefined) {
+      Flag.encode(message.flag, writer.uint32(10).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): CreateFlagResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseCreateFlagResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.flag = Flag.decode(reader, reader.uint32());
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): CreateFlagResponse {
+    return { flag: isSet(object.flag) ? Flag.fromJSON(object.flag) : undefined };
+  },
+
+  toJSON(message: CreateFlagResponse): unknown {
+    const obj: any = {};
+    if (message.flag !== undefined) {
+      obj.flag = Flag.toJSON(message.flag);
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<CreateFlagResponse>, I>>(base?: I): CreateFlagResponse {
+    return CreateFlagResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<CreateFlagResponse>, I>>(object: I): CreateFlagResponse {
+    const message = createBaseCreateFlagResponse();
+    message.flag = (object.flag !== undefined && object.flag !== null) ? Flag.fromPartial(object.flag) : undefined;
+    return message;
+  },
+};
+
+function createBaseUpdateFlagRequest(): UpdateFlagRequest {
+  return { name: "", enabled: false };
+}
+
+export const UpdateFlagRequest: MessageFns<UpdateFlagRequest> = {
+  encode(message: UpdateFlagRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    if (message.enabled !== false) {
+      writer.uint32(16).bool(message.enabled);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): UpdateFlagRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseUpdateFlagRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 16) {
+            break;
+          }
+
+          message.enabled = reader.bool();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): UpdateFlagRequest {
+    return {
+      name: isSet(object.name) ? globalThis.String(object.name) : "",
+      enabled: isSet(object.enabled) ? globalThis.Boolean(object.enabled) : false,
+    };
+  },
+
+  toJSON(message: UpdateFlagRequest): unknown {
+    const obj: any = {};
+    if (message.name !== "") {
+      obj.name = message.name;
+    }
+    if (message.enabled !== false) {
+      obj.enabled = message.enabled;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<UpdateFlagRequest>, I>>(base?: I): UpdateFlagRequest {
+    return UpdateFlagRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<UpdateFlagRequest>, I>>(object: I): UpdateFlagRequest {
+    const message = createBaseUpdateFlagRequest();
+    message.name = object.name ?? "";
+    message.enabled = object.enabled ?? false;
+    return message;
+  },
+};
+
+function createBaseUpdateFlagResponse(): UpdateFlagResponse {
+  return {};
+}
+
+export const UpdateFlagResponse: MessageFns<UpdateFlagResponse> = {
+  encode(_: UpdateFlagResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): UpdateFlagResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseUpdateFlagResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(_: any): UpdateFlagResponse {
+    return {};
+  },
+
+  toJSON(_: UpdateFlagResponse): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<UpdateFlagResponse>, I>>(base?: I): UpdateFlagResponse {
+    return UpdateFlagResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<UpdateFlagResponse>, I>>(_: I): UpdateFlagResponse {
+    const message = createBaseUpdateFlagResponse();
+    return message;
+  },
+};
+
+function createBaseListFlagsRequest(): ListFlagsRequest {
+  return {};
+}
+
+export const ListFlagsRequest: MessageFns<ListFlagsRequest> = {
+  encode(_: ListFlagsRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): ListFlagsRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseListFlagsRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(_: any): ListFlagsRequest {
+    return {};
+  },
+
+  toJSON(_: ListFlagsRequest): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<ListFlagsRequest>, I>>(base?: I): ListFlagsRequest {
+    return ListFlagsRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<ListFlagsRequest>, I>>(_: I): ListFlagsRequest {
+    const message = createBaseListFlagsRequest();
+    return message;
+  },
+};
+
+function createBaseListFlagsResponse(): ListFlagsResponse {
+  return { flag: [] };
+}
+
+export const ListFlagsResponse: MessageFns<ListFlagsResponse> = {
+  encode(message: ListFlagsResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.flag) {
+      Flag.encode(v!, writer.uint32(10).fork()).join();
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): ListFlagsResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseListFlagsResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.flag.push(Flag.decode(reader, reader.uint32()));
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): ListFlagsResponse {
+    return { flag: globalThis.Array.isArray(object?.flag) ? object.flag.map((e: any) => Flag.fromJSON(e)) : [] };
+  },
+
+  toJSON(message: ListFlagsResponse): unknown {
+    const obj: any = {};
+    if (message.flag?.length) {
+      obj.flag = message.flag.map((e) => Flag.toJSON(e));
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<ListFlagsResponse>, I>>(base?: I): ListFlagsResponse {
+    return ListFlagsResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<ListFlagsResponse>, I>>(object: I): ListFlagsResponse {
+    const message = createBaseListFlagsResponse();
+    message.flag = object.flag?.map((e) => Flag.fromPartial(e)) || [];
+    return message;
+  },
+};
+
+function createBaseDeleteFlagRequest(): DeleteFlagRequest {
+  return { name: "" };
+}
+
+export const DeleteFlagRequest: MessageFns<DeleteFlagRequest> = {
+  encode(message: DeleteFlagRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.name !== "") {
+      writer.uint32(10).string(message.name);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): DeleteFlagRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDeleteFlagRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.name = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): DeleteFlagRequest {
+    return { name: isSet(object.name) ? globalThis.String(object.name) : "" };
+  },
+
+  toJSON(message: DeleteFlagRequest): unknown {
+    const obj: any = {};
+    if (message.name !== "") {
+      obj.name = message.name;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<DeleteFlagRequest>, I>>(base?: I): DeleteFlagRequest {
+    return DeleteFlagRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<DeleteFlagRequest>, I>>(object: I): DeleteFlagRequest {
+    const message = createBaseDeleteFlagRequest();
+    message.name = object.name ?? "";
+    return message;
+  },
+};
+
+function createBaseDeleteFlagResponse(): DeleteFlagResponse {
+  return {};
+}
+
+export const DeleteFlagResponse: MessageFns<DeleteFlagResponse> = {
+  encode(_: DeleteFlagResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): DeleteFlagResponse {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseDeleteFlagResponse();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(_: any): DeleteFlagResponse {
+    return {};
+  },
+
+  toJSON(_: DeleteFlagResponse): unknown {
+    const obj: any = {};
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<DeleteFlagResponse>, I>>(base?: I): DeleteFlagResponse {
+    return DeleteFlagResponse.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<DeleteFlagResponse>, I>>(_: I): DeleteFlagResponse {
+    const message = createBaseDeleteFlagResponse();
+    return message;
+  },
+};
+
+function createBaseSupportRequest(): SupportRequest {
+  return {
+    id: "",
+    userId: "",
+    email: "",
+    subject: "",
+    description: "",
+    errorMessage: "",
+    failedItems: [],
+    shippingAddress: undefined,
+    status: "",
+    jiraTicketId: "",
+    createdAt: 0,
+    updatedAt: 0,
+    history: [],
+    ticketStatus: "",
+    ticketUrl: "",
+    incidentId: "",
+    fingerprint: "",
+    service: "",
+    doraIncidentId: "",
+    traceId: "",
+    spanId: "",
+  };
+}
+
+export const SupportRequest: MessageFns<SupportRequest> = {
+  encode(message: SupportRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.id !== "") {
+      writer.uint32(10).string(message.id);
+    }
+    if (message.userId !== "") {
+      writer.uint32(18).string(message.userId);
+    }
+    if (message.email !== "") {
+      writer.uint32(26).string(message.email);
+    }
+    if (message.subject !== "") {
+      writer.uint32(34).string(message.subject);
+    }
+    if (message.description !== "") {
+      writer.uint32(42).string(message.description);
+    }
+    if (message.errorMessage !== "") {
+      writer.uint32(50).string(message.errorMessage);
+    }
+    for (const v of message.failedItems) {
+      OrderItem.encode(v!, writer.uint32(58).fork()).join();
+    }
+    if (message.shippingAddress !== undefined) {
+      Address.encode(message.shippingAddress, writer.uint32(66).fork()).join();
+    }
+    if (message.status !== "") {
+      writer.uint32(74).string(message.status);
+    }
+    if (message.jiraTicketId !== "") {
+      writer.uint32(82).string(message.jiraTicketId);
+    }
+    if (message.createdAt !== 0) {
+      writer.uint32(88).int64(message.createdAt);
+    }
+    if (message.updatedAt !== 0) {
+      writer.uint32(96).int64(message.updatedAt);
+    }
+    for (const v of message.history) {
+      SupportRequestStatusChange.encode(v!, writer.uint32(106).fork()).join();
+    }
+    if (message.ticketStatus !== "") {
+      writer.uint32(114).string(message.ticketStatus);
+    }
+    if (message.ticketUrl !== "") {
+      writer.uint32(122).string(message.ticketUrl);
+    }
+    if (message.incidentId !== "") {
+      writer.uint32(130).string(message.incidentId);
+    }
+    if (message.fingerprint !== "") {
+      writer.uint32(138).string(message.fingerprint);
+    }
+    if (message.service !== "") {
+      writer.uint32(146).string(message.service);
+    }
+    if (message.doraIncidentId !== "") {
+      writer.uint32(154).string(message.doraIncidentId);
+    }
+    if (message.traceId !== "") {
+      writer.uint32(162).string(message.traceId);
+    }
+    if (message.spanId !== "") {
+      writer.uint32(170).string(message.spanId);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): SupportRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSupportRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.id = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.userId = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.email = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.subject = reader.string();
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.description = reader.string();
+          continue;
+        }
+        case 6: {
+          if (tag !== 50) {
+            break;
+          }
+
+          message.errorMessage = reader.string();
+          continue;
+        }
+        case 7: {
+          if (tag !== 58) {
+            break;
+          }
+
+          message.failedItems.push(OrderItem.decode(reader, reader.uint32()));
+          continue;
+        }
+        case 8: {
+          if (tag !== 66) {
+            break;
+          }
+
+          message.shippingAddress = Address.decode(reader, reader.uint32());
+          continue;
+        }
+        case 9: {
+          if (tag !== 74) {
+            break;
+          }
+
+          message.status = reader.string();
+          continue;
+        }
+        case 10: {
+          if (tag !== 82) {
+            break;
+          }
+
+          message.jiraTicketId = reader.string();
+          continue;
+        }
+        case 11: {
+          if (tag !== 88) {
+            break;
+          }
+
+          message.createdAt = longToNumber(reader.int64());
+          continue;
+        }
+        case 12: {
+          if (tag !== 96) {
+            break;
+          }
+
+          message.updatedAt = longToNumber(reader.int64());
+          continue;
+        }
+        case 13: {
+          if (tag !== 106) {
+            break;
+          }
+
+          message.history.push(SupportRequestStatusChange.decode(reader, reader.uint32()));
+          continue;
+        }
+        case 14: {
+          if (tag !== 114) {
+            break;
+          }
+
+          message.ticketStatus = reader.string();
+          continue;
+        }
+        case 15: {
+          if (tag !== 122) {
+            break;
+          }
+
+          message.ticketUrl = reader.string();
+          continue;
+        }
+        case 16: {
+          if (tag !== 130) {
+            break;
+          }
+
+          message.incidentId = reader.string();
+          continue;
+        }
+        case 17: {
+          if (tag !== 138) {
+            break;
+          }
+
+          message.fingerprint = reader.string();
+          continue;
+        }
+        case 18: {
+          if (tag !== 146) {
+            break;
+          }
+
+          message.service = reader.string();
+          continue;
+        }
+        case 19: {
+          if (tag !== 154) {
+            break;
+          }
+
+          message.doraIncidentId = reader.string();
+          continue;
+        }
+        case 20: {
+          if (tag !== 162) {
+            break;
+          }
+
+          message.traceId = reader.string();
+          continue;
+        }
+        case 21: {
+          if (tag !== 170) {
+            break;
+          }
+
+          message.spanId = reader.string();
+          continue;
+        }
+      }
+      if ((tag & 7) === 4 || tag === 0) {
+        break;
+      }
+      reader.skip(tag & 7);
+    }
+    return message;
+  },
+
+  fromJSON(object: any): SupportRequest {
+    return {
+      id: isSet(object.id) ? globalThis.String(object.id) : "",
+      userId: isSet(object.userId) ? globalThis.String(object.userId) : "",
+      email: isSet(object.email) ? globalThis.String(object.email) : "",
+      subject: isSet(object.subject) ? globalThis.String(object.subject) : "",
+      description: isSet(object.description) ? globalThis.String(object.description) : "",
+      errorMessage: isSet(object.errorMessage) ? globalThis.String(object.errorMessage) : "",
+      failedItems: globalThis.Array.isArray(object?.failedItems)
+        ? object.failedItems.map((e: any) => OrderItem.fromJSON(e))
+        : [],
+      shippingAddress: isSet(object.shippingAddress) ? Address.fromJSON(object.shippingAddress) : undefined,
+      status: isSet(object.status) ? globalThis.String(object.status) : "",
+      jiraTicketId: isSet(object.jiraTicketId) ? globalThis.String(object.jiraTicketId) : "",
+      createdAt: isSet(object.createdAt) ? globalThis.Number(object.createdAt) : 0,
+      updatedAt: isSet(object.updatedAt) ? globalThis.Number(object.updatedAt) : 0,
+      history: globalThis.Array.isArray(object?.history)
+        ? object.history.map((e: any) => SupportRequestStatusChange.fromJSON(e))
+        : [],
+      ticketStatus: isSet(object.ticketStatus) ? globalThis.String(object.ticketStatus) : "",
+      ticketUrl: isSet(object.ticketUrl) ? globalThis.String(object.ticketUrl) : "",
+      incidentId: isSet(object.incidentId) ? globalThis.String(object.incidentId) : "",
+      fingerprint: isSet(object.fingerprint) ? globalThis.String(object.fingerprint) : "",
+      service: isSet(object.service) ? globalThis.String(object.service) : "",
+      doraIncidentId: isSet(object.doraIncidentId) ? globalThis.String(object.doraIncidentId) : "",
+      traceId: isSet(object.traceId) ? globalThis.String(object.traceId) : "",
+      spanId: isSet(object.spanId) ? globalThis.String(object.spanId) : "",
+    };
+  },
+
+  toJSON(message: SupportRequest): unknown {
+    const obj: any = {};
+    if (message.id !== "") {
+      obj.id = message.id;
+    }
+    if (message.userId !== "") {
+      obj.userId = message.userId;
+    }
+    if (message.email !== "") {
+      obj.email = message.email;
+    }
+    if (message.subject !== "") {
+      obj.subject = message.subject;
+    }
+    if (message.description !== "") {
+      obj.description = message.description;
+    }
+    if (message.errorMessage !== "") {
+      obj.errorMessage = message.errorMessage;
+    }
+    if (message.failedItems?.length) {
+      obj.failedItems = message.failedItems.map((e) => OrderItem.toJSON(e));
+    }
+    if (message.shippingAddress !== undefined) {
+      obj.shippingAddress = Address.toJSON(message.shippingAddress);
+    }
+    if (message.status !== "") {
+      obj.status = message.status;
+    }
+    if (message.jiraTicketId !== "") {
+      obj.jiraTicketId = message.jiraTicketId;
+    }
+    if (message.createdAt !== 0) {
+      obj.createdAt = Math.round(message.createdAt);
+    }
+    if (message.updatedAt !== 0) {
+      obj.updatedAt = Math.round(message.updatedAt);
+    }
+    if (message.history?.length) {
+      obj.history = message.history.map((e) => SupportRequestStatusChange.toJSON(e));
+    }
+    if (message.ticketStatus !== "") {
+      obj.ticketStatus = message.ticketStatus;
+    }
+    if (message.ticketUrl !== "") {
+      obj.ticketUrl = message.ticketUrl;
+    }
+    if (message.incidentId !== "") {
+      obj.incidentId = message.incidentId;
+    }
+    if (message.fingerprint !== "") {
+      obj.fingerprint = message.fingerprint;
+    }
+    if (message.service !== "") {
+      obj.service = message.service;
+    }
+    if (message.doraIncidentId !== "") {
+      obj.doraIncidentId = message.doraIncidentId;
+    }
+    if (message.traceId !== "") {
+      obj.traceId = message.traceId;
+    }
+    if (message.spanId !== "") {
+      obj.spanId = message.spanId;
+    }
+    return obj;
+  },
+
+  create<I extends Exact<DeepPartial<SupportRequest>, I>>(base?: I): SupportRequest {
+    return SupportRequest.fromPartial(base ?? ({} as any));
+  },
+  fromPartial<I extends Exact<DeepPartial<SupportRequest>, I>>(object: I): SupportRequest {
+    const message = createBaseSupportRequest();
+    message.id = object.id ?? "";
+    message.userId = object.userId ?? "";
+    message.email = object.email ?? "";
+    message.subject = object.subject ?? "";
+    message.description = object.description ?? "";
+    message.errorMessage = object.errorMessage ?? "";
+    message.failedItems = object.failedItems?.map((e) => OrderItem.fromPartial(e)) || [];
+    message.shippingAddress = (object.shippingAddress !== undefined && object.shippingAddress !== null)
+      ? Address.fromPartial(object.shippingAddress)
+      : undefined;
+    message.status = object.status ?? "";
+    message.jiraTicketId = object.jiraTicketId ?? "";
+    message.createdAt = object.createdAt ?? 0;
+    message.updatedAt = object.updatedAt ?? 0;
+    message.history = object.history?.map((e) => SupportRequestStatusChange.fromPartial(e)) || [];
+    message.ticketStatus = object.ticketStatus ?? "";
+    message.ticketUrl = object.ticketUrl ?? "";
+    message.incidentId = object.incidentId ?? "";
+    message.fingerprint = object.fingerprint ?? "";
+    message.service = object.service ?? "";
+    message.doraIncidentId = object.doraIncidentId ?? "";
+    message.traceId = object.traceId ?? "";
+    message.spanId = object.spanId ?? "";
+    return message;
+  },
+};
+
+function createBaseSupportIncident(): SupportIncident {
+  return {
+    id: "",
+    fingerprint: "",
+    supportRequestIds: [],
+    affectedUserCount: 0,
+    ticketStatus: "",
+    ticketKey: "",
+    ticketUrl: "",
+    firstSeenAt: 0,
+    lastSeenAt: 0,
+  };
+}
+
+export const SupportIncident: MessageFns<SupportIncident> = {
+  encode(message: SupportIncident, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.id !== "") {
+      writer.uint32(10).string(message.id);
+    }
+    if (message.fingerprint !== "") {
+      writer.uint32(18).string(message.fingerprint);
+    }
+    for (const v of message.supportRequestIds) {
+      writer.uint32(26).string(v!);
+    }
+    if (message.affectedUserCount !== 0) {
+      writer.uint32(32).int32(message.affectedUserCount);
+    }
+    if (message.ticketStatus !== "") {
+      writer.uint32(42).string(message.ticketStatus);
+    }
+    if (message.ticketKey !== "") {
+      writer.uint32(50).string(message.ticketKey);
+    }
+    if (message.ticketUrl !== "") {
+      writer.uint32(58).string(message.ticketUrl);
+    }
+    if (message.firstSeenAt !== 0) {
+      writer.uint32(64).int64(message.firstSeenAt);
+    }
+    if (message.lastSeenAt !== 0) {
+      writer.uint32(72).int64(message.lastSeenAt);
+    }
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): SupportIncident {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseSupportIncident();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.id = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.fingerprint = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.supportRequestIds.push(reader.string());
+          continue;
+        }
+        case 4: {
+          if (tag !== 32) {
+            break;
+          }
+
+          message.affectedUserCount = reader.int32();
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.ticketStatus = reader.string();
+          continue;
+        }
+        case 6: {
+          if (tag !== 50) {
+            break;
+          }
+
+          message.ticketKey = reader.string();
+          continue;
+        }
+        case 7: {
+          if (tag !== 58) {
+            break;
+          }
+
+          message.ticketUrl = reader.string();
+          continue;
+        }
+        case 8: {
+          if (tag !== 64) {
+            break;
+          }
 
-  decode(input: BinaryReader | Uint8Array, length?: number): GetFlagRequest {
-    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
-    let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseGetFlagRequest();
-    while (reader.pos < end) {
-      const tag = reader.uint32();
-      switch (tag >>> 3) {
-        case 1: {
-          if (tag !== 10) {
+          message.firstSeenAt = longToNumber(reader.int64());
+          continue;
+        }
+        case 9: {
+          if (tag !== 72) {
             break;
           }
 
-          message.name = reader.string();
+          message.lastSeenAt = longToNumber(reader.int64());
           continue;
         }
       }
@@ -2867,44 +6801,100 @@ export const GetFlagRequest: MessageFns<GetFlagRequest> = {
     return message;
   },
 
-  fromJSON(object: any): GetFlagRequest {
-    return { name: isSet(object.name) ? globalThis.String(object.name) : "" };
+  fromJSON(object: any): SupportIncident {
+    return {
+      id: isSet(object.id) ? globalThis.String(object.id) : "",
+      fingerprint: isSet(object.fingerprint) ? globalThis.String(object.fingerprint) : "",
+      supportRequestIds: globalThis.Array.isArray(object?.supportRequestIds)
+        ? object.supportRequestIds.map((e: any) => globalThis.String(e))
+        : [],
+      affectedUserCount: isSet(object.affectedUserCount) ? globalThis.Number(object.affectedUserCount) : 0,
+      ticketStatus: isSet(object.ticketStatus) ? globalThis.String(object.ticketStatus) : "",
+      ticketKey: isSet(object.ticketKey) ? globalThis.String(object.ticketKey) : "",
+      ticketUrl: isSet(object.ticketUrl) ? globalThis.String(object.ticketUrl) : "",
+      firstSeenAt: isSet(object.firstSeenAt) ? globalThis.Number(object.firstSeenAt) : 0,
+      lastSeenAt: isSet(object.lastSeenAt) ? globalThis.Number(object.lastSeenAt) : 0,
+    };
   },
 
-  toJSON(message: GetFlagRequest): unknown {
+  toJSON(message: SupportIncident): unknown {
     const obj: any = {};
-    if (message.name !== "") {
-      obj.name = message.name;
+    if (message.id !== "") {
+      obj.id = message.id;
+    }
+    if (message.fingerprint !== "") {
+      obj.fingerprint = message.fingerprint;
+    }
+    if (message.supportRequestIds?.length) {
+      obj.supportRequestIds = message.supportRequestIds;
+    }
+    if (message.affectedUserCount !== 0) {
+      obj.affectedUserCount = Math.round(message.affectedUserCount);
+    }
+    if (message.ticketStatus !== "") {
+      obj.ticketStatus = message.ticketStatus;
+    }
+    if (message.ticketKey !== "") {
+      obj.ticketKey = message.ticketKey;
+    }
+    if (message.ticketUrl !== "") {
+      obj.ticketUrl = message.ticketUrl;
+    }
+    if (message.firstSeenAt !== 0) {
+      obj.firstSeenAt = Math.round(message.firstSeenAt);
+    }
+    if (message.lastSeenAt !== 0) {
+      obj.lastSeenAt = Math.round(message.lastSeenAt);
     }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<GetFlagRequest>, I>>(base?: I): GetFlagRequest {
-    return GetFlagRequest.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<SupportIncident>, I>>(base?: I): SupportIncident {
+    return SupportIncident.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<GetFlagRequest>, I>>(object: I): GetFlagRequest {
-    const message = createBaseGetFlagRequest();
-    message.name = object.name ?? "";
+  fromPartial<I extends Exact<DeepPartial<SupportIncident>, I>>(object: I): SupportIncident {
+    const message = createBaseSupportIncident();
+    message.id = object.id ?? "";
+    message.fingerprint = object.fingerprint ?? "";
+    message.supportRequestIds = object.supportRequestIds?.map((e) => e) || [];
+    message.affectedUserCount = object.affectedUserCount ?? 0;
+    message.ticketStatus = object.ticketStatus ?? "";
+    message.ticketKey = object.ticketKey ?? "";
+    message.ticketUrl = object.ticketUrl ?? "";
+    message.firstSeenAt = object.firstSeenAt ?? 0;
+    message.lastSeenAt = object.lastSeenAt ?? 0;
     return message;
   },
 };
 
-function createBaseGetFlagResponse(): GetFlagResponse {
-  return { flag: undefined };
+function createBaseSupportRequestStatusChange(): SupportRequestStatusChange {
+  return { fromStatus: "", toStatus: "", actor: "", reason: "", changedAt: 0 };
 }
 
-export const GetFlagResponse: MessageFns<GetFlagResponse> = {
-  encode(message: GetFlagResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    if (message.flag !== undefined) {
-      Flag.encode(message.flag, writer.uint32(10).fork()).join();
+export const SupportRequestStatusChange: MessageFns<SupportRequestStatusChange> = {
+  encode(message: SupportRequestStatusChange, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.fromStatus !== "") {
+      writer.uint32(10).string(message.fromStatus);
+    }
+    if (message.toStatus !== "") {
+      writer.uint32(18).string(message.toStatus);
+    }
+    if (message.actor !== "") {
+      writer.uint32(26).string(message.actor);
+    }
+    if (message.reason !== "") {
+      writer.uint32(34).string(message.reason);
+    }
+    if (message.changedAt !== 0) {
+      writer.uint32(40).int64(message.changedAt);
     }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): GetFlagResponse {
+  decode(input: BinaryReader | Uint8Array, length?: number): SupportRequestStatusChange {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseGetFlagResponse();
+    const message = createBaseSupportRequestStatusChange();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -2913,7 +6903,39 @@ export const GetFlagResponse: MessageFns<GetFlagResponse> = {
             break;
           }
 
-          message.flag = Flag.decode(reader, reader.uint32());
+          message.fromStatus = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.toStatus = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.actor = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.reason = reader.string();
+          continue;
+        }
+        case 5: {
+          if (tag !== 40) {
+            break;
+          }
+
+          message.changedAt = longToNumber(reader.int64());
           continue;
         }
       }
@@ -2925,50 +6947,96 @@ export const GetFlagResponse: MessageFns<GetFlagResponse> = {
     return message;
   },
 
-  fromJSON(object: any): GetFlagResponse {
-    return { flag: isSet(object.flag) ? Flag.fromJSON(object.flag) : undefined };
+  fromJSON(object: any): SupportRequestStatusChange {
+    return {
+      fromStatus: isSet(object.fromStatus) ? globalThis.String(object.fromStatus) : "",
+      toStatus: isSet(object.toStatus) ? globalThis.String(object.toStatus) : "",
+      actor: isSet(object.actor) ? globalThis.String(object.actor) : "",
+      reason: isSet(object.reason) ? globalThis.String(object.reason) : "",
+      changedAt: isSet(object.changedAt) ? globalThis.Number(object.changedAt) : 0,
+    };
   },
 
-  toJSON(message: GetFlagResponse): unknown {
+  toJSON(message: SupportRequestStatusChange): unknown {
     const obj: any = {};
-    if (message.flag !== undefined) {
-      obj.flag = Flag.toJSON(message.flag);
+    if (message.fromStatus !== "") {
+      obj.fromStatus = message.fromStatus;
+    }
+    if (message.toStatus !== "") {
+      obj.toStatus = message.toStatus;
+    }
+    if (message.actor !== "") {
+      obj.actor = message.actor;
+    }
+    if (message.reason !== "") {
+      obj.reason = message.reason;
+    }
+    if (message.changedAt !== 0) {
+      obj.changedAt = Math.round(message.changedAt);
     }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<GetFlagResponse>, I>>(base?: I): GetFlagResponse {
-    return GetFlagResponse.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<SupportRequestStatusChange>, I>>(base?: I): SupportRequestStatusChange {
+    return SupportRequestStatusChange.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<GetFlagResponse>, I>>(object: I): GetFlagResponse {
-    const message = createBaseGetFlagResponse();
-    message.flag = (object.flag !== undefined && object.flag !== null) ? Flag.fromPartial(object.flag) : undefined;
+  fromPartial<I extends Exact<DeepPartial<SupportRequestStatusChange>, I>>(object: I): SupportRequestStatusChange {
+    const message = createBaseSupportRequestStatusChange();
+    message.fromStatus = object.fromStatus ?? "";
+    message.toStatus = object.toStatus ?? "";
+    message.actor = object.actor ?? "";
+    message.reason = object.reason ?? "";
+    message.changedAt = object.changedAt ?? 0;
     return message;
   },
 };
 
-function createBaseCreateFlagRequest(): CreateFlagRequest {
-  return { name: "", description: "", enabled: false };
+function createBaseCreateSupportRequestRequest(): CreateSupportRequestRequest {
+  return {
+    userId: "",
+    email: "",
+    subject: "",
+    description: "",
+    errorMessage: "",
+    failedItems: [],
+    shippingAddress: undefined,
+    service: "",
+  };
 }
 
-export const CreateFlagRequest: MessageFns<CreateFlagRequest> = {
-  encode(message: CreateFlagRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    if (message.name !== "") {
-      writer.uint32(10).string(message.name);
+export const CreateSupportRequestRequest: MessageFns<CreateSupportRequestRequest> = {
+  encode(message: CreateSupportRequestRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.userId !== "") {
+      writer.uint32(10).string(message.userId);
+    }
+    if (message.email !== "") {
+      writer.uint32(18).string(message.email);
+    }
+    if (message.subject !== "") {
+      writer.uint32(26).string(message.subject);
     }
     if (message.description !== "") {
-      writer.uint32(18).string(message.description);
+      writer.uint32(34).string(message.description);
     }
-    if (message.enabled !== false) {
-      writer.uint32(24).bool(message.enabled);
+    if (message.errorMessage !== "") {
+      writer.uint32(42).string(message.errorMessage);
+    }
+    for (const v of message.failedItems) {
+      OrderItem.encode(v!, writer.uint32(50).fork()).join();
+    }
+    if (message.shippingAddress !== undefined) {
+      Address.encode(message.shippingAddress, writer.uint32(58).fork()).join();
+    }
+    if (message.service !== "") {
+      writer.uint32(66).string(message.service);
     }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): CreateFlagRequest {
+  decode(input: BinaryReader | Uint8Array, length?: number): CreateSupportRequestRequest {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseCreateFlagRequest();
+    const message = createBaseCreateSupportRequestRequest();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -2977,7 +7045,7 @@ export const CreateFlagRequest: MessageFns<CreateFlagRequest> = {
             break;
           }
 
-          message.name = reader.string();
+          message.userId = reader.string();
           continue;
         }
         case 2: {
@@ -2985,15 +7053,55 @@ export const CreateFlagRequest: MessageFns<CreateFlagRequest> = {
             break;
           }
 
-          message.description = reader.string();
+          message.email = reader.string();
           continue;
         }
         case 3: {
-          if (tag !== 24) {
+          if (tag !== 26) {
             break;
           }
 
-          message.enabled = reader.bool();
+          message.subject = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.description = reader.string();
+          continue;
+        }
+        case 5: {
+          if (tag !== 42) {
+            break;
+          }
+
+          message.errorMessage = reader.string();
+          continue;
+        }
+        case 6: {
+          if (tag !== 50) {
+            break;
+          }
+
+          message.failedItems.push(OrderItem.decode(reader, reader.uint32()));
+          continue;
+        }
+        case 7: {
+          if (tag !== 58) {
+            break;
+          }
+
+          message.shippingAddress = Address.decode(reader, reader.uint32());
+          continue;
+        }
+        case 8: {
+          if (tag !== 66) {
+            break;
+          }
+
+          message.service = reader.string();
           continue;
         }
       }
@@ -3005,56 +7113,85 @@ export const CreateFlagRequest: MessageFns<CreateFlagRequest> = {
     return message;
   },
 
-  fromJSON(object: any): CreateFlagRequest {
+  fromJSON(object: any): CreateSupportRequestRequest {
     return {
-      name: isSet(object.name) ? globalThis.String(object.name) : "",
+      userId: isSet(object.userId) ? globalThis.String(object.userId) : "",
+      email: isSet(object.email) ? globalThis.String(object.email) : "",
+      subject: isSet(object.subject) ? globalThis.String(object.subject) : "",
       description: isSet(object.description) ? globalThis.String(object.description) : "",
-      enabled: isSet(object.enabled) ? globalThis.Boolean(object.enabled) : false,
+      errorMessage: isSet(object.errorMessage) ? globalThis.String(object.errorMessage) : "",
+      failedItems: globalThis.Array.isArray(object?.failedItems)
+        ? object.failedItems.map((e: any) => OrderItem.fromJSON(e))
+        : [],
+      shippingAddress: isSet(object.shippingAddress) ? Address.fromJSON(object.shippingAddress) : undefined,
+      service: isSet(object.service) ? globalThis.String(object.service) : "",
     };
   },
 
-  toJSON(message: CreateFlagRequest): unknown {
+  toJSON(message: CreateSupportRequestRequest): unknown {
     const obj: any = {};
-    if (message.name !== "") {
-      obj.name = message.name;
+    if (message.userId !== "") {
+      obj.userId = message.userId;
+    }
+    if (message.email !== "") {
+      obj.email = message.email;
+    }
+    if (message.subject !== "") {
+      obj.subject = message.subject;
     }
     if (message.description !== "") {
       obj.description = message.description;
     }
-    if (message.enabled !== false) {
-      obj.enabled = message.enabled;
+    if (message.errorMessage !== "") {
+      obj.errorMessage = message.errorMessage;
+    }
+    if (message.failedItems?.length) {
+      obj.failedItems = message.failedItems.map((e) => OrderItem.toJSON(e));
+    }
+    if (message.shippingAddress !== undefined) {
+      obj.shippingAddress = Address.toJSON(message.shippingAddress);
+    }
+    if (message.service !== "") {
+      obj.service = message.service;
     }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<CreateFlagRequest>, I>>(base?: I): CreateFlagRequest {
-    return CreateFlagRequest.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<CreateSupportRequestRequest>, I>>(base?: I): CreateSupportRequestRequest {
+    return CreateSupportRequestRequest.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<CreateFlagRequest>, I>>(object: I): CreateFlagRequest {
-    const message = createBaseCreateFlagRequest();
-    message.name = object.name ?? "";
+  fromPartial<I extends Exact<DeepPartial<CreateSupportRequestRequest>, I>>(object: I): CreateSupportRequestRequest {
+    const message = createBaseCreateSupportRequestRequest();
+    message.userId = object.userId ?? "";
+    message.email = object.email ?? "";
+    message.subject = object.subject ?? "";
     message.description = object.description ?? "";
-    message.enabled = object.enabled ?? false;
+    message.errorMessage = object.errorMessage ?? "";
+    message.failedItems = object.failedItems?.map((e) => OrderItem.fromPartial(e)) || [];
+    message.shippingAddress = (object.shippingAddress !== undefined && object.shippingAddress !== null)
+      ? Address.fromPartial(object.shippingAddress)
+      : undefined;
+    message.service = object.service ?? "";
     return message;
   },
 };
 
-function createBaseCreateFlagResponse(): CreateFlagResponse {
-  return { flag: undefined };
+function createBaseCreateSupportRequestResponse(): CreateSupportRequestResponse {
+  return { supportRequest: undefined };
 }
 
-export const CreateFlagResponse: MessageFns<CreateFlagResponse> = {
-  encode(message: CreateFlagResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    if (message.flag !== undefined) {
-      Flag.encode(message.flag, writer.uint32(10).fork()).join();
+export const CreateSupportRequestResponse: MessageFns<CreateSupportRequestResponse> = {
+  encode(message: CreateSupportRequestResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.supportRequest !== undefined) {
+      SupportRequest.encode(message.supportRequest, writer.uint32(10).fork()).join();
     }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): CreateFlagResponse {
+  decode(input: BinaryReader | Uint8Array, length?: number): CreateSupportRequestResponse {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseCreateFlagResponse();
+    const message = createBaseCreateSupportRequestResponse();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -3063,7 +7200,7 @@ export const CreateFlagResponse: MessageFns<CreateFlagResponse> = {
             break;
           }
 
-          message.flag = Flag.decode(reader, reader.uint32());
+          message.supportRequest = SupportRequest.decode(reader, reader.uint32());
           continue;
         }
       }
@@ -3075,47 +7212,48 @@ export const CreateFlagResponse: MessageFns<CreateFlagResponse> = {
     return message;
   },
 
-  fromJSON(object: any): CreateFlagResponse {
-    return { flag: isSet(object.flag) ? Flag.fromJSON(object.flag) : undefined };
+  fromJSON(object: any): CreateSupportRequestResponse {
+    return {
+      supportRequest: isSet(object.supportRequest) ? SupportRequest.fromJSON(object.supportRequest) : undefined,
+    };
   },
 
-  toJSON(message: CreateFlagResponse): unknown {
+  toJSON(message: CreateSupportRequestResponse): unknown {
     const obj: any = {};
-    if (message.flag !== undefined) {
-      obj.flag = Flag.toJSON(message.flag);
+    if (message.supportRequest !== undefined) {
+      obj.supportRequest = SupportRequest.toJSON(message.supportRequest);
     }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<CreateFlagResponse>, I>>(base?: I): CreateFlagResponse {
-    return CreateFlagResponse.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<CreateSupportRequestResponse>, I>>(base?: I): CreateSupportRequestResponse {
+    return CreateSupportRequestResponse.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<CreateFlagResponse>, I>>(object: I): CreateFlagResponse {
-    const message = createBaseCreateFlagResponse();
-    message.flag = (object.flag !== undefined && object.flag !== null) ? Flag.fromPartial(object.flag) : undefined;
+  fromPartial<I extends Exact<DeepPartial<CreateSupportRequestResponse>, I>>(object: I): CreateSupportRequestResponse {
+    const message = createBaseCreateSupportRequestResponse();
+    message.supportRequest = (object.supportRequest !== undefined && object.supportRequest !== null)
+      ? SupportRequest.fromPartial(object.supportRequest)
+      : undefined;
     return message;
   },
 };
 
-function createBaseUpdateFlagRequest(): UpdateFlagRequest {
-  return { name: "", enabled: false };
+function createBaseGetSupportRequestRequest(): GetSupportRequestRequest {
+  return { id: "" };
 }
 
-export const UpdateFlagRequest: MessageFns<UpdateFlagRequest> = {
-  encode(message: UpdateFlagRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    if (message.name !== "") {
-      writer.uint32(10).string(message.name);
-    }
-    if (message.enabled !== false) {
-      writer.uint32(16).bool(message.enabled);
+export const GetSupportRequestRequest: MessageFns<GetSupportRequestRequest> = {
+  encode(message: GetSupportRequestRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.id !== "") {
+      writer.uint32(10).string(message.id);
     }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): UpdateFlagRequest {
+  decode(input: BinaryReader | Uint8Array, length?: number): GetSupportRequestRequest {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseUpdateFlagRequest();
+    const message = createBaseGetSupportRequestRequest();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -3124,15 +7262,7 @@ export const UpdateFlagRequest: MessageFns<UpdateFlagRequest> = {
             break;
           }
 
-          message.name = reader.string();
-          continue;
-        }
-        case 2: {
-          if (tag !== 16) {
-            break;
-          }
-
-          message.enabled = reader.bool();
+          message.id = reader.string();
           continue;
         }
       }
@@ -3144,94 +7274,88 @@ export const UpdateFlagRequest: MessageFns<UpdateFlagRequest> = {
     return message;
   },
 
-  fromJSON(object: any): UpdateFlagRequest {
-    return {
-      name: isSet(object.name) ? globalThis.String(object.name) : "",
-      enabled: isSet(object.enabled) ? globalThis.Boolean(object.enabled) : false,
-    };
+  fromJSON(object: any): GetSupportRequestRequest {
+    return { id: isSet(object.id) ? globalThis.String(object.id) : "" };
   },
 
-  toJSON(message: UpdateFlagRequest): unknown {
+  toJSON(message: GetSupportRequestRequest): unknown {
     const obj: any = {};
-    if (message.name !== "") {
-      obj.name = message.name;
-    }
-    if (message.enabled !== false) {
-      obj.enabled = message.enabled;
+    if (message.id !== "") {
+      obj.id = message.id;
     }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<UpdateFlagRequest>, I>>(base?: I): UpdateFlagRequest {
-    return UpdateFlagRequest.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<GetSupportRequestRequest>, I>>(base?: I): GetSupportRequestRequest {
+    return GetSupportRequestRequest.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<UpdateFlagRequest>, I>>(object: I): UpdateFlagRequest {
-    const message = createBaseUpdateFlagRequest();
-    message.name = object.name ?? "";
-    message.enabled = object.enabled ?? false;
+  fromPartial<I extends Exact<DeepPartial<GetSupportRequestRequest>, I>>(object: I): GetSupportRequestRequest {
+    const message = createBaseGetSupportRequestRequest();
+    message.id = object.id ?? "";
     return message;
   },
 };
 
-function createBaseUpdateFlagResponse(): UpdateFlagResponse {
-  return {};
+function createBaseListSupportRequestsRequest(): ListSupportRequestsRequest {
+  return { userId: "", status: "", createdAfter: 0, createdBefore: 0 };
 }
 
-export const UpdateFlagResponse: MessageFns<UpdateFlagResponse> = {
-  encode(_: UpdateFlagResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+export const ListSupportRequestsRequest: MessageFns<ListSupportRequestsRequest> = {
+  encode(message: ListSupportRequestsRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.userId !== "") {
+      writer.uint32(10).string(message.userId);
+    }
+    if (message.status !== "") {
+      writer.uint32(18).string(message.status);
+    }
+    if (message.createdAfter !== 0) {
+      writer.uint32(24).int64(message.createdAfter);
+    }
+    if (message.createdBefore !== 0) {
+      writer.uint32(32).int64(message.createdBefore);
+    }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): UpdateFlagResponse {
+  decode(input: BinaryReader | Uint8Array, length?: number): ListSupportRequestsRequest {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseUpdateFlagResponse();
+    const message = createBaseListSupportRequestsRequest();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
-      }
-      if ((tag & 7) === 4 || tag === 0) {
-        break;
-      }
-      reader.skip(tag & 7);
-    }
-    return message;
-  },
-
-  fromJSON(_: any): UpdateFlagResponse {
-    return {};
-  },
-
-  toJSON(_: UpdateFlagResponse): unknown {
-    const obj: any = {};
-    return obj;
-  },
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
 
-  create<I extends Exact<DeepPartial<UpdateFlagResponse>, I>>(base?: I): UpdateFlagResponse {
-    return UpdateFlagResponse.fromPartial(base ?? ({} as any));
-  },
-  fromPartial<I extends Exact<DeepPartial<UpdateFlagResponse>, I>>(_: I): UpdateFlagResponse {
-    const message = createBaseUpdateFlagResponse();
-    return message;
-  },
-};
+          message.userId = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
 
-function createBaseListFlagsRequest(): ListFlagsRequest {
-  return {};
-}
+          message.status = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 24) {
+            break;
+          }
 
-export const ListFlagsRequest: MessageFns<ListFlagsRequest> = {
-  encode(_: ListFlagsRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    return writer;
-  },
+          message.createdAfter = longToNumber(reader.int64());
+          continue;
+        }
+        case 4: {
+          if (tag !== 32) {
+            break;
+          }
 
-  decode(input: BinaryReader | Uint8Array, length?: number): ListFlagsRequest {
-    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
-    let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseListFlagsRequest();
-    while (reader.pos < end) {
-      const tag = reader.uint32();
-      switch (tag >>> 3) {
+          message.createdBefore = longToNumber(reader.int64());
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -3241,40 +7365,61 @@ export const ListFlagsRequest: MessageFns<ListFlagsRequest> = {
     return message;
   },
 
-  fromJSON(_: any): ListFlagsRequest {
-    return {};
+  fromJSON(object: any): ListSupportRequestsRequest {
+    return {
+      userId: isSet(object.userId) ? globalThis.String(object.userId) : "",
+      status: isSet(object.status) ? globalThis.String(object.status) : "",
+      createdAfter: isSet(object.createdAfter) ? globalThis.Number(object.createdAfter) : 0,
+      createdBefore: isSet(object.createdBefore) ? globalThis.Number(object.createdBefore) : 0,
+    };
   },
 
-  toJSON(_: ListFlagsRequest): unknown {
+  toJSON(message: ListSupportRequestsRequest): unknown {
     const obj: any = {};
+    if (message.userId !== "") {
+      obj.userId = message.userId;
+    }
+    if (message.status !== "") {
+      obj.status = message.status;
+    }
+    if (message.createdAfter !== 0) {
+      obj.createdAfter = Math.round(message.createdAfter);
+    }
+    if (message.createdBefore !== 0) {
+      obj.createdBefore = Math.round(message.createdBefore);
+    }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<ListFlagsRequest>, I>>(base?: I): ListFlagsRequest {
-    return ListFlagsRequest.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<ListSupportRequestsRequest>, I>>(base?: I): ListSupportRequestsRequest {
+    return ListSupportRequestsRequest.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<ListFlagsRequest>, I>>(_: I): ListFlagsRequest {
-    const message = createBaseListFlagsRequest();
+  fromPartial<I extends Exact<DeepPartial<ListSupportRequestsRequest>, I>>(object: I): ListSupportRequestsRequest {
+    const message = createBaseListSupportRequestsRequest();
+    message.userId = object.userId ?? "";
+    message.status = object.status ?? "";
+    message.createdAfter = object.createdAfter ?? 0;
+    message.createdBefore = object.createdBefore ?? 0;
     return message;
   },
 };
 
-function createBaseListFlagsResponse(): ListFlagsResponse {
-  return { flag: [] };
+function createBaseListSupportRequestsResponse(): ListSupportRequestsResponse {
+  return { supportRequests: [] };
 }
 
-export const ListFlagsResponse: MessageFns<ListFlagsResponse> = {
-  encode(message: ListFlagsResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    for (const v of message.flag) {
-      Flag.encode(v!, writer.uint32(10).fork()).join();
+export const ListSupportRequestsResponse: MessageFns<ListSupportRequestsResponse> = {
+  encode(message: ListSupportRequestsResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.supportRequests) {
+      SupportRequest.encode(v!, writer.uint32(10).fork()).join();
     }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): ListFlagsResponse {
+  decode(input: BinaryReader | Uint8Array, length?: number): ListSupportRequestsResponse {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseListFlagsResponse();
+    const message = createBaseListSupportRequestsResponse();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -3283,7 +7428,7 @@ export const ListFlagsResponse: MessageFns<ListFlagsResponse> = {
             break;
           }
 
-          message.flag.push(Flag.decode(reader, reader.uint32()));
+          message.supportRequests.push(SupportRequest.decode(reader, reader.uint32()));
           continue;
         }
       }
@@ -3295,44 +7440,57 @@ export const ListFlagsResponse: MessageFns<ListFlagsResponse> = {
     return message;
   },
 
-  fromJSON(object: any): ListFlagsResponse {
-    return { flag: globalThis.Array.isArray(object?.flag) ? object.flag.map((e: any) => Flag.fromJSON(e)) : [] };
+  fromJSON(object: any): ListSupportRequestsResponse {
+    return {
+      supportRequests: globalThis.Array.isArray(object?.supportRequests)
+        ? object.supportRequests.map((e: any) => SupportRequest.fromJSON(e))
+        : [],
+    };
   },
 
-  toJSON(message: ListFlagsResponse): unknown {
+  toJSON(message: ListSupportRequestsResponse): unknown {
     const obj: any = {};
-    if (message.flag?.length) {
-      obj.flag = message.flag.map((e) => Flag.toJSON(e));
+    if (message.supportRequests?.length) {
+      obj.supportRequests = message.supportRequests.map((e) => SupportRequest.toJSON(e));
     }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<ListFlagsResponse>, I>>(base?: I): ListFlagsResponse {
-    return ListFlagsResponse.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<ListSupportRequestsResponse>, I>>(base?: I): ListSupportRequestsResponse {
+    return ListSupportRequestsResponse.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<ListFlagsResponse>, I>>(object: I): ListFlagsResponse {
-    const message = createBaseListFlagsResponse();
-    message.flag = object.flag?.map((e) => Flag.fromPartial(e)) || [];
+  fromPartial<I extends Exact<DeepPartial<ListSupportRequestsResponse>, I>>(object: I): ListSupportRequestsResponse {
+    const message = createBaseListSupportRequestsResponse();
+    message.supportRequests = object.supportRequests?.map((e) => SupportRequest.fromPartial(e)) || [];
     return message;
   },
 };
 
-function createBaseDeleteFlagRequest(): DeleteFlagRequest {
-  return { name: "" };
+function createBaseUpdateSupportRequestStatusRequest(): UpdateSupportRequestStatusRequest {
+  return { id: "", status: "", actor: "", reason: "" };
 }
 
-export const DeleteFlagRequest: MessageFns<DeleteFlagRequest> = {
-  encode(message: DeleteFlagRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    if (message.name !== "") {
-      writer.uint32(10).string(message.name);
+export const UpdateSupportRequestStatusRequest: MessageFns<UpdateSupportRequestStatusRequest> = {
+  encode(message: UpdateSupportRequestStatusRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.id !== "") {
+      writer.uint32(10).string(message.id);
+    }
+    if (message.status !== "") {
+      writer.uint32(18).string(message.status);
+    }
+    if (message.actor !== "") {
+      writer.uint32(26).string(message.actor);
+    }
+    if (message.reason !== "") {
+      writer.uint32(34).string(message.reason);
     }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): DeleteFlagRequest {
+  decode(input: BinaryReader | Uint8Array, length?: number): UpdateSupportRequestStatusRequest {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseDeleteFlagRequest();
+    const message = createBaseUpdateSupportRequestStatusRequest();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -3341,7 +7499,31 @@ export const DeleteFlagRequest: MessageFns<DeleteFlagRequest> = {
             break;
           }
 
-          message.name = reader.string();
+          message.id = reader.string();
+          continue;
+        }
+        case 2: {
+          if (tag !== 18) {
+            break;
+          }
+
+          message.status = reader.string();
+          continue;
+        }
+        case 3: {
+          if (tag !== 26) {
+            break;
+          }
+
+          message.actor = reader.string();
+          continue;
+        }
+        case 4: {
+          if (tag !== 34) {
+            break;
+          }
+
+          message.reason = reader.string();
           continue;
         }
       }
@@ -3353,44 +7535,76 @@ export const DeleteFlagRequest: MessageFns<DeleteFlagRequest> = {
     return message;
   },
 
-  fromJSON(object: any): DeleteFlagRequest {
-    return { name: isSet(object.name) ? globalThis.String(object.name) : "" };
+  fromJSON(object: any): UpdateSupportRequestStatusRequest {
+    return {
+      id: isSet(object.id) ? globalThis.String(object.id) : "",
+      status: isSet(object.status) ? globalThis.String(object.status) : "",
+      actor: isSet(object.actor) ? globalThis.String(object.actor) : "",
+      reason: isSet(object.reason) ? globalThis.String(object.reason) : "",
+    };
   },
 
-  toJSON(message: DeleteFlagRequest): unknown {
+  toJSON(message: UpdateSupportRequestStatusRequest): unknown {
     const obj: any = {};
-    if (message.name !== "") {
-      obj.name = message.name;
+    if (message.id !== "") {
+      obj.id = message.id;
+    }
+    if (message.status !== "") {
+      obj.status = message.status;
+    }
+    if (message.actor !== "") {
+      obj.actor = message.actor;
+    }
+    if (message.reason !== "") {
+      obj.reason = message.reason;
     }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<DeleteFlagRequest>, I>>(base?: I): DeleteFlagRequest {
-    return DeleteFlagRequest.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<UpdateSupportRequestStatusRequest>, I>>(
+    base?: I,
+  ): UpdateSupportRequestStatusRequest {
+    return UpdateSupportRequestStatusRequest.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<DeleteFlagRequest>, I>>(object: I): DeleteFlagRequest {
-    const message = createBaseDeleteFlagRequest();
-    message.name = object.name ?? "";
+  fromPartial<I extends Exact<DeepPartial<UpdateSupportRequestStatusRequest>, I>>(
+    object: I,
+  ): UpdateSupportRequestStatusRequest {
+    const message = createBaseUpdateSupportRequestStatusRequest();
+    message.id = object.id ?? "";
+    message.status = object.status ?? "";
+    message.actor = object.actor ?? "";
+    message.reason = object.reason ?? "";
     return message;
   },
 };
 
-function createBaseDeleteFlagResponse(): DeleteFlagResponse {
-  return {};
+function createBaseGetSupportIncidentRequest(): GetSupportIncidentRequest {
+  return { id: "" };
 }
 
-export const DeleteFlagResponse: MessageFns<DeleteFlagResponse> = {
-  encode(_: DeleteFlagResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+export const GetSupportIncidentRequest: MessageFns<GetSupportIncidentRequest> = {
+  encode(message: GetSupportIncidentRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    if (message.id !== "") {
+      writer.uint32(10).string(message.id);
+    }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): DeleteFlagResponse {
+  decode(input: BinaryReader | Uint8Array, length?: number): GetSupportIncidentRequest {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseDeleteFlagResponse();
+    const message = createBaseGetSupportIncidentRequest();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
+        case 1: {
+          if (tag !== 10) {
+            break;
+          }
+
+          message.id = reader.string();
+          continue;
+        }
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -3400,82 +7614,86 @@ export const DeleteFlagResponse: MessageFns<DeleteFlagResponse> = {
     return message;
   },
 
-  fromJSON(_: any): DeleteFlagResponse {
-    return {};
+  fromJSON(object: any): GetSupportIncidentRequest {
+    return { id: isSet(object.id) ? globalThis.String(object.id) : "" };
   },
 
-  toJSON(_: DeleteFlagResponse): unknown {
+  toJSON(message: GetSupportIncidentRequest): unknown {
     const obj: any = {};
+    if (message.id !== "") {
+      obj.id = message.id;
+    }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<DeleteFlagResponse>, I>>(base?: I): DeleteFlagResponse {
-    return DeleteFlagResponse.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<GetSupportIncidentRequest>, I>>(base?: I): GetSupportIncidentRequest {
+    return GetSupportIncidentRequest.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<DeleteFlagResponse>, I>>(_: I): DeleteFlagResponse {
-    const message = createBaseDeleteFlagResponse();
+  fromPartial<I extends Exact<DeepPartial<GetSupportIncidentRequest>, I>>(object: I): GetSupportIncidentRequest {
+    const message = createBaseGetSupportIncidentRequest();
+    message.id = object.id ?? "";
     return message;
   },
 };
 
-function createBaseSupportRequest(): SupportRequest {
+function createBaseSupportJob(): SupportJob {
   return {
     id: "",
-    userId: "",
-    email: "",
-    subject: "",
-    description: "",
-    errorMessage: "",
-    failedItems: [],
-    shippingAddress: undefined,
-    status: "",
-    jiraTicketId: "",
+    kind: "",
+    supportRequestId: "",
+    target: "",
+    state: "",
+    attempts: 0,
+    maxAttempts: 0,
+    lastError: "",
     createdAt: 0,
+    updatedAt: 0,
+    nextAttemptAt: 0,
   };
 }
 
-export const SupportRequest: MessageFns<SupportRequest> = {
-  encode(message: SupportRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+export const SupportJob: MessageFns<SupportJob> = {
+  encode(message: SupportJob, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
     if (message.id !== "") {
       writer.uint32(10).string(message.id);
     }
-    if (message.userId !== "") {
-      writer.uint32(18).string(message.userId);
+    if (message.kind !== "") {
+      writer.uint32(18).string(message.kind);
     }
-    if (message.email !== "") {
-      writer.uint32(26).string(message.email);
+    if (message.supportRequestId !== "") {
+      writer.uint32(26).string(message.supportRequestId);
     }
-    if (message.subject !== "") {
-      writer.uint32(34).string(message.subject);
+    if (message.target !== "") {
+      writer.uint32(34).string(message.target);
     }
-    if (message.description !== "") {
-      writer.uint32(42).string(message.description);
+    if (message.state !== "") {
+      writer.uint32(42).string(message.state);
     }
-    if (message.errorMessage !== "") {
-      writer.uint32(50).string(message.errorMessage);
+    if (message.attempts !== 0) {
+      writer.uint32(48).int32(message.attempts);
     }
-    for (const v of message.failedItems) {
-      OrderItem.encode(v!, writer.uint32(58).fork()).join();
+    if (message.maxAttempts !== 0) {
+      writer.uint32(56).int32(message.maxAttempts);
     }
-    if (message.shippingAddress !== undefined) {
-      Address.encode(message.shippingAddress, writer.uint32(66).fork()).join();
+    if (message.lastError !== "") {
+      writer.uint32(66).string(message.lastError);
     }
-    if (message.status !== "") {
-      writer.uint32(74).string(message.status);
+    if (message.createdAt !== 0) {
+      writer.uint32(72).int64(message.createdAt);
     }
-    if (message.jiraTicketId !== "") {
-      writer.uint32(82).string(message.jiraTicketId);
+    if (message.updatedAt !== 0) {
+      writer.uint32(80).int64(message.updatedAt);
     }
-    if (message.createdAt !== 0) {
-      writer.uint32(88).int64(message.createdAt);
+    if (message.nextAttemptAt !== 0) {
+      writer.uint32(88).int64(message.nextAttemptAt);
     }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): SupportRequest {
+  decode(input: BinaryReader | Uint8Array, length?: number): SupportJob {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseSupportRequest();
+    const message = createBaseSupportJob();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -3492,7 +7710,7 @@ export const SupportRequest: MessageFns<SupportRequest> = {
             break;
           }
 
-          message.userId = reader.string();
+          message.kind = reader.string();
           continue;
         }
         case 3: {
@@ -3500,7 +7718,7 @@ export const SupportRequest: MessageFns<SupportRequest> = {
             break;
           }
 
-          message.email = reader.string();
+          message.supportRequestId = reader.string();
           continue;
         }
         case 4: {
@@ -3508,7 +7726,7 @@ export const SupportRequest: MessageFns<SupportRequest> = {
             break;
           }
 
-          message.subject = reader.string();
+          message.target = reader.string();
           continue;
         }
         case 5: {
@@ -3516,23 +7734,23 @@ export const SupportRequest: MessageFns<SupportRequest> = {
             break;
           }
 
-          message.description = reader.string();
+          message.state = reader.string();
           continue;
         }
         case 6: {
-          if (tag !== 50) {
+          if (tag !== 48) {
             break;
           }
 
-          message.errorMessage = reader.string();
+          message.attempts = reader.int32();
           continue;
         }
         case 7: {
-          if (tag !== 58) {
+          if (tag !== 56) {
             break;
           }
 
-          message.failedItems.push(OrderItem.decode(reader, reader.uint32()));
+          message.maxAttempts = reader.int32();
           continue;
         }
         case 8: {
@@ -3540,23 +7758,23 @@ export const SupportRequest: MessageFns<SupportRequest> = {
             break;
           }
 
-          message.shippingAddress = Address.decode(reader, reader.uint32());
+          message.lastError = reader.string();
           continue;
         }
         case 9: {
-          if (tag !== 74) {
+          if (tag !== 72) {
             break;
           }
 
-          message.status = reader.string();
+          message.createdAt = longToNumber(reader.int64());
           continue;
         }
         case 10: {
-          if (tag !== 82) {
+          if (tag !== 80) {
             break;
           }
 
-          message.jiraTicketId = reader.string();
+          message.updatedAt = longToNumber(reader.int64());
           continue;
         }
         case 11: {
@@ -3564,7 +7782,7 @@ export const SupportRequest: MessageFns<SupportRequest> = {
             break;
           }
 
-          message.createdAt = longToNumber(reader.int64());
+          message.nextAttemptAt = longToNumber(reader.int64());
           continue;
         }
       }
@@ -3576,185 +7794,96 @@ export const SupportRequest: MessageFns<SupportRequest> = {
     return message;
   },
 
-  fromJSON(object: any): SupportRequest {
+  fromJSON(object: any): SupportJob {
     return {
       id: isSet(object.id) ? globalThis.String(object.id) : "",
-      userId: isSet(object.userId) ? globalThis.String(object.userId) : "",
-      email: isSet(object.email) ? globalThis.String(object.email) : "",
-      subject: isSet(object.subject) ? globalThis.String(object.subject) : "",
-      description: isSet(object.description) ? globalThis.String(object.description) : "",
-      errorMessage: isSet(object.errorMessage) ? globalThis.String(object.errorMessage) : "",
-      failedItems: globalThis.Array.isArray(object?.failedItems)
-        ? object.failedItems.map((e: any) => OrderItem.fromJSON(e))
-        : [],
-      shippingAddress: isSet(object.shippingAddress) ? Address.fromJSON(object.shippingAddress) : undefined,
-      status: isSet(object.status) ? globalThis.String(object.status) : "",
-      jiraTicketId: isSet(object.jiraTicketId) ? globalThis.String(object.jiraTicketId) : "",
+      kind: isSet(object.kind) ? globalThis.String(object.kind) : "",
+      supportRequestId: isSet(object.supportRequestId) ? globalThis.String(object.supportRequestId) : "",
+      target: isSet(object.target) ? globalThis.String(object.target) : "",
+      state: isSet(object.state) ? globalThis.String(object.state) : "",
+      attempts: isSet(object.attempts) ? globalThis.Number(object.attempts) : 0,
+      maxAttempts: isSet(object.maxAttempts) ? globalThis.Number(object.maxAttempts) : 0,
+      lastError: isSet(object.lastError) ? globalThis.String(object.lastError) : "",
       createdAt: isSet(object.createdAt) ? globalThis.Number(object.createdAt) : 0,
+      updatedAt: isSet(object.updatedAt) ? globalThis.Number(object.updatedAt) : 0,
+      nextAttemptAt: isSet(object.nextAttemptAt) ? globalThis.Number(object.nextAttemptAt) : 0,
     };
   },
 
-  toJSON(message: SupportRequest): unknown {
+  toJSON(message: SupportJob): unknown {
     const obj: any = {};
     if (message.id !== "") {
       obj.id = message.id;
     }
-    if (message.userId !== "") {
-      obj.userId = message.userId;
-    }
-    if (message.email !== "") {
-      obj.email = message.email;
-    }
-    if (message.subject !== "") {
-      obj.subject = message.subject;
+    if (message.kind !== "") {
+      obj.kind = message.kind;
     }
-    if (message.description !== "") {
-      obj.description = message.description;
+    if (message.supportRequestId !== "") {
+      obj.supportRequestId = message.supportRequestId;
     }
-    if (message.errorMessage !== "") {
-      obj.errorMessage = message.errorMessage;
+    if (message.target !== "") {
+      obj.target = message.target;
     }
-    if (message.failedItems?.length) {
-      obj.failedItems = message.failedItems.map((e) => OrderItem.toJSON(e));
+    if (message.state !== "") {
+      obj.state = message.state;
     }
-    if (message.shippingAddress !== undefined) {
-      obj.shippingAddress = Address.toJSON(message.shippingAddress);
+    if (message.attempts !== 0) {
+      obj.attempts = Math.round(message.attempts);
     }
-    if (message.status !== "") {
-      obj.status = message.status;
+    if (message.maxAttempts !== 0) {
+      obj.maxAttempts = Math.round(message.maxAttempts);
     }
-    if (message.jiraTicketId !== "") {
-      obj.jiraTicketId = message.jiraTicketId;
+    if (message.lastError !== "") {
+      obj.lastError = message.lastError;
     }
     if (message.createdAt !== 0) {
       obj.createdAt = Math.round(message.createdAt);
     }
+    if (message.updatedAt !== 0) {
+      obj.updatedAt = Math.round(message.updatedAt);
+    }
+    if (message.nextAttemptAt !== 0) {
+      obj.nextAttemptAt = Math.round(message.nextAttemptAt);
+    }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<SupportRequest>, I>>(base?: I): SupportRequest {
-    return SupportRequest.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<SupportJob>, I>>(base?: I): SupportJob {
+    return SupportJob.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<SupportRequest>, I>>(object: I): SupportRequest {
-    const message = createBaseSupportRequest();
+  fromPartial<I extends Exact<DeepPartial<SupportJob>, I>>(object: I): SupportJob {
+    const message = createBaseSupportJob();
     message.id = object.id ?? "";
-    message.userId = object.userId ?? "";
-    message.email = object.email ?? "";
-    message.subject = object.subject ?? "";
-    message.description = object.description ?? "";
-    message.errorMessage = object.errorMessage ?? "";
-    message.failedItems = object.failedItems?.map((e) => OrderItem.fromPartial(e)) || [];
-    message.shippingAddress = (object.shippingAddress !== undefined && object.shippingAddress !== null)
-      ? Address.fromPartial(object.shippingAddress)
-      : undefined;
-    message.status = object.status ?? "";
-    message.jiraTicketId = object.jiraTicketId ?? "";
+    message.kind = object.kind ?? "";
+    message.supportRequestId = object.supportRequestId ?? "";
+    message.target = object.target ?? "";
+    message.state = object.state ?? "";
+    message.attempts = object.attempts ?? 0;
+    message.maxAttempts = object.maxAttempts ?? 0;
+    message.lastError = object.lastError ?? "";
     message.createdAt = object.createdAt ?? 0;
+    message.updatedAt = object.updatedAt ?? 0;
+    message.nextAttemptAt = object.nextAttemptAt ?? 0;
     return message;
   },
 };
 
-function createBaseCreateSupportRequestRequest(): CreateSupportRequestRequest {
-  return {
-    userId: "",
-    email: "",
-    subject: "",
-    description: "",
-    errorMessage: "",
-    failedItems: [],
-    shippingAddress: undefined,
-  };
-}
-
-export const CreateSupportRequestRequest: MessageFns<CreateSupportRequestRequest> = {
-  encode(message: CreateSupportRequestRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    if (message.userId !== "") {
-      writer.uint32(10).string(message.userId);
-    }
-    if (message.email !== "") {
-      writer.uint32(18).string(message.email);
-    }
-    if (message.subject !== "") {
-      writer.uint32(26).string(message.subject);
-    }
-    if (message.description !== "") {
-      writer.uint32(34).string(message.description);
-    }
-    if (message.errorMessage !== "") {
-      writer.uint32(42).string(message.errorMessage);
-    }
-    for (const v of message.failedItems) {
-      OrderItem.encode(v!, writer.uint32(50).fork()).join();
-    }
-    if (message.shippingAddress !== undefined) {
-      Address.encode(message.shippingAddress, writer.uint32(58).fork()).join();
-    }
-    return writer;
-  },
-
-  decode(input: BinaryReader | Uint8Array, length?: number): CreateSupportRequestRequest {
-    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
-    let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseCreateSupportRequestRequest();
-    while (reader.pos < end) {
-      const tag = reader.uint32();
-      switch (tag >>> 3) {
-        case 1: {
-          if (tag !== 10) {
-            break;
-          }
-
-          message.userId = reader.string();
-          continue;
-        }
-        case 2: {
-          if (tag !== 18) {
-            break;
-          }
-
-          message.email = reader.string();
-          continue;
-        }
-        case 3: {
-          if (tag !== 26) {
-            break;
-          }
-
-          message.subject = reader.string();
-          continue;
-        }
-        case 4: {
-          if (tag !== 34) {
-            break;
-          }
-
-          message.description = reader.string();
-          continue;
-        }
-        case 5: {
-          if (tag !== 42) {
-            break;
-          }
-
-          message.errorMessage = reader.string();
-          continue;
-        }
-        case 6: {
-          if (tag !== 50) {
-            break;
-          }
-
-          message.failedItems.push(OrderItem.decode(reader, reader.uint32()));
-          continue;
-        }
-        case 7: {
-          if (tag !== 58) {
-            break;
-          }
+function createBaseListDeadJobsRequest(): ListDeadJobsRequest {
+  return {};
+}
 
-          message.shippingAddress = Address.decode(reader, reader.uint32());
-          continue;
-        }
+export const ListDeadJobsRequest: MessageFns<ListDeadJobsRequest> = {
+  encode(_: ListDeadJobsRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    return writer;
+  },
+
+  decode(input: BinaryReader | Uint8Array, length?: number): ListDeadJobsRequest {
+    const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
+    let end = length === undefined ? reader.len : reader.pos + length;
+    const message = createBaseListDeadJobsRequest();
+    while (reader.pos < end) {
+      const tag = reader.uint32();
+      switch (tag >>> 3) {
       }
       if ((tag & 7) === 4 || tag === 0) {
         break;
@@ -3764,80 +7893,40 @@ export const CreateSupportRequestRequest: MessageFns<CreateSupportRequestRequest
     return message;
   },
 
-  fromJSON(object: any): CreateSupportRequestRequest {
-    return {
-      userId: isSet(object.userId) ? globalThis.String(object.userId) : "",
-      email: isSet(object.email) ? globalThis.String(object.email) : "",
-      subject: isSet(object.subject) ? globalThis.String(object.subject) : "",
-      description: isSet(object.description) ? globalThis.String(object.description) : "",
-      errorMessage: isSet(object.errorMessage) ? globalThis.String(object.errorMessage) : "",
-      failedItems: globalThis.Array.isArray(object?.failedItems)
-        ? object.failedItems.map((e: any) => OrderItem.fromJSON(e))
-        : [],
-      shippingAddress: isSet(object.shippingAddress) ? Address.fromJSON(object.shippingAddress) : undefined,
-    };
+  fromJSON(_: any): ListDeadJobsRequest {
+    return {};
   },
 
-  toJSON(message: CreateSupportRequestRequest): unknown {
+  toJSON(_: ListDeadJobsRequest): unknown {
     const obj: any = {};
-    if (message.userId !== "") {
-      obj.userId = message.userId;
-    }
-    if (message.email !== "") {
-      obj.email = message.email;
-    }
-    if (message.subject !== "") {
-      obj.subject = message.subject;
-    }
-    if (message.description !== "") {
-      obj.description = message.description;
-    }
-    if (message.errorMessage !== "") {
-      obj.errorMessage = message.errorMessage;
-    }
-    if (message.failedItems?.length) {
-      obj.failedItems = message.failedItems.map((e) => OrderItem.toJSON(e));
-    }
-    if (message.shippingAddress !== undefined) {
-      obj.shippingAddress = Address.toJSON(message.shippingAddress);
-    }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<CreateSupportRequestRequest>, I>>(base?: I): CreateSupportRequestRequest {
-    return CreateSupportRequestRequest.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<ListDeadJobsRequest>, I>>(base?: I): ListDeadJobsRequest {
+    return ListDeadJobsRequest.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<CreateSupportRequestRequest>, I>>(object: I): CreateSupportRequestRequest {
-    const message = createBaseCreateSupportRequestRequest();
-    message.userId = object.userId ?? "";
-    message.email = object.email ?? "";
-    message.subject = object.subject ?? "";
-    message.description = object.description ?? "";
-    message.errorMessage = object.errorMessage ?? "";
-    message.failedItems = object.failedItems?.map((e) => OrderItem.fromPartial(e)) || [];
-    message.shippingAddress = (object.shippingAddress !== undefined && object.shippingAddress !== null)
-      ? Address.fromPartial(object.shippingAddress)
-      : undefined;
+  fromPartial<I extends Exact<DeepPartial<ListDeadJobsRequest>, I>>(_: I): ListDeadJobsRequest {
+    const message = createBaseListDeadJobsRequest();
     return message;
   },
 };
 
-function createBaseCreateSupportRequestResponse(): CreateSupportRequestResponse {
-  return { supportRequest: undefined };
+function createBaseListDeadJobsResponse(): ListDeadJobsResponse {
+  return { jobs: [] };
 }
 
-export const CreateSupportRequestResponse: MessageFns<CreateSupportRequestResponse> = {
-  encode(message: CreateSupportRequestResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
-    if (message.supportRequest !== undefined) {
-      SupportRequest.encode(message.supportRequest, writer.uint32(10).fork()).join();
+export const ListDeadJobsResponse: MessageFns<ListDeadJobsResponse> = {
+  encode(message: ListDeadJobsResponse, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+    for (const v of message.jobs) {
+      SupportJob.encode(v!, writer.uint32(10).fork()).join();
     }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): CreateSupportRequestResponse {
+  decode(input: BinaryReader | Uint8Array, length?: number): ListDeadJobsResponse {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseCreateSupportRequestResponse();
+    const message = createBaseListDeadJobsResponse();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -3846,7 +7935,7 @@ export const CreateSupportRequestResponse: MessageFns<CreateSupportRequestRespon
             break;
           }
 
-          message.supportRequest = SupportRequest.decode(reader, reader.uint32());
+          message.jobs.push(SupportJob.decode(reader, reader.uint32()));
           continue;
         }
       }
@@ -3858,48 +7947,44 @@ export const CreateSupportRequestResponse: MessageFns<CreateSupportRequestRespon
     return message;
   },
 
-  fromJSON(object: any): CreateSupportRequestResponse {
-    return {
-      supportRequest: isSet(object.supportRequest) ? SupportRequest.fromJSON(object.supportRequest) : undefined,
-    };
+  fromJSON(object: any): ListDeadJobsResponse {
+    return { jobs: globalThis.Array.isArray(object?.jobs) ? object.jobs.map((e: any) => SupportJob.fromJSON(e)) : [] };
   },
 
-  toJSON(message: CreateSupportRequestResponse): unknown {
+  toJSON(message: ListDeadJobsResponse): unknown {
     const obj: any = {};
-    if (message.supportRequest !== undefined) {
-      obj.supportRequest = SupportRequest.toJSON(message.supportRequest);
+    if (message.jobs?.length) {
+      obj.jobs = message.jobs.map((e) => SupportJob.toJSON(e));
     }
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<CreateSupportRequestResponse>, I>>(base?: I): CreateSupportRequestResponse {
-    return CreateSupportRequestResponse.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<ListDeadJobsResponse>, I>>(base?: I): ListDeadJobsResponse {
+    return ListDeadJobsResponse.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<CreateSupportRequestResponse>, I>>(object: I): CreateSupportRequestResponse {
-    const message = createBaseCreateSupportRequestResponse();
-    message.supportRequest = (object.supportRequest !== undefined && object.supportRequest !== null)
-      ? SupportRequest.fromPartial(object.supportRequest)
-      : undefined;
+  fromPartial<I extends Exact<DeepPartial<ListDeadJobsResponse>, I>>(object: I): ListDeadJobsResponse {
+    const message = createBaseListDeadJobsResponse();
+    message.jobs = object.jobs?.map((e) => SupportJob.fromPartial(e)) || [];
     return message;
   },
 };
 
-function createBaseGetSupportRequestRequest(): GetSupportRequestRequest {
+function createBaseReplayDeadJobRequest(): ReplayDeadJobRequest {
   return { id: "" };
 }
 
-export const GetSupportRequestRequest: MessageFns<GetSupportRequestRequest> = {
-  encode(message: GetSupportRequestRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
+export const ReplayDeadJobRequest: MessageFns<ReplayDeadJobRequest> = {
+  encode(message: ReplayDeadJobRequest, writer: BinaryWriter = new BinaryWriter()): BinaryWriter {
     if (message.id !== "") {
       writer.uint32(10).string(message.id);
     }
     return writer;
   },
 
-  decode(input: BinaryReader | Uint8Array, length?: number): GetSupportRequestRequest {
+  decode(input: BinaryReader | Uint8Array, length?: number): ReplayDeadJobRequest {
     const reader = input instanceof BinaryReader ? input : new BinaryReader(input);
     let end = length === undefined ? reader.len : reader.pos + length;
-    const message = createBaseGetSupportRequestRequest();
+    const message = createBaseReplayDeadJobRequest();
     while (reader.pos < end) {
       const tag = reader.uint32();
       switch (tag >>> 3) {
@@ -3920,11 +8005,11 @@ export const GetSupportRequestRequest: MessageFns<GetSupportRequestRequest> = {
     return message;
   },
 
-  fromJSON(object: any): GetSupportRequestRequest {
+  fromJSON(object: any): ReplayDeadJobRequest {
     return { id: isSet(object.id) ? globalThis.String(object.id) : "" };
   },
 
-  toJSON(message: GetSupportRequestRequest): unknown {
+  toJSON(message: ReplayDeadJobRequest): unknown {
     const obj: any = {};
     if (message.id !== "") {
       obj.id = message.id;
@@ -3932,11 +8017,11 @@ export const GetSupportRequestRequest: MessageFns<GetSupportRequestRequest> = {
     return obj;
   },
 
-  create<I extends Exact<DeepPartial<GetSupportRequestRequest>, I>>(base?: I): GetSupportRequestRequest {
-    return GetSupportRequestRequest.fromPartial(base ?? ({} as any));
+  create<I extends Exact<DeepPartial<ReplayDeadJobRequest>, I>>(base?: I): ReplayDeadJobRequest {
+    return ReplayDeadJobRequest.fromPartial(base ?? ({} as any));
   },
-  fromPartial<I extends Exact<DeepPartial<GetSupportRequestRequest>, I>>(object: I): GetSupportRequestRequest {
-    const message = createBaseGetSupportRequestRequest();
+  fromPartial<I extends Exact<DeepPartial<ReplayDeadJobRequest>, I>>(object: I): ReplayDeadJobRequest {
+    const message = createBaseReplayDeadJobRequest();
     message.id = object.id ?? "";
     return message;
   },
@@ -4321,10 +8406,52 @@ export const PaymentServiceService = {
     responseSerialize: (value: ChargeResponse) => Buffer.from(ChargeResponse.encode(value).finish()),
     responseDeserialize: (value: Buffer) => ChargeResponse.decode(value),
   },
+  /**
+   * Capture charges the card of an authorization made with
+   * authorize_only.
+   */
+  capture: {
+    path: "/oteldemo.PaymentService/Capture",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: CaptureRequest) => Buffer.from(CaptureRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer) => CaptureRequest.decode(value),
+    responseSerialize: (value: ChargeResponse) => Buffer.from(ChargeResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer) => ChargeResponse.decode(value),
+  },
+  /** Void releases an authorization made with authorize_only. */
+  void: {
+    path: "/oteldemo.PaymentService/Void",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: VoidRequest) => Buffer.from(VoidRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer) => VoidRequest.decode(value),
+    responseSerialize: (value: Empty) => Buffer.from(Empty.encode(value).finish()),
+    responseDeserialize: (value: Buffer) => Empty.decode(value),
+  },
+  /** Refund returns amount of a completed charge to the card. */
+  refund: {
+    path: "/oteldemo.PaymentService/Refund",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: RefundRequest) => Buffer.from(RefundRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer) => RefundRequest.decode(value),
+    responseSerialize: (value: Empty) => Buffer.from(Empty.encode(value).finish()),
+    responseDeserialize: (value: Buffer) => Empty.decode(value),
+  },
 } as const;
 
 export interface PaymentServiceServer extends UntypedServiceImplementation {
   charge: handleUnaryCall<ChargeRequest, ChargeResponse>;
+  /**
+   * Capture charges the card of an authorization made with
+   * authorize_only.
+   */
+  capture: handleUnaryCall<CaptureRequest, ChargeResponse>;
+  /** Void releases an authorization made with authorize_only. */
+  void: handleUnaryCall<VoidRequest, Empty>;
+  /** Refund returns amount of a completed charge to the card. */
+  refund: handleUnaryCall<RefundRequest, Empty>;
 }
 
 export interface PaymentServiceClient extends Client {
@@ -4343,6 +8470,51 @@ export interface PaymentServiceClient extends Client {
     options: Partial<CallOptions>,
     callback: (error: ServiceError | null, response: ChargeResponse) => void,
   ): ClientUnaryCall;
+  /**
+   * Capture charges the card of an authorization made with
+   * authorize_only.
+   */
+  capture(
+    request: CaptureRequest,
+    callback: (error: ServiceError | null, response: ChargeResponse) => void,
+  ): ClientUnaryCall;
+  capture(
+    request: CaptureRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: ChargeResponse) => void,
+  ): ClientUnaryCall;
+  capture(
+    request: CaptureRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: ChargeResponse) => void,
+  ): ClientUnaryCall;
+  /** Void releases an authorization made with authorize_only. */
+  void(request: VoidRequest, callback: (error: ServiceError | null, response: Empty) => void): ClientUnaryCall;
+  void(
+    request: VoidRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: Empty) => void,
+  ): ClientUnaryCall;
+  void(
+    request: VoidRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: Empty) => void,
+  ): ClientUnaryCall;
+  /** Refund returns amount of a completed charge to the card. */
+  refund(request: RefundRequest, callback: (error: ServiceError | null, response: Empty) => void): ClientUnaryCall;
+  refund(
+    request: RefundRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: Empty) => void,
+  ): ClientUnaryCall;
+  refund(
+    request: RefundRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: Empty) => void,
+  ): ClientUnaryCall;
 }
 
 export const PaymentServiceClient = makeGenericClientConstructor(
@@ -4410,10 +8582,88 @@ export const CheckoutServiceService = {
     responseSerialize: (value: PlaceOrderResponse) => Buffer.from(PlaceOrderResponse.encode(value).finish()),
     responseDeserialize: (value: Buffer) => PlaceOrderResponse.decode(value),
   },
+  /**
+   * PreviewOrder prices the cart of a user without placing an order, and
+   * returns a quote token that PlaceOrder honors until it expires.
+   */
+  previewOrder: {
+    path: "/oteldemo.CheckoutService/PreviewOrder",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: PreviewOrderRequest) => Buffer.from(PreviewOrderRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer) => PreviewOrderRequest.decode(value),
+    responseSerialize: (value: PreviewOrderResponse) => Buffer.from(PreviewOrderResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer) => PreviewOrderResponse.decode(value),
+  },
+  /** ApproveOrder captures the payment of an order on hold and ships it. */
+  approveOrder: {
+    path: "/oteldemo.CheckoutService/ApproveOrder",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: ApproveOrderRequest) => Buffer.from(ApproveOrderRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer) => ApproveOrderRequest.decode(value),
+    responseSerialize: (value: ApproveOrderResponse) => Buffer.from(ApproveOrderResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer) => ApproveOrderResponse.decode(value),
+  },
+  /** RejectOrder voids the payment authorization of an order on hold. */
+  rejectOrder: {
+    path: "/oteldemo.CheckoutService/RejectOrder",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: RejectOrderRequest) => Buffer.from(RejectOrderRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer) => RejectOrderRequest.decode(value),
+    responseSerialize: (value: RejectOrderResponse) => Buffer.from(RejectOrderResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer) => RejectOrderResponse.decode(value),
+  },
+  /**
+   * SubmitOrder validates an order and queues it to be placed in the
+   * background, and returns its ID without waiting for it.
+   */
+  submitOrder: {
+    path: "/oteldemo.CheckoutService/SubmitOrder",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: PlaceOrderRequest) => Buffer.from(PlaceOrderRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer) => PlaceOrderRequest.decode(value),
+    responseSerialize: (value: SubmitOrderResponse) => Buffer.from(SubmitOrderResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer) => SubmitOrderResponse.decode(value),
+  },
+  /**
+   * WatchOrder streams the status updates of a submitted order, starting
+   * with those it already went through, until its status is final.
+   */
+  watchOrder: {
+    path: "/oteldemo.CheckoutService/WatchOrder",
+    requestStream: false,
+    responseStream: true,
+    requestSerialize: (value: WatchOrderRequest) => Buffer.from(WatchOrderRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer) => WatchOrderRequest.decode(value),
+    responseSerialize: (value: OrderStatusUpdate) => Buffer.from(OrderStatusUpdate.encode(value).finish()),
+    responseDeserialize: (value: Buffer) => OrderStatusUpdate.decode(value),
+  },
 } as const;
 
 export interface CheckoutServiceServer extends UntypedServiceImplementation {
   placeOrder: handleUnaryCall<PlaceOrderRequest, PlaceOrderResponse>;
+  /**
+   * PreviewOrder prices the cart of a user without placing an order, and
+   * returns a quote token that PlaceOrder honors until it expires.
+   */
+  previewOrder: handleUnaryCall<PreviewOrderRequest, PreviewOrderResponse>;
+  /** ApproveOrder captures the payment of an order on hold and ships it. */
+  approveOrder: handleUnaryCall<ApproveOrderRequest, ApproveOrderResponse>;
+  /** RejectOrder voids the payment authorization of an order on hold. */
+  rejectOrder: handleUnaryCall<RejectOrderRequest, RejectOrderResponse>;
+  /**
+   * SubmitOrder validates an order and queues it to be placed in the
+   * background, and returns its ID without waiting for it.
+   */
+  submitOrder: handleUnaryCall<PlaceOrderRequest, SubmitOrderResponse>;
+  /**
+   * WatchOrder streams the status updates of a submitted order, starting
+   * with those it already went through, until its status is final.
+   */
+  watchOrder: handleServerStreamingCall<WatchOrderRequest, OrderStatusUpdate>;
 }
 
 export interface CheckoutServiceClient extends Client {
@@ -4432,6 +8682,86 @@ export interface CheckoutServiceClient extends Client {
     options: Partial<CallOptions>,
     callback: (error: ServiceError | null, response: PlaceOrderResponse) => void,
   ): ClientUnaryCall;
+  /**
+   * PreviewOrder prices the cart of a user without placing an order, and
+   * returns a quote token that PlaceOrder honors until it expires.
+   */
+  previewOrder(
+    request: PreviewOrderRequest,
+    callback: (error: ServiceError | null, response: PreviewOrderResponse) => void,
+  ): ClientUnaryCall;
+  previewOrder(
+    request: PreviewOrderRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: PreviewOrderResponse) => void,
+  ): ClientUnaryCall;
+  previewOrder(
+    request: PreviewOrderRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: PreviewOrderResponse) => void,
+  ): ClientUnaryCall;
+  /** ApproveOrder captures the payment of an order on hold and ships it. */
+  approveOrder(
+    request: ApproveOrderRequest,
+    callback: (error: ServiceError | null, response: ApproveOrderResponse) => void,
+  ): ClientUnaryCall;
+  approveOrder(
+    request: ApproveOrderRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: ApproveOrderResponse) => void,
+  ): ClientUnaryCall;
+  approveOrder(
+    request: ApproveOrderRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: ApproveOrderResponse) => void,
+  ): ClientUnaryCall;
+  /** RejectOrder voids the payment authorization of an order on hold. */
+  rejectOrder(
+    request: RejectOrderRequest,
+    callback: (error: ServiceError | null, response: RejectOrderResponse) => void,
+  ): ClientUnaryCall;
+  rejectOrder(
+    request: RejectOrderRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: RejectOrderResponse) => void,
+  ): ClientUnaryCall;
+  rejectOrder(
+    request: RejectOrderRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: RejectOrderResponse) => void,
+  ): ClientUnaryCall;
+  /**
+   * SubmitOrder validates an order and queues it to be placed in the
+   * background, and returns its ID without waiting for it.
+   */
+  submitOrder(
+    request: PlaceOrderRequest,
+    callback: (error: ServiceError | null, response: SubmitOrderResponse) => void,
+  ): ClientUnaryCall;
+  submitOrder(
+    request: PlaceOrderRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: SubmitOrderResponse) => void,
+  ): ClientUnaryCall;
+  submitOrder(
+    request: PlaceOrderRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: SubmitOrderResponse) => void,
+  ): ClientUnaryCall;
+  /**
+   * WatchOrder streams the status updates of a submitted order, starting
+   * with those it already went through, until its status is final.
+   */
+  watchOrder(request: WatchOrderRequest, options?: Partial<CallOptions>): ClientReadableStream<OrderStatusUpdate>;
+  watchOrder(
+    request: WatchOrderRequest,
+    metadata?: Metadata,
+    options?: Partial<CallOptions>,
+  ): ClientReadableStream<OrderStatusUpdate>;
 }
 
 export const CheckoutServiceClient = makeGenericClientConstructor(
@@ -4647,11 +8977,45 @@ export const SupportServiceService = {
     responseSerialize: (value: SupportRequest) => Buffer.from(SupportRequest.encode(value).finish()),
     responseDeserialize: (value: Buffer) => SupportRequest.decode(value),
   },
+  listSupportRequests: {
+    path: "/oteldemo.SupportService/ListSupportRequests",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: ListSupportRequestsRequest) =>
+      Buffer.from(ListSupportRequestsRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer) => ListSupportRequestsRequest.decode(value),
+    responseSerialize: (value: ListSupportRequestsResponse) =>
+      Buffer.from(ListSupportRequestsResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer) => ListSupportRequestsResponse.decode(value),
+  },
+  updateSupportRequestStatus: {
+    path: "/oteldemo.SupportService/UpdateSupportRequestStatus",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: UpdateSupportRequestStatusRequest) =>
+      Buffer.from(UpdateSupportRequestStatusRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer) => UpdateSupportRequestStatusRequest.decode(value),
+    responseSerialize: (value: SupportRequest) => Buffer.from(SupportRequest.encode(value).finish()),
+    responseDeserialize: (value: Buffer) => SupportRequest.decode(value),
+  },
+  getSupportIncident: {
+    path: "/oteldemo.SupportService/GetSupportIncident",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: GetSupportIncidentRequest) =>
+      Buffer.from(GetSupportIncidentRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer) => GetSupportIncidentRequest.decode(value),
+    responseSerialize: (value: SupportIncident) => Buffer.from(SupportIncident.encode(value).finish()),
+    responseDeserialize: (value: Buffer) => SupportIncident.decode(value),
+  },
 } as const;
 
 export interface SupportServiceServer extends UntypedServiceImplementation {
   createSupportRequest: handleUnaryCall<CreateSupportRequestRequest, CreateSupportRequestResponse>;
   getSupportRequest: handleUnaryCall<GetSupportRequestRequest, SupportRequest>;
+  listSupportRequests: handleUnaryCall<ListSupportRequestsRequest, ListSupportRequestsResponse>;
+  updateSupportRequestStatus: handleUnaryCall<UpdateSupportRequestStatusRequest, SupportRequest>;
+  getSupportIncident: handleUnaryCall<GetSupportIncidentRequest, SupportIncident>;
 }
 
 export interface SupportServiceClient extends Client {
@@ -4685,6 +9049,51 @@ export interface SupportServiceClient extends Client {
     options: Partial<CallOptions>,
     callback: (error: ServiceError | null, response: SupportRequest) => void,
   ): ClientUnaryCall;
+  listSupportRequests(
+    request: ListSupportRequestsRequest,
+    callback: (error: ServiceError | null, response: ListSupportRequestsResponse) => void,
+  ): ClientUnaryCall;
+  listSupportRequests(
+    request: ListSupportRequestsRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: ListSupportRequestsResponse) => void,
+  ): ClientUnaryCall;
+  listSupportRequests(
+    request: ListSupportRequestsRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: ListSupportRequestsResponse) => void,
+  ): ClientUnaryCall;
+  updateSupportRequestStatus(
+    request: UpdateSupportRequestStatusRequest,
+    callback: (error: ServiceError | null, response: SupportRequest) => void,
+  ): ClientUnaryCall;
+  updateSupportRequestStatus(
+    request: UpdateSupportRequestStatusRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: SupportRequest) => void,
+  ): ClientUnaryCall;
+  updateSupportRequestStatus(
+    request: UpdateSupportRequestStatusRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: SupportRequest) => void,
+  ): ClientUnaryCall;
+  getSupportIncident(
+    request: GetSupportIncidentRequest,
+    callback: (error: ServiceError | null, response: SupportIncident) => void,
+  ): ClientUnaryCall;
+  getSupportIncident(
+    request: GetSupportIncidentRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: SupportIncident) => void,
+  ): ClientUnaryCall;
+  getSupportIncident(
+    request: GetSupportIncidentRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: SupportIncident) => void,
+  ): ClientUnaryCall;
 }
 
 export const SupportServiceClient = makeGenericClientConstructor(
@@ -4696,6 +9105,79 @@ export const SupportServiceClient = makeGenericClientConstructor(
   serviceName: string;
 };
 
+/**
+ * SupportAdminService manages the background jobs that file tickets and send
+ * notifications for support requests.
+ */
+export type SupportAdminServiceService = typeof SupportAdminServiceService;
+export const SupportAdminServiceService = {
+  listDeadJobs: {
+    path: "/oteldemo.SupportAdminService/ListDeadJobs",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: ListDeadJobsRequest) => Buffer.from(ListDeadJobsRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer) => ListDeadJobsRequest.decode(value),
+    responseSerialize: (value: ListDeadJobsResponse) => Buffer.from(ListDeadJobsResponse.encode(value).finish()),
+    responseDeserialize: (value: Buffer) => ListDeadJobsResponse.decode(value),
+  },
+  replayDeadJob: {
+    path: "/oteldemo.SupportAdminService/ReplayDeadJob",
+    requestStream: false,
+    responseStream: false,
+    requestSerialize: (value: ReplayDeadJobRequest) => Buffer.from(ReplayDeadJobRequest.encode(value).finish()),
+    requestDeserialize: (value: Buffer) => ReplayDeadJobRequest.decode(value),
+    responseSerialize: (value: SupportJob) => Buffer.from(SupportJob.encode(value).finish()),
+    responseDeserialize: (value: Buffer) => SupportJob.decode(value),
+  },
+} as const;
+
+export interface SupportAdminServiceServer extends UntypedServiceImplementation {
+  listDeadJobs: handleUnaryCall<ListDeadJobsRequest, ListDeadJobsResponse>;
+  replayDeadJob: handleUnaryCall<ReplayDeadJobRequest, SupportJob>;
+}
+
+export interface SupportAdminServiceClient extends Client {
+  listDeadJobs(
+    request: ListDeadJobsRequest,
+    callback: (error: ServiceError | null, response: ListDeadJobsResponse) => void,
+  ): ClientUnaryCall;
+  listDeadJobs(
+    request: ListDeadJobsRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: ListDeadJobsResponse) => void,
+  ): ClientUnaryCall;
+  listDeadJobs(
+    request: ListDeadJobsRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: ListDeadJobsResponse) => void,
+  ): ClientUnaryCall;
+  replayDeadJob(
+    request: ReplayDeadJobRequest,
+    callback: (error: ServiceError | null, response: SupportJob) => void,
+  ): ClientUnaryCall;
+  replayDeadJob(
+    request: ReplayDeadJobRequest,
+    metadata: Metadata,
+    callback: (error: ServiceError | null, response: SupportJob) => void,
+  ): ClientUnaryCall;
+  replayDeadJob(
+    request: ReplayDeadJobRequest,
+    metadata: Metadata,
+    options: Partial<CallOptions>,
+    callback: (error: ServiceError | null, response: SupportJob) => void,
+  ): ClientUnaryCall;
+}
+
+export const SupportAdminServiceClient = makeGenericClientConstructor(
+  SupportAdminServiceService,
+  "oteldemo.SupportAdminService",
+) as unknown as {
+  new (address: string, credentials: ChannelCredentials, options?: Partial<ClientOptions>): SupportAdminServiceClient;
+  service: typeof SupportAdminServiceService;
+  serviceName: string;
+};
+
 type Builtin = Date | Function | Uint8Array | string | number | boolean | undefined;
 
 export type DeepPartial<T> = T extends Builtin ? T
@@ -0,0 +1,64 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+import type { ServiceError } from '@grpc/grpc-js';
+import {
+  BadRequest,
+  BadRequest_FieldViolation,
+  ErrorInfo,
+  PreconditionFailure,
+  PreconditionFailure_Violation,
+  RequestInfo,
+} from '../protos/google/rpc/error_details';
+import { Status } from '../protos/google/rpc/status';
+
+// The google.rpc status details of a failed gRPC call, with the same shape as
+// the Details decoded by the Go rpcerr package.
+export interface IErrorDetails {
+  code: number;
+  message: string;
+  reason?: string;
+  domain?: string;
+  metadata?: Record<string, string>;
+  fieldViolations?: BadRequest_FieldViolation[];
+  preconditionViolations?: PreconditionFailure_Violation[];
+  traceId?: string;
+  spanId?: string;
+}
+
+// decodeErrorDetails decodes the google.rpc.Status a gRPC server sent in the
+// grpc-status-details-bin trailer of a failed call, or returns undefined if
+// it sent none.
+export const decodeErrorDetails = (error: unknown): IErrorDetails | undefined => {
+  const [bin] = (error as Partial<ServiceError> | undefined)?.metadata?.get('grpc-status-details-bin') || [];
+  if (!(bin instanceof Uint8Array)) {
+    return undefined;
+  }
+
+  const { code, message, details: anys } = Status.decode(bin);
+  const details: IErrorDetails = { code, message };
+  anys.forEach(({ typeUrl, value }) => {
+    // the type name of an Any is what follows the last / of its URL
+    switch (typeUrl.slice(typeUrl.lastIndexOf('/') + 1)) {
+      case 'google.rpc.ErrorInfo': {
+        const { reason, domain, metadata } = ErrorInfo.decode(value);
+        Object.assign(details, { reason, domain, metadata });
+        break;
+      }
+      case 'google.rpc.BadRequest':
+        details.fieldViolations = BadRequest.decode(value).fieldViolations;
+        break;
+      case 'google.rpc.PreconditionFailure':
+        details.preconditionViolations = PreconditionFailure.decode(value).violations;
+        break;
+      case 'google.rpc.RequestInfo': {
+        // rpcerr sends the trace and span IDs of the failed call as its
+        // request ID and serving data.
+        const { requestId, servingData } = RequestInfo.decode(value);
+        Object.assign(details, { traceId: requestId, spanId: servingData });
+        break;
+      }
+    }
+  });
+  return details;
+};
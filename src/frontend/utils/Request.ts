@@ -1,6 +1,20 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 
+import { IErrorDetails } from './ErrorDetails';
+
+// RequestError is thrown for responses that are not ok, with the structured
+// error details the API route returned, if any.
+export class RequestError extends Error {
+  details?: IErrorDetails;
+
+  constructor(message: string, details?: IErrorDetails) {
+    super(message);
+    this.name = 'RequestError';
+    this.details = details;
+  }
+}
+
 interface IRequestParams {
   url: string;
   body?: object;
@@ -34,6 +48,7 @@ const request = async <T>({
   if (!response.ok) {
     // Try to parse error response as JSON
     let errorMessage = `HTTP ${response.status}: ${response.statusText}`;
+    let details: IErrorDetails | undefined;
     if (responseText) {
       try {
         const errorData = JSON.parse(responseText);
@@ -41,13 +56,14 @@ const request = async <T>({
         if (errorData.error) {
           errorMessage = errorData.error;
         }
+        details = errorData.details;
       } catch {
         // If JSON parsing fails, use the raw response text
         errorMessage = responseText;
       }
     }
     console.log(`[Request] Throwing error:`, errorMessage);
-    throw new Error(errorMessage);
+    throw new RequestError(errorMessage, details);
   }
 
   if (!!responseText) {
@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package health implements the grpc.health.v1 service on top of readiness
+// checks, so that Check and Watch report whether a service can actually do
+// its work rather than whether the process is up.
+package health
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// DefaultInterval is how often registered checks are evaluated.
+const DefaultInterval = 5 * time.Second
+
+// Check reports whether a single dependency is ready. A nil error means the
+// dependency is ready.
+type Check func(ctx context.Context) error
+
+type namedCheck struct {
+	name  string
+	check Check
+}
+
+// Server serves grpc.health.v1 for a set of gRPC service names. Each service
+// name is SERVING only while all checks registered for it pass. The overall
+// status (empty service name) is SERVING only while every service is.
+type Server struct {
+	*grpchealth.Server
+
+	log      *logrus.Logger
+	interval time.Duration
+
+	mu       sync.Mutex
+	checks   map[string][]namedCheck
+	status   map[string]healthpb.HealthCheckResponse_ServingStatus
+	results  map[string]map[string]bool
+	shutdown bool
+}
+
+// NewServer returns a Server evaluating its checks every interval. All
+// services report NOT_SERVING until the first evaluation.
+func NewServer(log *logrus.Logger, interval time.Duration) *Server {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	s := &Server{
+		Server:   grpchealth.NewServer(),
+		log:      log,
+		interval: interval,
+		checks:   make(map[string][]namedCheck),
+		status:   make(map[string]healthpb.HealthCheckResponse_ServingStatus),
+		results:  make(map[string]map[string]bool),
+	}
+	s.Server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	return s
+}
+
+// AddCheck registers a readiness check for the given gRPC service name, as
+// found in the generated ServiceDesc (e.g. "oteldemo.CheckoutService").
+func (s *Server) AddCheck(service, name string, check Check) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checks[service] = append(s.checks[service], namedCheck{name: name, check: check})
+	if _, ok := s.status[service]; !ok {
+		s.status[service] = healthpb.HealthCheckResponse_NOT_SERVING
+		s.results[service] = make(map[string]bool)
+		s.Server.SetServingStatus(service, healthpb.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
+// Start evaluates the checks once and then keeps re-evaluating them in the
+// background until ctx is done or Shutdown is called.
+func (s *Server) Start(ctx context.Context) {
+	s.Evaluate(ctx)
+
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if s.isShutdown() {
+					return
+				}
+				s.Evaluate(ctx)
+			}
+		}
+	}()
+}
+
+// Evaluate runs every registered check once and publishes the resulting
+// serving status of each service. Watch streams are notified of transitions.
+func (s *Server) Evaluate(ctx context.Context) {
+	s.mu.Lock()
+	services := make(map[string][]namedCheck, len(s.checks))
+	for service, checks := range s.checks {
+		services[service] = append([]namedCheck(nil), checks...)
+	}
+	s.mu.Unlock()
+
+	results := make(map[string]map[string]error, len(services))
+	for service, checks := range services {
+		results[service] = make(map[string]error, len(checks))
+		for _, c := range checks {
+			checkCtx, cancel := context.WithTimeout(ctx, s.interval)
+			results[service][c.name] = c.check(checkCtx)
+			cancel()
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.shutdown {
+		return
+	}
+
+	overall := healthpb.HealthCheckResponse_SERVING
+	for _, service := range sortedKeys(results) {
+		next := healthpb.HealthCheckResponse_SERVING
+		for name, err := range results[service] {
+			s.results[service][name] = err == nil
+			if err != nil {
+				next = healthpb.HealthCheckResponse_NOT_SERVING
+				s.log.Debugf("health check %q for %q failed: %v", name, service, err)
+			}
+		}
+		if next != healthpb.HealthCheckResponse_SERVING {
+			overall = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		if prev := s.status[service]; prev != next {
+			s.log.Infof("health status of %q changed from %s to %s", service, prev, next)
+			s.status[service] = next
+		}
+		s.Server.SetServingStatus(service, next)
+	}
+	if prev, ok := s.status[""]; !ok || prev != overall {
+		s.log.Infof("overall health status changed to %s", overall)
+		s.status[""] = overall
+	}
+	s.Server.SetServingStatus("", overall)
+}
+
+// Shutdown sets every service to NOT_SERVING and stops further evaluation.
+// It should be called before the gRPC server starts draining so that
+// clients watching health move their traffic elsewhere.
+func (s *Server) Shutdown() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.shutdown = true
+	for service := range s.status {
+		s.status[service] = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+	s.Server.Shutdown()
+	s.log.Info("health status set to NOT_SERVING for shutdown")
+}
+
+func (s *Server) isShutdown() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.shutdown
+}
+
+// RegisterMetrics exports the serving status of every service as the
+// app.health.status gauge (1 serving, 0 not serving) and the result of every
+// check as the app.health.check.status gauge.
+func (s *Server) RegisterMetrics(meter metric.Meter) error {
+	statusGauge, err := meter.Int64ObservableGauge("app.health.status",
+		metric.WithDescription("Serving status of the gRPC service, 1 when SERVING"),
+	)
+	if err != nil {
+		return err
+	}
+	checkGauge, err := meter.Int64ObservableGauge("app.health.check.status",
+		metric.WithDescription("Result of the last readiness check, 1 when passing"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for service, st := range s.status {
+			o.ObserveInt64(statusGauge, boolToInt(st == healthpb.HealthCheckResponse_SERVING),
+				metric.WithAttributes(attribute.String("rpc.service", service)))
+		}
+		for service, checks := range s.results {
+			for name, ok := range checks {
+				o.ObserveInt64(checkGauge, boolToInt(ok && !s.shutdown),
+					metric.WithAttributes(
+						attribute.String("rpc.service", service),
+						attribute.String("app.health.check", name),
+					))
+			}
+		}
+		return nil
+	}, statusGauge, checkGauge)
+	return err
+}
+
+// ConnCheck reports a downstream gRPC client connection as not ready while
+// it is failing to connect. Idle connections are asked to connect so that
+// the next evaluation reflects whether the peer is reachable.
+func ConnCheck(conn *grpc.ClientConn) Check {
+	return func(context.Context) error {
+		switch state := conn.GetState(); state {
+		case connectivity.Idle:
+			conn.Connect()
+			return nil
+		case connectivity.TransientFailure, connectivity.Shutdown:
+			return errors.New("connection to " + conn.Target() + " is " + state.String())
+		default:
+			return nil
+		}
+	}
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
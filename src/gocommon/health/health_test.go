@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package health
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func newTestServer(t *testing.T) (*Server, healthpb.HealthClient) {
+	t.Helper()
+
+	log := logrus.New()
+	log.Out = io.Discard
+	hs := NewServer(log, time.Hour)
+
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, hs)
+	go func() { _ = srv.Serve(lis) }()
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return hs, healthpb.NewHealthClient(conn)
+}
+
+func checkStatus(t *testing.T, client healthpb.HealthClient, service string) healthpb.HealthCheckResponse_ServingStatus {
+	t.Helper()
+	resp, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+	if err != nil {
+		t.Fatalf("Check(%q) error = %v", service, err)
+	}
+	return resp.GetStatus()
+}
+
+func TestCheckFollowsReadiness(t *testing.T) {
+	hs, client := newTestServer(t)
+
+	var ready atomic.Bool
+	hs.AddCheck("oteldemo.ProductCatalogService", "catalog", func(context.Context) error {
+		if !ready.Load() {
+			return errors.New("catalog is empty")
+		}
+		return nil
+	})
+
+	if got := checkStatus(t, client, "oteldemo.ProductCatalogService"); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("before evaluation status = %s, want NOT_SERVING", got)
+	}
+
+	hs.Evaluate(context.Background())
+	if got := checkStatus(t, client, ""); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("overall status with failing check = %s, want NOT_SERVING", got)
+	}
+
+	ready.Store(true)
+	hs.Evaluate(context.Background())
+	if got := checkStatus(t, client, "oteldemo.ProductCatalogService"); got != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("status with passing check = %s, want SERVING", got)
+	}
+	if got := checkStatus(t, client, ""); got != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("overall status with passing check = %s, want SERVING", got)
+	}
+}
+
+func TestWatchStreamsTransitions(t *testing.T) {
+	hs, client := newTestServer(t)
+
+	var ready atomic.Bool
+	ready.Store(true)
+	hs.AddCheck("oteldemo.CheckoutService", "kafka", func(context.Context) error {
+		if !ready.Load() {
+			return errors.New("broker unreachable")
+		}
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.Watch(ctx, &healthpb.HealthCheckRequest{Service: "oteldemo.CheckoutService"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []healthpb.HealthCheckResponse_ServingStatus{
+		healthpb.HealthCheckResponse_NOT_SERVING,
+		healthpb.HealthCheckResponse_SERVING,
+		healthpb.HealthCheckResponse_NOT_SERVING,
+		healthpb.HealthCheckResponse_SERVING,
+		healthpb.HealthCheckResponse_NOT_SERVING,
+	}
+	steps := []func(){
+		func() { hs.Evaluate(ctx) },
+		func() { ready.Store(false); hs.Evaluate(ctx) },
+		func() { ready.Store(true); hs.Evaluate(ctx) },
+		hs.Shutdown,
+	}
+
+	for i, w := range want {
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatalf("Recv #%d error = %v", i, err)
+		}
+		if resp.GetStatus() != w {
+			t.Errorf("transition #%d = %s, want %s", i, resp.GetStatus(), w)
+		}
+		if i < len(steps) {
+			steps[i]()
+		}
+	}
+
+	// Evaluations after shutdown must not flip the status back.
+	hs.Evaluate(ctx)
+	if got := checkStatus(t, client, "oteldemo.CheckoutService"); got != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("status after shutdown = %s, want NOT_SERVING", got)
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package lifecycle runs a gRPC server until the process is asked to stop
+// and then shuts the service down in a fixed order: health goes NOT_SERVING,
+// in-flight RPCs drain, buffered work is flushed, client connections close and
+// finally the telemetry exporters flush whatever the shutdown itself produced.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc"
+
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/health"
+)
+
+// DefaultTimeout bounds the whole shutdown sequence. It stays below the 30s
+// grace period Docker and Kubernetes give a container before killing it.
+const DefaultTimeout = 20 * time.Second
+
+// Stage orders shutdown hooks. Stages run in ascending order and hooks within
+// a stage run in the order they were registered.
+type Stage int
+
+const (
+	// StageFlush is for buffered work that must not be lost, such as the
+	// Kafka producer or an outbox.
+	StageFlush Stage = iota
+	// StageClients is for closing connections to downstream services.
+	StageClients
+	// StageTelemetry is for flushing and stopping telemetry providers. It runs
+	// last so the spans and metrics emitted while shutting down are exported.
+	StageTelemetry
+)
+
+type hook struct {
+	stage Stage
+	name  string
+	fn    func(context.Context) error
+}
+
+// Manager coordinates the shutdown of a single gRPC service.
+type Manager struct {
+	log     *logrus.Logger
+	timeout time.Duration
+	health  *health.Server
+	hooks   []hook
+}
+
+// New returns a Manager that gives the shutdown sequence at most timeout to
+// complete. Half of it is reserved for draining in-flight RPCs.
+func New(log *logrus.Logger, timeout time.Duration) *Manager {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Manager{log: log, timeout: timeout}
+}
+
+// SetHealth makes the manager flip hs to NOT_SERVING before draining.
+func (m *Manager) SetHealth(hs *health.Server) {
+	m.health = hs
+}
+
+// OnShutdown registers fn to run during the given stage of the shutdown.
+func (m *Manager) OnShutdown(stage Stage, name string, fn func(context.Context) error) {
+	m.hooks = append(m.hooks, hook{stage: stage, name: name, fn: fn})
+}
+
+// OnShutdownClose is a convenience for registering a Close method, such as
+// the one of a *grpc.ClientConn, in the StageClients stage.
+func (m *Manager) OnShutdownClose(name string, closer interface{ Close() error }) {
+	m.OnShutdown(StageClients, name, func(context.Context) error { return closer.Close() })
+}
+
+// Serve serves srv on lis until ctx is done, SIGINT or SIGTERM is received or
+// the server fails, and then runs the shutdown sequence. It returns the error
+// the server failed with, if any.
+func (m *Manager) Serve(ctx context.Context, srv *grpc.Server, lis net.Listener) error {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(lis)
+	}()
+
+	var err error
+	select {
+	case err = <-serveErr:
+		m.log.Errorf("gRPC server failed: %v", err)
+	case <-ctx.Done():
+		m.log.Infof("shutdown requested: %v", context.Cause(ctx))
+	}
+
+	m.Shutdown(srv)
+	if errors.Is(err, grpc.ErrServerStopped) {
+		err = nil
+	}
+	return err
+}
+
+// Shutdown runs the shutdown sequence for srv. Hook failures are logged and
+// do not prevent later hooks from running.
+func (m *Manager) Shutdown(srv *grpc.Server) {
+	start := time.Now()
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	if m.health != nil {
+		m.health.Shutdown()
+	}
+
+	drained := m.drain(srv, m.timeout/2)
+
+	for _, stage := range []Stage{StageFlush, StageClients} {
+		m.runStage(ctx, stage)
+	}
+
+	duration := time.Since(start)
+	m.recordDuration(ctx, duration, drained)
+	m.log.Infof("shutdown completed in %s (drained=%t)", duration, drained)
+
+	m.runStage(ctx, StageTelemetry)
+}
+
+func (m *Manager) drain(srv *grpc.Server, timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		m.log.Info("gRPC server drained")
+		return true
+	case <-time.After(timeout):
+		m.log.Warnf("gRPC server did not drain within %s, cancelling in-flight RPCs", timeout)
+		srv.Stop()
+		<-done
+		return false
+	}
+}
+
+func (m *Manager) runStage(ctx context.Context, stage Stage) {
+	for _, h := range m.hooks {
+		if h.stage != stage {
+			continue
+		}
+		start := time.Now()
+		if err := h.fn(ctx); err != nil {
+			m.log.Errorf("shutdown of %s failed after %s: %v", h.name, time.Since(start), err)
+			continue
+		}
+		m.log.Infof("shutdown of %s completed in %s", h.name, time.Since(start))
+	}
+}
+
+func (m *Manager) recordDuration(ctx context.Context, d time.Duration, drained bool) {
+	hist, err := otel.Meter("github.com/open-telemetry/opentelemetry-demo/src/gocommon/lifecycle").Float64Histogram(
+		"app.shutdown.duration",
+		metric.WithDescription("Time spent draining RPCs and flushing buffered work on shutdown"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		m.log.Warnf("failed to create shutdown duration histogram: %v", err)
+		return
+	}
+	hist.Record(ctx, d.Seconds(), metric.WithAttributes(attribute.Bool("app.shutdown.drained", drained)))
+}
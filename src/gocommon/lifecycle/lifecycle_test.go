@@ -0,0 +1,121 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/health"
+)
+
+func quietLogger() *logrus.Logger {
+	log := logrus.New()
+	log.Out = io.Discard
+	return log
+}
+
+func TestServeRunsStagesInOrder(t *testing.T) {
+	log := quietLogger()
+	m := New(log, time.Second)
+
+	var order []string
+	record := func(name string) func(context.Context) error {
+		return func(context.Context) error {
+			order = append(order, name)
+			return nil
+		}
+	}
+	m.OnShutdown(StageTelemetry, "tracer", record("tracer"))
+	m.OnShutdown(StageClients, "cart", record("cart"))
+	m.OnShutdown(StageFlush, "kafka", func(context.Context) error {
+		order = append(order, "kafka")
+		return errors.New("broker gone")
+	})
+	m.OnShutdown(StageClients, "payment", record("payment"))
+
+	hs := health.NewServer(log, time.Hour)
+	hs.AddCheck("oteldemo.CheckoutService", "ok", func(context.Context) error { return nil })
+	hs.Evaluate(context.Background())
+	m.SetHealth(hs)
+
+	srv := grpc.NewServer()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- m.Serve(ctx, srv, bufconn.Listen(1024)) }()
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Serve() error = %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Serve() did not return after cancellation")
+	}
+
+	want := []string{"kafka", "cart", "payment", "tracer"}
+	if len(order) != len(want) {
+		t.Fatalf("hooks ran %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("hooks ran %v, want %v", order, want)
+		}
+	}
+
+	resp, err := hs.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("health after shutdown = %s, want NOT_SERVING", resp.GetStatus())
+	}
+}
+
+func TestShutdownCancelsRPCsThatDoNotDrain(t *testing.T) {
+	log := quietLogger()
+	m := New(log, 200*time.Millisecond)
+
+	hs := health.NewServer(log, time.Hour)
+	srv := grpc.NewServer()
+	healthpb.RegisterHealthServer(srv, hs)
+
+	lis := bufconn.Listen(1 << 20)
+	go func() { _ = srv.Serve(lis) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// A Watch stream never completes on its own, so it keeps GracefulStop waiting.
+	stream, err := healthpb.NewHealthClient(conn).Watch(context.Background(), &healthpb.HealthCheckRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stream.Recv(); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Now()
+	m.Shutdown(srv)
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Errorf("Shutdown() took %s, want it bounded by the drain timeout", elapsed)
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package logging connects the logrus loggers of the Go services to
+// OpenTelemetry. Entries logged with a context (log.WithContext(ctx)) carry
+// the trace_id and span_id of the active span, and entries at or above the
+// export level are emitted as OTel log records through the logger provider.
+//
+// Levels are configured per service with two environment variables:
+//
+//   - LOG_LEVEL is the minimum level written to stdout.
+//   - LOG_EXPORT_LEVEL is the minimum level emitted as OTel log records. It
+//     defaults to LOG_LEVEL and can only raise, not lower, the threshold.
+package logging
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/contrib/bridges/otellogrus"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	TraceIDKey = "trace_id"
+	SpanIDKey  = "span_id"
+)
+
+// TraceHook adds the trace and span IDs of the span in the entry's context to
+// the entry's fields.
+type TraceHook struct{}
+
+// Levels implements logrus.Hook.
+func (TraceHook) Levels() []logrus.Level { return logrus.AllLevels }
+
+// Fire implements logrus.Hook.
+func (TraceHook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+	sc := trace.SpanContextFromContext(entry.Context)
+	if !sc.IsValid() {
+		return nil
+	}
+	entry.Data[TraceIDKey] = sc.TraceID().String()
+	entry.Data[SpanIDKey] = sc.SpanID().String()
+	return nil
+}
+
+// Setup sets the level of log from LOG_LEVEL, falling back to defaultLevel,
+// and installs the OTel bridge and TraceHook. name is used as the
+// instrumentation scope of the emitted log records.
+func Setup(log *logrus.Logger, name string, provider otellog.LoggerProvider, defaultLevel logrus.Level) error {
+	level, err := levelFromEnv("LOG_LEVEL", defaultLevel)
+	if err != nil {
+		return err
+	}
+	exportLevel, err := levelFromEnv("LOG_EXPORT_LEVEL", level)
+	if err != nil {
+		return err
+	}
+
+	log.SetLevel(level)
+	// The bridge reads the span from the entry context itself, so it is
+	// registered before TraceHook to keep the IDs out of the record attributes.
+	log.AddHook(otellogrus.NewHook(name,
+		otellogrus.WithLoggerProvider(provider),
+		otellogrus.WithLevels(levelsAtOrAbove(exportLevel)),
+	))
+	log.AddHook(TraceHook{})
+	return nil
+}
+
+func levelFromEnv(key string, fallback logrus.Level) (logrus.Level, error) {
+	v := strings.TrimSpace(os.Getenv(key))
+	if v == "" {
+		return fallback, nil
+	}
+	level, err := logrus.ParseLevel(v)
+	if err != nil {
+		return fallback, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return level, nil
+}
+
+// levelsAtOrAbove returns the levels at least as severe as min. logrus
+// orders levels from most (panic) to least (trace) severe.
+func levelsAtOrAbove(min logrus.Level) []logrus.Level {
+	var levels []logrus.Level
+	for _, l := range logrus.AllLevels {
+		if l <= min {
+			levels = append(levels, l)
+		}
+	}
+	return levels
+}
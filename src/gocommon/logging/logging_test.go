@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+type recordingExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *recordingExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(context.Context) error   { return nil }
+func (e *recordingExporter) ForceFlush(context.Context) error { return nil }
+
+func TestSetupCorrelatesAndExports(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "debug")
+	t.Setenv("LOG_EXPORT_LEVEL", "info")
+
+	exporter := &recordingExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+
+	var out bytes.Buffer
+	log := logrus.New()
+	log.Out = &out
+	log.Formatter = &logrus.JSONFormatter{}
+	if err := Setup(log, "checkout", provider, logrus.InfoLevel); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "PlaceOrder")
+	defer span.End()
+
+	log.WithContext(ctx).Debug("preparing order")
+	log.WithContext(ctx).WithField("app.order.items.count", 2).Info("order placed")
+
+	var first map[string]any
+	if err := json.NewDecoder(&out).Decode(&first); err != nil {
+		t.Fatal(err)
+	}
+	if first[TraceIDKey] != span.SpanContext().TraceID().String() {
+		t.Errorf("%s = %v, want %s", TraceIDKey, first[TraceIDKey], span.SpanContext().TraceID())
+	}
+	if first[SpanIDKey] != span.SpanContext().SpanID().String() {
+		t.Errorf("%s = %v, want %s", SpanIDKey, first[SpanIDKey], span.SpanContext().SpanID())
+	}
+
+	if len(exporter.records) != 1 {
+		t.Fatalf("exported %d records, want only the info entry", len(exporter.records))
+	}
+	rec := exporter.records[0]
+	if rec.Body().AsString() != "order placed" {
+		t.Errorf("record body = %q, want %q", rec.Body().AsString(), "order placed")
+	}
+	if rec.TraceID() != span.SpanContext().TraceID() || rec.SpanID() != span.SpanContext().SpanID() {
+		t.Errorf("record is not correlated with the active span")
+	}
+	rec.WalkAttributes(func(kv otellog.KeyValue) bool {
+		if kv.Key == TraceIDKey || kv.Key == SpanIDKey {
+			t.Errorf("record has redundant attribute %q", kv.Key)
+		}
+		return true
+	})
+}
+
+func TestSetupRejectsInvalidLevel(t *testing.T) {
+	t.Setenv("LOG_LEVEL", "loud")
+
+	if err := Setup(logrus.New(), "support", sdklog.NewLoggerProvider(), logrus.InfoLevel); err == nil {
+		t.Fatal("Setup() error = nil, want error for invalid LOG_LEVEL")
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package rpcerr builds gRPC errors that carry google.rpc status details, and
+// decodes them on the client, so that callers act on structured data rather
+// than on error messages. Every error it builds identifies the trace it was
+// returned in with a RequestInfo detail.
+package rpcerr
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Error returns a status error with code, msg and details. A RequestInfo
+// detail with the trace ID of the span in ctx, if any, is added to the
+// details.
+func Error(ctx context.Context, code codes.Code, msg string, details ...protoadapt.MessageV1) error {
+	if sc := trace.SpanContextFromContext(ctx); sc.HasTraceID() {
+		details = append(details, &errdetails.RequestInfo{
+			RequestId:   sc.TraceID().String(),
+			ServingData: sc.SpanID().String(),
+		})
+	}
+	st := status.New(code, msg)
+	if withDetails, err := st.WithDetails(details...); err == nil {
+		st = withDetails
+	}
+	return st.Err()
+}
+
+// ErrorInfo returns an ErrorInfo detail. metadata lists key value pairs and
+// must have an even length.
+func ErrorInfo(domain, reason string, metadata ...string) *errdetails.ErrorInfo {
+	info := &errdetails.ErrorInfo{Domain: domain, Reason: reason}
+	if len(metadata) > 0 {
+		info.Metadata = make(map[string]string, len(metadata)/2)
+		for i := 0; i+1 < len(metadata); i += 2 {
+			info.Metadata[metadata[i]] = metadata[i+1]
+		}
+	}
+	return info
+}
+
+// FieldViolation is a request field that is invalid.
+type FieldViolation struct {
+	Field       string `json:"field"`
+	Description string `json:"description"`
+}
+
+// PreconditionViolation is a precondition of the request that failed, e.g.
+// a product whose price exceeds a limit.
+type PreconditionViolation struct {
+	Type        string `json:"type"`
+	Subject     string `json:"subject"`
+	Description string `json:"description"`
+}
+
+// BadRequest returns a BadRequest detail listing violations.
+func BadRequest(violations ...FieldViolation) *errdetails.BadRequest {
+	br := &errdetails.BadRequest{}
+	for _, v := range violations {
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{Field: v.Field, Description: v.Description})
+	}
+	return br
+}
+
+// PreconditionFailure returns a PreconditionFailure detail listing
+// violations.
+func PreconditionFailure(violations ...PreconditionViolation) *errdetails.PreconditionFailure {
+	pf := &errdetails.PreconditionFailure{}
+	for _, v := range violations {
+		pf.Violations = append(pf.Violations, &errdetails.PreconditionFailure_Violation{Type: v.Type, Subject: v.Subject, Description: v.Description})
+	}
+	return pf
+}
+
+// Details are the status details of an error, as decoded by Decode.
+type Details struct {
+	Code    codes.Code `json:"code"`
+	Message string     `json:"message"`
+	// Reason, Domain and Metadata come from the ErrorInfo detail.
+	Reason   string            `json:"reason,omitempty"`
+	Domain   string            `json:"domain,omitempty"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+	// FieldViolations come from the BadRequest detail.
+	FieldViolations []FieldViolation `json:"fieldViolations,omitempty"`
+	// PreconditionViolations come from the PreconditionFailure detail.
+	PreconditionViolations []PreconditionViolation `json:"preconditionViolations,omitempty"`
+	// TraceID and SpanID come from the RequestInfo detail.
+	TraceID string `json:"traceId,omitempty"`
+	SpanID  string `json:"spanId,omitempty"`
+}
+
+// Decode returns the details of err. It reports false if err is not a
+// status error; for status errors without details, only Code and Message
+// are set.
+func Decode(err error) (Details, bool) {
+	st, ok := status.FromError(err)
+	if err == nil || !ok {
+		return Details{}, false
+	}
+	d := Details{Code: st.Code(), Message: st.Message()}
+	for _, detail := range st.Details() {
+		switch v := detail.(type) {
+		case *errdetails.ErrorInfo:
+			d.Reason, d.Domain, d.Metadata = v.GetReason(), v.GetDomain(), v.GetMetadata()
+		case *errdetails.BadRequest:
+			for _, fv := range v.GetFieldViolations() {
+				d.FieldViolations = append(d.FieldViolations, FieldViolation{Field: fv.GetField(), Description: fv.GetDescription()})
+			}
+		case *errdetails.PreconditionFailure:
+			for _, pv := range v.GetViolations() {
+				d.PreconditionViolations = append(d.PreconditionViolations, PreconditionViolation{Type: pv.GetType(), Subject: pv.GetSubject(), Description: pv.GetDescription()})
+			}
+		case *errdetails.RequestInfo:
+			d.TraceID, d.SpanID = v.GetRequestId(), v.GetServingData()
+		}
+	}
+	return d, true
+}
@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package rpcerr
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestDecode(t *testing.T) {
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x4b, 0xf9, 0x2f, 0x35},
+		SpanID:  trace.SpanID{0x00, 0xf0, 0x67, 0xaa},
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), sc)
+
+	tests := []struct {
+		name string
+		err  error
+		want Details
+		ok   bool
+	}{
+		{
+			name: "all details",
+			err: Error(ctx, codes.FailedPrecondition, "order contains expensive items",
+				ErrorInfo("checkout.oteldemo", "PRICE_LIMIT_EXCEEDED", "threshold_usd", "25"),
+				PreconditionFailure(PreconditionViolation{Type: "PRICE_LIMIT", Subject: "66VCHSJNUP", Description: "costs $349.95"}),
+				BadRequest(FieldViolation{Field: "email", Description: "is required"}),
+			),
+			want: Details{
+				Code:                   codes.FailedPrecondition,
+				Message:                "order contains expensive items",
+				Reason:                 "PRICE_LIMIT_EXCEEDED",
+				Domain:                 "checkout.oteldemo",
+				Metadata:               map[string]string{"threshold_usd": "25"},
+				FieldViolations:        []FieldViolation{{Field: "email", Description: "is required"}},
+				PreconditionViolations: []PreconditionViolation{{Type: "PRICE_LIMIT", Subject: "66VCHSJNUP", Description: "costs $349.95"}},
+				TraceID:                sc.TraceID().String(),
+				SpanID:                 sc.SpanID().String(),
+			},
+			ok: true,
+		},
+		{
+			name: "without span",
+			err:  Error(context.Background(), codes.Internal, "failed"),
+			want: Details{Code: codes.Internal, Message: "failed"},
+			ok:   true,
+		},
+		{
+			name: "plain status",
+			err:  status.Error(codes.Unavailable, "shipping error"),
+			want: Details{Code: codes.Unavailable, Message: "shipping error"},
+			ok:   true,
+		},
+		{name: "not a status", err: errors.New("boom")},
+		{name: "nil"},
+	}
+	for _, tt := range tests {
+		got, ok := Decode(tt.err)
+		if ok != tt.ok || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Decode() = %+v, %t, want %+v, %t", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
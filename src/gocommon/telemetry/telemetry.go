@@ -0,0 +1,302 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package telemetry bootstraps the OpenTelemetry SDK for the Go services.
+// Traces, metrics and logs are configured from the standard OTEL_*
+// environment variables:
+//
+//   - OTEL_TRACES_EXPORTER, OTEL_METRICS_EXPORTER, OTEL_LOGS_EXPORTER select
+//     "otlp" (default), "console" or "none" for each signal.
+//   - OTEL_EXPORTER_OTLP_PROTOCOL and OTEL_EXPORTER_OTLP_{TRACES,METRICS,LOGS}_PROTOCOL
+//     select "grpc" (default) or "http/protobuf" for the OTLP exporters, which
+//     read their endpoint, headers and timeouts from the environment themselves.
+//   - OTEL_TRACES_SAMPLER and OTEL_TRACES_SAMPLER_ARG select the sampler.
+//   - OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES override the resource.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/runtime"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/propagation"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	sdkresource "go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	exporterOTLP    = "otlp"
+	exporterConsole = "console"
+	exporterNone    = "none"
+
+	protocolGRPC = "grpc"
+	protocolHTTP = "http/protobuf"
+)
+
+// Config describes the service being instrumented.
+type Config struct {
+	// ServiceName is used as service.name unless OTEL_SERVICE_NAME or
+	// OTEL_RESOURCE_ATTRIBUTES set one.
+	ServiceName string
+	// Attributes are added to the resource of every signal. Values from
+	// OTEL_RESOURCE_ATTRIBUTES take precedence.
+	Attributes []attribute.KeyValue
+	// Console is where the "console" exporters write. Defaults to os.Stdout.
+	Console io.Writer
+}
+
+// Telemetry holds the providers configured by Setup. They are also
+// registered as the global providers.
+type Telemetry struct {
+	Resource       *sdkresource.Resource
+	TracerProvider *sdktrace.TracerProvider
+	MeterProvider  *sdkmetric.MeterProvider
+	LoggerProvider *sdklog.LoggerProvider
+}
+
+// Tracer returns a tracer named after the service.
+func (t *Telemetry) Tracer(name string) trace.Tracer {
+	return t.TracerProvider.Tracer(name)
+}
+
+// Setup configures the tracer, meter and logger providers, registers them
+// globally, installs the W3C trace context and baggage propagators and starts
+// the Go runtime metrics. The returned function flushes and stops all of
+// them; it is safe to call even if Setup returned an error.
+func Setup(ctx context.Context, cfg Config) (*Telemetry, func(context.Context) error, error) {
+	if cfg.Console == nil {
+		cfg.Console = os.Stdout
+	}
+
+	var shutdowns []func(context.Context) error
+	shutdown := func(ctx context.Context) error {
+		var errs []error
+		for _, fn := range shutdowns {
+			errs = append(errs, fn(ctx))
+		}
+		return errors.Join(errs...)
+	}
+
+	t := &Telemetry{Resource: newResource(ctx, cfg)}
+
+	sampler, err := samplerFromEnv()
+	if err != nil {
+		return nil, shutdown, err
+	}
+
+	spanExporter, err := newSpanExporter(ctx, cfg.Console)
+	if err != nil {
+		return nil, shutdown, err
+	}
+	traceOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(t.Resource),
+		sdktrace.WithSampler(sampler),
+	}
+	if spanExporter != nil {
+		traceOpts = append(traceOpts, sdktrace.WithBatcher(spanExporter))
+	}
+	t.TracerProvider = sdktrace.NewTracerProvider(traceOpts...)
+	shutdowns = append(shutdowns, t.TracerProvider.Shutdown)
+
+	metricExporter, err := newMetricExporter(ctx, cfg.Console)
+	if err != nil {
+		return nil, shutdown, err
+	}
+	metricOpts := []sdkmetric.Option{sdkmetric.WithResource(t.Resource)}
+	if metricExporter != nil {
+		metricOpts = append(metricOpts, sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter)))
+	}
+	t.MeterProvider = sdkmetric.NewMeterProvider(metricOpts...)
+	shutdowns = append(shutdowns, t.MeterProvider.Shutdown)
+
+	logExporter, err := newLogExporter(ctx, cfg.Console)
+	if err != nil {
+		return nil, shutdown, err
+	}
+	logOpts := []sdklog.LoggerProviderOption{sdklog.WithResource(t.Resource)}
+	if logExporter != nil {
+		logOpts = append(logOpts, sdklog.WithProcessor(sdklog.NewBatchProcessor(logExporter)))
+	}
+	t.LoggerProvider = sdklog.NewLoggerProvider(logOpts...)
+	shutdowns = append(shutdowns, t.LoggerProvider.Shutdown)
+
+	otel.SetTracerProvider(t.TracerProvider)
+	otel.SetMeterProvider(t.MeterProvider)
+	global.SetLoggerProvider(t.LoggerProvider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if err := runtime.Start(
+		runtime.WithMeterProvider(t.MeterProvider),
+		runtime.WithMinimumReadMemStatsInterval(time.Second),
+	); err != nil {
+		return nil, shutdown, fmt.Errorf("failed to start runtime metrics: %w", err)
+	}
+
+	return t, shutdown, nil
+}
+
+func newResource(ctx context.Context, cfg Config) *sdkresource.Resource {
+	attrs := append([]attribute.KeyValue{}, cfg.Attributes...)
+	if cfg.ServiceName != "" {
+		attrs = append(attrs, semconv.ServiceName(cfg.ServiceName))
+	}
+
+	// Detectors later in the list win, so the environment overrides the
+	// defaults supplied by the service. Detection errors only mean that some
+	// attributes are missing, the partial resource is still usable.
+	res, _ := sdkresource.New(ctx,
+		sdkresource.WithSchemaURL(semconv.SchemaURL),
+		sdkresource.WithAttributes(attrs...),
+		sdkresource.WithTelemetrySDK(),
+		sdkresource.WithOS(),
+		sdkresource.WithProcess(),
+		sdkresource.WithContainer(),
+		sdkresource.WithHost(),
+		sdkresource.WithFromEnv(),
+	)
+	return res
+}
+
+func exporterFromEnv(key string) string {
+	v := strings.ToLower(strings.TrimSpace(os.Getenv(key)))
+	if v == "" {
+		return exporterOTLP
+	}
+	return v
+}
+
+func protocolFromEnv(signal string) string {
+	if v := os.Getenv("OTEL_EXPORTER_OTLP_" + signal + "_PROTOCOL"); v != "" {
+		return v
+	}
+	if v := os.Getenv("OTEL_EXPORTER_OTLP_PROTOCOL"); v != "" {
+		return v
+	}
+	return protocolGRPC
+}
+
+func newSpanExporter(ctx context.Context, console io.Writer) (sdktrace.SpanExporter, error) {
+	switch exp := exporterFromEnv("OTEL_TRACES_EXPORTER"); exp {
+	case exporterOTLP:
+		switch proto := protocolFromEnv("TRACES"); proto {
+		case protocolGRPC:
+			return otlptracegrpc.New(ctx)
+		case protocolHTTP:
+			return otlptracehttp.New(ctx)
+		default:
+			return nil, fmt.Errorf("unsupported OTLP traces protocol %q", proto)
+		}
+	case exporterConsole:
+		return stdouttrace.New(stdouttrace.WithWriter(console))
+	case exporterNone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported OTEL_TRACES_EXPORTER %q", exp)
+	}
+}
+
+func newMetricExporter(ctx context.Context, console io.Writer) (sdkmetric.Exporter, error) {
+	switch exp := exporterFromEnv("OTEL_METRICS_EXPORTER"); exp {
+	case exporterOTLP:
+		switch proto := protocolFromEnv("METRICS"); proto {
+		case protocolGRPC:
+			return otlpmetricgrpc.New(ctx)
+		case protocolHTTP:
+			return otlpmetrichttp.New(ctx)
+		default:
+			return nil, fmt.Errorf("unsupported OTLP metrics protocol %q", proto)
+		}
+	case exporterConsole:
+		return stdoutmetric.New(stdoutmetric.WithWriter(console))
+	case exporterNone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported OTEL_METRICS_EXPORTER %q", exp)
+	}
+}
+
+func newLogExporter(ctx context.Context, console io.Writer) (sdklog.Exporter, error) {
+	switch exp := exporterFromEnv("OTEL_LOGS_EXPORTER"); exp {
+	case exporterOTLP:
+		switch proto := protocolFromEnv("LOGS"); proto {
+		case protocolGRPC:
+			return otlploggrpc.New(ctx)
+		case protocolHTTP:
+			return otlploghttp.New(ctx)
+		default:
+			return nil, fmt.Errorf("unsupported OTLP logs protocol %q", proto)
+		}
+	case exporterConsole:
+		return stdoutlog.New(stdoutlog.WithWriter(console))
+	case exporterNone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported OTEL_LOGS_EXPORTER %q", exp)
+	}
+}
+
+// samplerFromEnv builds the sampler named by OTEL_TRACES_SAMPLER. Unlike the
+// SDK's own handling of these variables, invalid values are reported instead
+// of silently falling back to the default.
+func samplerFromEnv() (sdktrace.Sampler, error) {
+	name := strings.ToLower(strings.TrimSpace(os.Getenv("OTEL_TRACES_SAMPLER")))
+	arg := strings.TrimSpace(os.Getenv("OTEL_TRACES_SAMPLER_ARG"))
+
+	ratio := func() (float64, error) {
+		if arg == "" {
+			return 1, nil
+		}
+		r, err := strconv.ParseFloat(arg, 64)
+		if err != nil || r < 0 || r > 1 {
+			return 0, fmt.Errorf("invalid OTEL_TRACES_SAMPLER_ARG %q: want a ratio between 0 and 1", arg)
+		}
+		return r, nil
+	}
+
+	switch name {
+	case "", "parentbased_always_on":
+		return sdktrace.ParentBased(sdktrace.AlwaysSample()), nil
+	case "always_on":
+		return sdktrace.AlwaysSample(), nil
+	case "always_off":
+		return sdktrace.NeverSample(), nil
+	case "parentbased_always_off":
+		return sdktrace.ParentBased(sdktrace.NeverSample()), nil
+	case "traceidratio":
+		r, err := ratio()
+		if err != nil {
+			return nil, err
+		}
+		return sdktrace.TraceIDRatioBased(r), nil
+	case "parentbased_traceidratio":
+		r, err := ratio()
+		if err != nil {
+			return nil, err
+		}
+		return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(r)), nil
+	default:
+		return nil, fmt.Errorf("unsupported OTEL_TRACES_SAMPLER %q", name)
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+)
+
+func TestSetupConsoleExporters(t *testing.T) {
+	t.Setenv("OTEL_TRACES_EXPORTER", "console")
+	t.Setenv("OTEL_METRICS_EXPORTER", "none")
+	t.Setenv("OTEL_LOGS_EXPORTER", "none")
+	t.Setenv("OTEL_SERVICE_NAME", "")
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "service.namespace=opentelemetry-demo")
+
+	var out bytes.Buffer
+	tel, shutdown, err := Setup(context.Background(), Config{
+		ServiceName: "checkout",
+		Attributes:  []attribute.KeyValue{attribute.String("app.component", "test")},
+		Console:     &out,
+	})
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	attrs := tel.Resource.Set()
+	if v, _ := attrs.Value(semconv.ServiceNameKey); v.AsString() != "checkout" {
+		t.Errorf("service.name = %q, want %q", v.AsString(), "checkout")
+	}
+	if v, _ := attrs.Value("service.namespace"); v.AsString() != "opentelemetry-demo" {
+		t.Errorf("service.namespace = %q, want value from OTEL_RESOURCE_ATTRIBUTES", v.AsString())
+	}
+	if v, _ := attrs.Value("app.component"); v.AsString() != "test" {
+		t.Errorf("app.component = %q, want %q", v.AsString(), "test")
+	}
+
+	_, span := tel.Tracer("test").Start(context.Background(), "console-span")
+	span.End()
+
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "console-span") {
+		t.Errorf("console exporter output does not contain the span: %q", out.String())
+	}
+}
+
+func TestServiceNameFromEnvWins(t *testing.T) {
+	t.Setenv("OTEL_SERVICE_NAME", "checkout-canary")
+	t.Setenv("OTEL_RESOURCE_ATTRIBUTES", "")
+
+	res := newResource(context.Background(), Config{ServiceName: "checkout"})
+	if v, _ := res.Set().Value(semconv.ServiceNameKey); v.AsString() != "checkout-canary" {
+		t.Errorf("service.name = %q, want %q", v.AsString(), "checkout-canary")
+	}
+}
+
+func TestSamplerFromEnv(t *testing.T) {
+	tests := []struct {
+		sampler string
+		arg     string
+		want    string
+		wantErr bool
+	}{
+		{sampler: "", want: "ParentBased{root:AlwaysOnSampler"},
+		{sampler: "always_off", want: "AlwaysOffSampler"},
+		{sampler: "traceidratio", arg: "0.25", want: "TraceIDRatioBased{0.25}"},
+		{sampler: "parentbased_traceidratio", arg: "0.5", want: "ParentBased{root:TraceIDRatioBased{0.5}"},
+		{sampler: "traceidratio", arg: "2", wantErr: true},
+		{sampler: "sometimes", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sampler+"/"+tt.arg, func(t *testing.T) {
+			t.Setenv("OTEL_TRACES_SAMPLER", tt.sampler)
+			t.Setenv("OTEL_TRACES_SAMPLER_ARG", tt.arg)
+
+			s, err := samplerFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("samplerFromEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && !strings.HasPrefix(s.Description(), tt.want) {
+				t.Errorf("sampler = %q, want prefix %q", s.Description(), tt.want)
+			}
+		})
+	}
+}
+
+func TestUnsupportedExporter(t *testing.T) {
+	t.Setenv("OTEL_TRACES_EXPORTER", "zipkin")
+
+	if _, _, err := Setup(context.Background(), Config{ServiceName: "checkout"}); err == nil {
+		t.Fatal("Setup() error = nil, want error for unsupported exporter")
+	}
+}
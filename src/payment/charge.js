@@ -1,6 +1,7 @@
 // Copyright The OpenTelemetry Authors
 // SPDX-License-Identifier: Apache-2.0
 const { context, propagation, trace, metrics } = require('@opentelemetry/api');
+const grpc = require('@grpc/grpc-js');
 const cardValidator = require('simple-card-validator');
 const { v4: uuidv4 } = require('uuid');
 
@@ -15,6 +16,16 @@ const transactionsCounter = meter.createCounter('app.payment.transactions');
 
 const LOYALTY_LEVEL = ['platinum', 'gold', 'silver', 'bronze'];
 
+/** Authorizations made with authorizeOnly, by transaction ID, until they are captured or voided. */
+const authorizations = new Map();
+
+/** Error with a gRPC status code, passed as is to the callback of a handler. */
+function grpcError(code, message) {
+  const err = new Error(message);
+  err.code = code;
+  return err;
+}
+
 /** Return random element from given array */
 function random(arr) {
   const index = Math.floor(Math.random() * arr.length);
@@ -38,18 +49,26 @@ module.exports.charge = async request => {
     }
   }
 
-  const {
-    creditCardNumber: number,
-    creditCardExpirationYear: year,
-    creditCardExpirationMonth: month
-  } = request.creditCard;
   const currentMonth = new Date().getMonth() + 1;
   const currentYear = new Date().getFullYear();
-  const lastFourDigits = number.substr(-4);
   const transactionId = uuidv4();
 
-  const card = cardValidator(number);
-  const { card_type: cardType, valid } = card.getCardDetails();
+  // Checkout sends tokenized cards, already validated, without their numbers.
+  let cardType, lastFourDigits, year, month, valid;
+  if (request.card && request.card.token) {
+    ({ brand: cardType, lastFour: lastFourDigits, expirationYear: year, expirationMonth: month } = request.card);
+    valid = true;
+  } else {
+    const {
+      creditCardNumber: number,
+      creditCardExpirationYear,
+      creditCardExpirationMonth
+    } = request.creditCard;
+    year = creditCardExpirationYear;
+    month = creditCardExpirationMonth;
+    lastFourDigits = number.substr(-4);
+    ({ card_type: cardType, valid } = cardValidator(number).getCardDetails());
+  }
 
   const loyalty_level = random(LOYALTY_LEVEL);
 
@@ -80,9 +99,56 @@ module.exports.charge = async request => {
   }
 
   const { units, nanos, currencyCode } = request.amount;
+  if (request.authorizeOnly) {
+    authorizations.set(transactionId, { amount: request.amount, cardType, lastFourDigits });
+    span.setAttributes({ 'app.payment.charged': false, 'app.payment.authorized': true });
+    logger.info({ transactionId, cardType, lastFourDigits, amount: { units, nanos, currencyCode } }, 'Transaction authorized.');
+    span.end();
+    return { transactionId };
+  }
+
   logger.info({ transactionId, cardType, lastFourDigits, amount: { units, nanos, currencyCode }, loyalty_level }, 'Transaction complete.');
   transactionsCounter.add(1, { 'app.payment.currency': currencyCode });
   span.end();
 
   return { transactionId };
 };
+
+/** Charge the card of an authorization made with authorizeOnly. */
+module.exports.capture = async request => {
+  const { transactionId } = request;
+  const authorization = authorizations.get(transactionId);
+  if (!authorization) {
+    throw grpcError(grpc.status.NOT_FOUND, `No authorization with transaction ID ${transactionId}.`);
+  }
+  authorizations.delete(transactionId);
+
+  const { amount: { units, nanos, currencyCode }, cardType, lastFourDigits } = authorization;
+  logger.info({ transactionId, cardType, lastFourDigits, amount: { units, nanos, currencyCode } }, 'Transaction captured.');
+  transactionsCounter.add(1, { 'app.payment.currency': currencyCode });
+  return { transactionId };
+};
+
+/** Release an authorization made with authorizeOnly. */
+module.exports.void = async request => {
+  const { transactionId } = request;
+  if (!authorizations.delete(transactionId)) {
+    throw grpcError(grpc.status.NOT_FOUND, `No authorization with transaction ID ${transactionId}.`);
+  }
+  logger.info({ transactionId }, 'Transaction voided.');
+  return {};
+};
+
+/**
+ * Return an amount of a completed charge to the card. Completed charges are not kept, so the
+ * transaction is trusted as is.
+ */
+module.exports.refund = async request => {
+  const { transactionId, amount } = request;
+  if (!transactionId || !amount) {
+    throw grpcError(grpc.status.INVALID_ARGUMENT, 'A refund needs a transaction ID and an amount.');
+  }
+  const { units, nanos, currencyCode } = amount;
+  logger.info({ transactionId, amount: { units, nanos, currencyCode } }, 'Transaction refunded.');
+  return {};
+};
@@ -31,6 +31,54 @@ async function chargeServiceHandler(call, callback) {
   }
 }
 
+async function captureServiceHandler(call, callback) {
+  const span = opentelemetry.trace.getActiveSpan();
+
+  try {
+    span.setAttributes({ 'app.payment.transaction.id': call.request.transactionId })
+    callback(null, await charge.capture(call.request))
+  } catch (err) {
+    logger.warn({ err })
+
+    span.recordException(err)
+    span.setStatus({ code: opentelemetry.SpanStatusCode.ERROR })
+
+    callback(err)
+  }
+}
+
+async function voidServiceHandler(call, callback) {
+  const span = opentelemetry.trace.getActiveSpan();
+
+  try {
+    span.setAttributes({ 'app.payment.transaction.id': call.request.transactionId })
+    callback(null, await charge.void(call.request))
+  } catch (err) {
+    logger.warn({ err })
+
+    span.recordException(err)
+    span.setStatus({ code: opentelemetry.SpanStatusCode.ERROR })
+
+    callback(err)
+  }
+}
+
+async function refundServiceHandler(call, callback) {
+  const span = opentelemetry.trace.getActiveSpan();
+
+  try {
+    span.setAttributes({ 'app.payment.transaction.id': call.request.transactionId })
+    callback(null, await charge.refund(call.request))
+  } catch (err) {
+    logger.warn({ err })
+
+    span.recordException(err)
+    span.setStatus({ code: opentelemetry.SpanStatusCode.ERROR })
+
+    callback(err)
+  }
+}
+
 async function closeGracefully(signal) {
   server.forceShutdown()
   process.kill(process.pid, signal)
@@ -43,7 +91,12 @@ server.addService(health.service, new health.Implementation({
   '': health.servingStatus.SERVING
 }))
 
-server.addService(otelDemoPackage.oteldemo.PaymentService.service, { charge: chargeServiceHandler })
+server.addService(otelDemoPackage.oteldemo.PaymentService.service, {
+  charge: chargeServiceHandler,
+  capture: captureServiceHandler,
+  void: voidServiceHandler,
+  refund: refundServiceHandler,
+})
 
 server.bindAsync(`0.0.0.0:${process.env['PAYMENT_PORT']}`, grpc.ServerCredentials.createInsecure(), (err, port) => {
   if (err) {
@@ -8,35 +8,29 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io/fs"
 	"net"
 	"os"
-	"os/signal"
 	"strconv"
 	"strings"
-	"sync"
-	"syscall"
 	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
-	"go.opentelemetry.io/contrib/instrumentation/runtime"
-	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	otelcodes "go.opentelemetry.io/otel/codes"
-	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
-	"go.opentelemetry.io/otel/propagation"
-	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
-	sdkresource "go.opentelemetry.io/otel/sdk/resource"
-	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 
 	otelhooks "github.com/open-feature/go-sdk-contrib/hooks/open-telemetry/pkg"
 	flagd "github.com/open-feature/go-sdk-contrib/providers/flagd/pkg"
 	"github.com/open-feature/go-sdk/openfeature"
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/health"
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/lifecycle"
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/logging"
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/telemetry"
 	pb "github.com/opentelemetry/opentelemetry-demo/src/product-catalog/genproto/oteldemo"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -48,10 +42,8 @@ import (
 )
 
 var (
-	log               *logrus.Logger
-	catalog           []*pb.Product
-	resource          *sdkresource.Resource
-	initResourcesOnce sync.Once
+	log     *logrus.Logger
+	catalog []*pb.Product
 )
 
 const DEFAULT_RELOAD_INTERVAL = 10
@@ -62,81 +54,27 @@ func init() {
 	loadProductCatalog()
 }
 
-func initResource() *sdkresource.Resource {
-	initResourcesOnce.Do(func() {
-		extraResources, _ := sdkresource.New(
-			context.Background(),
-			sdkresource.WithOS(),
-			sdkresource.WithProcess(),
-			sdkresource.WithContainer(),
-			sdkresource.WithHost(),
-		)
-		resource, _ = sdkresource.Merge(
-			sdkresource.Default(),
-			extraResources,
-		)
-	})
-	return resource
-}
-
-func initTracerProvider() *sdktrace.TracerProvider {
-	ctx := context.Background()
+func main() {
+	lm := lifecycle.New(log, lifecycle.DefaultTimeout)
 
-	exporter, err := otlptracegrpc.New(ctx)
+	tel, shutdownTelemetry, err := telemetry.Setup(context.Background(), telemetry.Config{ServiceName: "product-catalog"})
+	lm.OnShutdown(lifecycle.StageTelemetry, "telemetry", shutdownTelemetry)
 	if err != nil {
-		log.Fatalf("OTLP Trace gRPC Creation: %v", err)
+		log.Fatalf("Telemetry Setup: %v", err)
 	}
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
-		sdktrace.WithResource(initResource()),
-	)
-	otel.SetTracerProvider(tp)
-	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
-	return tp
-}
-
-func initMeterProvider() *sdkmetric.MeterProvider {
-	ctx := context.Background()
-
-	exporter, err := otlpmetricgrpc.New(ctx)
-	if err != nil {
-		log.Fatalf("new otlp metric grpc exporter failed: %v", err)
+	if err := logging.Setup(log, "product-catalog", tel.LoggerProvider, logrus.InfoLevel); err != nil {
+		log.Fatalf("Logging Setup: %v", err)
 	}
 
-	mp := sdkmetric.NewMeterProvider(
-		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)),
-		sdkmetric.WithResource(initResource()),
-	)
-	otel.SetMeterProvider(mp)
-	return mp
-}
-
-func main() {
-	tp := initTracerProvider()
-	defer func() {
-		if err := tp.Shutdown(context.Background()); err != nil {
-			log.Fatalf("Tracer Provider Shutdown: %v", err)
-		}
-		log.Println("Shutdown tracer provider")
-	}()
-
-	mp := initMeterProvider()
-	defer func() {
-		if err := mp.Shutdown(context.Background()); err != nil {
-			log.Fatalf("Error shutting down meter provider: %v", err)
-		}
-		log.Println("Shutdown meter provider")
-	}()
 	openfeature.AddHooks(otelhooks.NewTracesHook())
-	err := openfeature.SetProvider(flagd.NewProvider())
-	if err != nil {
-		log.Fatal(err)
-	}
-
-	err = runtime.Start(runtime.WithMinimumReadMemStatsInterval(time.Second))
+	err = openfeature.SetProvider(flagd.NewProvider())
 	if err != nil {
 		log.Fatal(err)
 	}
+	lm.OnShutdown(lifecycle.StageClients, "flagd provider", func(context.Context) error {
+		openfeature.Shutdown()
+		return nil
+	})
 
 	svc := &productCatalog{}
 	var port string
@@ -155,21 +93,26 @@ func main() {
 
 	reflection.Register(srv)
 
-	pb.RegisterProductCatalogServiceServer(srv, svc)
-	healthpb.RegisterHealthServer(srv, svc)
-
-	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM, syscall.SIGKILL)
-	defer cancel()
-
-	go func() {
-		if err := srv.Serve(ln); err != nil {
-			log.Fatalf("Failed to serve gRPC server, err: %v", err)
+	hs := health.NewServer(log, health.DefaultInterval)
+	if err := hs.RegisterMetrics(tel.MeterProvider.Meter("product-catalog")); err != nil {
+		log.Fatal(err)
+	}
+	hs.AddCheck(pb.ProductCatalogService_ServiceDesc.ServiceName, "catalog", func(ctx context.Context) error {
+		if len(catalog) == 0 {
+			return errors.New("product catalog is empty")
 		}
-	}()
+		return nil
+	})
 
-	<-ctx.Done()
+	pb.RegisterProductCatalogServiceServer(srv, svc)
+	healthpb.RegisterHealthServer(srv, hs)
 
-	srv.GracefulStop()
+	hs.Start(context.Background())
+	lm.SetHealth(hs)
+
+	if err := lm.Serve(context.Background(), srv, ln); err != nil {
+		log.Fatalf("Failed to serve gRPC server, err: %v", err)
+	}
 	log.Println("Product Catalog gRPC server stopped")
 }
 
@@ -263,14 +206,6 @@ func mustMapEnv(target *string, key string) {
 	*target = value
 }
 
-func (p *productCatalog) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
-	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
-}
-
-func (p *productCatalog) Watch(req *healthpb.HealthCheckRequest, ws healthpb.Health_WatchServer) error {
-	return status.Errorf(codes.Unimplemented, "health check via Watch not implemented")
-}
-
 func (p *productCatalog) ListProducts(ctx context.Context, req *pb.Empty) (*pb.ListProductsResponse, error) {
 	span := trace.SpanFromContext(ctx)
 
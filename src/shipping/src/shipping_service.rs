@@ -123,6 +123,7 @@ impl ShippingService for ShippingServer {
 
         let parent_cx =
             global::get_text_map_propagator(|prop| prop.extract(&MetadataMap(request.metadata())));
+        let shipping_method = request.get_ref().shipping_method.clone();
         // in this case, generating a tracking ID is trivial
         // we'll create a span and associated events all in this function.
         let tracer = global::tracer("shipping");
@@ -135,6 +136,7 @@ impl ShippingService for ShippingServer {
         span.set_attribute(KeyValue::new(semconv::trace::RPC_METHOD, "ShipOrder"));
 
         span.add_event("Processing shipping order request".to_string(), vec![]);
+        span.set_attribute(KeyValue::new("app.shipping.method", shipping_method));
 
         let tid = create_tracking_id();
         span.set_attribute(KeyValue::new("app.shipping.tracking.id", tid.clone()));
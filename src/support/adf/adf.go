@@ -0,0 +1,300 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package adf renders Atlassian Document Format (ADF), the rich text format
+// of Jira descriptions, from Go templates. Templates produce a small subset
+// of Markdown, which Parse converts to ADF nodes:
+//
+//	# Heading (levels 1 to 6)
+//	- bullet item        1. ordered item
+//	| table | header |   (a |---| row after the header is optional)
+//	```lang              fenced code block
+//	---                  rule
+//	**strong**, `code` and [links](https://example.com) within text
+//
+// Lines of a paragraph are joined with hard breaks; a blank line starts a new
+// paragraph. Values from users should be passed through Escape so that they
+// are rendered as text rather than markup.
+package adf
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Node is an ADF node.
+type Node struct {
+	Type    string         `json:"type"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+	Content []Node         `json:"content,omitempty"`
+	Text    string         `json:"text,omitempty"`
+	Marks   []Mark         `json:"marks,omitempty"`
+}
+
+// Mark formats a text node.
+type Mark struct {
+	Type  string         `json:"type"`
+	Attrs map[string]any `json:"attrs,omitempty"`
+}
+
+// escaped are the characters that Escape protects. They cover both inline
+// markup and the markers that start a block at the beginning of a line.
+const escaped = "\\`*[]|#-."
+
+// Escape protects the markup characters in s, so that it renders as text.
+func Escape(s string) string {
+	var b strings.Builder
+	for _, c := range s {
+		if strings.ContainsRune(escaped, c) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// unescape removes the escapes added by Escape.
+func unescape(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) {
+			i++
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+var (
+	headingLine = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	bulletLine  = regexp.MustCompile(`^[-*]\s+(.*)$`)
+	orderedLine = regexp.MustCompile(`^\d+\.\s+(.*)$`)
+	ruleLine    = regexp.MustCompile(`^-{3,}$`)
+	tableSep    = regexp.MustCompile(`^\|[\s:\-|]+\|?$`)
+)
+
+// Parse converts markup to ADF block nodes.
+func Parse(markup string) []Node {
+	lines := strings.Split(strings.ReplaceAll(markup, "\r\n", "\n"), "\n")
+	var out []Node
+	var paragraph []string
+
+	flush := func() {
+		if len(paragraph) > 0 {
+			if inline := parseLines(paragraph); len(inline) > 0 {
+				out = append(out, Node{Type: "paragraph", Content: inline})
+			}
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		trimmed := strings.TrimLeft(line, " \t")
+
+		switch {
+		case trimmed == "":
+			flush()
+		case strings.HasPrefix(trimmed, "```"):
+			flush()
+			var code []string
+			for i++; i < len(lines) && strings.TrimSpace(lines[i]) != "```"; i++ {
+				code = append(code, lines[i])
+			}
+			node := Node{Type: "codeBlock", Attrs: map[string]any{"language": "text"}}
+			if lang := strings.TrimSpace(strings.TrimPrefix(trimmed, "```")); lang != "" {
+				node.Attrs["language"] = lang
+			}
+			if text := unescape(strings.Join(code, "\n")); text != "" {
+				node.Content = []Node{{Type: "text", Text: text}}
+			}
+			out = append(out, node)
+		case ruleLine.MatchString(trimmed):
+			flush()
+			out = append(out, Node{Type: "rule"})
+		case headingLine.MatchString(trimmed):
+			flush()
+			m := headingLine.FindStringSubmatch(trimmed)
+			out = append(out, Node{
+				Type:    "heading",
+				Attrs:   map[string]any{"level": len(m[1])},
+				Content: parseInline(m[2]),
+			})
+		case bulletLine.MatchString(trimmed), orderedLine.MatchString(trimmed):
+			flush()
+			pattern, listType := bulletLine, "bulletList"
+			if !bulletLine.MatchString(trimmed) {
+				pattern, listType = orderedLine, "orderedList"
+			}
+			list := Node{Type: listType}
+			for ; i < len(lines); i++ {
+				m := pattern.FindStringSubmatch(strings.TrimSpace(lines[i]))
+				if m == nil {
+					break
+				}
+				list.Content = append(list.Content, Node{
+					Type:    "listItem",
+					Content: []Node{{Type: "paragraph", Content: parseInline(m[1])}},
+				})
+			}
+			i--
+			out = append(out, list)
+		case strings.HasPrefix(trimmed, "|"):
+			flush()
+			table := Node{Type: "table", Attrs: map[string]any{"isNumberColumnEnabled": false, "layout": "default"}}
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+				row := strings.TrimSpace(lines[i])
+				if tableSep.MatchString(row) {
+					continue
+				}
+				cellType := "tableCell"
+				if len(table.Content) == 0 {
+					cellType = "tableHeader"
+				}
+				tr := Node{Type: "tableRow"}
+				for _, cell := range splitCells(row) {
+					tr.Content = append(tr.Content, Node{
+						Type:    cellType,
+						Content: []Node{{Type: "paragraph", Content: parseInline(cell)}},
+					})
+				}
+				table.Content = append(table.Content, tr)
+			}
+			i--
+			out = append(out, table)
+		default:
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flush()
+	return out
+}
+
+// splitCells splits a table row on the pipes that are not escaped.
+func splitCells(row string) []string {
+	row = strings.TrimPrefix(row, "|")
+	if strings.HasSuffix(row, "|") && !strings.HasSuffix(row, "\\|") {
+		row = row[:len(row)-1]
+	}
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(row); i++ {
+		switch {
+		case row[i] == '\\' && i+1 < len(row):
+			cell.WriteByte(row[i])
+			cell.WriteByte(row[i+1])
+			i++
+		case row[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(row[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// parseLines parses the lines of a paragraph, separated by hard breaks.
+func parseLines(lines []string) []Node {
+	var out []Node
+	for i, line := range lines {
+		if i > 0 {
+			out = append(out, Node{Type: "hardBreak"})
+		}
+		out = append(out, parseInline(line)...)
+	}
+	return out
+}
+
+// parseInline parses the inline markup of s into text nodes.
+func parseInline(s string) []Node {
+	var out []Node
+	var text strings.Builder
+	strong := false
+
+	emit := func(t string, marks ...Mark) {
+		if t == "" {
+			return
+		}
+		if strong {
+			marks = append([]Mark{{Type: "strong"}}, marks...)
+		}
+		// Merge with the previous node if it is formatted the same way.
+		if n := len(out); n > 0 && out[n-1].Type == "text" && sameMarks(out[n-1].Marks, marks) {
+			out[n-1].Text += t
+			return
+		}
+		out = append(out, Node{Type: "text", Text: t, Marks: marks})
+	}
+	flush := func() {
+		emit(text.String())
+		text.Reset()
+	}
+
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && i+1 < len(s):
+			i++
+			text.WriteByte(s[i])
+		case strings.HasPrefix(s[i:], "**"):
+			flush()
+			strong = !strong
+			i++
+		case s[i] == '`':
+			if end := closing(s, i+1, '`'); end > 0 {
+				flush()
+				emit(unescape(s[i+1:end]), Mark{Type: "code"})
+				i = end
+			} else {
+				text.WriteByte(s[i])
+			}
+		case s[i] == '[':
+			label := closing(s, i+1, ']')
+			if label > 0 && label+1 < len(s) && s[label+1] == '(' {
+				if end := closing(s, label+2, ')'); end > 0 {
+					flush()
+					href := strings.TrimSpace(s[label+2 : end])
+					emit(unescape(s[i+1:label]), Mark{Type: "link", Attrs: map[string]any{"href": href}})
+					i = end
+					continue
+				}
+			}
+			text.WriteByte(s[i])
+		default:
+			text.WriteByte(s[i])
+		}
+	}
+	flush()
+	return out
+}
+
+// closing returns the index of the first unescaped c in s from start, or -1.
+func closing(s string, start int, c byte) int {
+	for i := start; i < len(s); i++ {
+		if s[i] == '\\' {
+			i++
+			continue
+		}
+		if s[i] == c {
+			return i
+		}
+	}
+	return -1
+}
+
+func sameMarks(a, b []Mark) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].Type != b[i].Type || markHref(a[i]) != markHref(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func markHref(m Mark) string {
+	href, _ := m.Attrs["href"].(string)
+	return href
+}
@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adf
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// golden compares the JSON encoding of got with testdata/name, or rewrites
+// the file when the tests run with -update.
+func golden(t *testing.T, name string, got any) {
+	t.Helper()
+	data, err := json.MarshalIndent(got, "", "  ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = append(data, '\n')
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, want) {
+		t.Errorf("%s differs from the golden file (run go test -update to accept):\n%s", name, data)
+	}
+}
+
+func TestParseGolden(t *testing.T) {
+	markup, err := os.ReadFile(filepath.Join("testdata", "markup.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden(t, "markup.golden.json", Parse(string(markup)))
+}
+
+func TestEscape(t *testing.T) {
+	tests := []string{
+		"**not strong** and `not code`",
+		"[not a link](https://example.com)",
+		"# not a heading",
+		"- not a list",
+		"1. not a list",
+		"| not | a table |",
+		"--- not a rule",
+		"```",
+		`back\slash`,
+	}
+	for _, text := range tests {
+		want := []Node{{Type: "paragraph", Content: []Node{{Type: "text", Text: text}}}}
+		if got := Parse(Escape(text)); !reflect.DeepEqual(got, want) {
+			t.Errorf("Parse(Escape(%q)) = %+v, want plain text", text, got)
+		}
+	}
+}
+
+func TestTemplates(t *testing.T) {
+	builtin := fstest.MapFS{
+		"default.tmpl": {Data: []byte("Default for {{.}}")},
+		"bug.tmpl":     {Data: []byte("# Bug\n\n{{.}}")},
+	}
+	custom := fstest.MapFS{
+		"bug.tmpl":  {Data: []byte("# Custom bug\n\n{{.}}")},
+		"notes.txt": {Data: []byte("not a template")},
+	}
+	templates, err := Load(builtin, custom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		issueType string
+		want      string
+	}{
+		{"Bug", "Custom bug"},
+		{"bug", "Custom bug"},
+		{"Task", "Default for x"},
+	}
+	for _, tt := range tests {
+		nodes, err := templates.Render(tt.issueType, "x")
+		if err != nil {
+			t.Fatalf("Render(%s): %v", tt.issueType, err)
+		}
+		if got := nodes[0].Content[0].Text; got != tt.want {
+			t.Errorf("Render(%s) starts with %q, want %q", tt.issueType, got, tt.want)
+		}
+	}
+
+	if _, err := Load(custom); err == nil || !strings.Contains(err.Error(), "default.tmpl") {
+		t.Errorf("Load without a default template: err = %v", err)
+	}
+	if _, err := Load(fstest.MapFS{"default.tmpl": {Data: []byte("{{.Broken")}}); err == nil {
+		t.Error("Load of a malformed template: err = nil")
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package adf
+
+import (
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+)
+
+// DefaultTemplate is used for issue types that have no template of their own.
+const DefaultTemplate = "default"
+
+// Templates renders ADF documents from one template per issue type.
+type Templates struct {
+	byType map[string]*template.Template
+}
+
+// Load reads the *.tmpl files of each file system. A template is named after
+// its file, e.g. bug.tmpl is used for the issue type Bug; templates of later
+// file systems replace those of earlier ones, so that templates on disk can
+// override built-in ones. One of the file systems must provide
+// default.tmpl.
+func Load(fsyss ...fs.FS) (*Templates, error) {
+	t := &Templates{byType: make(map[string]*template.Template)}
+	for _, fsys := range fsyss {
+		names, err := fs.Glob(fsys, "*.tmpl")
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			data, err := fs.ReadFile(fsys, name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read ticket template: %v", err)
+			}
+			issueType := strings.ToLower(strings.TrimSuffix(name, path.Ext(name)))
+			tmpl, err := template.New(name).Option("missingkey=error").Parse(string(data))
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse ticket template %s: %v", name, err)
+			}
+			t.byType[issueType] = tmpl
+		}
+	}
+	if t.byType[DefaultTemplate] == nil {
+		return nil, fmt.Errorf("no %s.tmpl ticket template", DefaultTemplate)
+	}
+	return t, nil
+}
+
+// Render executes the template of issueType, or the default template, with
+// data and converts the result to ADF.
+func (t *Templates) Render(issueType string, data any) ([]Node, error) {
+	tmpl, ok := t.byType[strings.ToLower(issueType)]
+	if !ok {
+		tmpl = t.byType[DefaultTemplate]
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return nil, fmt.Errorf("failed to render ticket template %s: %v", tmpl.Name(), err)
+	}
+	return Parse(b.String()), nil
+}
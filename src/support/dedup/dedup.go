@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dedup fingerprints support requests so that reports of the same
+// failure from different customers can be grouped into one incident.
+package dedup
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	uuidPattern   = regexp.MustCompile(`[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`)
+	hexPattern    = regexp.MustCompile(`\b(?:0x)?[0-9a-f]{8,}\b`)
+	numberPattern = regexp.MustCompile(`\d+(?:[.,]\d+)*`)
+	spacePattern  = regexp.MustCompile(`\s+`)
+)
+
+// Normalize reduces an error message to the parts that identify the
+// failure: it is lowercased, and IDs, hex strings and numbers, which differ
+// between occurrences, are replaced with placeholders.
+func Normalize(msg string) string {
+	msg = strings.ToLower(msg)
+	msg = uuidPattern.ReplaceAllString(msg, "<id>")
+	msg = hexPattern.ReplaceAllString(msg, "<hex>")
+	msg = numberPattern.ReplaceAllString(msg, "<n>")
+	return strings.TrimSpace(spacePattern.ReplaceAllString(msg, " "))
+}
+
+// Fingerprint identifies a failure by the service that failed, the
+// normalized error message and the set of failed products.
+func Fingerprint(service, errorMessage string, productIDs []string) string {
+	ids := make([]string, 0, len(productIDs))
+	seen := make(map[string]bool, len(productIDs))
+	for _, id := range productIDs {
+		if id != "" && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+
+	h := sha256.New()
+	h.Write([]byte(strings.ToLower(service)))
+	h.Write([]byte{0})
+	h.Write([]byte(Normalize(errorMessage)))
+	h.Write([]byte{0})
+	h.Write([]byte(strings.Join(ids, ",")))
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
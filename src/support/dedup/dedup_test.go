@@ -0,0 +1,48 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dedup
+
+import "testing"
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want string
+	}{
+		{"Order contains expensive items", "order contains expensive items"},
+		{"  Order  contains\nexpensive items ", "order contains expensive items"},
+		{"Order 7d0f7b2e-2b9a-4c4e-9d43-3f1a1b2c3d4e failed", "order <id> failed"},
+		{"trace 4bf92f3577b34da6a3ce929d0e0e4736 failed", "trace <hex> failed"},
+		{"Total $1,234.56 exceeds 1000", "total $<n> exceeds <n>"},
+		{"bad facade", "bad facade"},
+	}
+	for _, tt := range tests {
+		if got := Normalize(tt.msg); got != tt.want {
+			t.Errorf("Normalize(%q) = %q, want %q", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	base := Fingerprint("checkout", "Order 1001 contains expensive items", []string{"OLJCESPC7Z", "66VCHSJNUP"})
+
+	tests := []struct {
+		name       string
+		service    string
+		msg        string
+		productIDs []string
+		same       bool
+	}{
+		{"other order number", "checkout", "order 2002 contains expensive items", []string{"OLJCESPC7Z", "66VCHSJNUP"}, true},
+		{"product order and duplicates", "Checkout", "Order 1001 contains expensive items", []string{"66VCHSJNUP", "OLJCESPC7Z", "66VCHSJNUP"}, true},
+		{"other product", "checkout", "Order 1001 contains expensive items", []string{"OLJCESPC7Z"}, false},
+		{"other message", "checkout", "Payment declined", []string{"OLJCESPC7Z", "66VCHSJNUP"}, false},
+		{"other service", "payment", "Order 1001 contains expensive items", []string{"OLJCESPC7Z", "66VCHSJNUP"}, false},
+	}
+	for _, tt := range tests {
+		if got := Fingerprint(tt.service, tt.msg, tt.productIDs); (got == base) != tt.same {
+			t.Errorf("%s: Fingerprint() = %s, base %s, want same %t", tt.name, got, base, tt.same)
+		}
+	}
+}
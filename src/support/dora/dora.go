@@ -0,0 +1,183 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package dora records support incidents with the DORA metrics service and
+// resolves them once the support request is resolved, so that the service
+// can measure the time to restore.
+package dora
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// ErrNotConfigured is returned by New without the URL of the DORA metrics
+// service.
+var ErrNotConfigured = errors.New("DORA metrics service is not configured")
+
+// Config configures the Client. Token, if set, is sent as a bearer token;
+// otherwise Username and Password, if set, are sent with basic auth.
+type Config struct {
+	// URL is the base URL of the DORA metrics service, e.g.
+	// http://dora-metrics:8081.
+	URL      string
+	Token    string
+	Username string
+	Password string
+	// HTTPClient is used for the API calls. It defaults to a client with a
+	// 10 second timeout.
+	HTTPClient *http.Client
+}
+
+// Incident is an incident to be recorded.
+type Incident struct {
+	Title       string
+	Description string
+	// Service is the service that failed, e.g. "checkout".
+	Service  string
+	Severity string
+	// TicketKey is the key of the ticket filed for the incident.
+	TicketKey string
+	// TraceID and SpanID identify the span the incident was reported in.
+	TraceID string
+	SpanID  string
+	// ErrorDetails is the error message of the failure.
+	ErrorDetails string
+	Tags         []string
+}
+
+// Resolution describes how an incident was resolved.
+type Resolution struct {
+	Notes      string
+	ResolvedAt time.Time
+	// TimeToRestore is how long the service was failing, as measured by the
+	// caller.
+	TimeToRestore time.Duration
+}
+
+// Client calls the API of the DORA metrics service.
+type Client struct {
+	cfg    Config
+	client *http.Client
+}
+
+// New returns a Client for cfg.
+func New(cfg Config) (*Client, error) {
+	if cfg.URL == "" {
+		return nil, ErrNotConfigured
+	}
+	if _, err := url.Parse(cfg.URL); err != nil {
+		return nil, fmt.Errorf("invalid DORA metrics URL: %v", err)
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	cfg.URL = strings.TrimRight(cfg.URL, "/")
+	return &Client{cfg: cfg, client: client}, nil
+}
+
+type incidentRequest struct {
+	Title        string   `json:"title"`
+	Description  string   `json:"description"`
+	ServiceName  string   `json:"serviceName"`
+	Severity     string   `json:"severity"`
+	Source       string   `json:"source"`
+	JiraTicketID string   `json:"jiraTicketId,omitempty"`
+	TraceID      string   `json:"traceId,omitempty"`
+	SpanID       string   `json:"spanId,omitempty"`
+	ErrorDetails string   `json:"errorDetails,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+}
+
+type incidentResponse struct {
+	ID string `json:"id"`
+}
+
+// Create records inc and returns the ID the DORA metrics service gave it.
+func (c *Client) Create(ctx context.Context, inc Incident) (string, error) {
+	body := incidentRequest{
+		Title:        inc.Title,
+		Description:  inc.Description,
+		ServiceName:  inc.Service,
+		Severity:     inc.Severity,
+		Source:       "support-request",
+		JiraTicketID: inc.TicketKey,
+		TraceID:      inc.TraceID,
+		SpanID:       inc.SpanID,
+		ErrorDetails: inc.ErrorDetails,
+		Tags:         inc.Tags,
+	}
+	var resp incidentResponse
+	if err := c.do(ctx, http.MethodPost, "/incidents", body, &resp); err != nil {
+		return "", err
+	}
+	if resp.ID == "" {
+		return "", errors.New("DORA API returned no incident ID")
+	}
+	return resp.ID, nil
+}
+
+type resolveRequest struct {
+	Notes                string  `json:"notes"`
+	ResolutionMethod     string  `json:"resolutionMethod"`
+	ResolvedAt           string  `json:"resolvedAt"`
+	TimeToRestoreSeconds float64 `json:"timeToRestoreSeconds"`
+}
+
+// Resolve marks the incident with the given ID as resolved.
+func (c *Client) Resolve(ctx context.Context, id string, r Resolution) error {
+	body := resolveRequest{
+		Notes:                r.Notes,
+		ResolutionMethod:     "support-request",
+		ResolvedAt:           r.ResolvedAt.UTC().Format(time.RFC3339),
+		TimeToRestoreSeconds: r.TimeToRestore.Seconds(),
+	}
+	return c.do(ctx, http.MethodPut, "/incidents/"+url.PathEscape(id)+"/resolve", body, nil)
+}
+
+// do sends body as JSON and decodes the response into out, unless out is nil.
+func (c *Client) do(ctx context.Context, method, path string, body, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal DORA request: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.cfg.URL+path, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create DORA HTTP request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	switch {
+	case c.cfg.Token != "":
+		req.Header.Set("Authorization", "Bearer "+c.cfg.Token)
+	case c.cfg.Username != "" || c.cfg.Password != "":
+		req.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make DORA API request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("DORA API returned status %d: %s", resp.StatusCode, string(data))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode DORA API response: %v", err)
+	}
+	return nil
+}
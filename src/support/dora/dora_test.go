@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package dora
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient(t *testing.T) {
+	type call struct {
+		method, path, auth string
+		body               map[string]any
+	}
+	var calls []call
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		c := call{method: r.Method, path: r.URL.Path, auth: r.Header.Get("Authorization")}
+		json.NewDecoder(r.Body).Decode(&c.body)
+		calls = append(calls, c)
+		if r.URL.Path == "/incidents/missing/resolve" {
+			http.Error(w, `{"error": "Incident missing not found"}`, http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprint(w, `{"id": "inc-1", "status": "open"}`)
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name     string
+		cfg      Config
+		wantAuth string
+	}{
+		{"no auth", Config{URL: srv.URL + "/"}, ""},
+		{"token", Config{URL: srv.URL, Token: "secret", Username: "ignored"}, "Bearer secret"},
+		{"basic", Config{URL: srv.URL, Username: "support", Password: "pw"}, "Basic c3VwcG9ydDpwdw=="},
+	}
+	for _, tt := range tests {
+		calls = nil
+		c, err := New(tt.cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		id, err := c.Create(context.Background(), Incident{Title: "Checkout failed", Service: "payment", Severity: "critical", TicketKey: "DEMO-1", TraceID: "4bf92f3577b34da6a3ce929d0e0e4736"})
+		if err != nil || id != "inc-1" {
+			t.Fatalf("%s: Create() = %q, %v", tt.name, id, err)
+		}
+		if err := c.Resolve(context.Background(), id, Resolution{ResolvedAt: time.Unix(1000, 0), TimeToRestore: 90 * time.Second}); err != nil {
+			t.Fatalf("%s: Resolve() = %v", tt.name, err)
+		}
+		if len(calls) != 2 {
+			t.Fatalf("%s: %d calls, want 2", tt.name, len(calls))
+		}
+		create, resolve := calls[0], calls[1]
+		if create.method != http.MethodPost || create.path != "/incidents" || create.body["serviceName"] != "payment" || create.body["jiraTicketId"] != "DEMO-1" || create.body["traceId"] == nil {
+			t.Errorf("%s: create call = %+v", tt.name, create)
+		}
+		if resolve.method != http.MethodPut || resolve.path != "/incidents/inc-1/resolve" || resolve.body["timeToRestoreSeconds"] != 90.0 {
+			t.Errorf("%s: resolve call = %+v", tt.name, resolve)
+		}
+		for _, c := range calls {
+			if c.auth != tt.wantAuth {
+				t.Errorf("%s: %s %s auth = %q, want %q", tt.name, c.method, c.path, c.auth, tt.wantAuth)
+			}
+		}
+	}
+
+	c, _ := New(Config{URL: srv.URL})
+	if err := c.Resolve(context.Background(), "missing", Resolution{}); err == nil {
+		t.Error("Resolve() of a missing incident: err = nil")
+	}
+	if _, err := New(Config{}); !errors.Is(err, ErrNotConfigured) {
+		t.Errorf("New() without URL: err = %v, want ErrNotConfigured", err)
+	}
+}
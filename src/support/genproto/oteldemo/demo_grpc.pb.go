@@ -771,7 +771,10 @@ var CurrencyService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	PaymentService_Charge_FullMethodName = "/oteldemo.PaymentService/Charge"
+	PaymentService_Charge_FullMethodName  = "/oteldemo.PaymentService/Charge"
+	PaymentService_Capture_FullMethodName = "/oteldemo.PaymentService/Capture"
+	PaymentService_Void_FullMethodName    = "/oteldemo.PaymentService/Void"
+	PaymentService_Refund_FullMethodName  = "/oteldemo.PaymentService/Refund"
 )
 
 // PaymentServiceClient is the client API for PaymentService service.
@@ -779,6 +782,13 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type PaymentServiceClient interface {
 	Charge(ctx context.Context, in *ChargeRequest, opts ...grpc.CallOption) (*ChargeResponse, error)
+	// Capture charges the card of an authorization made with
+	// authorize_only.
+	Capture(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (*ChargeResponse, error)
+	// Void releases an authorization made with authorize_only.
+	Void(ctx context.Context, in *VoidRequest, opts ...grpc.CallOption) (*Empty, error)
+	// Refund returns amount of a completed charge to the card.
+	Refund(ctx context.Context, in *RefundRequest, opts ...grpc.CallOption) (*Empty, error)
 }
 
 type paymentServiceClient struct {
@@ -799,11 +809,48 @@ func (c *paymentServiceClient) Charge(ctx context.Context, in *ChargeRequest, op
 	return out, nil
 }
 
+func (c *paymentServiceClient) Capture(ctx context.Context, in *CaptureRequest, opts ...grpc.CallOption) (*ChargeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChargeResponse)
+	err := c.cc.Invoke(ctx, PaymentService_Capture_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) Void(ctx context.Context, in *VoidRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, PaymentService_Void_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) Refund(ctx context.Context, in *RefundRequest, opts ...grpc.CallOption) (*Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Empty)
+	err := c.cc.Invoke(ctx, PaymentService_Refund_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PaymentServiceServer is the server API for PaymentService service.
 // All implementations must embed UnimplementedPaymentServiceServer
 // for forward compatibility.
 type PaymentServiceServer interface {
 	Charge(context.Context, *ChargeRequest) (*ChargeResponse, error)
+	// Capture charges the card of an authorization made with
+	// authorize_only.
+	Capture(context.Context, *CaptureRequest) (*ChargeResponse, error)
+	// Void releases an authorization made with authorize_only.
+	Void(context.Context, *VoidRequest) (*Empty, error)
+	// Refund returns amount of a completed charge to the card.
+	Refund(context.Context, *RefundRequest) (*Empty, error)
 	mustEmbedUnimplementedPaymentServiceServer()
 }
 
@@ -817,6 +864,15 @@ type UnimplementedPaymentServiceServer struct{}
 func (UnimplementedPaymentServiceServer) Charge(context.Context, *ChargeRequest) (*ChargeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Charge not implemented")
 }
+func (UnimplementedPaymentServiceServer) Capture(context.Context, *CaptureRequest) (*ChargeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Capture not implemented")
+}
+func (UnimplementedPaymentServiceServer) Void(context.Context, *VoidRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Void not implemented")
+}
+func (UnimplementedPaymentServiceServer) Refund(context.Context, *RefundRequest) (*Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Refund not implemented")
+}
 func (UnimplementedPaymentServiceServer) mustEmbedUnimplementedPaymentServiceServer() {}
 func (UnimplementedPaymentServiceServer) testEmbeddedByValue()                        {}
 
@@ -856,6 +912,60 @@ func _PaymentService_Charge_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PaymentService_Capture_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).Capture(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_Capture_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).Capture(ctx, req.(*CaptureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_Void_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoidRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).Void(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_Void_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).Void(ctx, req.(*VoidRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_Refund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).Refund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_Refund_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).Refund(ctx, req.(*RefundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PaymentService_ServiceDesc is the grpc.ServiceDesc for PaymentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -867,6 +977,18 @@ var PaymentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Charge",
 			Handler:    _PaymentService_Charge_Handler,
 		},
+		{
+			MethodName: "Capture",
+			Handler:    _PaymentService_Capture_Handler,
+		},
+		{
+			MethodName: "Void",
+			Handler:    _PaymentService_Void_Handler,
+		},
+		{
+			MethodName: "Refund",
+			Handler:    _PaymentService_Refund_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "demo.proto",
@@ -975,7 +1097,12 @@ var EmailService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	CheckoutService_PlaceOrder_FullMethodName = "/oteldemo.CheckoutService/PlaceOrder"
+	CheckoutService_PlaceOrder_FullMethodName   = "/oteldemo.CheckoutService/PlaceOrder"
+	CheckoutService_PreviewOrder_FullMethodName = "/oteldemo.CheckoutService/PreviewOrder"
+	CheckoutService_ApproveOrder_FullMethodName = "/oteldemo.CheckoutService/ApproveOrder"
+	CheckoutService_RejectOrder_FullMethodName  = "/oteldemo.CheckoutService/RejectOrder"
+	CheckoutService_SubmitOrder_FullMethodName  = "/oteldemo.CheckoutService/SubmitOrder"
+	CheckoutService_WatchOrder_FullMethodName   = "/oteldemo.CheckoutService/WatchOrder"
 )
 
 // CheckoutServiceClient is the client API for CheckoutService service.
@@ -983,6 +1110,19 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type CheckoutServiceClient interface {
 	PlaceOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*PlaceOrderResponse, error)
+	// PreviewOrder prices the cart of a user without placing an order, and
+	// returns a quote token that PlaceOrder honors until it expires.
+	PreviewOrder(ctx context.Context, in *PreviewOrderRequest, opts ...grpc.CallOption) (*PreviewOrderResponse, error)
+	// ApproveOrder captures the payment of an order on hold and ships it.
+	ApproveOrder(ctx context.Context, in *ApproveOrderRequest, opts ...grpc.CallOption) (*ApproveOrderResponse, error)
+	// RejectOrder voids the payment authorization of an order on hold.
+	RejectOrder(ctx context.Context, in *RejectOrderRequest, opts ...grpc.CallOption) (*RejectOrderResponse, error)
+	// SubmitOrder validates an order and queues it to be placed in the
+	// background, and returns its ID without waiting for it.
+	SubmitOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*SubmitOrderResponse, error)
+	// WatchOrder streams the status updates of a submitted order, starting
+	// with those it already went through, until its status is final.
+	WatchOrder(ctx context.Context, in *WatchOrderRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderStatusUpdate], error)
 }
 
 type checkoutServiceClient struct {
@@ -1003,11 +1143,83 @@ func (c *checkoutServiceClient) PlaceOrder(ctx context.Context, in *PlaceOrderRe
 	return out, nil
 }
 
+func (c *checkoutServiceClient) PreviewOrder(ctx context.Context, in *PreviewOrderRequest, opts ...grpc.CallOption) (*PreviewOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PreviewOrderResponse)
+	err := c.cc.Invoke(ctx, CheckoutService_PreviewOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkoutServiceClient) ApproveOrder(ctx context.Context, in *ApproveOrderRequest, opts ...grpc.CallOption) (*ApproveOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApproveOrderResponse)
+	err := c.cc.Invoke(ctx, CheckoutService_ApproveOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkoutServiceClient) RejectOrder(ctx context.Context, in *RejectOrderRequest, opts ...grpc.CallOption) (*RejectOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RejectOrderResponse)
+	err := c.cc.Invoke(ctx, CheckoutService_RejectOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkoutServiceClient) SubmitOrder(ctx context.Context, in *PlaceOrderRequest, opts ...grpc.CallOption) (*SubmitOrderResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SubmitOrderResponse)
+	err := c.cc.Invoke(ctx, CheckoutService_SubmitOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *checkoutServiceClient) WatchOrder(ctx context.Context, in *WatchOrderRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderStatusUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &CheckoutService_ServiceDesc.Streams[0], CheckoutService_WatchOrder_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchOrderRequest, OrderStatusUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CheckoutService_WatchOrderClient = grpc.ServerStreamingClient[OrderStatusUpdate]
+
 // CheckoutServiceServer is the server API for CheckoutService service.
 // All implementations must embed UnimplementedCheckoutServiceServer
 // for forward compatibility.
 type CheckoutServiceServer interface {
 	PlaceOrder(context.Context, *PlaceOrderRequest) (*PlaceOrderResponse, error)
+	// PreviewOrder prices the cart of a user without placing an order, and
+	// returns a quote token that PlaceOrder honors until it expires.
+	PreviewOrder(context.Context, *PreviewOrderRequest) (*PreviewOrderResponse, error)
+	// ApproveOrder captures the payment of an order on hold and ships it.
+	ApproveOrder(context.Context, *ApproveOrderRequest) (*ApproveOrderResponse, error)
+	// RejectOrder voids the payment authorization of an order on hold.
+	RejectOrder(context.Context, *RejectOrderRequest) (*RejectOrderResponse, error)
+	// SubmitOrder validates an order and queues it to be placed in the
+	// background, and returns its ID without waiting for it.
+	SubmitOrder(context.Context, *PlaceOrderRequest) (*SubmitOrderResponse, error)
+	// WatchOrder streams the status updates of a submitted order, starting
+	// with those it already went through, until its status is final.
+	WatchOrder(*WatchOrderRequest, grpc.ServerStreamingServer[OrderStatusUpdate]) error
 	mustEmbedUnimplementedCheckoutServiceServer()
 }
 
@@ -1021,6 +1233,21 @@ type UnimplementedCheckoutServiceServer struct{}
 func (UnimplementedCheckoutServiceServer) PlaceOrder(context.Context, *PlaceOrderRequest) (*PlaceOrderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method PlaceOrder not implemented")
 }
+func (UnimplementedCheckoutServiceServer) PreviewOrder(context.Context, *PreviewOrderRequest) (*PreviewOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewOrder not implemented")
+}
+func (UnimplementedCheckoutServiceServer) ApproveOrder(context.Context, *ApproveOrderRequest) (*ApproveOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveOrder not implemented")
+}
+func (UnimplementedCheckoutServiceServer) RejectOrder(context.Context, *RejectOrderRequest) (*RejectOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectOrder not implemented")
+}
+func (UnimplementedCheckoutServiceServer) SubmitOrder(context.Context, *PlaceOrderRequest) (*SubmitOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SubmitOrder not implemented")
+}
+func (UnimplementedCheckoutServiceServer) WatchOrder(*WatchOrderRequest, grpc.ServerStreamingServer[OrderStatusUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchOrder not implemented")
+}
 func (UnimplementedCheckoutServiceServer) mustEmbedUnimplementedCheckoutServiceServer() {}
 func (UnimplementedCheckoutServiceServer) testEmbeddedByValue()                         {}
 
@@ -1060,6 +1287,89 @@ func _CheckoutService_PlaceOrder_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _CheckoutService_PreviewOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckoutServiceServer).PreviewOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckoutService_PreviewOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckoutServiceServer).PreviewOrder(ctx, req.(*PreviewOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckoutService_ApproveOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckoutServiceServer).ApproveOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckoutService_ApproveOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckoutServiceServer).ApproveOrder(ctx, req.(*ApproveOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckoutService_RejectOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RejectOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckoutServiceServer).RejectOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckoutService_RejectOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckoutServiceServer).RejectOrder(ctx, req.(*RejectOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckoutService_SubmitOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlaceOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CheckoutServiceServer).SubmitOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: CheckoutService_SubmitOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CheckoutServiceServer).SubmitOrder(ctx, req.(*PlaceOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CheckoutService_WatchOrder_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchOrderRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(CheckoutServiceServer).WatchOrder(m, &grpc.GenericServerStream[WatchOrderRequest, OrderStatusUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type CheckoutService_WatchOrderServer = grpc.ServerStreamingServer[OrderStatusUpdate]
+
 // CheckoutService_ServiceDesc is the grpc.ServiceDesc for CheckoutService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1071,8 +1381,30 @@ var CheckoutService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PlaceOrder",
 			Handler:    _CheckoutService_PlaceOrder_Handler,
 		},
+		{
+			MethodName: "PreviewOrder",
+			Handler:    _CheckoutService_PreviewOrder_Handler,
+		},
+		{
+			MethodName: "ApproveOrder",
+			Handler:    _CheckoutService_ApproveOrder_Handler,
+		},
+		{
+			MethodName: "RejectOrder",
+			Handler:    _CheckoutService_RejectOrder_Handler,
+		},
+		{
+			MethodName: "SubmitOrder",
+			Handler:    _CheckoutService_SubmitOrder_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchOrder",
+			Handler:       _CheckoutService_WatchOrder_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "demo.proto",
 }
 
@@ -1433,8 +1765,11 @@ var FeatureFlagService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	SupportService_CreateSupportRequest_FullMethodName = "/oteldemo.SupportService/CreateSupportRequest"
-	SupportService_GetSupportRequest_FullMethodName    = "/oteldemo.SupportService/GetSupportRequest"
+	SupportService_CreateSupportRequest_FullMethodName       = "/oteldemo.SupportService/CreateSupportRequest"
+	SupportService_GetSupportRequest_FullMethodName          = "/oteldemo.SupportService/GetSupportRequest"
+	SupportService_ListSupportRequests_FullMethodName        = "/oteldemo.SupportService/ListSupportRequests"
+	SupportService_UpdateSupportRequestStatus_FullMethodName = "/oteldemo.SupportService/UpdateSupportRequestStatus"
+	SupportService_GetSupportIncident_FullMethodName         = "/oteldemo.SupportService/GetSupportIncident"
 )
 
 // SupportServiceClient is the client API for SupportService service.
@@ -1443,6 +1778,9 @@ const (
 type SupportServiceClient interface {
 	CreateSupportRequest(ctx context.Context, in *CreateSupportRequestRequest, opts ...grpc.CallOption) (*CreateSupportRequestResponse, error)
 	GetSupportRequest(ctx context.Context, in *GetSupportRequestRequest, opts ...grpc.CallOption) (*SupportRequest, error)
+	ListSupportRequests(ctx context.Context, in *ListSupportRequestsRequest, opts ...grpc.CallOption) (*ListSupportRequestsResponse, error)
+	UpdateSupportRequestStatus(ctx context.Context, in *UpdateSupportRequestStatusRequest, opts ...grpc.CallOption) (*SupportRequest, error)
+	GetSupportIncident(ctx context.Context, in *GetSupportIncidentRequest, opts ...grpc.CallOption) (*SupportIncident, error)
 }
 
 type supportServiceClient struct {
@@ -1473,12 +1811,45 @@ func (c *supportServiceClient) GetSupportRequest(ctx context.Context, in *GetSup
 	return out, nil
 }
 
+func (c *supportServiceClient) ListSupportRequests(ctx context.Context, in *ListSupportRequestsRequest, opts ...grpc.CallOption) (*ListSupportRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListSupportRequestsResponse)
+	err := c.cc.Invoke(ctx, SupportService_ListSupportRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supportServiceClient) UpdateSupportRequestStatus(ctx context.Context, in *UpdateSupportRequestStatusRequest, opts ...grpc.CallOption) (*SupportRequest, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SupportRequest)
+	err := c.cc.Invoke(ctx, SupportService_UpdateSupportRequestStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supportServiceClient) GetSupportIncident(ctx context.Context, in *GetSupportIncidentRequest, opts ...grpc.CallOption) (*SupportIncident, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SupportIncident)
+	err := c.cc.Invoke(ctx, SupportService_GetSupportIncident_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SupportServiceServer is the server API for SupportService service.
 // All implementations must embed UnimplementedSupportServiceServer
 // for forward compatibility.
 type SupportServiceServer interface {
 	CreateSupportRequest(context.Context, *CreateSupportRequestRequest) (*CreateSupportRequestResponse, error)
 	GetSupportRequest(context.Context, *GetSupportRequestRequest) (*SupportRequest, error)
+	ListSupportRequests(context.Context, *ListSupportRequestsRequest) (*ListSupportRequestsResponse, error)
+	UpdateSupportRequestStatus(context.Context, *UpdateSupportRequestStatusRequest) (*SupportRequest, error)
+	GetSupportIncident(context.Context, *GetSupportIncidentRequest) (*SupportIncident, error)
 	mustEmbedUnimplementedSupportServiceServer()
 }
 
@@ -1495,6 +1866,15 @@ func (UnimplementedSupportServiceServer) CreateSupportRequest(context.Context, *
 func (UnimplementedSupportServiceServer) GetSupportRequest(context.Context, *GetSupportRequestRequest) (*SupportRequest, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetSupportRequest not implemented")
 }
+func (UnimplementedSupportServiceServer) ListSupportRequests(context.Context, *ListSupportRequestsRequest) (*ListSupportRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSupportRequests not implemented")
+}
+func (UnimplementedSupportServiceServer) UpdateSupportRequestStatus(context.Context, *UpdateSupportRequestStatusRequest) (*SupportRequest, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateSupportRequestStatus not implemented")
+}
+func (UnimplementedSupportServiceServer) GetSupportIncident(context.Context, *GetSupportIncidentRequest) (*SupportIncident, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSupportIncident not implemented")
+}
 func (UnimplementedSupportServiceServer) mustEmbedUnimplementedSupportServiceServer() {}
 func (UnimplementedSupportServiceServer) testEmbeddedByValue()                        {}
 
@@ -1552,6 +1932,60 @@ func _SupportService_GetSupportRequest_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SupportService_ListSupportRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSupportRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupportServiceServer).ListSupportRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupportService_ListSupportRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupportServiceServer).ListSupportRequests(ctx, req.(*ListSupportRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupportService_UpdateSupportRequestStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateSupportRequestStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupportServiceServer).UpdateSupportRequestStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupportService_UpdateSupportRequestStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupportServiceServer).UpdateSupportRequestStatus(ctx, req.(*UpdateSupportRequestStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupportService_GetSupportIncident_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetSupportIncidentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupportServiceServer).GetSupportIncident(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupportService_GetSupportIncident_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupportServiceServer).GetSupportIncident(ctx, req.(*GetSupportIncidentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SupportService_ServiceDesc is the grpc.ServiceDesc for SupportService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1567,6 +2001,164 @@ var SupportService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetSupportRequest",
 			Handler:    _SupportService_GetSupportRequest_Handler,
 		},
+		{
+			MethodName: "ListSupportRequests",
+			Handler:    _SupportService_ListSupportRequests_Handler,
+		},
+		{
+			MethodName: "UpdateSupportRequestStatus",
+			Handler:    _SupportService_UpdateSupportRequestStatus_Handler,
+		},
+		{
+			MethodName: "GetSupportIncident",
+			Handler:    _SupportService_GetSupportIncident_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "demo.proto",
+}
+
+const (
+	SupportAdminService_ListDeadJobs_FullMethodName  = "/oteldemo.SupportAdminService/ListDeadJobs"
+	SupportAdminService_ReplayDeadJob_FullMethodName = "/oteldemo.SupportAdminService/ReplayDeadJob"
+)
+
+// SupportAdminServiceClient is the client API for SupportAdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// SupportAdminService manages the background jobs that file tickets and send
+// notifications for support requests.
+type SupportAdminServiceClient interface {
+	ListDeadJobs(ctx context.Context, in *ListDeadJobsRequest, opts ...grpc.CallOption) (*ListDeadJobsResponse, error)
+	ReplayDeadJob(ctx context.Context, in *ReplayDeadJobRequest, opts ...grpc.CallOption) (*SupportJob, error)
+}
+
+type supportAdminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSupportAdminServiceClient(cc grpc.ClientConnInterface) SupportAdminServiceClient {
+	return &supportAdminServiceClient{cc}
+}
+
+func (c *supportAdminServiceClient) ListDeadJobs(ctx context.Context, in *ListDeadJobsRequest, opts ...grpc.CallOption) (*ListDeadJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListDeadJobsResponse)
+	err := c.cc.Invoke(ctx, SupportAdminService_ListDeadJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *supportAdminServiceClient) ReplayDeadJob(ctx context.Context, in *ReplayDeadJobRequest, opts ...grpc.CallOption) (*SupportJob, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SupportJob)
+	err := c.cc.Invoke(ctx, SupportAdminService_ReplayDeadJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SupportAdminServiceServer is the server API for SupportAdminService service.
+// All implementations must embed UnimplementedSupportAdminServiceServer
+// for forward compatibility.
+//
+// SupportAdminService manages the background jobs that file tickets and send
+// notifications for support requests.
+type SupportAdminServiceServer interface {
+	ListDeadJobs(context.Context, *ListDeadJobsRequest) (*ListDeadJobsResponse, error)
+	ReplayDeadJob(context.Context, *ReplayDeadJobRequest) (*SupportJob, error)
+	mustEmbedUnimplementedSupportAdminServiceServer()
+}
+
+// UnimplementedSupportAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedSupportAdminServiceServer struct{}
+
+func (UnimplementedSupportAdminServiceServer) ListDeadJobs(context.Context, *ListDeadJobsRequest) (*ListDeadJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeadJobs not implemented")
+}
+func (UnimplementedSupportAdminServiceServer) ReplayDeadJob(context.Context, *ReplayDeadJobRequest) (*SupportJob, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReplayDeadJob not implemented")
+}
+func (UnimplementedSupportAdminServiceServer) mustEmbedUnimplementedSupportAdminServiceServer() {}
+func (UnimplementedSupportAdminServiceServer) testEmbeddedByValue()                             {}
+
+// UnsafeSupportAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SupportAdminServiceServer will
+// result in compilation errors.
+type UnsafeSupportAdminServiceServer interface {
+	mustEmbedUnimplementedSupportAdminServiceServer()
+}
+
+func RegisterSupportAdminServiceServer(s grpc.ServiceRegistrar, srv SupportAdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSupportAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&SupportAdminService_ServiceDesc, srv)
+}
+
+func _SupportAdminService_ListDeadJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListDeadJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupportAdminServiceServer).ListDeadJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupportAdminService_ListDeadJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupportAdminServiceServer).ListDeadJobs(ctx, req.(*ListDeadJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SupportAdminService_ReplayDeadJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplayDeadJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SupportAdminServiceServer).ReplayDeadJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SupportAdminService_ReplayDeadJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SupportAdminServiceServer).ReplayDeadJob(ctx, req.(*ReplayDeadJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SupportAdminService_ServiceDesc is the grpc.ServiceDesc for SupportAdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var SupportAdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "oteldemo.SupportAdminService",
+	HandlerType: (*SupportAdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListDeadJobs",
+			Handler:    _SupportAdminService_ListDeadJobs_Handler,
+		},
+		{
+			MethodName: "ReplayDeadJob",
+			Handler:    _SupportAdminService_ReplayDeadJob_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "demo.proto",
@@ -0,0 +1,405 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/opentelemetry/opentelemetry-demo/src/support/dora"
+	pb "github.com/opentelemetry/opentelemetry-demo/src/support/genproto/oteldemo"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/jobs"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/notify"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/redact"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/store"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/ticket"
+)
+
+// Kinds of the background jobs of a support request. A ticket job files the
+// ticket of a new incident and then enqueues one notify job per routed
+// notifier, whose name is the job target, and a dora job. A comment job adds
+// a duplicate request to the ticket of its incident. A dora_resolve job
+// resolves the DORA incident once the request is resolved, and a
+// notify_customer job tells the customer.
+const (
+	jobTicket         = "ticket"
+	jobComment        = "comment"
+	jobNotify         = "notify"
+	jobDora           = "dora"
+	jobDoraResolve    = "dora_resolve"
+	jobNotifyCustomer = "notify_customer"
+)
+
+// openJobStore opens the job store next to the support request store
+// selected by SUPPORT_STORE.
+func openJobStore(ctx context.Context) (jobs.Store, error) {
+	switch kind := getEnvOrDefault("SUPPORT_STORE", "memory"); kind {
+	case "memory":
+		return jobs.NewMemory(), nil
+	case "sqlite":
+		return jobs.OpenSQLite(ctx, getEnvOrDefault("SUPPORT_SQLITE_PATH", "support.db"))
+	default:
+		return nil, fmt.Errorf("unsupported SUPPORT_STORE %q", kind)
+	}
+}
+
+// jobConfigFromEnv reads SUPPORT_JOB_WORKERS and SUPPORT_JOB_MAX_ATTEMPTS.
+func jobConfigFromEnv() (jobs.Config, error) {
+	var cfg jobs.Config
+	var err error
+	if cfg.Workers, err = strconv.Atoi(getEnvOrDefault("SUPPORT_JOB_WORKERS", "4")); err != nil {
+		return cfg, fmt.Errorf("invalid SUPPORT_JOB_WORKERS: %v", err)
+	}
+	if cfg.MaxAttempts, err = strconv.Atoi(getEnvOrDefault("SUPPORT_JOB_MAX_ATTEMPTS", "8")); err != nil {
+		return cfg, fmt.Errorf("invalid SUPPORT_JOB_MAX_ATTEMPTS: %v", err)
+	}
+	return cfg, nil
+}
+
+// registerJobs registers the handlers of the support request jobs.
+func (s *supportService) registerJobs() {
+	s.queue.Handle(jobTicket, s.runTicketJob)
+	s.queue.Handle(jobComment, s.runCommentJob)
+	s.queue.Handle(jobNotify, s.runNotifyJob)
+	s.queue.Handle(jobDora, s.runDoraJob)
+	s.queue.Handle(jobDoraResolve, s.runDoraResolveJob)
+	s.queue.Handle(jobNotifyCustomer, s.runNotifyCustomerJob)
+	s.queue.OnDead(func(ctx context.Context, job jobs.Job) {
+		if job.Kind == jobTicket || job.Kind == jobComment {
+			s.setTicketStatus(ctx, job, store.TicketFailed)
+		}
+	})
+}
+
+// setTicketStatus records the status of the ticket a ticket or comment job
+// files. The status of a ticket job also applies to its incident, so that
+// new reports do not join an incident whose ticket failed.
+func (s *supportService) setTicketStatus(ctx context.Context, job jobs.Job, ticketStatus string) {
+	sr, err := s.store.SetTicket(ctx, job.SupportRequestID, store.Ticket{Status: ticketStatus})
+	if err != nil {
+		logger.WithContext(ctx).Errorf("Failed to mark the ticket of support request %s as %s: %v", job.SupportRequestID, ticketStatus, err)
+		return
+	}
+	if job.Kind == jobTicket && sr.IncidentId != "" {
+		if err := s.store.SetIncidentTicket(ctx, sr.IncidentId, store.Ticket{Status: ticketStatus}); err != nil {
+			logger.WithContext(ctx).Errorf("Failed to mark the ticket of incident %s as %s: %v", sr.IncidentId, ticketStatus, err)
+		}
+	}
+}
+
+// supportRequest loads the support request a job belongs to. A request that
+// no longer exists fails the job permanently.
+func (s *supportService) supportRequest(ctx context.Context, job jobs.Job) (*pb.SupportRequest, error) {
+	sr, err := s.store.Get(ctx, job.SupportRequestID)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, jobs.Permanent(err)
+	}
+	return sr, err
+}
+
+// runTicketJob files the ticket of a support request, unless an earlier
+// attempt already did, then schedules the notifications and the DORA
+// incident.
+func (s *supportService) runTicketJob(ctx context.Context, job jobs.Job) error {
+	sr, err := s.supportRequest(ctx, job)
+	if err != nil {
+		return err
+	}
+	span := trace.SpanFromContext(ctx)
+
+	if sr.JiraTicketId == "" {
+		ref, err := s.createTicket(ctx, requestOf(sr))
+		if err != nil {
+			span.AddEvent("ticket_creation_failed", trace.WithAttributes(
+				attribute.String("app.ticket.provider", s.tickets.Name()),
+				attribute.String("error", err.Error()),
+			))
+			return fmt.Errorf("failed to create %s ticket: %v", s.tickets.Name(), err)
+		}
+		logger.WithContext(ctx).Infof("Created %s ticket %s for support request %s", s.tickets.Name(), ref.Key, sr.Id)
+		span.AddEvent("ticket_created", trace.WithAttributes(
+			attribute.String("app.ticket.provider", s.tickets.Name()),
+			attribute.String("app.ticket.key", ref.Key),
+		))
+		created := store.Ticket{Status: store.TicketCreated, ID: ref.ID, Key: ref.Key, URL: ref.URL}
+		if sr.IncidentId != "" {
+			if err := s.store.SetIncidentTicket(ctx, sr.IncidentId, created); err != nil {
+				return fmt.Errorf("failed to record ticket %s: %v", ref.Key, err)
+			}
+		}
+		if sr, err = s.store.SetTicket(ctx, sr.Id, created); err != nil {
+			return fmt.Errorf("failed to record ticket %s: %v", ref.Key, err)
+		}
+	}
+
+	for _, notifier := range s.notifier.Route(s.notification(ctx, sr)) {
+		if err := s.queue.Enqueue(ctx, jobNotify, sr.Id, notifier.Name()); err != nil {
+			return err
+		}
+	}
+	if s.dora == nil {
+		return nil
+	}
+	return s.queue.Enqueue(ctx, jobDora, sr.Id, "")
+}
+
+// runCommentJob comments on the ticket of the incident a duplicate support
+// request belongs to, once that ticket is created, and links the request to
+// it.
+func (s *supportService) runCommentJob(ctx context.Context, job jobs.Job) error {
+	sr, err := s.supportRequest(ctx, job)
+	if err != nil {
+		return err
+	}
+	if sr.JiraTicketId != "" {
+		return nil
+	}
+	incident, err := s.store.GetIncident(ctx, sr.IncidentId)
+	if errors.Is(err, store.ErrIncidentNotFound) {
+		return jobs.Permanent(err)
+	}
+	if err != nil {
+		return err
+	}
+	switch incident.Ticket.Status {
+	case store.TicketCreated:
+	case store.TicketFailed:
+		return jobs.Permanent(fmt.Errorf("the ticket of incident %s failed", incident.ID))
+	default:
+		return fmt.Errorf("the ticket of incident %s is not created yet", incident.ID)
+	}
+
+	ref := ticket.Ref{ID: incident.Ticket.ID, Key: incident.Ticket.Key, URL: incident.Ticket.URL}
+	if err := s.tickets.Comment(ctx, ref, s.duplicateComment(sr, incident)); err != nil {
+		return fmt.Errorf("failed to comment on %s ticket %s: %v", s.tickets.Name(), ref.Key, err)
+	}
+	logger.WithContext(ctx).Infof("Added support request %s to %s ticket %s", sr.Id, s.tickets.Name(), ref.Key)
+	trace.SpanFromContext(ctx).AddEvent("ticket_commented", trace.WithAttributes(
+		attribute.String("app.ticket.provider", s.tickets.Name()),
+		attribute.String("app.ticket.key", ref.Key),
+		attribute.String("app.support.incident.id", incident.ID),
+		attribute.Int("app.support.incident.affected_users", len(incident.UserIDs)),
+	))
+	_, err = s.store.SetTicket(ctx, sr.Id, store.Ticket{Status: store.TicketCreated, Key: ref.Key, URL: ref.URL})
+	return err
+}
+
+// duplicateComment describes a duplicate support request for the ticket of
+// its incident.
+func (s *supportService) duplicateComment(sr *pb.SupportRequest, incident store.Incident) string {
+	return fmt.Sprintf("Also reported by user %s (support request %s) at %s.\n\nError Message: %s\n\n%d users affected by this incident so far, in %d reports.",
+		sr.UserId, sr.Id, time.Unix(sr.CreatedAt, 0).UTC().Format(time.RFC3339), s.redaction.For(redact.Ticket).Text(sr.ErrorMessage), len(incident.UserIDs), len(incident.RequestIDs))
+}
+
+// runNotifyJob sends the notification of a support request with the notifier
+// named by the job target.
+func (s *supportService) runNotifyJob(ctx context.Context, job jobs.Job) error {
+	sr, err := s.supportRequest(ctx, job)
+	if err != nil {
+		return err
+	}
+	for _, result := range s.notifier.DispatchTo(ctx, s.notification(ctx, sr), job.Target) {
+		if errors.Is(result.Err, notify.ErrUnknownNotifier) {
+			return jobs.Permanent(result.Err)
+		}
+		if result.Err != nil {
+			return fmt.Errorf("failed to send %s notification: %v", result.Notifier, result.Err)
+		}
+		logger.WithContext(ctx).Infof("Sent %s notification for ticket: %s", result.Notifier, sr.JiraTicketId)
+	}
+	return nil
+}
+
+// runDoraJob records the DORA incident of a support request, unless an
+// earlier attempt already did.
+func (s *supportService) runDoraJob(ctx context.Context, job jobs.Job) error {
+	sr, err := s.supportRequest(ctx, job)
+	if err != nil {
+		return err
+	}
+	if sr.DoraIncidentId != "" {
+		return nil
+	}
+	span := trace.SpanFromContext(ctx)
+	id, err := s.createDoraIncident(ctx, sr)
+	if err != nil {
+		span.AddEvent("dora_incident_creation_failed", trace.WithAttributes(
+			attribute.String("error", err.Error()),
+		))
+		return err
+	}
+	logger.WithContext(ctx).Infof("Created DORA metrics incident %s for ticket: %s", id, sr.JiraTicketId)
+	span.AddEvent("dora_incident_created", trace.WithAttributes(
+		attribute.String("app.ticket.key", sr.JiraTicketId),
+		attribute.String("app.dora.incident.id", id),
+	))
+	if _, err := s.store.SetDoraIncident(ctx, sr.Id, id); err != nil {
+		return fmt.Errorf("failed to record DORA incident %s: %v", id, err)
+	}
+	return nil
+}
+
+// runDoraResolveJob resolves the DORA incident of a resolved support request
+// with the time to restore measured from the request's history. It waits for
+// the dora job to record the incident first.
+func (s *supportService) runDoraResolveJob(ctx context.Context, job jobs.Job) error {
+	sr, err := s.supportRequest(ctx, job)
+	if err != nil {
+		return err
+	}
+	timeToRestore, ok := store.TimeToRestore(sr)
+	if !ok {
+		logger.WithContext(ctx).Infof("Support request %s was reopened, not resolving its DORA incident", sr.Id)
+		return nil
+	}
+	if sr.DoraIncidentId == "" {
+		if sr.TicketStatus == store.TicketFailed {
+			return jobs.Permanent(fmt.Errorf("no DORA incident was recorded for support request %s, its ticket failed", sr.Id))
+		}
+		return fmt.Errorf("the DORA incident of support request %s is not recorded yet", sr.Id)
+	}
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.String("app.dora.incident.id", sr.DoraIncidentId),
+		attribute.Float64("app.support.time_to_restore", timeToRestore.Seconds()),
+	)
+	resolvedAt := time.Unix(sr.CreatedAt, 0).Add(timeToRestore)
+	if err := s.dora.Resolve(ctx, sr.DoraIncidentId, dora.Resolution{
+		Notes:         fmt.Sprintf("Support request %s resolved, ticket %s", sr.Id, sr.JiraTicketId),
+		ResolvedAt:    resolvedAt,
+		TimeToRestore: timeToRestore,
+	}); err != nil {
+		span.AddEvent("dora_incident_resolution_failed", trace.WithAttributes(
+			attribute.String("error", err.Error()),
+		))
+		return err
+	}
+	logger.WithContext(ctx).Infof("Resolved DORA metrics incident %s after %s", sr.DoraIncidentId, timeToRestore)
+	span.AddEvent("dora_incident_resolved")
+	return nil
+}
+
+// runNotifyCustomerJob tells the customer who filed a resolved support
+// request about the resolution, with the customer notifier.
+func (s *supportService) runNotifyCustomerJob(ctx context.Context, job jobs.Job) error {
+	sr, err := s.supportRequest(ctx, job)
+	if err != nil {
+		return err
+	}
+	timeToRestore, ok := store.TimeToRestore(sr)
+	if !ok {
+		logger.WithContext(ctx).Infof("Support request %s was reopened, not notifying the customer", sr.Id)
+		return nil
+	}
+	n := notify.Notification{
+		Event:     notify.EventResolved,
+		Subject:   s.redaction.For(redact.Notification).Text(sr.Subject),
+		Severity:  severityOf(requestOf(sr)),
+		UserID:    sr.UserId,
+		TicketKey: sr.JiraTicketId,
+		TicketURL: sr.TicketUrl,
+		Time:      time.Unix(sr.CreatedAt, 0).Add(timeToRestore),
+		Recipient: sr.Email,
+	}
+	for _, result := range s.notifier.DispatchTo(ctx, n, s.customerNotifier) {
+		if errors.Is(result.Err, notify.ErrUnknownNotifier) {
+			return jobs.Permanent(result.Err)
+		}
+		if result.Err != nil {
+			return fmt.Errorf("failed to send %s notification: %v", result.Notifier, result.Err)
+		}
+		logger.WithContext(ctx).Infof("Told the customer of support request %s about its resolution by %s", sr.Id, result.Notifier)
+	}
+	return nil
+}
+
+// requestOf returns the request a stored support request was created from.
+func requestOf(sr *pb.SupportRequest) *pb.CreateSupportRequestRequest {
+	return &pb.CreateSupportRequestRequest{
+		UserId:          sr.UserId,
+		Email:           sr.Email,
+		Subject:         sr.Subject,
+		Description:     sr.Description,
+		ErrorMessage:    sr.ErrorMessage,
+		FailedItems:     sr.FailedItems,
+		ShippingAddress: sr.ShippingAddress,
+		Service:         sr.Service,
+	}
+}
+
+// supportAdminService lets operators inspect and replay the jobs that ran out
+// of attempts.
+type supportAdminService struct {
+	pb.UnimplementedSupportAdminServiceServer
+	support *supportService
+}
+
+func (a *supportAdminService) ListDeadJobs(ctx context.Context, _ *pb.ListDeadJobsRequest) (*pb.ListDeadJobsResponse, error) {
+	logger.WithContext(ctx).Infof("[ListDeadJobs]")
+
+	dead, err := a.support.queue.Dead(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list dead jobs: %v", err)
+	}
+	resp := &pb.ListDeadJobsResponse{}
+	for _, job := range dead {
+		resp.Jobs = append(resp.Jobs, jobProto(job))
+	}
+	trace.SpanFromContext(ctx).SetAttributes(attribute.Int("app.job.count", len(resp.Jobs)))
+	return resp, nil
+}
+
+func (a *supportAdminService) ReplayDeadJob(ctx context.Context, req *pb.ReplayDeadJobRequest) (*pb.SupportJob, error) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.String("app.job.id", req.Id))
+
+	logger.WithContext(ctx).Infof("[ReplayDeadJob] id=%q", req.Id)
+
+	if req.Id == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "job id is required")
+	}
+	job, err := a.support.queue.Replay(ctx, req.Id)
+	switch {
+	case errors.Is(err, jobs.ErrNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, jobs.ErrNotDead):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "failed to replay job: %v", err)
+	}
+
+	span.SetAttributes(
+		attribute.String("app.job.kind", job.Kind),
+		attribute.String("app.support.id", job.SupportRequestID),
+	)
+	if job.Kind == jobTicket || job.Kind == jobComment {
+		a.support.setTicketStatus(ctx, job, store.TicketPending)
+	}
+	return jobProto(job), nil
+}
+
+func jobProto(job jobs.Job) *pb.SupportJob {
+	return &pb.SupportJob{
+		Id:               job.ID,
+		Kind:             job.Kind,
+		SupportRequestId: job.SupportRequestID,
+		Target:           job.Target,
+		State:            job.State,
+		Attempts:         int32(job.Attempts),
+		MaxAttempts:      int32(job.MaxAttempts),
+		LastError:        job.LastError,
+		CreatedAt:        job.CreatedAt.Unix(),
+		UpdatedAt:        job.UpdatedAt.Unix(),
+		NextAttemptAt:    job.NextAttemptAt.Unix(),
+	}
+}
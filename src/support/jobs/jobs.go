@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package jobs runs the side effects of support requests, such as filing
+// tickets and sending notifications, in the background. Each side effect is a
+// persistent Job that is retried with exponential backoff until it succeeds
+// or runs out of attempts, when it is moved to the dead-letter list from
+// where it can be replayed.
+package jobs
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// States of a job.
+const (
+	StatePending = "pending"
+	StateRunning = "running"
+	StateDone    = "done"
+	StateDead    = "dead"
+)
+
+var (
+	// ErrNotFound is returned when no job has the given ID.
+	ErrNotFound = errors.New("job not found")
+	// ErrNotDead is returned when replaying a job that is not dead.
+	ErrNotDead = errors.New("job is not dead")
+)
+
+// Job is one side effect of a support request. A support request has at
+// most one job per kind and target, which makes enqueueing idempotent.
+type Job struct {
+	ID               string
+	Kind             string
+	SupportRequestID string
+	// Target distinguishes jobs of the same kind for one support request,
+	// e.g. the notifier to send a notification with. It may be empty.
+	Target      string
+	State       string
+	Attempts    int
+	MaxAttempts int
+	LastError   string
+	// TraceParent is the W3C trace context of the request that enqueued the
+	// job, so its runs can be linked to that trace.
+	TraceParent string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	// NextAttemptAt is when a pending job is due. For a running job it is
+	// when its lease expires and the job may be claimed again, which
+	// recovers jobs interrupted by a crash.
+	NextAttemptAt time.Time
+}
+
+// Store persists jobs. Implementations are safe for concurrent use.
+type Store interface {
+	// Enqueue adds job unless a job of the same kind and target exists for
+	// its support request. It reports whether job was added.
+	Enqueue(ctx context.Context, job Job) (bool, error)
+	// Claim marks up to limit due jobs as running until lease and returns
+	// them, oldest first.
+	Claim(ctx context.Context, now, lease time.Time, limit int) ([]Job, error)
+	// Complete marks a job done after its last attempt.
+	Complete(ctx context.Context, id string, attempts int, now time.Time) error
+	// Retry makes a job pending again at next after a failed attempt.
+	Retry(ctx context.Context, id string, attempts int, lastError string, now, next time.Time) error
+	// Bury moves a job to the dead-letter list.
+	Bury(ctx context.Context, id string, attempts int, lastError string, now time.Time) error
+	// Get returns the job with the given ID.
+	Get(ctx context.Context, id string) (Job, error)
+	// Dead returns the dead jobs, oldest first.
+	Dead(ctx context.Context) ([]Job, error)
+	// Replay makes a dead job pending and due at now with its attempts reset.
+	Replay(ctx context.Context, id string, now time.Time) (Job, error)
+	Close() error
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps err to tell the queue not to retry the job.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// IsPermanent reports whether err was wrapped with Permanent.
+func IsPermanent(err error) bool {
+	var p *permanentError
+	return errors.As(err, &p)
+}
+
+// Backoff returns the delay before retrying a job that has failed attempts
+// times: base doubled for every attempt after the first, capped at max.
+func Backoff(attempts int, base, max time.Duration) time.Duration {
+	d := base
+	for i := 1; i < attempts; i++ {
+		d *= 2
+		if d >= max {
+			return max
+		}
+	}
+	if d > max {
+		return max
+	}
+	return d
+}
@@ -0,0 +1,270 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jobs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func stores(t *testing.T) map[string]Store {
+	sqlite, err := OpenSQLite(context.Background(), filepath.Join(t.TempDir(), "support.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlite.Close() })
+	return map[string]Store{
+		"memory": NewMemory(),
+		"sqlite": sqlite,
+	}
+}
+
+func newJob(id, requestID, kind, target string, createdAt int64) Job {
+	at := time.Unix(createdAt, 0)
+	return Job{
+		ID:               id,
+		Kind:             kind,
+		SupportRequestID: requestID,
+		Target:           target,
+		State:            StatePending,
+		MaxAttempts:      3,
+		CreatedAt:        at,
+		UpdatedAt:        at,
+		NextAttemptAt:    at,
+	}
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.Background()
+	for name, s := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			for _, job := range []Job{
+				newJob("j1", "r1", "ticket", "", 100),
+				newJob("j2", "r1", "notify", "slack", 101),
+				newJob("j3", "r1", "notify", "email", 102),
+			} {
+				if added, err := s.Enqueue(ctx, job); err != nil || !added {
+					t.Fatalf("Enqueue(%s) = %v, %v", job.ID, added, err)
+				}
+			}
+			if added, err := s.Enqueue(ctx, newJob("j4", "r1", "notify", "slack", 103)); err != nil || added {
+				t.Errorf("Enqueue() duplicate = %v, %v, want it ignored", added, err)
+			}
+
+			claimed, err := s.Claim(ctx, time.Unix(101, 0), time.Unix(200, 0), 5)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(claimed) != 2 || claimed[0].ID != "j1" || claimed[1].ID != "j2" || claimed[0].State != StateRunning {
+				t.Fatalf("Claim() = %+v, want j1 and j2 running", claimed)
+			}
+			if claimed, _ := s.Claim(ctx, time.Unix(150, 0), time.Unix(300, 0), 5); len(claimed) != 1 || claimed[0].ID != "j3" {
+				t.Errorf("Claim() = %+v, want only j3 while the others are leased", claimed)
+			}
+
+			if err := s.Complete(ctx, "j1", 1, time.Unix(110, 0)); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Retry(ctx, "j2", 1, "timeout", time.Unix(110, 0), time.Unix(120, 0)); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Bury(ctx, "j3", 3, "unauthorized", time.Unix(110, 0)); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Complete(ctx, "missing", 1, time.Unix(110, 0)); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Complete() missing error = %v, want ErrNotFound", err)
+			}
+
+			j2, err := s.Get(ctx, "j2")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if j2.State != StatePending || j2.Attempts != 1 || j2.LastError != "timeout" || !j2.NextAttemptAt.Equal(time.Unix(120, 0)) {
+				t.Errorf("retried job = %+v", j2)
+			}
+
+			dead, err := s.Dead(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(dead) != 1 || dead[0].ID != "j3" || dead[0].LastError != "unauthorized" {
+				t.Fatalf("Dead() = %+v, want j3", dead)
+			}
+
+			if _, err := s.Replay(ctx, "j2", time.Unix(130, 0)); !errors.Is(err, ErrNotDead) {
+				t.Errorf("Replay() pending error = %v, want ErrNotDead", err)
+			}
+			if _, err := s.Replay(ctx, "missing", time.Unix(130, 0)); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Replay() missing error = %v, want ErrNotFound", err)
+			}
+			replayed, err := s.Replay(ctx, "j3", time.Unix(130, 0))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if replayed.State != StatePending || replayed.Attempts != 0 {
+				t.Errorf("Replay() = %+v", replayed)
+			}
+			if dead, _ := s.Dead(ctx); len(dead) != 0 {
+				t.Errorf("Dead() after replay = %+v", dead)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{4, 8 * time.Second},
+		{10, time.Minute},
+	}
+	for _, tt := range tests {
+		if got := Backoff(tt.attempts, time.Second, time.Minute); got != tt.want {
+			t.Errorf("Backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func testQueue(t *testing.T, maxAttempts int) *Queue {
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	q := NewQueue(NewMemory(), Config{
+		MaxAttempts:  maxAttempts,
+		BaseBackoff:  time.Millisecond,
+		MaxBackoff:   5 * time.Millisecond,
+		PollInterval: time.Millisecond,
+		Timeout:      time.Second,
+	}, log)
+	return q
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestQueue(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int
+		err          error
+		wantState    string
+		wantAttempts int
+	}{
+		{"succeeds", 0, nil, StateDone, 1},
+		{"retries", 2, errors.New("unavailable"), StateDone, 3},
+		{"dead letter", 10, errors.New("unavailable"), StateDead, 4},
+		{"permanent", 10, Permanent(errors.New("bad request")), StateDead, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			q := testQueue(t, 4)
+
+			var mu sync.Mutex
+			calls := 0
+			q.Handle("ticket", func(context.Context, Job) error {
+				mu.Lock()
+				defer mu.Unlock()
+				calls++
+				if calls <= tt.failures {
+					return tt.err
+				}
+				return nil
+			})
+			deadCh := make(chan Job, 1)
+			q.OnDead(func(_ context.Context, job Job) { deadCh <- job })
+
+			if err := q.Enqueue(ctx, "ticket", "r1", ""); err != nil {
+				t.Fatal(err)
+			}
+			if err := q.Enqueue(ctx, "ticket", "r1", ""); err != nil {
+				t.Fatal(err)
+			}
+			q.Start(ctx)
+			t.Cleanup(func() { q.Stop(ctx) })
+
+			var job Job
+			waitFor(t, tt.wantState, func() bool {
+				all := q.store.(*Memory)
+				all.mu.Lock()
+				defer all.mu.Unlock()
+				for _, j := range all.jobs {
+					job = *j
+				}
+				return len(all.jobs) == 1 && job.State == tt.wantState
+			})
+			if job.Attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", job.Attempts, tt.wantAttempts)
+			}
+			if tt.wantState == StateDead {
+				select {
+				case d := <-deadCh:
+					if d.ID != job.ID || d.LastError == "" {
+						t.Errorf("OnDead() job = %+v", d)
+					}
+				case <-time.After(time.Second):
+					t.Error("OnDead() was not called")
+				}
+			}
+		})
+	}
+}
+
+func TestQueueReplay(t *testing.T) {
+	ctx := context.Background()
+	q := testQueue(t, 1)
+	var mu sync.Mutex
+	fail := true
+	q.Handle("notify", func(context.Context, Job) error {
+		mu.Lock()
+		defer mu.Unlock()
+		if fail {
+			return errors.New("webhook down")
+		}
+		return nil
+	})
+	if err := q.Enqueue(ctx, "notify", "r1", "webhook"); err != nil {
+		t.Fatal(err)
+	}
+	q.Start(ctx)
+	t.Cleanup(func() { q.Stop(ctx) })
+
+	var dead []Job
+	waitFor(t, "the dead job", func() bool {
+		dead, _ = q.Dead(ctx)
+		return len(dead) == 1
+	})
+
+	mu.Lock()
+	fail = false
+	mu.Unlock()
+	if _, err := q.Replay(ctx, dead[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, "the replayed job", func() bool {
+		job, _ := q.store.Get(ctx, dead[0].ID)
+		return job.State == StateDone
+	})
+
+	if err := q.Enqueue(ctx, "unknown", "r1", ""); err == nil {
+		t.Error("Enqueue() of an unknown kind error = nil")
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jobs
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Memory is a Store that keeps jobs in memory. They are lost when the process
+// exits.
+type Memory struct {
+	mu   sync.Mutex
+	jobs map[string]*Job
+}
+
+var _ Store = (*Memory)(nil)
+
+// NewMemory returns an empty Memory store.
+func NewMemory() *Memory {
+	return &Memory{jobs: make(map[string]*Job)}
+}
+
+func (m *Memory) Enqueue(_ context.Context, job Job) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, j := range m.jobs {
+		if j.Kind == job.Kind && j.SupportRequestID == job.SupportRequestID && j.Target == job.Target {
+			return false, nil
+		}
+	}
+	m.jobs[job.ID] = &job
+	return true, nil
+}
+
+func (m *Memory) Claim(_ context.Context, now, lease time.Time, limit int) ([]Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var due []*Job
+	for _, j := range m.jobs {
+		if (j.State == StatePending || j.State == StateRunning) && !j.NextAttemptAt.After(now) {
+			due = append(due, j)
+		}
+	}
+	sortJobs(due)
+	if len(due) > limit {
+		due = due[:limit]
+	}
+
+	out := make([]Job, 0, len(due))
+	for _, j := range due {
+		j.State = StateRunning
+		j.NextAttemptAt = lease
+		j.UpdatedAt = now
+		out = append(out, *j)
+	}
+	return out, nil
+}
+
+func (m *Memory) Complete(_ context.Context, id string, attempts int, now time.Time) error {
+	return m.update(id, func(j *Job) {
+		j.State = StateDone
+		j.Attempts = attempts
+		j.LastError = ""
+		j.UpdatedAt = now
+	})
+}
+
+func (m *Memory) Retry(_ context.Context, id string, attempts int, lastError string, now, next time.Time) error {
+	return m.update(id, func(j *Job) {
+		j.State = StatePending
+		j.Attempts = attempts
+		j.LastError = lastError
+		j.UpdatedAt = now
+		j.NextAttemptAt = next
+	})
+}
+
+func (m *Memory) Bury(_ context.Context, id string, attempts int, lastError string, now time.Time) error {
+	return m.update(id, func(j *Job) {
+		j.State = StateDead
+		j.Attempts = attempts
+		j.LastError = lastError
+		j.UpdatedAt = now
+	})
+}
+
+func (m *Memory) Get(_ context.Context, id string) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j, ok := m.jobs[id]
+	if !ok {
+		return Job{}, ErrNotFound
+	}
+	return *j, nil
+}
+
+func (m *Memory) Dead(_ context.Context) ([]Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var dead []*Job
+	for _, j := range m.jobs {
+		if j.State == StateDead {
+			dead = append(dead, j)
+		}
+	}
+	sortJobs(dead)
+	out := make([]Job, 0, len(dead))
+	for _, j := range dead {
+		out = append(out, *j)
+	}
+	return out, nil
+}
+
+func (m *Memory) Replay(_ context.Context, id string, now time.Time) (Job, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j, ok := m.jobs[id]
+	if !ok {
+		return Job{}, ErrNotFound
+	}
+	if j.State != StateDead {
+		return Job{}, ErrNotDead
+	}
+	j.State = StatePending
+	j.Attempts = 0
+	j.UpdatedAt = now
+	j.NextAttemptAt = now
+	return *j, nil
+}
+
+// Close implements Store. It does nothing.
+func (m *Memory) Close() error { return nil }
+
+func (m *Memory) update(id string, fn func(*Job)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	j, ok := m.jobs[id]
+	if !ok {
+		return ErrNotFound
+	}
+	fn(j)
+	return nil
+}
+
+func sortJobs(jobs []*Job) {
+	sort.Slice(jobs, func(a, b int) bool {
+		if !jobs[a].CreatedAt.Equal(jobs[b].CreatedAt) {
+			return jobs[a].CreatedAt.Before(jobs[b].CreatedAt)
+		}
+		return jobs[a].ID < jobs[b].ID
+	})
+}
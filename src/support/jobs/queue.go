@@ -0,0 +1,303 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Handler runs one attempt of a job. Returning an error retries the job
+// unless it was wrapped with Permanent.
+type Handler func(ctx context.Context, job Job) error
+
+// Config tunes a Queue. Zero fields take the defaults below.
+type Config struct {
+	// Workers is the number of jobs run concurrently. Defaults to 4.
+	Workers int
+	// MaxAttempts is the number of attempts before a job is dead. Defaults
+	// to 8.
+	MaxAttempts int
+	// BaseBackoff is the delay before the first retry, doubled for every
+	// further retry up to MaxBackoff. Default to 1s and 5m.
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+	// Timeout bounds one attempt. Defaults to 30s.
+	Timeout time.Duration
+	// PollInterval is how often the store is checked for due jobs when the
+	// queue is idle. Defaults to 1s.
+	PollInterval time.Duration
+}
+
+func (c *Config) setDefaults() {
+	if c.Workers <= 0 {
+		c.Workers = 4
+	}
+	if c.MaxAttempts <= 0 {
+		c.MaxAttempts = 8
+	}
+	if c.BaseBackoff <= 0 {
+		c.BaseBackoff = time.Second
+	}
+	if c.MaxBackoff <= 0 {
+		c.MaxBackoff = 5 * time.Minute
+	}
+	if c.Timeout <= 0 {
+		c.Timeout = 30 * time.Second
+	}
+	if c.PollInterval <= 0 {
+		c.PollInterval = time.Second
+	}
+}
+
+// Queue enqueues jobs in a Store and runs them with the handler registered
+// for their kind.
+type Queue struct {
+	store    Store
+	cfg      Config
+	log      *logrus.Logger
+	tracer   trace.Tracer
+	handlers map[string]Handler
+	onDead   func(ctx context.Context, job Job)
+
+	wake   chan struct{}
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewQueue returns a Queue over store. Register handlers with Handle before
+// calling Start.
+func NewQueue(store Store, cfg Config, log *logrus.Logger) *Queue {
+	cfg.setDefaults()
+	return &Queue{
+		store:    store,
+		cfg:      cfg,
+		log:      log,
+		tracer:   otel.Tracer("support/jobs"),
+		handlers: make(map[string]Handler),
+		wake:     make(chan struct{}, 1),
+	}
+}
+
+// Handle registers the handler of jobs of kind.
+func (q *Queue) Handle(kind string, h Handler) {
+	q.handlers[kind] = h
+}
+
+// OnDead registers fn to be called when a job is moved to the dead-letter
+// list.
+func (q *Queue) OnDead(fn func(ctx context.Context, job Job)) {
+	q.onDead = fn
+}
+
+// Enqueue adds a job of kind for a support request, unless one with the same
+// target exists already. The trace context of ctx is stored with the job.
+func (q *Queue) Enqueue(ctx context.Context, kind, supportRequestID, target string) error {
+	if _, ok := q.handlers[kind]; !ok {
+		return fmt.Errorf("no handler for jobs of kind %q", kind)
+	}
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+
+	now := time.Now()
+	job := Job{
+		ID:               uuid.NewString(),
+		Kind:             kind,
+		SupportRequestID: supportRequestID,
+		Target:           target,
+		State:            StatePending,
+		MaxAttempts:      q.cfg.MaxAttempts,
+		TraceParent:      carrier.Get("traceparent"),
+		CreatedAt:        now,
+		UpdatedAt:        now,
+		NextAttemptAt:    now,
+	}
+	added, err := q.store.Enqueue(ctx, job)
+	if err != nil {
+		return fmt.Errorf("failed to enqueue %s job: %+v", kind, err)
+	}
+	if added {
+		q.notify()
+	}
+	return nil
+}
+
+// Dead returns the jobs on the dead-letter list.
+func (q *Queue) Dead(ctx context.Context) ([]Job, error) {
+	return q.store.Dead(ctx)
+}
+
+// Replay moves a dead job back to the queue with its attempts reset.
+func (q *Queue) Replay(ctx context.Context, id string) (Job, error) {
+	job, err := q.store.Replay(ctx, id, time.Now())
+	if err != nil {
+		return Job{}, err
+	}
+	q.notify()
+	return job, nil
+}
+
+// Start runs the workers until Stop is called.
+func (q *Queue) Start(ctx context.Context) {
+	ctx, q.cancel = context.WithCancel(context.WithoutCancel(ctx))
+	jobs := make(chan Job)
+
+	q.wg.Add(1)
+	go func() {
+		defer q.wg.Done()
+		defer close(jobs)
+		q.dispatch(ctx, jobs)
+	}()
+	for i := 0; i < q.cfg.Workers; i++ {
+		q.wg.Add(1)
+		go func() {
+			defer q.wg.Done()
+			for job := range jobs {
+				q.run(ctx, job)
+			}
+		}()
+	}
+}
+
+// Stop stops claiming jobs and waits for the running ones to finish, or
+// for ctx to be done. Jobs still queued are run after the next Start.
+func (q *Queue) Stop(ctx context.Context) error {
+	if q.cancel == nil {
+		return nil
+	}
+	q.cancel()
+	done := make(chan struct{})
+	go func() {
+		q.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (q *Queue) notify() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+// dispatch claims due jobs and hands them to the workers.
+func (q *Queue) dispatch(ctx context.Context, jobs chan<- Job) {
+	ticker := time.NewTicker(q.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		now := time.Now()
+		// The lease outlives an attempt, so a claimed job is only picked up
+		// again if the process died while running it.
+		claimed, err := q.store.Claim(ctx, now, now.Add(2*q.cfg.Timeout), q.cfg.Workers)
+		if err != nil && ctx.Err() == nil {
+			q.log.Errorf("Failed to claim jobs: %v", err)
+		}
+		for _, job := range claimed {
+			select {
+			case jobs <- job:
+			case <-ctx.Done():
+				return
+			}
+		}
+		if len(claimed) == q.cfg.Workers {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.wake:
+		case <-ticker.C:
+		}
+	}
+}
+
+// run runs one attempt of job in a span linked to the trace that enqueued it.
+func (q *Queue) run(ctx context.Context, job Job) {
+	opts := []trace.SpanStartOption{
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("app.job.id", job.ID),
+			attribute.String("app.job.kind", job.Kind),
+			attribute.String("app.job.target", job.Target),
+			attribute.String("app.support.id", job.SupportRequestID),
+			attribute.Int("app.job.attempt", job.Attempts+1),
+		),
+	}
+	if job.TraceParent != "" {
+		origin := propagation.TraceContext{}.Extract(context.Background(), propagation.MapCarrier{"traceparent": job.TraceParent})
+		if sc := trace.SpanContextFromContext(origin); sc.IsValid() {
+			opts = append(opts, trace.WithLinks(trace.Link{SpanContext: sc}))
+		}
+	}
+	ctx, span := q.tracer.Start(ctx, "job "+job.Kind, opts...)
+	defer span.End()
+
+	attempts := job.Attempts + 1
+	err := q.attempt(ctx, job)
+	now := time.Now()
+	log := q.log.WithContext(ctx).WithFields(logrus.Fields{"job_id": job.ID, "job_kind": job.Kind, "support_id": job.SupportRequestID})
+
+	// Record the outcome even if the queue is stopping, so that the attempt
+	// is not repeated.
+	storeCtx := context.WithoutCancel(ctx)
+	switch {
+	case err == nil:
+		if err := q.store.Complete(storeCtx, job.ID, attempts, now); err != nil {
+			log.Errorf("Failed to complete job: %v", err)
+		}
+		return
+	case IsPermanent(err) || attempts >= job.MaxAttempts:
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		log.Errorf("Job failed after %d attempts, moving it to the dead-letter list: %v", attempts, err)
+		if err := q.store.Bury(storeCtx, job.ID, attempts, err.Error(), now); err != nil {
+			log.Errorf("Failed to bury job: %v", err)
+			return
+		}
+		if q.onDead != nil {
+			job.State, job.Attempts, job.LastError = StateDead, attempts, err.Error()
+			q.onDead(storeCtx, job)
+		}
+	default:
+		delay := Backoff(attempts, q.cfg.BaseBackoff, q.cfg.MaxBackoff)
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.SetAttributes(attribute.Int64("app.job.retry_in_ms", delay.Milliseconds()))
+		log.Warnf("Job attempt %d failed, retrying in %v: %v", attempts, delay, err)
+		if err := q.store.Retry(storeCtx, job.ID, attempts, err.Error(), now, now.Add(delay)); err != nil {
+			log.Errorf("Failed to reschedule job: %v", err)
+		}
+	}
+}
+
+func (q *Queue) attempt(ctx context.Context, job Job) (err error) {
+	h, ok := q.handlers[job.Kind]
+	if !ok {
+		return Permanent(fmt.Errorf("no handler for jobs of kind %q", job.Kind))
+	}
+	ctx, cancel := context.WithTimeout(ctx, q.cfg.Timeout)
+	defer cancel()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job handler panicked: %v", r)
+		}
+	}()
+	return h(ctx, job)
+}
@@ -0,0 +1,213 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package jobs
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS support_jobs (
+	id                 TEXT PRIMARY KEY,
+	kind               TEXT NOT NULL,
+	support_request_id TEXT NOT NULL,
+	target             TEXT NOT NULL,
+	state              TEXT NOT NULL,
+	attempts           INTEGER NOT NULL,
+	max_attempts       INTEGER NOT NULL,
+	last_error         TEXT NOT NULL,
+	trace_parent       TEXT NOT NULL,
+	created_at         INTEGER NOT NULL,
+	updated_at         INTEGER NOT NULL,
+	next_attempt_at    INTEGER NOT NULL,
+	UNIQUE (support_request_id, kind, target)
+);
+CREATE INDEX IF NOT EXISTS support_jobs_due ON support_jobs (state, next_attempt_at);
+`
+
+const jobColumns = "id, kind, support_request_id, target, state, attempts, max_attempts, last_error, trace_parent, created_at, updated_at, next_attempt_at"
+
+// SQLite is a Store backed by a SQLite database file. It may share the file
+// with the support request store.
+type SQLite struct {
+	db *sql.DB
+}
+
+var _ Store = (*SQLite)(nil)
+
+// OpenSQLite opens, and creates if needed, the SQLite database at path.
+func OpenSQLite(ctx context.Context, path string) (*SQLite, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %+v", path, err)
+	}
+	// SQLite allows a single writer; serializing access through one
+	// connection avoids SQLITE_BUSY errors between the workers, and the busy
+	// timeout covers other connections to the same file.
+	db.SetMaxOpenConns(1)
+	if _, err := db.ExecContext(ctx, "PRAGMA journal_mode = WAL; PRAGMA busy_timeout = 5000;"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to configure %s: %+v", path, err)
+	}
+	if _, err := db.ExecContext(ctx, sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema in %s: %+v", path, err)
+	}
+	return &SQLite{db: db}, nil
+}
+
+func (s *SQLite) Enqueue(ctx context.Context, job Job) (bool, error) {
+	res, err := s.db.ExecContext(ctx,
+		"INSERT INTO support_jobs ("+jobColumns+") VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?) ON CONFLICT (support_request_id, kind, target) DO NOTHING",
+		job.ID, job.Kind, job.SupportRequestID, job.Target, job.State, job.Attempts, job.MaxAttempts, job.LastError, job.TraceParent,
+		job.CreatedAt.UnixNano(), job.UpdatedAt.UnixNano(), job.NextAttemptAt.UnixNano(),
+	)
+	if err != nil {
+		return false, err
+	}
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+func (s *SQLite) Claim(ctx context.Context, now, lease time.Time, limit int) ([]Job, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx,
+		"SELECT "+jobColumns+" FROM support_jobs WHERE state IN (?, ?) AND next_attempt_at <= ? ORDER BY created_at, id LIMIT ?",
+		StatePending, StateRunning, now.UnixNano(), limit)
+	if err != nil {
+		return nil, err
+	}
+	var out []Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		out = append(out, job)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range out {
+		if _, err := tx.ExecContext(ctx,
+			"UPDATE support_jobs SET state = ?, next_attempt_at = ?, updated_at = ? WHERE id = ?",
+			StateRunning, lease.UnixNano(), now.UnixNano(), out[i].ID,
+		); err != nil {
+			return nil, err
+		}
+		out[i].State = StateRunning
+		out[i].NextAttemptAt = lease
+		out[i].UpdatedAt = now
+	}
+	return out, tx.Commit()
+}
+
+func (s *SQLite) Complete(ctx context.Context, id string, attempts int, now time.Time) error {
+	return s.update(ctx, id,
+		"UPDATE support_jobs SET state = ?, attempts = ?, last_error = '', updated_at = ? WHERE id = ?",
+		StateDone, attempts, now.UnixNano(), id)
+}
+
+func (s *SQLite) Retry(ctx context.Context, id string, attempts int, lastError string, now, next time.Time) error {
+	return s.update(ctx, id,
+		"UPDATE support_jobs SET state = ?, attempts = ?, last_error = ?, updated_at = ?, next_attempt_at = ? WHERE id = ?",
+		StatePending, attempts, lastError, now.UnixNano(), next.UnixNano(), id)
+}
+
+func (s *SQLite) Bury(ctx context.Context, id string, attempts int, lastError string, now time.Time) error {
+	return s.update(ctx, id,
+		"UPDATE support_jobs SET state = ?, attempts = ?, last_error = ?, updated_at = ? WHERE id = ?",
+		StateDead, attempts, lastError, now.UnixNano(), id)
+}
+
+func (s *SQLite) Get(ctx context.Context, id string) (Job, error) {
+	job, err := scanJob(s.db.QueryRowContext(ctx, "SELECT "+jobColumns+" FROM support_jobs WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Job{}, ErrNotFound
+	}
+	return job, err
+}
+
+func (s *SQLite) Dead(ctx context.Context) ([]Job, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT "+jobColumns+" FROM support_jobs WHERE state = ? ORDER BY created_at, id", StateDead)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var out []Job
+	for rows.Next() {
+		job, err := scanJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, job)
+	}
+	return out, rows.Err()
+}
+
+func (s *SQLite) Replay(ctx context.Context, id string, now time.Time) (Job, error) {
+	res, err := s.db.ExecContext(ctx,
+		"UPDATE support_jobs SET state = ?, attempts = 0, updated_at = ?, next_attempt_at = ? WHERE id = ? AND state = ?",
+		StatePending, now.UnixNano(), now.UnixNano(), id, StateDead)
+	if err != nil {
+		return Job{}, err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return Job{}, err
+	} else if n == 0 {
+		if _, err := s.Get(ctx, id); err != nil {
+			return Job{}, err
+		}
+		return Job{}, ErrNotDead
+	}
+	return s.Get(ctx, id)
+}
+
+// Close closes the database.
+func (s *SQLite) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLite) update(ctx context.Context, id, query string, args ...any) error {
+	res, err := s.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+func scanJob(row interface{ Scan(...any) error }) (Job, error) {
+	var job Job
+	var createdAt, updatedAt, nextAttemptAt int64
+	if err := row.Scan(&job.ID, &job.Kind, &job.SupportRequestID, &job.Target, &job.State, &job.Attempts, &job.MaxAttempts,
+		&job.LastError, &job.TraceParent, &createdAt, &updatedAt, &nextAttemptAt); err != nil {
+		return Job{}, err
+	}
+	job.CreatedAt = time.Unix(0, createdAt)
+	job.UpdatedAt = time.Unix(0, updatedAt)
+	job.NextAttemptAt = time.Unix(0, nextAttemptAt)
+	return job, nil
+}
@@ -4,37 +4,42 @@
 package main
 
 import (
-	"bytes"
 	"context"
-	"encoding/base64"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"log"
 	"net"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
-	"go.opentelemetry.io/contrib/instrumentation/runtime"
-	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
-	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
-	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
-	"go.opentelemetry.io/otel/propagation"
-	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
-	sdkresource "go.opentelemetry.io/otel/sdk/resource"
-	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
-
+	"google.golang.org/protobuf/proto"
+
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/health"
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/lifecycle"
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/logging"
+	"github.com/open-telemetry/opentelemetry-demo/src/gocommon/telemetry"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/adf"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/dedup"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/dora"
 	pb "github.com/opentelemetry/opentelemetry-demo/src/support/genproto/oteldemo"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/jobs"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/notify"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/redact"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/store"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/ticket"
 )
 
 var (
@@ -44,46 +49,39 @@ var (
 
 type supportService struct {
 	pb.UnimplementedSupportServiceServer
-	jiraConfig  JiraConfig
-	slackConfig SlackConfig
-}
-
-type JiraConfig struct {
-	URL      string
-	Username string
-	APIToken string
-	Project  string
-}
-
-type SlackConfig struct {
-	WebhookURL string
-	Channel    string
-}
-
-type JiraIssue struct {
-	Fields JiraFields `json:"fields"`
-}
-
-type JiraFields struct {
-	Project     JiraProject     `json:"project"`
-	Summary     string          `json:"summary"`
-	Description JiraDescription `json:"description"`
-	IssueType   JiraIssueType   `json:"issuetype"`
-}
-
-type JiraProject struct {
-	Key string `json:"key"`
-}
-
-type JiraDescription struct {
-	Type    string        `json:"type"`
-	Version int           `json:"version"`
-	Content []JiraContent `json:"content"`
+	store    store.Store
+	notifier *notify.Dispatcher
+	// customerNotifier names the notifier that tells customers their
+	// support request is resolved. It is empty when customers are not told.
+	customerNotifier string
+	// queue runs the ticket, notification and DORA side effects of support
+	// requests in the background.
+	queue *jobs.Queue
+	// dedupWindow is how long after the last report of an incident a
+	// matching request is still grouped into it.
+	dedupWindow time.Duration
+	// redaction removes personal data before it is sent to telemetry,
+	// tickets, notifications and DORA.
+	redaction *redact.Policy
+	// dora records incidents with the DORA metrics service. It is nil when
+	// DORA_URL is empty.
+	dora *dora.Client
+	// tickets is nil when no ticket provider is configured.
+	tickets ticket.Provider
+	// templates render the Jira descriptions of tickets. The built-in
+	// templates are used when it is nil.
+	templates *adf.Templates
+	// productURL prefixes product IDs to link failed items in tickets.
+	productURL string
+	// catalog is used to look up the categories of failed items. It is nil
+	// when PRODUCT_CATALOG_ADDR is not set.
+	catalog pb.ProductCatalogServiceClient
 }
 
 type JiraContent struct {
-	Type    string      `json:"type"`
-	Content interface{} `json:"content,omitempty"`
+	Type    string         `json:"type"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+	Content interface{}    `json:"content,omitempty"`
 }
 
 type JiraText struct {
@@ -93,7 +91,8 @@ type JiraText struct {
 }
 
 type JiraMark struct {
-	Type string `json:"type"`
+	Type  string         `json:"type"`
+	Attrs map[string]any `json:"attrs,omitempty"`
 }
 
 type JiraListItem struct {
@@ -101,40 +100,8 @@ type JiraListItem struct {
 	Content []JiraContent `json:"content"`
 }
 
-type JiraIssueType struct {
-	Name string `json:"name"`
-}
-
-type JiraResponse struct {
-	ID  string `json:"id"`
-	Key string `json:"key"`
-}
-
-type SlackMessage struct {
-	Channel     string            `json:"channel,omitempty"`
-	Text        string            `json:"text"`
-	Attachments []SlackAttachment `json:"attachments,omitempty"`
-}
-
-type SlackAttachment struct {
-	Color     string       `json:"color"`
-	Title     string       `json:"title"`
-	TitleLink string       `json:"title_link"`
-	Text      string       `json:"text"`
-	Fields    []SlackField `json:"fields"`
-	Footer    string       `json:"footer"`
-	Timestamp int64        `json:"ts"`
-}
-
-type SlackField struct {
-	Title string `json:"title"`
-	Value string `json:"value"`
-	Short bool   `json:"short"`
-}
-
 func init() {
 	logger = logrus.New()
-	logger.SetLevel(logrus.InfoLevel)
 	logger.SetFormatter(&logrus.JSONFormatter{
 		FieldMap: logrus.FieldMap{
 			logrus.FieldKeyTime:  "timestamp",
@@ -146,139 +113,298 @@ func init() {
 	logger.SetOutput(os.Stdout)
 }
 
-func initTracerProvider() *sdktrace.TracerProvider {
+func main() {
 	ctx := context.Background()
+	lm := lifecycle.New(logger, lifecycle.DefaultTimeout)
 
-	exporter, err := otlptracegrpc.New(ctx)
+	tel, shutdownTelemetry, err := telemetry.Setup(ctx, telemetry.Config{ServiceName: "support"})
+	lm.OnShutdown(lifecycle.StageTelemetry, "telemetry", shutdownTelemetry)
 	if err != nil {
-		log.Fatalf("new otlp trace grpc exporter failed: %v", err)
+		log.Fatal(err)
+	}
+	if err := logging.Setup(logger, "support", tel.LoggerProvider, logrus.InfoLevel); err != nil {
+		log.Fatal(err)
 	}
 
-	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
-		sdktrace.WithResource(initResource()),
-	)
-	otel.SetTracerProvider(tp)
-	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
-	return tp
-}
-
-func initMeterProvider() *sdkmetric.MeterProvider {
-	ctx := context.Background()
+	tracer = tel.Tracer("support")
 
-	exporter, err := otlpmetricgrpc.New(ctx)
-	if err != nil {
-		log.Fatalf("new otlp metric grpc exporter failed: %v", err)
+	port := os.Getenv("SUPPORT_PORT")
+	if port == "" {
+		port = "8080"
 	}
 
-	mp := sdkmetric.NewMeterProvider(
-		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)),
-		sdkmetric.WithResource(initResource()),
-	)
-	otel.SetMeterProvider(mp)
-	return mp
-}
+	svc := &supportService{}
 
-func initResource() *sdkresource.Resource {
-	extraResources, _ := sdkresource.New(
-		context.Background(),
-		sdkresource.WithOS(),
-		sdkresource.WithProcess(),
-		sdkresource.WithContainer(),
-		sdkresource.WithHost(),
-	)
-	resource, _ := sdkresource.Merge(
-		sdkresource.Default(),
-		extraResources,
-	)
-	return resource
-}
+	if svc.redaction, err = redactionPolicyFromEnv(); err != nil {
+		log.Fatal(err)
+	}
 
-func main() {
-	ctx := context.Background()
-	tp := initTracerProvider()
-	defer func() {
-		if err := tp.Shutdown(ctx); err != nil {
-			log.Printf("Error shutting down tracer provider: %v", err)
-		}
-	}()
+	svc.store, err = openStore(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	lm.OnShutdownClose("support store", svc.store)
 
-	mp := initMeterProvider()
-	defer func() {
-		if err := mp.Shutdown(ctx); err != nil {
-			log.Printf("Error shutting down meter provider: %v", err)
-		}
-	}()
+	svc.tickets, err = ticket.New(ticketConfigFromEnv())
+	if errors.Is(err, ticket.ErrNotConfigured) {
+		logger.Warnf("Tickets will not be created: %v", err)
+	} else if err != nil {
+		log.Fatal(err)
+	}
 
-	if err := runtime.Start(runtime.WithMinimumReadMemStatsInterval(time.Second)); err != nil {
+	svc.dora, err = dora.New(doraConfigFromEnv())
+	if errors.Is(err, dora.ErrNotConfigured) {
+		logger.Warnf("DORA incidents will not be recorded: %v", err)
+	} else if err != nil {
 		log.Fatal(err)
 	}
 
-	tracer = tp.Tracer("support")
+	if svc.templates, err = ticketTemplatesFromEnv(); err != nil {
+		log.Fatal(err)
+	}
+	svc.productURL = os.Getenv("TICKET_PRODUCT_URL")
 
-	port := os.Getenv("SUPPORT_PORT")
-	if port == "" {
-		port = "8080"
+	notifyConfig, err := notifyConfigFromEnv()
+	if err != nil {
+		log.Fatal(err)
 	}
+	if svc.notifier, err = notify.New(notifyConfig); err != nil {
+		log.Fatal(err)
+	}
+	svc.customerNotifier = customerNotifierFromEnv(svc.notifier)
 
-	svc := &supportService{
-		jiraConfig: JiraConfig{
-			URL:      getEnvOrDefault("JIRA_URL", ""),
-			Username: getEnvOrDefault("JIRA_USERNAME", ""),
-			APIToken: getEnvOrDefault("JIRA_API_TOKEN", ""),
-			Project:  getEnvOrDefault("JIRA_PROJECT", "DEMO"),
-		},
-		slackConfig: SlackConfig{
-			WebhookURL: getEnvOrDefault("SLACK_WEBHOOK_URL", ""),
-			Channel:    getEnvOrDefault("SLACK_CHANNEL", "#support"),
-		},
+	jobStore, err := openJobStore(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+	lm.OnShutdownClose("job store", jobStore)
+	jobConfig, err := jobConfigFromEnv()
+	if err != nil {
+		log.Fatal(err)
+	}
+	svc.queue = jobs.NewQueue(jobStore, jobConfig, logger)
+	if svc.dedupWindow, err = time.ParseDuration(getEnvOrDefault("SUPPORT_DEDUP_WINDOW", "30m")); err != nil {
+		log.Fatalf("invalid SUPPORT_DEDUP_WINDOW: %v", err)
+	}
+	svc.registerJobs()
+
+	if addr := os.Getenv("PRODUCT_CATALOG_ADDR"); addr != "" {
+		c, err := grpc.NewClient(addr,
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+		)
+		if err != nil {
+			log.Fatal(err)
+		}
+		svc.catalog = pb.NewProductCatalogServiceClient(c)
+		lm.OnShutdownClose("product-catalog client", c)
 	}
 
 	logger.Infof("Support service starting on port %s", port)
-	logger.Infof("Jira config: URL=%s, Project=%s", svc.jiraConfig.URL, svc.jiraConfig.Project)
-	logger.Infof("Slack config: Channel=%s, WebhookURL configured=%t", svc.slackConfig.Channel, svc.slackConfig.WebhookURL != "")
+	if svc.tickets != nil {
+		logger.Infof("Ticket provider: %s", svc.tickets.Name())
+	}
+	logger.Infof("Notifiers: %v", svc.notifier.Notifiers())
 
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
 	if err != nil {
 		log.Fatal(err)
 	}
 
+	hs := health.NewServer(logger, health.DefaultInterval)
+	if err := hs.RegisterMetrics(tel.MeterProvider.Meter("support")); err != nil {
+		log.Fatal(err)
+	}
+	hs.AddCheck(pb.SupportService_ServiceDesc.ServiceName, "store", svc.store.Ping)
+	hs.AddCheck(pb.SupportService_ServiceDesc.ServiceName, "tickets", svc.checkTickets)
+
 	srv := grpc.NewServer(
 		grpc.StatsHandler(otelgrpc.NewServerHandler()),
 	)
 	pb.RegisterSupportServiceServer(srv, svc)
-	healthpb.RegisterHealthServer(srv, svc)
+	pb.RegisterSupportAdminServiceServer(srv, &supportAdminService{support: svc})
+	healthpb.RegisterHealthServer(srv, hs)
+	hs.Start(ctx)
+
+	// Webhooks being applied may enqueue jobs, so the webhook server is
+	// shut down before the queue is stopped.
+	webhookSrv, webhookLis, err := svc.newWebhookServer()
+	if err != nil {
+		log.Fatal(err)
+	}
+	if webhookSrv != nil {
+		go func() {
+			if err := webhookSrv.Serve(webhookLis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Fatal(err)
+			}
+		}()
+		lm.OnShutdown(lifecycle.StageFlush, "ticket webhooks", webhookSrv.Shutdown)
+	}
+
+	// Jobs left over from a previous run are picked up as soon as the queue
+	// starts. Running jobs are finished before the stores are closed.
+	svc.queue.Start(ctx)
+	lm.OnShutdown(lifecycle.StageFlush, "support jobs", svc.queue.Stop)
+	lm.SetHealth(hs)
 
 	logger.Infof("Support service listening on %s", lis.Addr().String())
-	if err := srv.Serve(lis); err != nil {
+	if err := lm.Serve(ctx, srv, lis); err != nil {
 		log.Fatal(err)
 	}
 }
 
-func getEnvOrDefault(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
+// openStore opens the store selected by SUPPORT_STORE: "memory" (default) or
+// "sqlite", which keeps the database at SUPPORT_SQLITE_PATH.
+func openStore(ctx context.Context) (store.Store, error) {
+	switch kind := getEnvOrDefault("SUPPORT_STORE", "memory"); kind {
+	case "memory":
+		logger.Infof("Storing support requests in memory")
+		return store.NewMemory(), nil
+	case "sqlite":
+		path := getEnvOrDefault("SUPPORT_SQLITE_PATH", "support.db")
+		logger.Infof("Storing support requests in SQLite database %s", path)
+		return store.OpenSQLite(ctx, path)
+	default:
+		return nil, fmt.Errorf("unsupported SUPPORT_STORE %q", kind)
+	}
+}
+
+// redactionPolicyFromEnv reads the redaction rules in REDACTION_POLICY_FILE,
+// if set, and the hash key in REDACTION_HASH_KEY.
+func redactionPolicyFromEnv() (*redact.Policy, error) {
+	key := getEnvOrDefault("REDACTION_HASH_KEY", "")
+	if key == "" {
+		logger.Warnf("REDACTION_HASH_KEY is not set - hashed personal data may be guessed")
+	}
+	if path := getEnvOrDefault("REDACTION_POLICY_FILE", ""); path != "" {
+		return redact.LoadPolicy(path, key)
+	}
+	return redact.NewPolicy(nil, key)
+}
+
+// ticketConfigFromEnv selects the ticket provider with TICKET_PROVIDER (jira,
+// github, gitlab, linear or file) and reads the settings of each provider.
+func ticketConfigFromEnv() ticket.Config {
+	return ticket.Config{
+		Provider: getEnvOrDefault("TICKET_PROVIDER", ticket.ProviderJira),
+		Jira: ticket.JiraConfig{
+			URL:      getEnvOrDefault("JIRA_URL", ""),
+			Username: getEnvOrDefault("JIRA_USERNAME", ""),
+			APIToken: getEnvOrDefault("JIRA_API_TOKEN", ""),
+			Project:  getEnvOrDefault("JIRA_PROJECT", "DEMO"),
+		},
+		GitHub: ticket.GitHubConfig{
+			APIURL:     getEnvOrDefault("GITHUB_API_URL", ""),
+			Token:      getEnvOrDefault("GITHUB_TOKEN", ""),
+			Repository: getEnvOrDefault("GITHUB_REPOSITORY", ""),
+		},
+		GitLab: ticket.GitLabConfig{
+			URL:     getEnvOrDefault("GITLAB_URL", ""),
+			Token:   getEnvOrDefault("GITLAB_TOKEN", ""),
+			Project: getEnvOrDefault("GITLAB_PROJECT", ""),
+		},
+		Linear: ticket.LinearConfig{
+			APIURL: getEnvOrDefault("LINEAR_API_URL", ""),
+			APIKey: getEnvOrDefault("LINEAR_API_KEY", ""),
+			TeamID: getEnvOrDefault("LINEAR_TEAM_ID", ""),
+		},
+		File: ticket.FileConfig{
+			Path: getEnvOrDefault("TICKET_FILE_PATH", ""),
+		},
 	}
-	return defaultValue
 }
 
-func (s *supportService) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
-	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+// customerNotifierFromEnv returns the notifier named by
+// SUPPORT_CUSTOMER_NOTIFIER, "email" by default, unless it is not
+// configured.
+func customerNotifierFromEnv(d *notify.Dispatcher) string {
+	name := getEnvOrDefault("SUPPORT_CUSTOMER_NOTIFIER", "email")
+	for _, configured := range d.Notifiers() {
+		if configured == name {
+			return name
+		}
+	}
+	logger.Warnf("Customers will not be told about resolved support requests: notifier %q is not configured", name)
+	return ""
 }
 
-func (s *supportService) Watch(req *healthpb.HealthCheckRequest, ws healthpb.Health_WatchServer) error {
-	return status.Errorf(codes.Unimplemented, "health check via Watch not implemented")
+// doraConfigFromEnv reads the DORA metrics service settings. DORA_URL
+// defaults to the dora-metrics service of the demo; setting it to an empty
+// value disables DORA incidents.
+func doraConfigFromEnv() dora.Config {
+	url, ok := os.LookupEnv("DORA_URL")
+	if !ok {
+		url = "http://dora-metrics:8081"
+	}
+	return dora.Config{
+		URL:      url,
+		Token:    os.Getenv("DORA_API_TOKEN"),
+		Username: os.Getenv("DORA_USERNAME"),
+		Password: os.Getenv("DORA_PASSWORD"),
+	}
+}
+
+// notifyConfigFromEnv reads the settings of the notifiers and the routing
+// rules in NOTIFY_RULES_FILE, if set.
+func notifyConfigFromEnv() (notify.Config, error) {
+	cfg := notify.Config{
+		Slack: notify.SlackConfig{
+			WebhookURL: getEnvOrDefault("SLACK_WEBHOOK_URL", ""),
+			Channel:    getEnvOrDefault("SLACK_CHANNEL", "#support"),
+			Format:     getEnvOrDefault("SLACK_FORMAT", notify.SlackAttachments),
+		},
+		Teams: notify.TeamsConfig{
+			WebhookURL: getEnvOrDefault("TEAMS_WEBHOOK_URL", ""),
+		},
+		Webhook: notify.WebhookConfig{
+			URL:    getEnvOrDefault("NOTIFY_WEBHOOK_URL", ""),
+			Secret: getEnvOrDefault("NOTIFY_WEBHOOK_SECRET", ""),
+		},
+		SMTP: notify.SMTPConfig{
+			Host:     getEnvOrDefault("SMTP_HOST", ""),
+			Port:     getEnvOrDefault("SMTP_PORT", "587"),
+			Username: getEnvOrDefault("SMTP_USERNAME", ""),
+			Password: getEnvOrDefault("SMTP_PASSWORD", ""),
+			From:     getEnvOrDefault("SMTP_FROM", ""),
+		},
+		PagerDuty: notify.PagerDutyConfig{
+			RoutingKey: getEnvOrDefault("PAGERDUTY_ROUTING_KEY", ""),
+		},
+	}
+	for _, to := range strings.Split(getEnvOrDefault("SMTP_TO", ""), ",") {
+		if to = strings.TrimSpace(to); to != "" {
+			cfg.SMTP.To = append(cfg.SMTP.To, to)
+		}
+	}
+	if path := getEnvOrDefault("NOTIFY_RULES_FILE", ""); path != "" {
+		rules, err := notify.LoadRules(path)
+		if err != nil {
+			return cfg, err
+		}
+		cfg.Rules = rules
+	}
+	return cfg, nil
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
 }
 
 func (s *supportService) CreateSupportRequest(ctx context.Context, req *pb.CreateSupportRequestRequest) (*pb.CreateSupportRequestResponse, error) {
 	span := trace.SpanFromContext(ctx)
+	redacted := s.redactRequest(req, redact.Telemetry)
 	span.SetAttributes(
 		attribute.String("app.user.id", req.UserId),
-		attribute.String("app.user.email", req.Email),
-		attribute.String("app.support.subject", req.Subject),
+		attribute.String("app.support.subject", redacted.Subject),
 	)
+	if redacted.Email != "" {
+		span.SetAttributes(attribute.String("app.user.email", redacted.Email))
+	}
 
-	logger.Infof("[CreateSupportRequest] user_id=%q email=%q subject=%q", req.UserId, req.Email, req.Subject)
+	logger.WithContext(ctx).Infof("[CreateSupportRequest] user_id=%q email=%q subject=%q", req.UserId, redacted.Email, redacted.Subject)
 
 	// Generate unique ID for the support request
 	supportID, err := uuid.NewUUID()
@@ -286,56 +412,12 @@ func (s *supportService) CreateSupportRequest(ctx context.Context, req *pb.Creat
 		return nil, status.Errorf(codes.Internal, "failed to generate support request ID: %v", err)
 	}
 
-	// Create Jira ticket if configured
-	var jiraTicketID string
-	logger.Infof("Jira config check: URL=%q, APIToken configured=%t", s.jiraConfig.URL, s.jiraConfig.APIToken != "")
-	if s.jiraConfig.URL != "" && s.jiraConfig.APIToken != "" {
-		logger.Infof("Creating Jira ticket with Augment Code instructions...")
-		jiraTicketID, err = s.createJiraTicket(ctx, req)
-		if err != nil {
-			logger.Warnf("Failed to create Jira ticket: %v", err)
-			span.AddEvent("jira_ticket_creation_failed", trace.WithAttributes(
-				attribute.String("error", err.Error()),
-			))
-		} else {
-			logger.Infof("Created Jira ticket: %s", jiraTicketID)
-			span.AddEvent("jira_ticket_created", trace.WithAttributes(
-				attribute.String("jira.ticket.id", jiraTicketID),
-			))
-
-			// Send Slack notification if configured
-			if s.slackConfig.WebhookURL != "" {
-				err = s.sendSlackNotification(ctx, req, jiraTicketID)
-				if err != nil {
-					logger.Warnf("Failed to send Slack notification: %v", err)
-					span.AddEvent("slack_notification_failed", trace.WithAttributes(
-						attribute.String("error", err.Error()),
-					))
-				} else {
-					logger.Infof("Sent Slack notification for Jira ticket: %s", jiraTicketID)
-					span.AddEvent("slack_notification_sent", trace.WithAttributes(
-						attribute.String("jira.ticket.id", jiraTicketID),
-					))
-				}
-			}
-
-			// Create DORA metrics incident
-			err = s.createDoraIncident(ctx, req, jiraTicketID)
-			if err != nil {
-				logger.Warnf("Failed to create DORA metrics incident: %v", err)
-				span.AddEvent("dora_incident_creation_failed", trace.WithAttributes(
-					attribute.String("error", err.Error()),
-				))
-			} else {
-				logger.Infof("Created DORA metrics incident for Jira ticket: %s", jiraTicketID)
-				span.AddEvent("dora_incident_created", trace.WithAttributes(
-					attribute.String("jira.ticket.id", jiraTicketID),
-				))
-			}
-		}
-	} else {
-		logger.Warnf("Jira not configured - skipping ticket creation. URL=%q, APIToken configured=%t", s.jiraConfig.URL, s.jiraConfig.APIToken != "")
+	service := serviceOf(req)
+	var productIDs []string
+	for _, item := range req.FailedItems {
+		productIDs = append(productIDs, item.GetItem().GetProductId())
 	}
+	now := time.Now()
 
 	// Create support request response
 	supportRequest := &pb.SupportRequest{
@@ -347,15 +429,70 @@ func (s *supportService) CreateSupportRequest(ctx context.Context, req *pb.Creat
 		ErrorMessage:    req.ErrorMessage,
 		FailedItems:     req.FailedItems,
 		ShippingAddress: req.ShippingAddress,
-		Status:          "CREATED",
-		JiraTicketId:    jiraTicketID,
-		CreatedAt:       time.Now().Unix(),
+		Status:          store.StatusCreated,
+		CreatedAt:       now.Unix(),
+		Service:         service,
+		Fingerprint:     dedup.Fingerprint(service, req.ErrorMessage, productIDs),
+	}
+	if s.tickets != nil {
+		supportRequest.TicketStatus = store.TicketPending
+	}
+	if sc := span.SpanContext(); sc.IsValid() {
+		supportRequest.TraceId = sc.TraceID().String()
+		supportRequest.SpanId = sc.SpanID().String()
+	}
+
+	// Reports of the same failure within the deduplication window share one
+	// incident and its ticket.
+	incident, newIncident, err := s.store.Group(ctx, store.Report{
+		Fingerprint:   supportRequest.Fingerprint,
+		RequestID:     supportRequest.Id,
+		UserID:        supportRequest.UserId,
+		At:            now,
+		NewIncidentID: uuid.NewString(),
+	}, s.dedupWindow)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to group support request: %v", err)
+	}
+	supportRequest.IncidentId = incident.ID
+	span.SetAttributes(
+		attribute.String("app.support.fingerprint", supportRequest.Fingerprint),
+		attribute.String("app.support.incident.id", incident.ID),
+		attribute.Bool("app.support.incident.duplicate", !newIncident),
+		attribute.Int("app.support.incident.affected_users", len(incident.UserIDs)),
+	)
+
+	if err := s.store.Create(ctx, supportRequest); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to store support request: %v", err)
+	}
+
+	// The ticket, and the notifications and DORA incident that follow it, are
+	// created in the background so that the caller does not wait for them. A
+	// duplicate is added to the ticket of its incident as a comment instead.
+	if s.tickets != nil {
+		kind := jobTicket
+		if !newIncident {
+			kind = jobComment
+			logger.WithContext(ctx).Infof("Support request %s duplicates incident %s, affecting %d users", supportRequest.Id, incident.ID, len(incident.UserIDs))
+		}
+		if err := s.queue.Enqueue(ctx, kind, supportRequest.Id, ""); err != nil {
+			logger.WithContext(ctx).Errorf("Failed to schedule %s ticket %s: %v", s.tickets.Name(), kind, err)
+			if _, err := s.store.SetTicket(ctx, supportRequest.Id, store.Ticket{Status: store.TicketFailed}); err != nil {
+				logger.WithContext(ctx).Errorf("Failed to mark the ticket as failed: %v", err)
+			}
+		}
+	} else {
+		logger.WithContext(ctx).Warnf("No ticket provider configured - skipping ticket creation")
+	}
+	// Return the stored request so that the response carries its history.
+	if stored, err := s.store.Get(ctx, supportRequest.Id); err == nil {
+		supportRequest = stored
 	}
 
 	span.SetAttributes(
 		attribute.String("app.support.id", supportRequest.Id),
 		attribute.String("app.support.status", supportRequest.Status),
-		attribute.String("app.jira.ticket.id", jiraTicketID),
+		attribute.String("app.ticket.status", supportRequest.TicketStatus),
 	)
 
 	return &pb.CreateSupportRequestResponse{
@@ -369,290 +506,370 @@ func (s *supportService) GetSupportRequest(ctx context.Context, req *pb.GetSuppo
 		attribute.String("app.support.id", req.Id),
 	)
 
-	logger.Infof("[GetSupportRequest] id=%q", req.Id)
+	logger.WithContext(ctx).Infof("[GetSupportRequest] id=%q", req.Id)
 
-	// For now, return a simple response indicating the feature is not fully implemented
-	// In a real implementation, this would fetch from a database
-	return nil, status.Errorf(codes.Unimplemented, "GetSupportRequest not yet implemented - use CreateSupportRequest to create tickets")
+	if req.Id == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "support request id is required")
+	}
+	supportRequest, err := s.store.Get(ctx, req.Id)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	span.SetAttributes(attribute.String("app.support.status", supportRequest.Status))
+	return supportRequest, nil
 }
 
-func (s *supportService) createJiraTicket(ctx context.Context, req *pb.CreateSupportRequestRequest) (string, error) {
+func (s *supportService) ListSupportRequests(ctx context.Context, req *pb.ListSupportRequestsRequest) (*pb.ListSupportRequestsResponse, error) {
 	span := trace.SpanFromContext(ctx)
 	span.SetAttributes(
-		attribute.String("jira.url", s.jiraConfig.URL),
-		attribute.String("jira.project", s.jiraConfig.Project),
+		attribute.String("app.user.id", req.UserId),
+		attribute.String("app.support.status", req.Status),
 	)
 
-	// Build description with order details
-	description := fmt.Sprintf("Support request from user: %s\n\nEmail: %s\n\nDescription: %s\n\nError Message: %s\n\n",
-		req.UserId, req.Email, req.Description, req.ErrorMessage)
+	logger.WithContext(ctx).Infof("[ListSupportRequests] user_id=%q status=%q created_after=%d created_before=%d",
+		req.UserId, req.Status, req.CreatedAfter, req.CreatedBefore)
 
-	if len(req.FailedItems) > 0 {
-		description += "Failed Items:\n"
-		for _, item := range req.FailedItems {
-			description += fmt.Sprintf("- Product ID: %s, Quantity: %d\n", item.Item.ProductId, item.Item.Quantity)
-		}
+	if req.Status != "" && !store.ValidStatus(req.Status) {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown support request status %q", req.Status)
 	}
-
-	if req.ShippingAddress != nil {
-		description += fmt.Sprintf("\nShipping Address:\n%s\n%s, %s %s\n%s\n",
-			req.ShippingAddress.StreetAddress,
-			req.ShippingAddress.City,
-			req.ShippingAddress.State,
-			req.ShippingAddress.ZipCode,
-			req.ShippingAddress.Country)
+	filter := store.Filter{UserID: req.UserId, Status: req.Status}
+	if req.CreatedAfter > 0 {
+		filter.CreatedAfter = time.Unix(req.CreatedAfter, 0)
 	}
-
-	// Create Jira issue payload with Augment Code instructions
-	jiraIssue := JiraIssue{
-		Fields: JiraFields{
-			Project: JiraProject{
-				Key: s.jiraConfig.Project,
-			},
-			Summary: req.Subject,
-			Description: JiraDescription{
-				Type:    "doc",
-				Version: 1,
-				Content: s.buildJiraDescriptionContent(description, req),
-			},
-			IssueType: JiraIssueType{
-				Name: "Bug",
-			},
-		},
+	if req.CreatedBefore > 0 {
+		filter.CreatedBefore = time.Unix(req.CreatedBefore, 0)
 	}
-
-	// Convert to JSON
-	payload, err := json.Marshal(jiraIssue)
-	if err != nil {
-		return "", fmt.Errorf("failed to marshal Jira issue: %v", err)
+	if !filter.CreatedAfter.IsZero() && !filter.CreatedBefore.IsZero() && filter.CreatedBefore.Before(filter.CreatedAfter) {
+		return nil, status.Errorf(codes.InvalidArgument, "created_before is before created_after")
 	}
 
-	// Create HTTP request
-	url := fmt.Sprintf("%s/rest/api/3/issue", s.jiraConfig.URL)
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(payload))
+	supportRequests, err := s.store.List(ctx, filter)
 	if err != nil {
-		return "", fmt.Errorf("failed to create HTTP request: %v", err)
+		return nil, storeError(err)
 	}
+	span.SetAttributes(attribute.Int("app.support.count", len(supportRequests)))
+	return &pb.ListSupportRequestsResponse{SupportRequests: supportRequests}, nil
+}
+
+func (s *supportService) UpdateSupportRequestStatus(ctx context.Context, req *pb.UpdateSupportRequestStatusRequest) (*pb.SupportRequest, error) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.String("app.support.id", req.Id),
+		attribute.String("app.support.status", req.Status),
+	)
 
-	// Set headers
-	httpReq.Header.Set("Content-Type", "application/json")
-	httpReq.Header.Set("Authorization", fmt.Sprintf("Basic %s",
-		base64.StdEncoding.EncodeToString([]byte(s.jiraConfig.Username+":"+s.jiraConfig.APIToken))))
+	logger.WithContext(ctx).Infof("[UpdateSupportRequestStatus] id=%q status=%q actor=%q", req.Id, req.Status, req.Actor)
 
-	// Make request
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(httpReq)
+	if req.Id == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "support request id is required")
+	}
+	supportRequest, err := s.store.UpdateStatus(ctx, req.Id, store.StatusChange{
+		To:     req.Status,
+		Actor:  req.Actor,
+		Reason: req.Reason,
+		At:     time.Now(),
+	})
 	if err != nil {
-		return "", fmt.Errorf("failed to make Jira API request: %v", err)
+		return nil, storeError(err)
 	}
-	defer resp.Body.Close()
 
-	// Check response status
-	if resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return "", fmt.Errorf("Jira API returned status %d: %s", resp.StatusCode, string(body))
+	s.afterStatusChange(ctx, supportRequest)
+	return supportRequest, nil
+}
+
+// afterStatusChange records the status change of sr, whether requested
+// through UpdateSupportRequestStatus or by a ticket webhook, and schedules
+// what follows from it.
+func (s *supportService) afterStatusChange(ctx context.Context, sr *pb.SupportRequest) {
+	history := sr.History
+	trace.SpanFromContext(ctx).AddEvent("support_request_status_changed", trace.WithAttributes(
+		attribute.String("app.support.id", sr.Id),
+		attribute.String("app.support.status.from", history[len(history)-1].FromStatus),
+		attribute.String("app.support.status.to", sr.Status),
+	))
+	if sr.Status != store.StatusResolved {
+		return
 	}
 
-	// Parse response
-	var jiraResp JiraResponse
-	if err := json.NewDecoder(resp.Body).Decode(&jiraResp); err != nil {
-		return "", fmt.Errorf("failed to decode Jira response: %v", err)
+	// Resolving the request that opened an incident resolves its DORA
+	// incident; duplicates have none. The target tells the resolutions of a
+	// reopened request apart.
+	if s.opensDoraIncident(ctx, sr) {
+		if err := s.queue.Enqueue(ctx, jobDoraResolve, sr.Id, strconv.Itoa(len(history))); err != nil {
+			logger.WithContext(ctx).Errorf("Failed to schedule the resolution of the DORA incident: %v", err)
+		}
 	}
+	if s.customerNotifier != "" && sr.Email != "" {
+		if err := s.queue.Enqueue(ctx, jobNotifyCustomer, sr.Id, strconv.Itoa(len(history))); err != nil {
+			logger.WithContext(ctx).Errorf("Failed to schedule the resolution notification: %v", err)
+		}
+	}
+}
 
+func (s *supportService) GetSupportIncident(ctx context.Context, req *pb.GetSupportIncidentRequest) (*pb.SupportIncident, error) {
+	span := trace.SpanFromContext(ctx)
 	span.SetAttributes(
-		attribute.String("jira.issue.id", jiraResp.ID),
-		attribute.String("jira.issue.key", jiraResp.Key),
+		attribute.String("app.support.incident.id", req.Id),
 	)
 
-	return jiraResp.Key, nil
+	logger.WithContext(ctx).Infof("[GetSupportIncident] id=%q", req.Id)
+
+	if req.Id == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "incident id is required")
+	}
+	incident, err := s.store.GetIncident(ctx, req.Id)
+	if err != nil {
+		return nil, storeError(err)
+	}
+	span.SetAttributes(attribute.Int("app.support.incident.affected_users", len(incident.UserIDs)))
+	return &pb.SupportIncident{
+		Id:                incident.ID,
+		Fingerprint:       incident.Fingerprint,
+		SupportRequestIds: incident.RequestIDs,
+		AffectedUserCount: int32(len(incident.UserIDs)),
+		TicketStatus:      incident.Ticket.Status,
+		TicketKey:         incident.Ticket.Key,
+		TicketUrl:         incident.Ticket.URL,
+		FirstSeenAt:       incident.FirstSeen.Unix(),
+		LastSeenAt:        incident.LastSeen.Unix(),
+	}, nil
 }
 
-// buildJiraDescriptionContent creates the Jira description content with Augment Code instructions
-func (s *supportService) buildJiraDescriptionContent(description string, req *pb.CreateSupportRequestRequest) []JiraContent {
-	logger.Infof("Building Jira description content with Augment Code instructions")
-
-	// Add the Augment Code instructions as simple text to the description
-	augmentInstructions := `
-
-=== AUGMENT CODE INSTRUCTIONS ===
-
-ACTION REQUESTED:
-- Please review the details above and analyze the codebase for root cause.
-- Summarize the specific cause (function, line, feature flag, business logic) and outline the fix you recommend.
-- Before making any changes, ASK THE USER: "Would you like Augment Code to apply this fix automatically?"
-
-If the user responds YES:
-- Create a new branch named bugfix/JIRA-<ticket_id>-checkout-threshold
-- Apply the code fix as outlined
-- Add/modify relevant tests to cover this scenario
-- Open a Pull Request on GitHub via MCP, referencing the Jira ticket and including a summary of the fix
-- Notify the user via Slack when the PR is ready for review, with links to both the PR and Jira ticket
-
-If the user responds NO:
-- Stop and provide the recommended fix steps and reasoning only.`
-
-	// Combine original description with instructions
-	fullDescription := description + augmentInstructions
-
-	logger.Infof("Full description length: %d characters", len(fullDescription))
-	logger.Infof("Description preview: %s...", fullDescription[:min(200, len(fullDescription))])
-
-	content := []JiraContent{
-		{
-			Type: "paragraph",
-			Content: []JiraText{
-				{
-					Type: "text",
-					Text: fullDescription,
-				},
-			},
-		},
+// storeError converts an error returned by the store to a gRPC status.
+func storeError(err error) error {
+	var unknown *store.UnknownStatusError
+	var invalid *store.InvalidTransitionError
+	switch {
+	case errors.Is(err, store.ErrNotFound), errors.Is(err, store.ErrIncidentNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.As(err, &unknown):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.As(err, &invalid):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return status.Errorf(codes.Internal, "support request store failure: %v", err)
 	}
+}
 
-	logger.Infof("Generated %d content elements for Jira", len(content))
-	return content
+// checkTickets is a readiness check that fails while the configured ticket
+// provider cannot be reached. Without a provider, the ticket jobs are
+// skipped, as logged at startup, so it passes.
+func (s *supportService) checkTickets(ctx context.Context) error {
+	if s.tickets == nil {
+		return nil
+	}
+	return s.tickets.Ping(ctx)
 }
 
-func (s *supportService) sendSlackNotification(ctx context.Context, req *pb.CreateSupportRequestRequest, jiraTicketID string) error {
+func (s *supportService) createTicket(ctx context.Context, req *pb.CreateSupportRequestRequest) (ticket.Ref, error) {
 	span := trace.SpanFromContext(ctx)
 	span.SetAttributes(
-		attribute.String("slack.webhook.url", s.slackConfig.WebhookURL),
-		attribute.String("slack.channel", s.slackConfig.Channel),
-		attribute.String("jira.ticket.id", jiraTicketID),
+		attribute.String("app.ticket.provider", s.tickets.Name()),
 	)
+	req = s.redactRequest(req, redact.Ticket)
 
-	// Build Jira ticket URL
-	jiraTicketURL := fmt.Sprintf("%s/browse/%s", s.jiraConfig.URL, jiraTicketID)
-
-	// Create Slack message
-	slackMessage := SlackMessage{
-		Channel: s.slackConfig.Channel,
-		Text:    fmt.Sprintf("🚨 New Support Ticket Created: %s", req.Subject),
-		Attachments: []SlackAttachment{
-			{
-				Color:     "danger",
-				Title:     fmt.Sprintf("Jira Ticket: %s", jiraTicketID),
-				TitleLink: jiraTicketURL,
-				Text:      fmt.Sprintf("A new support ticket has been created for a checkout failure.\n\n*Error:* %s", req.ErrorMessage),
-				Fields: []SlackField{
-					{
-						Title: "User ID",
-						Value: req.UserId,
-						Short: true,
-					},
-					{
-						Title: "Email",
-						Value: req.Email,
-						Short: true,
-					},
-					{
-						Title: "Subject",
-						Value: req.Subject,
-						Short: false,
-					},
-				},
-				Footer:    "OpenTelemetry Demo Support",
-				Timestamp: time.Now().Unix(),
-			},
-		},
-	}
+	// Build description with order details. The Jira templates render the
+	// error and the order details themselves, after the overview.
+	overview := fmt.Sprintf("Support request from user: %s\n\nEmail: %s\n\nDescription: %s\n\n",
+		req.UserId, req.Email, req.Description)
+	description := overview + fmt.Sprintf("Error Message: %s\n\n", req.ErrorMessage)
 
-	// Add failed items if present
 	if len(req.FailedItems) > 0 {
-		var itemsText string
+		description += "Failed Items:\n"
 		for _, item := range req.FailedItems {
-			itemsText += fmt.Sprintf("• Product ID: %s (Qty: %d)\n", item.Item.ProductId, item.Item.Quantity)
+			description += fmt.Sprintf("- Product ID: %s, Quantity: %d\n", item.Item.ProductId, item.Item.Quantity)
 		}
-		slackMessage.Attachments[0].Fields = append(slackMessage.Attachments[0].Fields, SlackField{
-			Title: "Failed Items",
-			Value: itemsText,
-			Short: false,
-		})
 	}
 
-	// Convert to JSON
-	payload, err := json.Marshal(slackMessage)
+	if req.ShippingAddress != nil {
+		description += fmt.Sprintf("\nShipping Address:\n%s\n%s, %s %s\n%s\n",
+			req.ShippingAddress.StreetAddress,
+			req.ShippingAddress.City,
+			req.ShippingAddress.State,
+			req.ShippingAddress.ZipCode,
+			req.ShippingAddress.Country)
+	}
+
+	// The Jira provider uses the ADF content rendered from the ticket
+	// templates; the other providers file the plain text description.
+	ref, err := s.tickets.Create(ctx, ticket.Issue{
+		Summary:     req.Subject,
+		Description: description,
+		Type:        issueTypeOf(req),
+		ADFContent:  s.buildJiraDescriptionContent(overview, req),
+	})
 	if err != nil {
-		return fmt.Errorf("failed to marshal Slack message: %v", err)
+		return ticket.Ref{}, err
 	}
 
-	// Create HTTP request
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", s.slackConfig.WebhookURL, bytes.NewBuffer(payload))
+	span.SetAttributes(
+		attribute.String("app.ticket.id", ref.ID),
+		attribute.String("app.ticket.key", ref.Key),
+	)
+
+	return ref, nil
+}
+
+// buildJiraDescriptionContent renders the Jira description of req from the
+// ticket template of its issue type. description is the plain text overview
+// the template starts with. If the template fails, the overview is filed as
+// is, so that the ticket is still created.
+func (s *supportService) buildJiraDescriptionContent(description string, req *pb.CreateSupportRequestRequest) []JiraContent {
+	templates := s.templates
+	if templates == nil {
+		var err error
+		if templates, err = builtinTemplates(); err != nil {
+			logger.Errorf("Failed to load the built-in ticket templates: %v", err)
+			return jiraContent(adf.Parse(adf.Escape(description)))
+		}
+	}
+
+	data := s.ticketTemplateData(description, req)
+	nodes, err := templates.Render(data.IssueType, data)
 	if err != nil {
-		return fmt.Errorf("failed to create HTTP request: %v", err)
+		logger.Errorf("Failed to render the Jira description: %v", err)
+		return jiraContent(adf.Parse(adf.Escape(description)))
 	}
 
-	// Set headers
-	httpReq.Header.Set("Content-Type", "application/json")
+	logger.Infof("Generated %d content elements for Jira", len(nodes))
+	return jiraContent(nodes)
+}
+
+// notification describes the ticket filed for sr to the notifiers.
+func (s *supportService) notification(ctx context.Context, sr *pb.SupportRequest) notify.Notification {
+	r := s.redaction.For(redact.Notification)
+	n := notify.Notification{
+		Subject:   r.Text(sr.Subject),
+		Severity:  severityOf(requestOf(sr)),
+		Message:   r.Text(sr.ErrorMessage),
+		UserID:    sr.UserId,
+		Email:     r.Email(sr.Email),
+		TicketKey: sr.JiraTicketId,
+		TicketURL: sr.TicketUrl,
+		Time:      time.Unix(sr.CreatedAt, 0),
+	}
+	for _, item := range sr.FailedItems {
+		n.FailedItems = append(n.FailedItems, notify.Item{
+			ProductID:  item.GetItem().GetProductId(),
+			Quantity:   item.GetItem().GetQuantity(),
+			Categories: s.productCategories(ctx, item.GetItem().GetProductId()),
+		})
+	}
+	return n
+}
 
-	// Make request
-	client := &http.Client{Timeout: 30 * time.Second}
-	resp, err := client.Do(httpReq)
+// productCategories returns the categories of a product, or nil if they
+// cannot be looked up.
+func (s *supportService) productCategories(ctx context.Context, productID string) []string {
+	if s.catalog == nil {
+		return nil
+	}
+	product, err := s.catalog.GetProduct(ctx, &pb.GetProductRequest{Id: productID})
 	if err != nil {
-		return fmt.Errorf("failed to make Slack webhook request: %v", err)
+		logger.WithContext(ctx).Warnf("Failed to look up categories of product %q: %v", productID, err)
+		return nil
 	}
-	defer resp.Body.Close()
+	return product.GetCategories()
+}
 
-	// Check response status
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("Slack webhook returned status %d: %s", resp.StatusCode, string(body))
+// severityOf derives the severity of a support request: payment failures are
+// critical, failed orders high and anything else medium.
+func severityOf(req *pb.CreateSupportRequestRequest) string {
+	msg := strings.ToLower(req.ErrorMessage)
+	switch {
+	case strings.Contains(msg, "payment") || strings.Contains(msg, "charge"):
+		return notify.SeverityCritical
+	case len(req.FailedItems) > 0 || req.ErrorMessage != "":
+		return notify.SeverityHigh
+	default:
+		return notify.SeverityMedium
 	}
+}
 
-	return nil
+// services maps words found in error messages to the service they blame.
+var services = []struct {
+	keywords []string
+	service  string
+}{
+	{[]string{"payment", "charge", "card"}, "payment"},
+	{[]string{"shipping", "shipment", "quote"}, "shipping"},
+	{[]string{"currency", "conversion"}, "currency"},
+	{[]string{"cart"}, "cart"},
+	{[]string{"catalog"}, "product-catalog"},
 }
 
-func (s *supportService) createDoraIncident(ctx context.Context, req *pb.CreateSupportRequestRequest, jiraTicketID string) error {
+// serviceOf returns the service that failed: the one named by the request,
+// or else the one its error message blames, defaulting to checkout, which
+// reports most failures.
+func serviceOf(req *pb.CreateSupportRequestRequest) string {
+	if req.Service != "" {
+		return req.Service
+	}
+	msg := strings.ToLower(req.ErrorMessage)
+	for _, s := range services {
+		for _, keyword := range s.keywords {
+			if strings.Contains(msg, keyword) {
+				return s.service
+			}
+		}
+	}
+	return "checkout"
+}
+
+// createDoraIncident records the incident of a support request with the DORA
+// metrics service and returns its ID.
+func (s *supportService) createDoraIncident(ctx context.Context, sr *pb.SupportRequest) (string, error) {
+	req := s.redactRequest(requestOf(sr), redact.Dora)
 	span := trace.SpanFromContext(ctx)
 	span.SetAttributes(
-		attribute.String("dora.incident.jira_ticket_id", jiraTicketID),
-		attribute.String("dora.incident.service", "checkout"),
+		attribute.String("dora.incident.jira_ticket_id", sr.JiraTicketId),
+		attribute.String("dora.incident.service", sr.Service),
+		attribute.String("dora.incident.severity", severityOf(req)),
 	)
 
-	// Build DORA incident payload
-	doraIncident := map[string]interface{}{
-		"title":        req.Subject,
-		"description":  fmt.Sprintf("Support request from user %s: %s\n\nError: %s\n\nJira Ticket: %s", req.UserId, req.Description, req.ErrorMessage, jiraTicketID),
-		"service":      "checkout",
-		"severity":     "high",
-		"source":       "support-request",
-		"jiraTicketId": jiraTicketID,
-		"metadata": map[string]interface{}{
-			"userId":       req.UserId,
-			"email":        req.Email,
-			"errorMessage": req.ErrorMessage,
-		},
-	}
+	return s.dora.Create(ctx, dora.Incident{
+		Title:        req.Subject,
+		Description:  fmt.Sprintf("Support request from user %s: %s\n\nError: %s\n\nJira Ticket: %s", req.UserId, req.Description, req.ErrorMessage, sr.JiraTicketId),
+		Service:      sr.Service,
+		Severity:     severityOf(req),
+		TicketKey:    sr.JiraTicketId,
+		TraceID:      sr.TraceId,
+		SpanID:       sr.SpanId,
+		ErrorDetails: req.ErrorMessage,
+		Tags:         []string{"support-request", "incident:" + sr.IncidentId},
+	})
+}
 
-	// Convert to JSON
-	payload, err := json.Marshal(doraIncident)
-	if err != nil {
-		return fmt.Errorf("failed to marshal DORA incident: %v", err)
+// opensDoraIncident reports whether a DORA incident is, or will be, recorded
+// for sr: it is only recorded after the ticket of the request that opened an
+// incident is filed.
+func (s *supportService) opensDoraIncident(ctx context.Context, sr *pb.SupportRequest) bool {
+	if s.dora == nil || s.tickets == nil {
+		return false
 	}
-
-	// Create HTTP request to DORA metrics service
-	doraURL := "http://dora-metrics:8081/incidents"
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", doraURL, bytes.NewBuffer(payload))
-	if err != nil {
-		return fmt.Errorf("failed to create DORA HTTP request: %v", err)
+	if sr.DoraIncidentId != "" || sr.IncidentId == "" {
+		return true
 	}
-
-	// Set headers
-	httpReq.Header.Set("Content-Type", "application/json")
-
-	// Make request
-	client := &http.Client{Timeout: 10 * time.Second}
-	resp, err := client.Do(httpReq)
+	incident, err := s.store.GetIncident(ctx, sr.IncidentId)
 	if err != nil {
-		return fmt.Errorf("failed to make DORA API request: %v", err)
+		logger.WithContext(ctx).Errorf("Failed to look up incident %s: %v", sr.IncidentId, err)
+		return false
 	}
-	defer resp.Body.Close()
+	return len(incident.RequestIDs) > 0 && incident.RequestIDs[0] == sr.Id
+}
 
-	// Check response status
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("DORA API returned status %d: %s", resp.StatusCode, string(body))
+// redactRequest returns a copy of req with the personal data redacted as the
+// policy requires for dest.
+func (s *supportService) redactRequest(req *pb.CreateSupportRequestRequest, dest string) *pb.CreateSupportRequestRequest {
+	r := s.redaction.For(dest)
+	out := proto.Clone(req).(*pb.CreateSupportRequestRequest)
+	out.Email = r.Email(out.Email)
+	out.Subject = r.Text(out.Subject)
+	out.Description = r.Text(out.Description)
+	out.ErrorMessage = r.Text(out.ErrorMessage)
+	if addr := out.ShippingAddress; addr != nil {
+		addr.StreetAddress = r.StreetAddress(addr.StreetAddress)
+		addr.ZipCode = r.ZipCode(addr.ZipCode)
 	}
-
-	return nil
+	return out
 }
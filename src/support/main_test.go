@@ -4,9 +4,32 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/opentelemetry/opentelemetry-demo/src/support/dora"
 	pb "github.com/opentelemetry/opentelemetry-demo/src/support/genproto/oteldemo"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/jobs"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/notify"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/redact"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/store"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/ticket"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/webhook"
 )
 
 func TestBuildJiraDescriptionContent(t *testing.T) {
@@ -123,3 +146,398 @@ func TestJiraContentStructure(t *testing.T) {
 		}
 	}
 }
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestTicketTemplatesGolden(t *testing.T) {
+	s := &supportService{productURL: "http://localhost:8080/product/"}
+	tests := []struct {
+		golden string
+		req    *pb.CreateSupportRequestRequest
+	}{
+		{"ticket_bug.golden.json", &pb.CreateSupportRequestRequest{
+			UserId:       "user-1",
+			Email:        "j***@example.com",
+			Description:  "Checkout failed twice.\n- I tried another card",
+			ErrorMessage: "Order contains expensive items: item 66VCHSJNUP costs $349.95 which exceeds the threshold of $25",
+			FailedItems: []*pb.OrderItem{
+				{Item: &pb.CartItem{ProductId: "66VCHSJNUP", Quantity: 1}},
+				{Item: &pb.CartItem{ProductId: "OLJCESPC7Z", Quantity: 3}},
+			},
+			ShippingAddress: &pb.Address{City: "Mountain View", State: "CA", Country: "USA", ZipCode: "94***"},
+		}},
+		{"ticket_task.golden.json", &pb.CreateSupportRequestRequest{
+			UserId:      "user-2",
+			Email:       "m***@example.com",
+			Description: "How do I change the **shipping address** of an order?",
+		}},
+	}
+	for _, tt := range tests {
+		overview := fmt.Sprintf("Support request from user: %s\n\nEmail: %s\n\nDescription: %s\n\n", tt.req.UserId, tt.req.Email, tt.req.Description)
+		data, err := json.MarshalIndent(s.buildJiraDescriptionContent(overview, tt.req), "", "  ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		data = append(data, '\n')
+		path := filepath.Join("testdata", tt.golden)
+		if *update {
+			if err := os.WriteFile(path, data, 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		want, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, want) {
+			t.Errorf("%s differs from the golden file (run go test -update to accept):\n%s", tt.golden, data)
+		}
+	}
+}
+
+// rawPII is the personal data of piiRequest, none of which may reach a sink
+// with the default redaction policy.
+var rawPII = []string{"jane.doe@example.com", "jane.doe", "1600 Amphitheatre Parkway", "94043", "4111 1111 1111 1111"}
+
+var piiRequest = &pb.CreateSupportRequestRequest{
+	UserId:       "user-1",
+	Email:        "jane.doe@example.com",
+	Subject:      "Payment failed for jane.doe@example.com",
+	Description:  "I paid with 4111 1111 1111 1111, reach me at jane.doe@example.com",
+	ErrorMessage: "payment declined for card 4111 1111 1111 1111",
+	FailedItems:  []*pb.OrderItem{{Item: &pb.CartItem{ProductId: "OLJCESPC7Z", Quantity: 1}}},
+	ShippingAddress: &pb.Address{
+		StreetAddress: "1600 Amphitheatre Parkway",
+		City:          "Mountain View",
+		State:         "CA",
+		Country:       "USA",
+		ZipCode:       "94043",
+	},
+}
+
+type fakeProvider struct {
+	issues   []ticket.Issue
+	comments []string
+	down     bool
+}
+
+func (f *fakeProvider) Name() string { return "fake" }
+
+func (f *fakeProvider) Create(_ context.Context, issue ticket.Issue) (ticket.Ref, error) {
+	f.issues = append(f.issues, issue)
+	return ticket.Ref{ID: "1", Key: "FAKE-1"}, nil
+}
+
+func (f *fakeProvider) Comment(_ context.Context, _ ticket.Ref, body string) error {
+	f.comments = append(f.comments, body)
+	return nil
+}
+
+func (f *fakeProvider) Ping(context.Context) error {
+	if f.down {
+		return errors.New("tracker is down")
+	}
+	return nil
+}
+
+type fakeNotifier struct {
+	sent []notify.Notification
+}
+
+func (f *fakeNotifier) Name() string { return "fake" }
+
+func (f *fakeNotifier) Notify(_ context.Context, n notify.Notification) error {
+	f.sent = append(f.sent, n)
+	return nil
+}
+
+// assertNoPII fails if the JSON encoding of v contains personal data.
+func assertNoPII(t *testing.T, sink string, v any) {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, pii := range rawPII {
+		if strings.Contains(string(data), pii) {
+			t.Errorf("%s received %q: %s", sink, pii, data)
+		}
+	}
+}
+
+func TestNoRawPIIReachesSinks(t *testing.T) {
+	policy, err := redact.NewPolicy(nil, "test key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tickets := &fakeProvider{}
+	notifier := &fakeNotifier{}
+	dispatcher, err := notify.NewDispatcher([]notify.Notifier{notifier}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doraBody []byte
+	doraServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		doraBody, _ = io.ReadAll(r.Body)
+		fmt.Fprint(w, `{"id": "dora-1"}`)
+	}))
+	defer doraServer.Close()
+	doraClient, err := dora.New(dora.Config{URL: doraServer.URL})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &supportService{
+		store:     store.NewMemory(),
+		notifier:  dispatcher,
+		tickets:   tickets,
+		queue:     jobs.NewQueue(jobs.NewMemory(), jobs.Config{}, logger),
+		redaction: policy,
+		dora:      doraClient,
+	}
+	s.registerJobs()
+
+	var logs bytes.Buffer
+	logger.SetOutput(&logs)
+	defer logger.SetOutput(io.Discard)
+
+	sr := tracetest.NewSpanRecorder()
+	ctx, span := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)).Tracer("test").Start(context.Background(), "CreateSupportRequest")
+	resp, err := s.CreateSupportRequest(ctx, piiRequest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	job := jobs.Job{Kind: jobTicket, SupportRequestID: resp.SupportRequest.Id}
+	if err := s.runTicketJob(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	job.Kind, job.Target = jobNotify, notifier.Name()
+	if err := s.runNotifyJob(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.runDoraJob(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	span.End()
+
+	if len(tickets.issues) != 1 || len(notifier.sent) != 1 || doraBody == nil {
+		t.Fatalf("sinks received %d tickets, %d notifications, DORA %q", len(tickets.issues), len(notifier.sent), doraBody)
+	}
+	assertNoPII(t, "ticket", tickets.issues)
+	assertNoPII(t, "notification", notifier.sent)
+	assertNoPII(t, "DORA", string(doraBody))
+	assertNoPII(t, "logs", logs.String())
+	for _, ended := range sr.Ended() {
+		var telemetry []string
+		for _, attr := range ended.Attributes() {
+			telemetry = append(telemetry, fmt.Sprintf("%s=%s", attr.Key, attr.Value.Emit()))
+		}
+		for _, event := range ended.Events() {
+			for _, attr := range event.Attributes {
+				telemetry = append(telemetry, fmt.Sprintf("%s=%s", attr.Key, attr.Value.Emit()))
+			}
+		}
+		assertNoPII(t, "span "+ended.Name(), telemetry)
+	}
+
+	// The redacted data remains useful to support staff.
+	if !strings.Contains(tickets.issues[0].Description, "j***@example.com") || !strings.Contains(tickets.issues[0].Description, "1111") {
+		t.Errorf("ticket description lost the masked data:\n%s", tickets.issues[0].Description)
+	}
+}
+
+func TestDoraIncidentLifecycle(t *testing.T) {
+	var requests []string
+	var created, resolved map[string]any
+	doraServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.Header.Get("Authorization"))
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/incidents":
+			json.NewDecoder(r.Body).Decode(&created)
+			fmt.Fprint(w, `{"id": "dora-1"}`)
+		case r.Method == http.MethodPut && r.URL.Path == "/incidents/dora-1/resolve":
+			json.NewDecoder(r.Body).Decode(&resolved)
+			fmt.Fprint(w, `{"id": "dora-1", "status": "resolved"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer doraServer.Close()
+	doraClient, err := dora.New(dora.Config{URL: doraServer.URL, Token: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	dispatcher, err := notify.NewDispatcher(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &supportService{
+		store:    store.NewMemory(),
+		notifier: dispatcher,
+		tickets:  &fakeProvider{},
+		queue:    jobs.NewQueue(jobs.NewMemory(), jobs.Config{}, logger),
+		dora:     doraClient,
+	}
+	s.registerJobs()
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "CreateSupportRequest")
+	defer span.End()
+	resp, err := s.CreateSupportRequest(ctx, &pb.CreateSupportRequestRequest{
+		UserId:       "user-1",
+		Subject:      "Payment failed",
+		ErrorMessage: "failed to charge card: payment declined",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	job := jobs.Job{SupportRequestID: resp.SupportRequest.Id}
+	for _, run := range []jobs.Handler{s.runTicketJob, s.runDoraJob, s.runDoraJob} {
+		if err := run(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(requests) != 1 {
+		t.Fatalf("DORA requests = %q, want the incident created once", requests)
+	}
+	wantCreated := map[string]any{
+		"serviceName":  "payment",
+		"severity":     "critical",
+		"jiraTicketId": "FAKE-1",
+		"traceId":      span.SpanContext().TraceID().String(),
+		"spanId":       span.SpanContext().SpanID().String(),
+	}
+	for key, want := range wantCreated {
+		if created[key] != want {
+			t.Errorf("created incident %s = %v, want %v", key, created[key], want)
+		}
+	}
+
+	// The incident stays open until the request is resolved.
+	if err := s.runDoraResolveJob(ctx, job); err != nil || len(requests) != 1 {
+		t.Fatalf("resolving an open request: err = %v, DORA requests = %q", err, requests)
+	}
+	for _, to := range []string{store.StatusTriaged, store.StatusInProgress, store.StatusResolved} {
+		if _, err := s.UpdateSupportRequestStatus(ctx, &pb.UpdateSupportRequestStatusRequest{Id: resp.SupportRequest.Id, Status: to, Actor: "agent"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.runDoraResolveJob(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	if got := requests[len(requests)-1]; got != "PUT /incidents/dora-1/resolve Bearer secret" {
+		t.Errorf("last DORA request = %q, want the incident resolved", got)
+	}
+	if ttr, ok := resolved["timeToRestoreSeconds"].(float64); !ok || ttr < 0 {
+		t.Errorf("resolution = %v, want the time to restore", resolved)
+	}
+}
+
+func TestTicketWebhookResolvesIncident(t *testing.T) {
+	customer := &fakeNotifier{}
+	dispatcher, err := notify.NewDispatcher([]notify.Notifier{customer}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &supportService{
+		store:            store.NewMemory(),
+		notifier:         dispatcher,
+		customerNotifier: customer.Name(),
+		tickets:          &fakeProvider{},
+		queue:            jobs.NewQueue(jobs.NewMemory(), jobs.Config{}, logger),
+		dedupWindow:      time.Hour,
+	}
+	s.registerJobs()
+	h, err := webhook.NewHandler(webhook.Config{Secrets: map[string]string{ticket.ProviderJira: "secret"}}, s.applyTicketEvent, logger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	post := func(key, status string) int {
+		body := fmt.Sprintf(`{"webhookEvent": "jira:issue_updated", "user": {"displayName": "Ada"}, "issue": {"key": %q},
+			"changelog": {"items": [{"field": "status", "toString": %q}]}}`, key, status)
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/webhooks/jira?secret=secret", strings.NewReader(body)))
+		return w.Code
+	}
+
+	// Two customers report the same failure, which files one ticket.
+	ctx := context.Background()
+	var ids []string
+	for _, email := range []string{"ada@example.com", "bob@example.com"} {
+		resp, err := s.CreateSupportRequest(ctx, &pb.CreateSupportRequestRequest{
+			UserId:       email,
+			Email:        email,
+			Subject:      "Checkout failed",
+			ErrorMessage: "failed to get shipping quote",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, resp.SupportRequest.Id)
+	}
+	if err := s.runTicketJob(ctx, jobs.Job{SupportRequestID: ids[0]}); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.runCommentJob(ctx, jobs.Job{SupportRequestID: ids[1]}); err != nil {
+		t.Fatal(err)
+	}
+
+	if code := post("OTHER-1", "Done"); code != http.StatusAccepted {
+		t.Errorf("webhook for an unknown ticket: status = %d, want %d", code, http.StatusAccepted)
+	}
+	// Redelivered webhooks change nothing.
+	for i := 0; i < 2; i++ {
+		if code := post("FAKE-1", "Done"); code != http.StatusNoContent {
+			t.Fatalf("webhook: status = %d, want %d", code, http.StatusNoContent)
+		}
+	}
+	for _, id := range ids {
+		sr, err := s.store.Get(ctx, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sr.Status != store.StatusResolved || len(sr.History) != 4 {
+			t.Fatalf("support request %s: status %s after %d changes, want RESOLVED after 4", id, sr.Status, len(sr.History))
+		}
+		last := sr.History[3]
+		if last.Actor != "jira:Ada" || last.Reason != "jira ticket FAKE-1 moved to Done" {
+			t.Errorf("support request %s: last change = %+v", id, last)
+		}
+		if err := s.runNotifyCustomerJob(ctx, jobs.Job{SupportRequestID: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(customer.sent) != 2 {
+		t.Fatalf("sent %d notifications, want one per customer", len(customer.sent))
+	}
+	for i, n := range customer.sent {
+		if n.Event != notify.EventResolved || n.Recipient != []string{"ada@example.com", "bob@example.com"}[i] || n.TicketKey != "FAKE-1" {
+			t.Errorf("notification %d = %+v, want the resolution sent to the customer", i, n)
+		}
+	}
+
+	// A reopened ticket reopens the requests; they are not told about the
+	// earlier resolution any more.
+	if code := post("FAKE-1", "Reopened"); code != http.StatusNoContent {
+		t.Fatalf("webhook: status = %d, want %d", code, http.StatusNoContent)
+	}
+	customer.sent = nil
+	if err := s.runNotifyCustomerJob(ctx, jobs.Job{SupportRequestID: ids[0]}); err != nil || len(customer.sent) != 0 {
+		t.Errorf("notifying a reopened request: err = %v, sent %d notifications", err, len(customer.sent))
+	}
+}
+
+func TestCheckTickets(t *testing.T) {
+	ctx := context.Background()
+	if err := (&supportService{}).checkTickets(ctx); err != nil {
+		t.Errorf("without a ticket provider: checkTickets() = %v, want nil", err)
+	}
+	tickets := &fakeProvider{}
+	s := &supportService{tickets: tickets}
+	if err := s.checkTickets(ctx); err != nil {
+		t.Errorf("with a reachable provider: checkTickets() = %v, want nil", err)
+	}
+	tickets.down = true
+	if err := s.checkTickets(ctx); err == nil {
+		t.Error("with an unreachable provider: checkTickets() = nil, want error")
+	}
+}
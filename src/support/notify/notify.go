@@ -0,0 +1,331 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package notify tells people about new and resolved support tickets.
+// Notifiers are implemented for Slack, Microsoft Teams, a generic signed
+// webhook, SMTP email and PagerDuty; a Dispatcher routes each notification to
+// the notifiers selected by its rules and sends it to all of them
+// concurrently.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Severities of a notification, from least to most severe.
+const (
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+// Events a notification is sent for.
+const (
+	// EventCreated is sent to staff when a ticket is filed. It is the
+	// default.
+	EventCreated = "created"
+	// EventResolved is sent when a ticket is resolved, typically to the
+	// customer who reported the failure.
+	EventResolved = "resolved"
+)
+
+// ErrUnknownNotifier is returned by DispatchTo for a notifier that is not
+// configured.
+var ErrUnknownNotifier = errors.New("unknown notifier")
+
+var severityRank = map[string]int{
+	SeverityLow:      1,
+	SeverityMedium:   2,
+	SeverityHigh:     3,
+	SeverityCritical: 4,
+}
+
+// Item is an item of the order that failed.
+type Item struct {
+	ProductID string
+	Quantity  int32
+	// Categories of the product, if known.
+	Categories []string
+}
+
+// Notification describes a new or resolved support ticket.
+type Notification struct {
+	// Event is EventCreated, or empty, for new tickets and EventResolved
+	// for resolved ones.
+	Event string
+	// Subject is the subject of the support request.
+	Subject  string
+	Severity string
+	// Message is the error the user ran into.
+	Message     string
+	UserID      string
+	Email       string
+	FailedItems []Item
+	TicketKey   string
+	TicketURL   string
+	Time        time.Time
+	// Recipient is the email address of the customer a notification is
+	// sent to. The email notifier sends to it instead of its configured
+	// recipients.
+	Recipient string
+}
+
+// Notifier sends notifications to one channel.
+type Notifier interface {
+	// Name identifies the notifier in routing rules and telemetry.
+	Name() string
+	Notify(ctx context.Context, n Notification) error
+}
+
+// Result is the outcome of sending a notification with one notifier.
+type Result struct {
+	Notifier string
+	Err      error
+	Duration time.Duration
+}
+
+// Config configures the notifiers. A notifier is enabled when its
+// destination (webhook URL, SMTP host or routing key) is set.
+type Config struct {
+	Slack     SlackConfig
+	Teams     TeamsConfig
+	Webhook   WebhookConfig
+	SMTP      SMTPConfig
+	PagerDuty PagerDutyConfig
+	Rules     []Rule
+
+	// HTTPClient is used by the notifiers that call an API. Defaults to a
+	// client with a 30 second timeout.
+	HTTPClient *http.Client
+}
+
+// New returns a Dispatcher over the notifiers enabled in cfg.
+func New(cfg Config) (*Dispatcher, error) {
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	var notifiers []Notifier
+	if cfg.Slack.WebhookURL != "" {
+		slack, err := NewSlack(cfg.Slack, client)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, slack)
+	}
+	if cfg.Teams.WebhookURL != "" {
+		notifiers = append(notifiers, NewTeams(cfg.Teams, client))
+	}
+	if cfg.Webhook.URL != "" {
+		notifiers = append(notifiers, NewWebhook(cfg.Webhook, client))
+	}
+	if cfg.SMTP.Host != "" {
+		email, err := NewSMTP(cfg.SMTP)
+		if err != nil {
+			return nil, err
+		}
+		notifiers = append(notifiers, email)
+	}
+	if cfg.PagerDuty.RoutingKey != "" {
+		notifiers = append(notifiers, NewPagerDuty(cfg.PagerDuty, client))
+	}
+	return NewDispatcher(notifiers, cfg.Rules)
+}
+
+// Dispatcher sends notifications to the notifiers selected by its rules.
+type Dispatcher struct {
+	notifiers []Notifier
+	rules     []Rule
+}
+
+// NewDispatcher returns a Dispatcher over notifiers. Without rules every
+// notification goes to every notifier.
+func NewDispatcher(notifiers []Notifier, rules []Rule) (*Dispatcher, error) {
+	known := make(map[string]bool, len(notifiers))
+	for _, n := range notifiers {
+		known[n.Name()] = true
+	}
+	for _, r := range rules {
+		if err := r.validate(known); err != nil {
+			return nil, err
+		}
+	}
+	return &Dispatcher{notifiers: notifiers, rules: rules}, nil
+}
+
+// Notifiers returns the names of the configured notifiers.
+func (d *Dispatcher) Notifiers() []string {
+	var names []string
+	for _, n := range d.notifiers {
+		names = append(names, n.Name())
+	}
+	return names
+}
+
+// Route returns the notifiers n is sent to.
+func (d *Dispatcher) Route(n Notification) []Notifier {
+	if len(d.rules) == 0 {
+		return d.notifiers
+	}
+	selected := make(map[string]bool)
+	for _, r := range d.rules {
+		if r.matches(n) {
+			for _, name := range r.Notifiers {
+				selected[name] = true
+			}
+		}
+	}
+	var out []Notifier
+	for _, notifier := range d.notifiers {
+		if selected[notifier.Name()] {
+			out = append(out, notifier)
+		}
+	}
+	return out
+}
+
+// Dispatch sends n to the notifiers selected by the rules concurrently and
+// waits for all of them. The outcome of each is recorded as a span event on
+// the span in ctx and returned in the order of the notifiers.
+func (d *Dispatcher) Dispatch(ctx context.Context, n Notification) []Result {
+	return d.send(ctx, n, d.Route(n))
+}
+
+// DispatchTo sends n to the named notifiers like Dispatch, bypassing the
+// rules. A name that is not configured results in ErrUnknownNotifier.
+func (d *Dispatcher) DispatchTo(ctx context.Context, n Notification, names ...string) []Result {
+	var notifiers []Notifier
+	var unknown []Result
+	for _, name := range names {
+		notifier := d.notifier(name)
+		if notifier == nil {
+			unknown = append(unknown, Result{Notifier: name, Err: fmt.Errorf("%w: %s", ErrUnknownNotifier, name)})
+			continue
+		}
+		notifiers = append(notifiers, notifier)
+	}
+	return append(d.send(ctx, n, notifiers), unknown...)
+}
+
+func (d *Dispatcher) notifier(name string) Notifier {
+	for _, n := range d.notifiers {
+		if n.Name() == name {
+			return n
+		}
+	}
+	return nil
+}
+
+func (d *Dispatcher) send(ctx context.Context, n Notification, notifiers []Notifier) []Result {
+	results := make([]Result, len(notifiers))
+
+	var wg sync.WaitGroup
+	for i, notifier := range notifiers {
+		wg.Add(1)
+		go func(i int, notifier Notifier) {
+			defer wg.Done()
+			start := time.Now()
+			err := notifier.Notify(ctx, n)
+			results[i] = Result{Notifier: notifier.Name(), Err: err, Duration: time.Since(start)}
+		}(i, notifier)
+	}
+	wg.Wait()
+
+	span := trace.SpanFromContext(ctx)
+	for _, r := range results {
+		attrs := []attribute.KeyValue{
+			attribute.String("app.notifier.name", r.Notifier),
+			attribute.Int64("app.notifier.duration_ms", r.Duration.Milliseconds()),
+		}
+		if r.Err != nil {
+			span.AddEvent("notification_failed", trace.WithAttributes(append(attrs, attribute.String("error", r.Err.Error()))...))
+		} else {
+			span.AddEvent("notification_sent", trace.WithAttributes(attrs...))
+		}
+	}
+	return results
+}
+
+// headline returns a one line summary of n.
+func headline(n Notification) string {
+	if n.Event == EventResolved {
+		if n.TicketKey == "" {
+			return fmt.Sprintf("Support Request Resolved: %s", n.Subject)
+		}
+		return fmt.Sprintf("Support Ticket %s Resolved: %s", n.TicketKey, n.Subject)
+	}
+	if n.TicketKey == "" {
+		return fmt.Sprintf("New Support Request: %s", n.Subject)
+	}
+	return fmt.Sprintf("New Support Ticket %s: %s", n.TicketKey, n.Subject)
+}
+
+// event returns the event of n, defaulting to EventCreated.
+func event(n Notification) string {
+	if n.Event == "" {
+		return EventCreated
+	}
+	return n.Event
+}
+
+// itemLines returns one line per failed item, each prefixed with bullet.
+func itemLines(n Notification, bullet string) string {
+	var b strings.Builder
+	for _, item := range n.FailedItems {
+		fmt.Fprintf(&b, "%s Product ID: %s (Qty: %d)", bullet, item.ProductID, item.Quantity)
+		if len(item.Categories) > 0 {
+			fmt.Fprintf(&b, " [%s]", strings.Join(item.Categories, ", "))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// postJSON sends body as JSON to url. The request fails unless the response
+// status is one of ok.
+func postJSON(ctx context.Context, client *http.Client, url string, header http.Header, body any, ok ...int) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %v", err)
+	}
+	return post(ctx, client, url, header, payload, ok...)
+}
+
+func post(ctx context.Context, client *http.Client, url string, header http.Header, payload []byte, ok ...int) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %v", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	for _, code := range ok {
+		if resp.StatusCode == code {
+			return nil
+		}
+	}
+	data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+	return fmt.Errorf("returned status %d: %s", resp.StatusCode, string(data))
+}
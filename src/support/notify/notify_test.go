@@ -0,0 +1,358 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+var testNotification = Notification{
+	Subject:  "Checkout failed on telescope",
+	Severity: SeverityHigh,
+	Message:  "Order contains expensive items",
+	UserID:   "user-1",
+	Email:    "user@example.com",
+	FailedItems: []Item{
+		{ProductID: "66VCHSJNUP", Quantity: 1, Categories: []string{"telescopes"}},
+	},
+	TicketKey: "DEMO-7",
+	TicketURL: "https://jira.example.com/browse/DEMO-7",
+	Time:      time.Unix(1700000000, 0),
+}
+
+// capture is a stand-in API that records the requests it receives.
+type capture struct {
+	mu       sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+}
+
+func newCapture(t *testing.T, status int) (*capture, *httptest.Server) {
+	c := &capture{status: status}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		c.mu.Lock()
+		c.requests = append(c.requests, r)
+		c.bodies = append(c.bodies, body)
+		c.mu.Unlock()
+		w.WriteHeader(c.status)
+	}))
+	t.Cleanup(srv.Close)
+	return c, srv
+}
+
+func (c *capture) json(t *testing.T) map[string]any {
+	t.Helper()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.bodies) != 1 {
+		t.Fatalf("received %d requests, want 1", len(c.bodies))
+	}
+	var out map[string]any
+	if err := json.Unmarshal(c.bodies[0], &out); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestSlack(t *testing.T) {
+	for _, format := range []string{SlackAttachments, SlackBlocks} {
+		t.Run(format, func(t *testing.T) {
+			c, srv := newCapture(t, http.StatusOK)
+			s, err := NewSlack(SlackConfig{WebhookURL: srv.URL, Channel: "#support", Format: format}, srv.Client())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Notify(context.Background(), testNotification); err != nil {
+				t.Fatal(err)
+			}
+			msg := c.json(t)
+			if msg["channel"] != "#support" || !strings.Contains(msg["text"].(string), "DEMO-7") {
+				t.Errorf("message = %v", msg)
+			}
+			if _, ok := msg[format]; !ok {
+				t.Errorf("message has no %s: %v", format, msg)
+			}
+		})
+	}
+}
+
+func TestTeams(t *testing.T) {
+	c, srv := newCapture(t, http.StatusAccepted)
+	if err := NewTeams(TeamsConfig{WebhookURL: srv.URL}, srv.Client()).Notify(context.Background(), testNotification); err != nil {
+		t.Fatal(err)
+	}
+	attachment := c.json(t)["attachments"].([]any)[0].(map[string]any)
+	if attachment["contentType"] != "application/vnd.microsoft.card.adaptive" {
+		t.Errorf("contentType = %v", attachment["contentType"])
+	}
+	card := attachment["content"].(map[string]any)
+	if card["type"] != "AdaptiveCard" || card["actions"] == nil {
+		t.Errorf("card = %v", card)
+	}
+}
+
+func TestWebhookSignature(t *testing.T) {
+	c, srv := newCapture(t, http.StatusNoContent)
+	w := NewWebhook(WebhookConfig{URL: srv.URL, Secret: "s3cret"}, srv.Client())
+	w.now = func() time.Time { return time.Unix(1700000123, 0) }
+	if err := w.Notify(context.Background(), testNotification); err != nil {
+		t.Fatal(err)
+	}
+
+	r := c.requests[0]
+	ts := r.Header.Get(TimestampHeader)
+	if ts != "1700000123" {
+		t.Errorf("%s = %q", TimestampHeader, ts)
+	}
+	if got, want := r.Header.Get(SignatureHeader), Sign("s3cret", ts, c.bodies[0]); got != want {
+		t.Errorf("%s = %q, want %q", SignatureHeader, got, want)
+	}
+	if got := c.json(t)["ticket_key"]; got != "DEMO-7" {
+		t.Errorf("ticket_key = %v", got)
+	}
+}
+
+func TestSMTP(t *testing.T) {
+	s, err := NewSMTP(SMTPConfig{Host: "mail.example.com", From: "support@example.com", To: []string{"oncall@example.com"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotAddr string
+	var gotMsg []byte
+	s.send = func(addr string, _ smtp.Auth, _ string, _ []string, msg []byte) error {
+		gotAddr, gotMsg = addr, msg
+		return nil
+	}
+	n := testNotification
+	n.Subject = "Broken\r\nBcc: everyone@example.com"
+	if err := s.Notify(context.Background(), n); err != nil {
+		t.Fatal(err)
+	}
+	if gotAddr != "mail.example.com:587" {
+		t.Errorf("addr = %q", gotAddr)
+	}
+	msg := string(gotMsg)
+	if !strings.Contains(msg, "Subject: [HIGH] New Support Ticket DEMO-7: Broken  Bcc: everyone@example.com\r\n") {
+		t.Errorf("message does not have a single line subject:\n%s", msg)
+	}
+	if strings.Contains(msg, "\r\nBcc:") {
+		t.Errorf("subject injected a header:\n%s", msg)
+	}
+
+	// A resolved ticket is reported to the customer only.
+	var gotTo []string
+	s.send = func(_ string, _ smtp.Auth, _ string, to []string, msg []byte) error {
+		gotTo, gotMsg = to, msg
+		return nil
+	}
+	n = testNotification
+	n.Event, n.Recipient = EventResolved, "jane.doe@example.com"
+	if err := s.Notify(context.Background(), n); err != nil {
+		t.Fatal(err)
+	}
+	msg = string(gotMsg)
+	if len(gotTo) != 1 || gotTo[0] != "jane.doe@example.com" || !strings.Contains(msg, "To: jane.doe@example.com\r\n") {
+		t.Errorf("resolved notification sent to %v:\n%s", gotTo, msg)
+	}
+	if !strings.Contains(msg, "Subject: Support Ticket DEMO-7 Resolved: ") || strings.Contains(msg, n.Message) {
+		t.Errorf("resolved notification is not addressed to the customer:\n%s", msg)
+	}
+}
+
+func TestPagerDuty(t *testing.T) {
+	c, srv := newCapture(t, http.StatusAccepted)
+	p := NewPagerDuty(PagerDutyConfig{RoutingKey: "rk", URL: srv.URL}, srv.Client())
+	if err := p.Notify(context.Background(), testNotification); err != nil {
+		t.Fatal(err)
+	}
+	event := c.json(t)
+	payload := event["payload"].(map[string]any)
+	if event["routing_key"] != "rk" || event["event_action"] != "trigger" || event["dedup_key"] != "DEMO-7" || payload["severity"] != "error" {
+		t.Errorf("event = %v", event)
+	}
+
+	resolved := testNotification
+	resolved.Event = EventResolved
+	if err := p.Notify(context.Background(), resolved); err != nil {
+		t.Fatal(err)
+	}
+	var event2 map[string]any
+	if err := json.Unmarshal(c.bodies[1], &event2); err != nil {
+		t.Fatal(err)
+	}
+	if event2["event_action"] != "resolve" || event2["dedup_key"] != "DEMO-7" {
+		t.Errorf("resolved event = %v", event2)
+	}
+}
+
+func TestNotifierAPIError(t *testing.T) {
+	_, srv := newCapture(t, http.StatusInternalServerError)
+	slack, _ := NewSlack(SlackConfig{WebhookURL: srv.URL}, srv.Client())
+	for _, n := range []Notifier{
+		slack,
+		NewTeams(TeamsConfig{WebhookURL: srv.URL}, srv.Client()),
+		NewWebhook(WebhookConfig{URL: srv.URL}, srv.Client()),
+		NewPagerDuty(PagerDutyConfig{RoutingKey: "rk", URL: srv.URL}, srv.Client()),
+	} {
+		if err := n.Notify(context.Background(), testNotification); err == nil || !strings.Contains(err.Error(), "500") {
+			t.Errorf("%s: Notify() error = %v, want the status", n.Name(), err)
+		}
+	}
+}
+
+type fakeNotifier struct {
+	name  string
+	err   error
+	delay time.Duration
+	calls int
+}
+
+func (f *fakeNotifier) Name() string { return f.name }
+
+func (f *fakeNotifier) Notify(context.Context, Notification) error {
+	time.Sleep(f.delay)
+	f.calls++
+	return f.err
+}
+
+func TestRouting(t *testing.T) {
+	slack := &fakeNotifier{name: "slack"}
+	pager := &fakeNotifier{name: "pagerduty"}
+	email := &fakeNotifier{name: "email"}
+	d, err := NewDispatcher([]Notifier{slack, pager, email}, []Rule{
+		{Name: "everything", Notifiers: []string{"slack"}},
+		{Name: "urgent", Notifiers: []string{"pagerduty"}, MinSeverity: SeverityCritical},
+		{Name: "payments", Notifiers: []string{"pagerduty"}, SubjectKeywords: []string{"PAYMENT"}},
+		{Name: "optics", Notifiers: []string{"email"}, Categories: []string{"Telescopes"}, MinSeverity: SeverityMedium},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := func(ns []Notifier) string {
+		var out []string
+		for _, n := range ns {
+			out = append(out, n.Name())
+		}
+		return strings.Join(out, ",")
+	}
+
+	tests := []struct {
+		name string
+		n    Notification
+		want string
+	}{
+		{"category", testNotification, "slack,email"},
+		{"severity", Notification{Severity: SeverityCritical}, "slack,pagerduty"},
+		{"keyword", Notification{Subject: "Payment declined", Severity: SeverityLow}, "slack,pagerduty"},
+		{"low category", Notification{Severity: SeverityLow, FailedItems: testNotification.FailedItems}, "slack"},
+	}
+	for _, tt := range tests {
+		if got := names(d.Route(tt.n)); got != tt.want {
+			t.Errorf("%s: Route() = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+
+	if _, err := NewDispatcher([]Notifier{slack}, []Rule{{Name: "bad", Notifiers: []string{"teams"}}}); err == nil {
+		t.Error("NewDispatcher() with unknown notifier error = nil")
+	}
+}
+
+func TestDispatchConcurrentWithSpanEvents(t *testing.T) {
+	slow := []*fakeNotifier{
+		{name: "a", delay: 100 * time.Millisecond},
+		{name: "b", delay: 100 * time.Millisecond, err: errors.New("boom")},
+		{name: "c", delay: 100 * time.Millisecond},
+	}
+	d, err := NewDispatcher([]Notifier{slow[0], slow[1], slow[2]}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sr := tracetest.NewSpanRecorder()
+	ctx, span := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr)).Tracer("test").Start(context.Background(), "CreateSupportRequest")
+	start := time.Now()
+	results := d.Dispatch(ctx, testNotification)
+	elapsed := time.Since(start)
+	span.End()
+
+	if elapsed > 250*time.Millisecond {
+		t.Errorf("Dispatch() took %v, want the notifiers to run concurrently", elapsed)
+	}
+	if len(results) != 3 || results[1].Notifier != "b" || results[1].Err == nil || results[0].Err != nil {
+		t.Errorf("results = %+v", results)
+	}
+
+	events := sr.Ended()[0].Events()
+	if len(events) != 3 {
+		t.Fatalf("recorded %d span events, want 3", len(events))
+	}
+	if events[0].Name != "notification_sent" || events[1].Name != "notification_failed" {
+		t.Errorf("events = %v, %v", events[0].Name, events[1].Name)
+	}
+}
+
+func TestDispatchTo(t *testing.T) {
+	slack := &fakeNotifier{name: "slack"}
+	pager := &fakeNotifier{name: "pagerduty"}
+	d, err := NewDispatcher([]Notifier{slack, pager}, []Rule{{Name: "urgent", Notifiers: []string{"pagerduty"}, MinSeverity: SeverityCritical}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := d.DispatchTo(context.Background(), testNotification, "pagerduty", "teams")
+	if len(results) != 2 || results[0].Notifier != "pagerduty" || results[0].Err != nil {
+		t.Fatalf("results = %+v", results)
+	}
+	if !errors.Is(results[1].Err, ErrUnknownNotifier) {
+		t.Errorf("unknown notifier error = %v, want ErrUnknownNotifier", results[1].Err)
+	}
+	if pager.calls != 1 || slack.calls != 0 {
+		t.Errorf("calls = slack %d, pagerduty %d, want only pagerduty bypassing the rules", slack.calls, pager.calls)
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	data := `[{"name":"urgent","notifiers":["pagerduty"],"min_severity":"critical","subject_keywords":["payment"]}]`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := LoadRules(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rules) != 1 || rules[0].MinSeverity != SeverityCritical || rules[0].SubjectKeywords[0] != "payment" {
+		t.Errorf("rules = %+v", rules)
+	}
+}
+
+func TestNewEnablesConfiguredNotifiers(t *testing.T) {
+	d, err := New(Config{
+		Slack:     SlackConfig{WebhookURL: "http://slack"},
+		PagerDuty: PagerDutyConfig{RoutingKey: "rk"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(d.Notifiers(), ","); got != "slack,pagerduty" {
+		t.Errorf("Notifiers() = %s", got)
+	}
+}
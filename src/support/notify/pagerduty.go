@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// PagerDutyConfig configures the PagerDuty Events API v2 notifier.
+type PagerDutyConfig struct {
+	RoutingKey string
+	// URL defaults to https://events.pagerduty.com/v2/enqueue.
+	URL string
+}
+
+// pagerDutySeverity maps notification severities to PagerDuty's.
+var pagerDutySeverity = map[string]string{
+	SeverityLow:      "info",
+	SeverityMedium:   "warning",
+	SeverityHigh:     "error",
+	SeverityCritical: "critical",
+}
+
+// PagerDuty triggers PagerDuty alerts. The ticket key is used as the dedup
+// key so repeated notifications for one ticket raise a single alert, which
+// the notification of the resolved ticket resolves.
+type PagerDuty struct {
+	cfg    PagerDutyConfig
+	client *http.Client
+}
+
+// NewPagerDuty returns a PagerDuty notifier.
+func NewPagerDuty(cfg PagerDutyConfig, client *http.Client) *PagerDuty {
+	if cfg.URL == "" {
+		cfg.URL = "https://events.pagerduty.com/v2/enqueue"
+	}
+	return &PagerDuty{cfg: cfg, client: client}
+}
+
+func (p *PagerDuty) Name() string { return "pagerduty" }
+
+func (p *PagerDuty) Notify(ctx context.Context, n Notification) error {
+	severity, ok := pagerDutySeverity[n.Severity]
+	if !ok {
+		severity = "error"
+	}
+	details := map[string]any{
+		"user_id": n.UserID,
+		"error":   n.Message,
+	}
+	if len(n.FailedItems) > 0 {
+		details["failed_items"] = itemLines(n, "-")
+	}
+	action := "trigger"
+	if n.Event == EventResolved && n.TicketKey != "" {
+		action = "resolve"
+	}
+	event := map[string]any{
+		"routing_key":  p.cfg.RoutingKey,
+		"event_action": action,
+		"payload": map[string]any{
+			"summary":        headline(n),
+			"source":         "support",
+			"severity":       severity,
+			"timestamp":      n.Time.UTC().Format("2006-01-02T15:04:05Z"),
+			"component":      "checkout",
+			"custom_details": details,
+		},
+	}
+	if n.TicketKey != "" {
+		event["dedup_key"] = n.TicketKey
+	}
+	if n.TicketURL != "" {
+		event["links"] = []map[string]string{{"href": n.TicketURL, "text": "Ticket " + n.TicketKey}}
+	}
+	if err := postJSON(ctx, p.client, p.cfg.URL, nil, event, http.StatusAccepted); err != nil {
+		return fmt.Errorf("pagerduty %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package notify
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Rule routes the notifications it matches to a set of notifiers. A rule
+// matches when all of its conditions hold; conditions left empty always
+// hold. A notification goes to the union of the notifiers of all matching
+// rules.
+type Rule struct {
+	Name      string   `json:"name"`
+	Notifiers []string `json:"notifiers"`
+	// MinSeverity matches notifications at least this severe.
+	MinSeverity string `json:"min_severity,omitempty"`
+	// SubjectKeywords matches notifications whose subject contains any of
+	// the keywords, ignoring case.
+	SubjectKeywords []string `json:"subject_keywords,omitempty"`
+	// Categories matches notifications with a failed item in any of the
+	// product categories.
+	Categories []string `json:"categories,omitempty"`
+}
+
+// LoadRules reads a JSON array of rules from path.
+func LoadRules(path string) ([]Rule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read notification rules: %v", err)
+	}
+	var rules []Rule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse notification rules %s: %v", path, err)
+	}
+	return rules, nil
+}
+
+func (r Rule) validate(known map[string]bool) error {
+	if len(r.Notifiers) == 0 {
+		return fmt.Errorf("notification rule %q has no notifiers", r.Name)
+	}
+	for _, name := range r.Notifiers {
+		if !known[name] {
+			return fmt.Errorf("notification rule %q uses notifier %q, which is not configured", r.Name, name)
+		}
+	}
+	if r.MinSeverity != "" && severityRank[r.MinSeverity] == 0 {
+		return fmt.Errorf("notification rule %q has unknown severity %q", r.Name, r.MinSeverity)
+	}
+	return nil
+}
+
+func (r Rule) matches(n Notification) bool {
+	if r.MinSeverity != "" && severityRank[n.Severity] < severityRank[r.MinSeverity] {
+		return false
+	}
+	if len(r.SubjectKeywords) > 0 {
+		subject := strings.ToLower(n.Subject)
+		found := false
+		for _, kw := range r.SubjectKeywords {
+			if strings.Contains(subject, strings.ToLower(kw)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(r.Categories) > 0 && !hasCategory(n, r.Categories) {
+		return false
+	}
+	return true
+}
+
+func hasCategory(n Notification, categories []string) bool {
+	for _, item := range n.FailedItems {
+		for _, have := range item.Categories {
+			for _, want := range categories {
+				if strings.EqualFold(have, want) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
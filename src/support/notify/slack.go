@@ -0,0 +1,137 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Formats of Slack messages.
+const (
+	SlackAttachments = "attachments"
+	SlackBlocks      = "blocks"
+)
+
+// SlackConfig configures the Slack incoming webhook notifier.
+type SlackConfig struct {
+	WebhookURL string
+	Channel    string
+	// Format is SlackAttachments (default) or SlackBlocks for Block Kit.
+	Format string
+}
+
+type slackMessage struct {
+	Channel     string            `json:"channel,omitempty"`
+	Text        string            `json:"text"`
+	Attachments []slackAttachment `json:"attachments,omitempty"`
+	Blocks      []slackBlock      `json:"blocks,omitempty"`
+}
+
+type slackAttachment struct {
+	Color     string       `json:"color"`
+	Title     string       `json:"title"`
+	TitleLink string       `json:"title_link,omitempty"`
+	Text      string       `json:"text"`
+	Fields    []slackField `json:"fields"`
+	Footer    string       `json:"footer"`
+	Timestamp int64        `json:"ts"`
+}
+
+type slackField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Fields   []slackText `json:"fields,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Slack posts notifications to a Slack incoming webhook.
+type Slack struct {
+	cfg    SlackConfig
+	client *http.Client
+}
+
+// NewSlack returns a Slack notifier.
+func NewSlack(cfg SlackConfig, client *http.Client) (*Slack, error) {
+	switch cfg.Format {
+	case "":
+		cfg.Format = SlackAttachments
+	case SlackAttachments, SlackBlocks:
+	default:
+		return nil, fmt.Errorf("unsupported Slack message format %q", cfg.Format)
+	}
+	return &Slack{cfg: cfg, client: client}, nil
+}
+
+func (s *Slack) Name() string { return "slack" }
+
+func (s *Slack) Notify(ctx context.Context, n Notification) error {
+	msg := slackMessage{
+		Channel: s.cfg.Channel,
+		Text:    "🚨 " + headline(n),
+	}
+	if s.cfg.Format == SlackBlocks {
+		msg.Blocks = slackBlocks(n)
+	} else {
+		msg.Attachments = []slackAttachment{slackAttachmentFor(n)}
+	}
+	if err := postJSON(ctx, s.client, s.cfg.WebhookURL, nil, msg, http.StatusOK); err != nil {
+		return fmt.Errorf("slack webhook %v", err)
+	}
+	return nil
+}
+
+func slackAttachmentFor(n Notification) slackAttachment {
+	a := slackAttachment{
+		Color:     "danger",
+		Title:     fmt.Sprintf("Ticket: %s", n.TicketKey),
+		TitleLink: n.TicketURL,
+		Text:      fmt.Sprintf("A new support ticket has been created for a checkout failure.\n\n*Error:* %s", n.Message),
+		Fields: []slackField{
+			{Title: "User ID", Value: n.UserID, Short: true},
+			{Title: "Email", Value: n.Email, Short: true},
+			{Title: "Severity", Value: n.Severity, Short: true},
+			{Title: "Subject", Value: n.Subject, Short: false},
+		},
+		Footer:    "OpenTelemetry Demo Support",
+		Timestamp: n.Time.Unix(),
+	}
+	if len(n.FailedItems) > 0 {
+		a.Fields = append(a.Fields, slackField{Title: "Failed Items", Value: itemLines(n, "•"), Short: false})
+	}
+	return a
+}
+
+func slackBlocks(n Notification) []slackBlock {
+	ticket := n.TicketKey
+	if n.TicketURL != "" {
+		ticket = fmt.Sprintf("<%s|%s>", n.TicketURL, n.TicketKey)
+	}
+	blocks := []slackBlock{
+		{Type: "header", Text: &slackText{Type: "plain_text", Text: "🚨 " + headline(n)}},
+		{Type: "section", Text: &slackText{Type: "mrkdwn", Text: fmt.Sprintf("*Error:* %s", n.Message)}},
+		{Type: "section", Fields: []slackText{
+			{Type: "mrkdwn", Text: "*Ticket:*\n" + ticket},
+			{Type: "mrkdwn", Text: "*Severity:*\n" + n.Severity},
+			{Type: "mrkdwn", Text: "*User ID:*\n" + n.UserID},
+			{Type: "mrkdwn", Text: "*Email:*\n" + n.Email},
+		}},
+	}
+	if len(n.FailedItems) > 0 {
+		blocks = append(blocks, slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: "*Failed Items:*\n" + itemLines(n, "•")}})
+	}
+	return append(blocks, slackBlock{Type: "context", Elements: []slackText{{Type: "mrkdwn", Text: "OpenTelemetry Demo Support"}}})
+}
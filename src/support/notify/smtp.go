@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strings"
+)
+
+// SMTPConfig configures the email notifier.
+type SMTPConfig struct {
+	Host string
+	Port string
+	// Username and Password enable PLAIN authentication when set.
+	Username string
+	Password string
+	From     string
+	To       []string
+}
+
+// SMTP emails notifications to the configured recipients, or to the customer
+// named by Notification.Recipient.
+type SMTP struct {
+	cfg  SMTPConfig
+	send func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+// NewSMTP returns an SMTP notifier.
+func NewSMTP(cfg SMTPConfig) (*SMTP, error) {
+	if cfg.Host == "" || cfg.From == "" || len(cfg.To) == 0 {
+		return nil, fmt.Errorf("smtp notifier needs a host, a sender and recipients")
+	}
+	if cfg.Port == "" {
+		cfg.Port = "587"
+	}
+	return &SMTP{cfg: cfg, send: smtp.SendMail}, nil
+}
+
+func (s *SMTP) Name() string { return "email" }
+
+// Notify sends the email. net/smtp does not take a context, so ctx only
+// short-circuits sending when it is already done.
+func (s *SMTP) Notify(ctx context.Context, n Notification) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	var auth smtp.Auth
+	if s.cfg.Username != "" {
+		auth = smtp.PlainAuth("", s.cfg.Username, s.cfg.Password, s.cfg.Host)
+	}
+	to := s.cfg.To
+	if n.Recipient != "" {
+		to = []string{n.Recipient}
+	}
+	addr := net.JoinHostPort(s.cfg.Host, s.cfg.Port)
+	if err := s.send(addr, auth, s.cfg.From, to, s.message(n, to)); err != nil {
+		return fmt.Errorf("smtp: %v", err)
+	}
+	return nil
+}
+
+func (s *SMTP) message(n Notification, to []string) []byte {
+	subject := fmt.Sprintf("[%s] %s", strings.ToUpper(n.Severity), headline(n))
+	body := fmt.Sprintf("A new support ticket has been created for a checkout failure.\n\n"+
+		"Ticket: %s\nSeverity: %s\nUser ID: %s\nEmail: %s\n\nError: %s\n",
+		n.TicketKey, n.Severity, n.UserID, n.Email, n.Message)
+	if n.TicketURL != "" {
+		body += "\n" + n.TicketURL + "\n"
+	}
+	if len(n.FailedItems) > 0 {
+		body += "\nFailed Items:\n" + itemLines(n, "-")
+	}
+	// The customer is told about the resolution without the internal
+	// details of the ticket.
+	if n.Event == EventResolved {
+		subject = headline(n)
+		resolved := fmt.Sprintf("Your support request \"%s\" has been resolved", n.Subject)
+		if n.TicketKey != "" {
+			resolved += fmt.Sprintf(" (ticket %s)", n.TicketKey)
+		}
+		body = resolved + ".\n\nPlease try again, and reply to this email if the problem persists.\n"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "From: %s\r\n", s.cfg.From)
+	fmt.Fprintf(&b, "To: %s\r\n", headerSafe(strings.Join(to, ", ")))
+	fmt.Fprintf(&b, "Subject: %s\r\n", headerSafe(subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", n.Time.Format("Mon, 02 Jan 2006 15:04:05 -0700"))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(body, "\n", "\r\n"))
+	return []byte(b.String())
+}
+
+// headerSafe strips line breaks, which would let user input inject headers.
+func headerSafe(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
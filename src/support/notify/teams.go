@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package notify
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// TeamsConfig configures the Microsoft Teams notifier.
+type TeamsConfig struct {
+	// WebhookURL is a Teams incoming webhook or Workflows URL.
+	WebhookURL string
+}
+
+// Teams posts notifications to Microsoft Teams as Adaptive Cards.
+type Teams struct {
+	cfg    TeamsConfig
+	client *http.Client
+}
+
+// NewTeams returns a Teams notifier.
+func NewTeams(cfg TeamsConfig, client *http.Client) *Teams {
+	return &Teams{cfg: cfg, client: client}
+}
+
+func (t *Teams) Name() string { return "teams" }
+
+func (t *Teams) Notify(ctx context.Context, n Notification) error {
+	facts := []map[string]string{
+		{"title": "Ticket", "value": n.TicketKey},
+		{"title": "Severity", "value": n.Severity},
+		{"title": "User ID", "value": n.UserID},
+		{"title": "Email", "value": n.Email},
+	}
+	body := []map[string]any{
+		{"type": "TextBlock", "text": headline(n), "weight": "Bolder", "size": "Medium", "wrap": true},
+		{"type": "TextBlock", "text": "Error: " + n.Message, "wrap": true, "color": "Attention"},
+		{"type": "FactSet", "facts": facts},
+	}
+	if len(n.FailedItems) > 0 {
+		body = append(body, map[string]any{"type": "TextBlock", "text": "Failed Items:\n\n" + itemLines(n, "-"), "wrap": true})
+	}
+	card := map[string]any{
+		"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+		"type":    "AdaptiveCard",
+		"version": "1.4",
+		"body":    body,
+	}
+	if n.TicketURL != "" {
+		card["actions"] = []map[string]string{{"type": "Action.OpenUrl", "title": "Open ticket", "url": n.TicketURL}}
+	}
+
+	msg := map[string]any{
+		"type": "message",
+		"attachments": []map[string]any{{
+			"contentType": "application/vnd.microsoft.card.adaptive",
+			"content":     card,
+		}},
+	}
+	if err := postJSON(ctx, t.client, t.cfg.WebhookURL, nil, msg, http.StatusOK, http.StatusAccepted); err != nil {
+		return fmt.Errorf("teams webhook %v", err)
+	}
+	return nil
+}
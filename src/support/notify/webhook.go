@@ -0,0 +1,107 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package notify
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Headers set by the webhook notifier.
+const (
+	SignatureHeader = "X-Signature-256"
+	TimestampHeader = "X-Signature-Timestamp"
+)
+
+// WebhookConfig configures the generic webhook notifier.
+type WebhookConfig struct {
+	URL string
+	// Secret signs the requests. Without it requests are sent unsigned.
+	Secret string
+}
+
+type webhookItem struct {
+	ProductID  string   `json:"product_id"`
+	Quantity   int32    `json:"quantity"`
+	Categories []string `json:"categories,omitempty"`
+}
+
+type webhookPayload struct {
+	Event       string        `json:"event"`
+	Subject     string        `json:"subject"`
+	Severity    string        `json:"severity"`
+	Message     string        `json:"message"`
+	UserID      string        `json:"user_id"`
+	Email       string        `json:"email"`
+	FailedItems []webhookItem `json:"failed_items,omitempty"`
+	TicketKey   string        `json:"ticket_key,omitempty"`
+	TicketURL   string        `json:"ticket_url,omitempty"`
+	Time        time.Time     `json:"time"`
+}
+
+// Webhook posts notifications as JSON to any URL. When a secret is
+// configured the request carries an HMAC-SHA256 signature of
+// "<timestamp>.<body>" in SignatureHeader, as "sha256=<hex>", and the Unix
+// timestamp in TimestampHeader, so receivers can reject forged or replayed
+// requests.
+type Webhook struct {
+	cfg    WebhookConfig
+	client *http.Client
+	now    func() time.Time
+}
+
+// NewWebhook returns a Webhook notifier.
+func NewWebhook(cfg WebhookConfig, client *http.Client) *Webhook {
+	return &Webhook{cfg: cfg, client: client, now: time.Now}
+}
+
+func (w *Webhook) Name() string { return "webhook" }
+
+func (w *Webhook) Notify(ctx context.Context, n Notification) error {
+	p := webhookPayload{
+		Event:     event(n),
+		Subject:   n.Subject,
+		Severity:  n.Severity,
+		Message:   n.Message,
+		UserID:    n.UserID,
+		Email:     n.Email,
+		TicketKey: n.TicketKey,
+		TicketURL: n.TicketURL,
+		Time:      n.Time.UTC(),
+	}
+	for _, item := range n.FailedItems {
+		p.FailedItems = append(p.FailedItems, webhookItem(item))
+	}
+	payload, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("webhook: failed to marshal request: %v", err)
+	}
+
+	header := http.Header{}
+	if w.cfg.Secret != "" {
+		ts := strconv.FormatInt(w.now().Unix(), 10)
+		header.Set(TimestampHeader, ts)
+		header.Set(SignatureHeader, Sign(w.cfg.Secret, ts, payload))
+	}
+	if err := post(ctx, w.client, w.cfg.URL, header, payload, http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusNoContent); err != nil {
+		return fmt.Errorf("webhook %v", err)
+	}
+	return nil
+}
+
+// Sign returns the value of SignatureHeader for body sent at timestamp.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,250 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package redact removes personal data from support requests before it
+// leaves the support service. A Policy sets, for every destination
+// (telemetry, tickets, notifications, DORA), what happens to each kind of
+// personal data: it is masked, replaced with a keyed hash, dropped or kept.
+package redact
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Destinations data is sent to.
+const (
+	Telemetry    = "telemetry"
+	Ticket       = "ticket"
+	Notification = "notification"
+	Dora         = "dora"
+)
+
+// Kinds of personal data.
+const (
+	Email         = "email"
+	StreetAddress = "street_address"
+	ZipCode       = "zip_code"
+	CardNumber    = "card_number"
+)
+
+// Actions applied to personal data.
+const (
+	// Mask hides all but a few characters, e.g. j***@example.com.
+	Mask = "mask"
+	// Hash replaces the value with a keyed hash, so that values can still be
+	// correlated without being revealed.
+	Hash = "hash"
+	// Drop removes the value.
+	Drop = "drop"
+	// Keep leaves the value as is.
+	Keep = "keep"
+)
+
+var (
+	destinations = []string{Telemetry, Ticket, Notification, Dora}
+	kinds        = []string{Email, StreetAddress, ZipCode, CardNumber}
+	actions      = map[string]bool{Mask: true, Hash: true, Drop: true, Keep: true}
+)
+
+// defaultActions applies to every destination unless the policy overrides
+// it. Street addresses and card numbers are never needed outside the
+// service; emails and zip codes are kept recognizable for support staff.
+// Telemetry hashes emails instead, so that traces of one customer can still
+// be found.
+var defaultActions = map[string]string{
+	Email:         Mask,
+	StreetAddress: Drop,
+	ZipCode:       Mask,
+	CardNumber:    Mask,
+}
+
+var telemetryDefaults = map[string]string{
+	Email: Hash,
+}
+
+// Rules maps a destination to the action for each kind of personal data.
+// Kinds that are not listed get the default action.
+type Rules map[string]map[string]string
+
+// Policy redacts personal data according to Rules.
+type Policy struct {
+	rules Rules
+	key   []byte
+}
+
+// NewPolicy returns a Policy applying rules on top of the defaults. key keys
+// the hashes; without it hashes of common values such as emails could be
+// reversed by guessing.
+func NewPolicy(rules Rules, key string) (*Policy, error) {
+	p := &Policy{rules: make(Rules), key: []byte(key)}
+	for _, dest := range destinations {
+		p.rules[dest] = make(map[string]string)
+		for kind, action := range defaultActions {
+			p.rules[dest][kind] = action
+		}
+	}
+	for kind, action := range telemetryDefaults {
+		p.rules[Telemetry][kind] = action
+	}
+	for dest, byKind := range rules {
+		if _, ok := p.rules[dest]; !ok {
+			return nil, fmt.Errorf("unknown redaction destination %q, want one of %s", dest, strings.Join(destinations, ", "))
+		}
+		for kind, action := range byKind {
+			if _, ok := defaultActions[kind]; !ok {
+				return nil, fmt.Errorf("unknown kind of personal data %q for %s, want one of %s", kind, dest, strings.Join(kinds, ", "))
+			}
+			if !actions[action] {
+				return nil, fmt.Errorf("unknown redaction action %q for %s %s", action, dest, kind)
+			}
+			p.rules[dest][kind] = action
+		}
+	}
+	return p, nil
+}
+
+// LoadPolicy reads the rules of a Policy from a JSON file such as
+//
+//	{"telemetry": {"email": "hash"}, "ticket": {"email": "keep", "street_address": "mask"}}
+func LoadPolicy(path, key string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read redaction policy: %v", err)
+	}
+	var rules Rules
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse redaction policy %s: %v", path, err)
+	}
+	return NewPolicy(rules, key)
+}
+
+// For returns the Redactor of a destination. A nil Policy masks everything.
+func (p *Policy) For(dest string) Redactor {
+	if p == nil {
+		return Redactor{}
+	}
+	return Redactor{actions: p.rules[dest], key: p.key}
+}
+
+// Redactor redacts the personal data sent to one destination.
+type Redactor struct {
+	actions map[string]string
+	key     []byte
+}
+
+// Email redacts an email address.
+func (r Redactor) Email(s string) string {
+	return r.apply(Email, s, maskEmail)
+}
+
+// StreetAddress redacts the street line of an address.
+func (r Redactor) StreetAddress(s string) string {
+	return r.apply(StreetAddress, s, func(string) string { return "***" })
+}
+
+// ZipCode redacts a zip or postal code.
+func (r Redactor) ZipCode(s string) string {
+	return r.apply(ZipCode, s, func(s string) string { return keepStart(s, 2) })
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	cardPattern  = regexp.MustCompile(`\b\d(?:[ \-]?\d){12,18}\b`)
+)
+
+// Text redacts the email addresses and card numbers found in free text,
+// such as a description written by the customer.
+func (r Redactor) Text(s string) string {
+	s = emailPattern.ReplaceAllStringFunc(s, r.Email)
+	return cardPattern.ReplaceAllStringFunc(s, func(match string) string {
+		if !luhn(match) {
+			return match
+		}
+		return r.apply(CardNumber, match, maskCard)
+	})
+}
+
+func (r Redactor) apply(kind, s string, mask func(string) string) string {
+	if s == "" {
+		return s
+	}
+	switch r.actions[kind] {
+	case Keep:
+		return s
+	case Drop:
+		return ""
+	case Hash:
+		mac := hmac.New(sha256.New, r.key)
+		mac.Write([]byte(s))
+		return "sha256:" + hex.EncodeToString(mac.Sum(nil))[:16]
+	default:
+		// A zero Redactor masks everything.
+		return mask(s)
+	}
+}
+
+// maskEmail keeps the first character of the local part and the domain.
+func maskEmail(s string) string {
+	at := strings.LastIndex(s, "@")
+	if at < 1 {
+		return "***"
+	}
+	return s[:1] + "***" + s[at:]
+}
+
+// maskCard keeps the last four digits and the separators of a card number.
+func maskCard(s string) string {
+	digits := 0
+	for _, c := range s {
+		if c >= '0' && c <= '9' {
+			digits++
+		}
+	}
+	var b strings.Builder
+	seen := 0
+	for _, c := range s {
+		if c >= '0' && c <= '9' {
+			seen++
+			if seen <= digits-4 {
+				c = '*'
+			}
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+func keepStart(s string, n int) string {
+	if len(s) <= n {
+		return strings.Repeat("*", len(s))
+	}
+	return s[:n] + strings.Repeat("*", len(s)-n)
+}
+
+// luhn reports whether the digits of s pass the Luhn checksum, which tells
+// card numbers from other long numbers such as order IDs.
+func luhn(s string) bool {
+	sum, double := 0, false
+	for i := len(s) - 1; i >= 0; i-- {
+		c := s[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if double {
+			if d *= 2; d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return sum%10 == 0
+}
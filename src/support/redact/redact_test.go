@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package redact
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	testEmail = "jane.doe@example.com"
+	testCard  = "4111 1111 1111 1111"
+)
+
+func TestRedactor(t *testing.T) {
+	p, err := NewPolicy(Rules{
+		Ticket: {Email: Keep, StreetAddress: Mask, ZipCode: Hash},
+		Dora:   {Email: Drop, CardNumber: Drop},
+	}, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"default email", p.For(Notification).Email(testEmail), "j***@example.com"},
+		{"kept email", p.For(Ticket).Email(testEmail), testEmail},
+		{"dropped email", p.For(Dora).Email(testEmail), ""},
+		{"default street", p.For(Notification).StreetAddress("1600 Amphitheatre Pkwy"), ""},
+		{"masked street", p.For(Ticket).StreetAddress("1600 Amphitheatre Pkwy"), "***"},
+		{"default zip", p.For(Notification).ZipCode("94043"), "94***"},
+		{"empty", p.For(Ticket).StreetAddress(""), ""},
+		{"text", p.For(Notification).Text("Card " + testCard + " of " + testEmail + " was declined"), "Card **** **** **** 1111 of j***@example.com was declined"},
+		{"dropped card", p.For(Dora).Text("Card " + testCard + " declined"), "Card  declined"},
+		{"not a card", p.For(Notification).Text("Order 1234567890123 failed"), "Order 1234567890123 failed"},
+		{"zero redactor", Redactor{}.Email(testEmail), "j***@example.com"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, tt.got, tt.want)
+		}
+	}
+
+	hashed := p.For(Telemetry).Email(testEmail)
+	if !strings.HasPrefix(hashed, "sha256:") || strings.Contains(hashed, "jane") {
+		t.Errorf("hashed email = %q", hashed)
+	}
+	other, _ := NewPolicy(nil, "other key")
+	if other.For(Telemetry).Email(testEmail) == hashed {
+		t.Error("hashes with different keys are equal")
+	}
+}
+
+func TestNewPolicyErrors(t *testing.T) {
+	for _, rules := range []Rules{
+		{"logs": {Email: Mask}},
+		{Ticket: {"phone": Mask}},
+		{Ticket: {Email: "encrypt"}},
+	} {
+		if _, err := NewPolicy(rules, ""); err == nil {
+			t.Errorf("NewPolicy(%v) error = nil", rules)
+		}
+	}
+}
+
+func TestLoadPolicy(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policy.json")
+	if err := os.WriteFile(path, []byte(`{"ticket": {"email": "keep"}}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	p, err := LoadPolicy(path, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.For(Ticket).Email(testEmail); got != testEmail {
+		t.Errorf("ticket email = %q, want it kept", got)
+	}
+	if got := p.For(Ticket).ZipCode("94043"); got != "94***" {
+		t.Errorf("ticket zip = %q, want the default mask", got)
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	pb "github.com/opentelemetry/opentelemetry-demo/src/support/genproto/oteldemo"
+)
+
+// Memory is a Store that keeps support requests in memory. They are lost
+// when the process exits.
+type Memory struct {
+	mu       sync.RWMutex
+	requests map[string]*pb.SupportRequest
+	// incidents are keyed by ID; latest maps a fingerprint to the ID of its
+	// most recent incident.
+	incidents map[string]*Incident
+	latest    map[string]string
+}
+
+var _ Store = (*Memory)(nil)
+
+// NewMemory returns an empty Memory store.
+func NewMemory() *Memory {
+	return &Memory{
+		requests:  make(map[string]*pb.SupportRequest),
+		incidents: make(map[string]*Incident),
+		latest:    make(map[string]string),
+	}
+}
+
+func (m *Memory) Create(_ context.Context, req *pb.SupportRequest) error {
+	if err := validateNew(req); err != nil {
+		return err
+	}
+	stored := proto.Clone(req).(*pb.SupportRequest)
+	stored.UpdatedAt = stored.CreatedAt
+	stored.History = []*pb.SupportRequestStatusChange{creation(req)}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.requests[req.GetId()]; ok {
+		return ErrExists
+	}
+	m.requests[req.GetId()] = stored
+	return nil
+}
+
+func (m *Memory) Get(_ context.Context, id string) (*pb.SupportRequest, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	req, ok := m.requests[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return proto.Clone(req).(*pb.SupportRequest), nil
+}
+
+func (m *Memory) List(_ context.Context, f Filter) ([]*pb.SupportRequest, error) {
+	m.mu.RLock()
+	var out []*pb.SupportRequest
+	for _, req := range m.requests {
+		if f.matches(req) {
+			out = append(out, proto.Clone(req).(*pb.SupportRequest))
+		}
+	}
+	m.mu.RUnlock()
+
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].GetCreatedAt() != out[j].GetCreatedAt() {
+			return out[i].GetCreatedAt() > out[j].GetCreatedAt()
+		}
+		return out[i].GetId() < out[j].GetId()
+	})
+	return out, nil
+}
+
+func (m *Memory) UpdateStatus(_ context.Context, id string, change StatusChange) (*pb.SupportRequest, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	req, ok := m.requests[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	if err := ValidateTransition(req.GetStatus(), change.To); err != nil {
+		return nil, err
+	}
+
+	req.History = append(req.History, &pb.SupportRequestStatusChange{
+		FromStatus: req.GetStatus(),
+		ToStatus:   change.To,
+		Actor:      change.Actor,
+		Reason:     change.Reason,
+		ChangedAt:  change.At.Unix(),
+	})
+	req.Status = change.To
+	req.UpdatedAt = change.At.Unix()
+	return proto.Clone(req).(*pb.SupportRequest), nil
+}
+
+func (m *Memory) SetTicket(_ context.Context, id string, t Ticket) (*pb.SupportRequest, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	req, ok := m.requests[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	req.TicketStatus = t.Status
+	req.JiraTicketId = t.Key
+	req.TicketUrl = t.URL
+	return proto.Clone(req).(*pb.SupportRequest), nil
+}
+
+func (m *Memory) SetDoraIncident(_ context.Context, id, doraIncidentID string) (*pb.SupportRequest, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	req, ok := m.requests[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	req.DoraIncidentId = doraIncidentID
+	return proto.Clone(req).(*pb.SupportRequest), nil
+}
+
+func (m *Memory) Group(_ context.Context, r Report, window time.Duration) (Incident, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if inc, ok := m.incidents[m.latest[r.Fingerprint]]; ok && inc.joinable(r.At, window) {
+		inc.add(r)
+		return cloneIncident(inc), false, nil
+	}
+	inc := &Incident{ID: r.NewIncidentID, Fingerprint: r.Fingerprint, FirstSeen: r.At, LastSeen: r.At}
+	inc.add(r)
+	m.incidents[inc.ID] = inc
+	m.latest[r.Fingerprint] = inc.ID
+	return cloneIncident(inc), true, nil
+}
+
+func (m *Memory) GetIncident(_ context.Context, id string) (Incident, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	inc, ok := m.incidents[id]
+	if !ok {
+		return Incident{}, ErrIncidentNotFound
+	}
+	return cloneIncident(inc), nil
+}
+
+func (m *Memory) SetIncidentTicket(_ context.Context, id string, t Ticket) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	inc, ok := m.incidents[id]
+	if !ok {
+		return ErrIncidentNotFound
+	}
+	inc.Ticket = t
+	return nil
+}
+
+func (m *Memory) IncidentByTicket(_ context.Context, key string) (Incident, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	var latest *Incident
+	for _, inc := range m.incidents {
+		if key == "" || inc.Ticket.Key != key {
+			continue
+		}
+		if latest == nil || inc.FirstSeen.After(latest.FirstSeen) {
+			latest = inc
+		}
+	}
+	if latest == nil {
+		return Incident{}, ErrIncidentNotFound
+	}
+	return cloneIncident(latest), nil
+}
+
+func cloneIncident(inc *Incident) Incident {
+	out := *inc
+	out.RequestIDs = append([]string(nil), inc.RequestIDs...)
+	out.UserIDs = append([]string(nil), inc.UserIDs...)
+	return out
+}
+
+// Ping implements Store. A memory store is always usable.
+func (m *Memory) Ping(context.Context) error { return nil }
+
+// Close implements Store. It does nothing.
+func (m *Memory) Close() error { return nil }
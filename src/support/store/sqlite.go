@@ -0,0 +1,453 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+	_ "modernc.org/sqlite"
+
+	pb "github.com/opentelemetry/opentelemetry-demo/src/support/genproto/oteldemo"
+)
+
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS support_requests (
+	id         TEXT PRIMARY KEY,
+	user_id    TEXT NOT NULL,
+	status     TEXT NOT NULL,
+	created_at INTEGER NOT NULL,
+	updated_at INTEGER NOT NULL,
+	data       BLOB NOT NULL
+);
+CREATE INDEX IF NOT EXISTS support_requests_user_id ON support_requests (user_id, created_at);
+CREATE INDEX IF NOT EXISTS support_requests_status ON support_requests (status, created_at);
+
+CREATE TABLE IF NOT EXISTS support_request_history (
+	seq         INTEGER PRIMARY KEY AUTOINCREMENT,
+	request_id  TEXT NOT NULL REFERENCES support_requests (id),
+	from_status TEXT NOT NULL,
+	to_status   TEXT NOT NULL,
+	actor       TEXT NOT NULL,
+	reason      TEXT NOT NULL,
+	changed_at  INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS support_request_history_request_id ON support_request_history (request_id, seq);
+
+CREATE TABLE IF NOT EXISTS support_incidents (
+	id            TEXT PRIMARY KEY,
+	fingerprint   TEXT NOT NULL,
+	ticket_status TEXT NOT NULL DEFAULT '',
+	ticket_id     TEXT NOT NULL DEFAULT '',
+	ticket_key    TEXT NOT NULL DEFAULT '',
+	ticket_url    TEXT NOT NULL DEFAULT '',
+	first_seen    INTEGER NOT NULL,
+	last_seen     INTEGER NOT NULL
+);
+CREATE INDEX IF NOT EXISTS support_incidents_fingerprint ON support_incidents (fingerprint, first_seen);
+CREATE INDEX IF NOT EXISTS support_incidents_ticket_key ON support_incidents (ticket_key, first_seen);
+
+CREATE TABLE IF NOT EXISTS support_incident_reports (
+	seq         INTEGER PRIMARY KEY AUTOINCREMENT,
+	incident_id TEXT NOT NULL REFERENCES support_incidents (id),
+	request_id  TEXT NOT NULL,
+	user_id     TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS support_incident_reports_incident_id ON support_incident_reports (incident_id, seq);
+`
+
+// SQLite is a Store backed by a SQLite database file. The support request
+// itself is stored as a serialized message next to the columns used for
+// filtering; its history is kept in a separate table, as are incidents and
+// the reports grouped into them.
+type SQLite struct {
+	db *sql.DB
+}
+
+var _ Store = (*SQLite)(nil)
+
+// OpenSQLite opens, and creates if needed, the SQLite database at path.
+func OpenSQLite(ctx context.Context, path string) (*SQLite, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %+v", path, err)
+	}
+	// SQLite allows a single writer; serializing access through one
+	// connection avoids SQLITE_BUSY errors under concurrent requests, and the
+	// busy timeout covers the job queue sharing the file.
+	db.SetMaxOpenConns(1)
+	if _, err := db.ExecContext(ctx, "PRAGMA journal_mode = WAL; PRAGMA foreign_keys = ON; PRAGMA busy_timeout = 5000;"); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to configure %s: %+v", path, err)
+	}
+	if _, err := db.ExecContext(ctx, sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to create schema in %s: %+v", path, err)
+	}
+	return &SQLite{db: db}, nil
+}
+
+func (s *SQLite) Create(ctx context.Context, req *pb.SupportRequest) error {
+	if err := validateNew(req); err != nil {
+		return err
+	}
+	data, err := marshalRequest(req)
+	if err != nil {
+		return err
+	}
+
+	return s.inTx(ctx, func(tx *sql.Tx) error {
+		var exists bool
+		if err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM support_requests WHERE id = ?)", req.GetId()).Scan(&exists); err != nil {
+			return err
+		}
+		if exists {
+			return ErrExists
+		}
+		if _, err := tx.ExecContext(ctx,
+			"INSERT INTO support_requests (id, user_id, status, created_at, updated_at, data) VALUES (?, ?, ?, ?, ?, ?)",
+			req.GetId(), req.GetUserId(), req.GetStatus(), req.GetCreatedAt(), req.GetCreatedAt(), data,
+		); err != nil {
+			return err
+		}
+		return insertHistory(ctx, tx, req.GetId(), creation(req))
+	})
+}
+
+func (s *SQLite) Get(ctx context.Context, id string) (*pb.SupportRequest, error) {
+	req, err := scanRequest(s.db.QueryRowContext(ctx,
+		"SELECT status, updated_at, data FROM support_requests WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if req.History, err = s.history(ctx, id); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+func (s *SQLite) List(ctx context.Context, f Filter) ([]*pb.SupportRequest, error) {
+	var where []string
+	var args []any
+	if f.UserID != "" {
+		where = append(where, "user_id = ?")
+		args = append(args, f.UserID)
+	}
+	if f.Status != "" {
+		where = append(where, "status = ?")
+		args = append(args, f.Status)
+	}
+	if !f.CreatedAfter.IsZero() {
+		where = append(where, "created_at >= ?")
+		args = append(args, f.CreatedAfter.Unix())
+	}
+	if !f.CreatedBefore.IsZero() {
+		where = append(where, "created_at <= ?")
+		args = append(args, f.CreatedBefore.Unix())
+	}
+	query := "SELECT status, updated_at, data FROM support_requests"
+	if len(where) > 0 {
+		query += " WHERE " + strings.Join(where, " AND ")
+	}
+	query += " ORDER BY created_at DESC, id"
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	var out []*pb.SupportRequest
+	for rows.Next() {
+		req, err := scanRequest(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		out = append(out, req)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	// The single connection is released once rows is closed, so the
+	// histories can only be read afterwards.
+	for _, req := range out {
+		if req.History, err = s.history(ctx, req.GetId()); err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+func (s *SQLite) UpdateStatus(ctx context.Context, id string, change StatusChange) (*pb.SupportRequest, error) {
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		var from string
+		err := tx.QueryRowContext(ctx, "SELECT status FROM support_requests WHERE id = ?", id).Scan(&from)
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := ValidateTransition(from, change.To); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx,
+			"UPDATE support_requests SET status = ?, updated_at = ? WHERE id = ?",
+			change.To, change.At.Unix(), id,
+		); err != nil {
+			return err
+		}
+		return insertHistory(ctx, tx, id, &pb.SupportRequestStatusChange{
+			FromStatus: from,
+			ToStatus:   change.To,
+			Actor:      change.Actor,
+			Reason:     change.Reason,
+			ChangedAt:  change.At.Unix(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, id)
+}
+
+func (s *SQLite) SetTicket(ctx context.Context, id string, t Ticket) (*pb.SupportRequest, error) {
+	return s.updateData(ctx, id, func(req *pb.SupportRequest) {
+		req.TicketStatus = t.Status
+		req.JiraTicketId = t.Key
+		req.TicketUrl = t.URL
+	})
+}
+
+func (s *SQLite) SetDoraIncident(ctx context.Context, id, doraIncidentID string) (*pb.SupportRequest, error) {
+	return s.updateData(ctx, id, func(req *pb.SupportRequest) {
+		req.DoraIncidentId = doraIncidentID
+	})
+}
+
+// updateData applies update to the serialized support request with the given
+// ID, for changes that are not status transitions.
+func (s *SQLite) updateData(ctx context.Context, id string, update func(*pb.SupportRequest)) (*pb.SupportRequest, error) {
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		req, err := scanRequest(tx.QueryRowContext(ctx,
+			"SELECT status, updated_at, data FROM support_requests WHERE id = ?", id))
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		if err != nil {
+			return err
+		}
+		update(req)
+		data, err := marshalRequest(req)
+		if err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx, "UPDATE support_requests SET data = ? WHERE id = ?", data, id)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Get(ctx, id)
+}
+
+func (s *SQLite) Group(ctx context.Context, r Report, window time.Duration) (Incident, bool, error) {
+	var id string
+	var created bool
+	err := s.inTx(ctx, func(tx *sql.Tx) error {
+		inc, err := scanIncident(tx.QueryRowContext(ctx,
+			"SELECT "+incidentColumns+" FROM support_incidents WHERE fingerprint = ? ORDER BY first_seen DESC, rowid DESC LIMIT 1",
+			r.Fingerprint))
+		switch {
+		case err == nil && inc.joinable(r.At, window):
+			id = inc.ID
+			if _, err := tx.ExecContext(ctx,
+				"UPDATE support_incidents SET last_seen = MAX(last_seen, ?) WHERE id = ?", r.At.UnixNano(), id,
+			); err != nil {
+				return err
+			}
+		case err == nil || errors.Is(err, sql.ErrNoRows):
+			id, created = r.NewIncidentID, true
+			if _, err := tx.ExecContext(ctx,
+				"INSERT INTO support_incidents (id, fingerprint, first_seen, last_seen) VALUES (?, ?, ?, ?)",
+				id, r.Fingerprint, r.At.UnixNano(), r.At.UnixNano(),
+			); err != nil {
+				return err
+			}
+		default:
+			return err
+		}
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO support_incident_reports (incident_id, request_id, user_id) VALUES (?, ?, ?)",
+			id, r.RequestID, r.UserID)
+		return err
+	})
+	if err != nil {
+		return Incident{}, false, err
+	}
+	inc, err := s.GetIncident(ctx, id)
+	return inc, created, err
+}
+
+func (s *SQLite) GetIncident(ctx context.Context, id string) (Incident, error) {
+	inc, err := scanIncident(s.db.QueryRowContext(ctx,
+		"SELECT "+incidentColumns+" FROM support_incidents WHERE id = ?", id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Incident{}, ErrIncidentNotFound
+	}
+	if err != nil {
+		return Incident{}, err
+	}
+
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT request_id, user_id FROM support_incident_reports WHERE incident_id = ? ORDER BY seq", id)
+	if err != nil {
+		return Incident{}, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var requestID, userID string
+		if err := rows.Scan(&requestID, &userID); err != nil {
+			return Incident{}, err
+		}
+		inc.RequestIDs = append(inc.RequestIDs, requestID)
+		if !contains(inc.UserIDs, userID) {
+			inc.UserIDs = append(inc.UserIDs, userID)
+		}
+	}
+	return inc, rows.Err()
+}
+
+func (s *SQLite) SetIncidentTicket(ctx context.Context, id string, t Ticket) error {
+	res, err := s.db.ExecContext(ctx,
+		"UPDATE support_incidents SET ticket_status = ?, ticket_id = ?, ticket_key = ?, ticket_url = ? WHERE id = ?",
+		t.Status, t.ID, t.Key, t.URL, id)
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrIncidentNotFound
+	}
+	return nil
+}
+
+func (s *SQLite) IncidentByTicket(ctx context.Context, key string) (Incident, error) {
+	if key == "" {
+		return Incident{}, ErrIncidentNotFound
+	}
+	var id string
+	err := s.db.QueryRowContext(ctx,
+		"SELECT id FROM support_incidents WHERE ticket_key = ? ORDER BY first_seen DESC, rowid DESC LIMIT 1", key).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Incident{}, ErrIncidentNotFound
+	}
+	if err != nil {
+		return Incident{}, err
+	}
+	return s.GetIncident(ctx, id)
+}
+
+// Ping checks that the database can be reached.
+func (s *SQLite) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+// Close closes the database.
+func (s *SQLite) Close() error {
+	return s.db.Close()
+}
+
+func (s *SQLite) inTx(ctx context.Context, fn func(*sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *SQLite) history(ctx context.Context, id string) ([]*pb.SupportRequestStatusChange, error) {
+	rows, err := s.db.QueryContext(ctx,
+		"SELECT from_status, to_status, actor, reason, changed_at FROM support_request_history WHERE request_id = ? ORDER BY seq", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var out []*pb.SupportRequestStatusChange
+	for rows.Next() {
+		var c pb.SupportRequestStatusChange
+		if err := rows.Scan(&c.FromStatus, &c.ToStatus, &c.Actor, &c.Reason, &c.ChangedAt); err != nil {
+			return nil, err
+		}
+		out = append(out, &c)
+	}
+	return out, rows.Err()
+}
+
+func insertHistory(ctx context.Context, tx *sql.Tx, id string, c *pb.SupportRequestStatusChange) error {
+	_, err := tx.ExecContext(ctx,
+		"INSERT INTO support_request_history (request_id, from_status, to_status, actor, reason, changed_at) VALUES (?, ?, ?, ?, ?, ?)",
+		id, c.GetFromStatus(), c.GetToStatus(), c.GetActor(), c.GetReason(), c.GetChangedAt(),
+	)
+	return err
+}
+
+// marshalRequest serializes req without the fields that are kept in their
+// own columns or table.
+func marshalRequest(req *pb.SupportRequest) ([]byte, error) {
+	stored := proto.Clone(req).(*pb.SupportRequest)
+	stored.Status = ""
+	stored.UpdatedAt = 0
+	stored.History = nil
+	data, err := proto.Marshal(stored)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal support request: %+v", err)
+	}
+	return data, nil
+}
+
+func scanRequest(row interface{ Scan(...any) error }) (*pb.SupportRequest, error) {
+	var status string
+	var updatedAt int64
+	var data []byte
+	if err := row.Scan(&status, &updatedAt, &data); err != nil {
+		return nil, err
+	}
+	var req pb.SupportRequest
+	if err := proto.Unmarshal(data, &req); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal support request: %+v", err)
+	}
+	req.Status = status
+	req.UpdatedAt = updatedAt
+	return &req, nil
+}
+
+const incidentColumns = "id, fingerprint, ticket_status, ticket_id, ticket_key, ticket_url, first_seen, last_seen"
+
+// scanIncident scans an incident without its reports.
+func scanIncident(row interface{ Scan(...any) error }) (Incident, error) {
+	var inc Incident
+	var firstSeen, lastSeen int64
+	if err := row.Scan(&inc.ID, &inc.Fingerprint, &inc.Ticket.Status, &inc.Ticket.ID, &inc.Ticket.Key, &inc.Ticket.URL, &firstSeen, &lastSeen); err != nil {
+		return Incident{}, err
+	}
+	inc.FirstSeen = time.Unix(0, firstSeen)
+	inc.LastSeen = time.Unix(0, lastSeen)
+	return inc, nil
+}
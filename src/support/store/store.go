@@ -0,0 +1,284 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package store persists support requests and the history of their status
+// transitions. Two implementations are provided: Memory, which keeps requests
+// for the lifetime of the process, and SQLite, which keeps them in a file.
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/opentelemetry/opentelemetry-demo/src/support/genproto/oteldemo"
+)
+
+// Statuses of a support request.
+const (
+	StatusCreated    = "CREATED"
+	StatusTriaged    = "TRIAGED"
+	StatusInProgress = "IN_PROGRESS"
+	StatusResolved   = "RESOLVED"
+	StatusClosed     = "CLOSED"
+)
+
+// Statuses of the ticket filed for a support request. The ticket status is
+// empty when no ticket provider is configured.
+const (
+	TicketPending = "PENDING"
+	TicketCreated = "CREATED"
+	TicketFailed  = "FAILED"
+)
+
+// transitions lists the statuses each status may move to. Requests normally
+// move forward one step at a time; they may also be closed before work starts
+// (duplicates, invalid requests) and reopened after being resolved.
+var transitions = map[string][]string{
+	StatusCreated:    {StatusTriaged, StatusClosed},
+	StatusTriaged:    {StatusInProgress, StatusClosed},
+	StatusInProgress: {StatusResolved},
+	StatusResolved:   {StatusClosed, StatusInProgress},
+	StatusClosed:     nil,
+}
+
+var (
+	// ErrNotFound is returned when no support request has the given ID.
+	ErrNotFound = errors.New("support request not found")
+	// ErrExists is returned when creating a support request whose ID is taken.
+	ErrExists = errors.New("support request already exists")
+	// ErrIncidentNotFound is returned when no incident has the given ID.
+	ErrIncidentNotFound = errors.New("incident not found")
+)
+
+// UnknownStatusError is returned for a status that is not one of the
+// Status constants.
+type UnknownStatusError struct {
+	Status string
+}
+
+func (e *UnknownStatusError) Error() string {
+	return fmt.Sprintf("unknown support request status %q", e.Status)
+}
+
+// InvalidTransitionError is returned when a support request cannot move from
+// its current status to the requested one.
+type InvalidTransitionError struct {
+	From, To string
+}
+
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("support request cannot move from %s to %s", e.From, e.To)
+}
+
+// ValidStatus reports whether status is one of the Status constants.
+func ValidStatus(status string) bool {
+	_, ok := transitions[status]
+	return ok
+}
+
+// ValidateTransition returns an error unless a support request in status from
+// may move to status to.
+func ValidateTransition(from, to string) error {
+	if !ValidStatus(to) {
+		return &UnknownStatusError{Status: to}
+	}
+	for _, next := range transitions[from] {
+		if next == to {
+			return nil
+		}
+	}
+	return &InvalidTransitionError{From: from, To: to}
+}
+
+// Path returns the shortest sequence of transitions that moves a support
+// request from status from to status to, excluding from. It returns nil if
+// to cannot be reached, or from equals to.
+func Path(from, to string) []string {
+	prev := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		status := queue[0]
+		queue = queue[1:]
+		for _, next := range transitions[status] {
+			if _, seen := prev[next]; seen {
+				continue
+			}
+			prev[next] = status
+			if next == to {
+				var path []string
+				for s := to; s != from; s = prev[s] {
+					path = append([]string{s}, path...)
+				}
+				return path
+			}
+			queue = append(queue, next)
+		}
+	}
+	return nil
+}
+
+// Filter selects support requests in List. Zero fields match everything.
+type Filter struct {
+	UserID string
+	Status string
+	// CreatedAfter and CreatedBefore bound the creation time inclusively.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+func (f Filter) matches(req *pb.SupportRequest) bool {
+	if f.UserID != "" && req.GetUserId() != f.UserID {
+		return false
+	}
+	if f.Status != "" && req.GetStatus() != f.Status {
+		return false
+	}
+	if !f.CreatedAfter.IsZero() && req.GetCreatedAt() < f.CreatedAfter.Unix() {
+		return false
+	}
+	if !f.CreatedBefore.IsZero() && req.GetCreatedAt() > f.CreatedBefore.Unix() {
+		return false
+	}
+	return true
+}
+
+// StatusChange describes a status transition requested through UpdateStatus.
+type StatusChange struct {
+	To     string
+	Actor  string
+	Reason string
+	At     time.Time
+}
+
+// Ticket describes the ticket filed for a support request.
+type Ticket struct {
+	Status string
+	// ID is the tracker's internal identifier, which some trackers need to
+	// comment on the ticket. It is only kept for incidents.
+	ID  string
+	Key string
+	URL string
+}
+
+// Incident groups the support requests with the same fingerprint. The first
+// request files the ticket; the others are added to it.
+type Incident struct {
+	ID          string
+	Fingerprint string
+	// RequestIDs are the grouped support requests, oldest first.
+	RequestIDs []string
+	// UserIDs are the distinct users who reported the incident, in the
+	// order they first did.
+	UserIDs   []string
+	Ticket    Ticket
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+// Report is a support request to be grouped into an incident.
+type Report struct {
+	Fingerprint string
+	RequestID   string
+	UserID      string
+	At          time.Time
+	// NewIncidentID is the ID given to the incident if the report starts one.
+	NewIncidentID string
+}
+
+// Store persists support requests. Implementations are safe for concurrent
+// use and never retain or return the caller's messages, so callers are free
+// to modify them.
+type Store interface {
+	// Create stores a new support request. Its status must be CREATED; the
+	// creation is recorded as the first entry of its history.
+	Create(ctx context.Context, req *pb.SupportRequest) error
+	// Get returns the support request with the given ID and its history.
+	Get(ctx context.Context, id string) (*pb.SupportRequest, error)
+	// List returns the support requests matching f, newest first.
+	List(ctx context.Context, f Filter) ([]*pb.SupportRequest, error)
+	// UpdateStatus moves the support request with the given ID to a new
+	// status and records the transition in its history.
+	UpdateStatus(ctx context.Context, id string, change StatusChange) (*pb.SupportRequest, error)
+	// SetTicket records the ticket filed for the support request with the
+	// given ID. Unlike a status change it is not recorded in the history.
+	SetTicket(ctx context.Context, id string, t Ticket) (*pb.SupportRequest, error)
+	// SetDoraIncident records the ID of the DORA metrics incident recorded
+	// for the support request with the given ID.
+	SetDoraIncident(ctx context.Context, id, doraIncidentID string) (*pb.SupportRequest, error)
+
+	// Group adds r to the latest incident with its fingerprint if that was
+	// last seen within window and its ticket did not fail; otherwise r
+	// starts a new incident. It reports whether a new incident was started.
+	Group(ctx context.Context, r Report, window time.Duration) (Incident, bool, error)
+	// GetIncident returns the incident with the given ID.
+	GetIncident(ctx context.Context, id string) (Incident, error)
+	// SetIncidentTicket records the ticket filed for an incident.
+	SetIncidentTicket(ctx context.Context, id string, t Ticket) error
+	// IncidentByTicket returns the latest incident whose ticket has the given
+	// key.
+	IncidentByTicket(ctx context.Context, key string) (Incident, error)
+	// Ping reports whether the store can be used.
+	Ping(ctx context.Context) error
+	Close() error
+}
+
+// TimeToRestore returns how long the failure reported by req lasted: from
+// the creation of req until it was last resolved. It reports false unless req
+// is resolved or was closed after being resolved.
+func TimeToRestore(req *pb.SupportRequest) (time.Duration, bool) {
+	if req.GetStatus() != StatusResolved && req.GetStatus() != StatusClosed {
+		return 0, false
+	}
+	history := req.GetHistory()
+	for i := len(history) - 1; i >= 0; i-- {
+		if history[i].GetToStatus() == StatusResolved {
+			return time.Duration(history[i].GetChangedAt()-req.GetCreatedAt()) * time.Second, true
+		}
+	}
+	return 0, false
+}
+
+// creation returns the history entry recorded when req is created.
+func creation(req *pb.SupportRequest) *pb.SupportRequestStatusChange {
+	return &pb.SupportRequestStatusChange{
+		ToStatus:  StatusCreated,
+		ChangedAt: req.GetCreatedAt(),
+	}
+}
+
+func validateNew(req *pb.SupportRequest) error {
+	if req.GetId() == "" {
+		return errors.New("support request has no ID")
+	}
+	if req.GetStatus() != StatusCreated {
+		return fmt.Errorf("new support request has status %q, want %s", req.GetStatus(), StatusCreated)
+	}
+	return nil
+}
+
+// joinable reports whether a report at the given time may join inc.
+func (inc *Incident) joinable(at time.Time, window time.Duration) bool {
+	return inc.Ticket.Status != TicketFailed && !inc.LastSeen.Before(at.Add(-window))
+}
+
+// add records a report of inc.
+func (inc *Incident) add(r Report) {
+	inc.RequestIDs = append(inc.RequestIDs, r.RequestID)
+	if !contains(inc.UserIDs, r.UserID) {
+		inc.UserIDs = append(inc.UserIDs, r.UserID)
+	}
+	if r.At.After(inc.LastSeen) {
+		inc.LastSeen = r.At
+	}
+}
+
+func contains(values []string, v string) bool {
+	for _, s := range values {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
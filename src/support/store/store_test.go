@@ -0,0 +1,354 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package store
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/opentelemetry/opentelemetry-demo/src/support/genproto/oteldemo"
+)
+
+func stores(t *testing.T) map[string]Store {
+	sqlite, err := OpenSQLite(context.Background(), filepath.Join(t.TempDir(), "support.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlite.Close() })
+	return map[string]Store{
+		"memory": NewMemory(),
+		"sqlite": sqlite,
+	}
+}
+
+func newRequest(id, userID string, createdAt int64) *pb.SupportRequest {
+	return &pb.SupportRequest{
+		Id:        id,
+		UserId:    userID,
+		Email:     userID + "@example.com",
+		Subject:   "Checkout failed",
+		Status:    StatusCreated,
+		CreatedAt: createdAt,
+		FailedItems: []*pb.OrderItem{
+			{Item: &pb.CartItem{ProductId: "OLJCESPC7Z", Quantity: 2}},
+		},
+	}
+}
+
+func TestStoreLifecycle(t *testing.T) {
+	ctx := context.Background()
+	for name, s := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			if err := s.Ping(ctx); err != nil {
+				t.Fatalf("Ping() error = %v", err)
+			}
+			if err := s.Create(ctx, newRequest("r1", "alice", 100)); err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Create(ctx, newRequest("r1", "alice", 100)); !errors.Is(err, ErrExists) {
+				t.Errorf("Create() duplicate error = %v, want ErrExists", err)
+			}
+			if _, err := s.Get(ctx, "missing"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("Get() missing error = %v, want ErrNotFound", err)
+			}
+
+			got, err := s.Get(ctx, "r1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.GetEmail() != "alice@example.com" || len(got.GetFailedItems()) != 1 {
+				t.Errorf("Get() = %v, want the created request", got)
+			}
+			if len(got.GetHistory()) != 1 || got.GetHistory()[0].GetToStatus() != StatusCreated {
+				t.Errorf("history = %v, want the creation", got.GetHistory())
+			}
+
+			for i, to := range []string{StatusTriaged, StatusInProgress, StatusResolved} {
+				got, err = s.UpdateStatus(ctx, "r1", StatusChange{To: to, Actor: "oncall", At: time.Unix(200+int64(i), 0)})
+				if err != nil {
+					t.Fatalf("UpdateStatus(%s) error = %v", to, err)
+				}
+			}
+			if got.GetStatus() != StatusResolved || got.GetUpdatedAt() != 202 {
+				t.Errorf("status = %s at %d, want %s at 202", got.GetStatus(), got.GetUpdatedAt(), StatusResolved)
+			}
+			if len(got.GetHistory()) != 4 {
+				t.Fatalf("history has %d entries, want 4", len(got.GetHistory()))
+			}
+			last := got.GetHistory()[3]
+			if last.GetFromStatus() != StatusInProgress || last.GetToStatus() != StatusResolved || last.GetActor() != "oncall" {
+				t.Errorf("last history entry = %v", last)
+			}
+
+			var invalid *InvalidTransitionError
+			if _, err := s.UpdateStatus(ctx, "r1", StatusChange{To: StatusTriaged, At: time.Unix(300, 0)}); !errors.As(err, &invalid) {
+				t.Errorf("UpdateStatus() backwards error = %v, want InvalidTransitionError", err)
+			}
+			var unknown *UnknownStatusError
+			if _, err := s.UpdateStatus(ctx, "r1", StatusChange{To: "DONE", At: time.Unix(300, 0)}); !errors.As(err, &unknown) {
+				t.Errorf("UpdateStatus() unknown error = %v, want UnknownStatusError", err)
+			}
+			if _, err := s.UpdateStatus(ctx, "missing", StatusChange{To: StatusTriaged}); !errors.Is(err, ErrNotFound) {
+				t.Errorf("UpdateStatus() missing error = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestStoreList(t *testing.T) {
+	ctx := context.Background()
+	for name, s := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			for _, req := range []*pb.SupportRequest{
+				newRequest("a1", "alice", 100),
+				newRequest("a2", "alice", 300),
+				newRequest("b1", "bob", 200),
+			} {
+				if err := s.Create(ctx, req); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if _, err := s.UpdateStatus(ctx, "b1", StatusChange{To: StatusTriaged, At: time.Unix(250, 0)}); err != nil {
+				t.Fatal(err)
+			}
+
+			tests := []struct {
+				name   string
+				filter Filter
+				want   []string
+			}{
+				{"all", Filter{}, []string{"a2", "b1", "a1"}},
+				{"user", Filter{UserID: "alice"}, []string{"a2", "a1"}},
+				{"status", Filter{Status: StatusCreated}, []string{"a2", "a1"}},
+				{"range", Filter{CreatedAfter: time.Unix(100, 0), CreatedBefore: time.Unix(200, 0)}, []string{"b1", "a1"}},
+				{"combined", Filter{UserID: "bob", Status: StatusTriaged, CreatedAfter: time.Unix(150, 0)}, []string{"b1"}},
+				{"none", Filter{UserID: "carol"}, nil},
+			}
+			for _, tt := range tests {
+				got, err := s.List(ctx, tt.filter)
+				if err != nil {
+					t.Fatalf("%s: List() error = %v", tt.name, err)
+				}
+				var ids []string
+				for _, req := range got {
+					ids = append(ids, req.GetId())
+				}
+				if len(ids) != len(tt.want) {
+					t.Errorf("%s: List() = %v, want %v", tt.name, ids, tt.want)
+					continue
+				}
+				for i := range ids {
+					if ids[i] != tt.want[i] {
+						t.Errorf("%s: List() = %v, want %v", tt.name, ids, tt.want)
+						break
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestStoreSetTicket(t *testing.T) {
+	ctx := context.Background()
+	for name, s := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			req := newRequest("r1", "alice", 100)
+			req.TicketStatus = TicketPending
+			if err := s.Create(ctx, req); err != nil {
+				t.Fatal(err)
+			}
+			got, err := s.SetTicket(ctx, "r1", Ticket{Status: TicketCreated, Key: "DEMO-1", URL: "https://jira.example.com/browse/DEMO-1"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.GetTicketStatus() != TicketCreated || got.GetJiraTicketId() != "DEMO-1" || got.GetTicketUrl() == "" {
+				t.Errorf("SetTicket() = %v", got)
+			}
+			if got.GetStatus() != StatusCreated || len(got.GetHistory()) != 1 {
+				t.Errorf("SetTicket() changed the status to %s with history %v", got.GetStatus(), got.GetHistory())
+			}
+			if _, err := s.SetTicket(ctx, "missing", Ticket{Status: TicketFailed}); !errors.Is(err, ErrNotFound) {
+				t.Errorf("SetTicket() missing error = %v, want ErrNotFound", err)
+			}
+
+			got, err = s.SetDoraIncident(ctx, "r1", "dora-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.GetDoraIncidentId() != "dora-1" || got.GetJiraTicketId() != "DEMO-1" {
+				t.Errorf("SetDoraIncident() = %v", got)
+			}
+			if _, err := s.SetDoraIncident(ctx, "missing", "dora-2"); !errors.Is(err, ErrNotFound) {
+				t.Errorf("SetDoraIncident() missing error = %v, want ErrNotFound", err)
+			}
+		})
+	}
+}
+
+func TestStoreGroup(t *testing.T) {
+	ctx := context.Background()
+	window := 10 * time.Minute
+	report := func(requestID, userID string, at int64) Report {
+		return Report{Fingerprint: "fp", RequestID: requestID, UserID: userID, At: time.Unix(at, 0), NewIncidentID: "inc-" + requestID}
+	}
+
+	for name, s := range stores(t) {
+		t.Run(name, func(t *testing.T) {
+			steps := []struct {
+				report      Report
+				wantID      string
+				wantCreated bool
+			}{
+				{report("r1", "alice", 1000), "inc-r1", true},
+				{report("r2", "bob", 1300), "inc-r1", false},
+				{report("r3", "alice", 1800), "inc-r1", false},
+				// Ten minutes after the last report the incident is closed.
+				{report("r4", "carol", 2401), "inc-r4", true},
+				{Report{Fingerprint: "other", RequestID: "r5", UserID: "dave", At: time.Unix(2402, 0), NewIncidentID: "inc-r5"}, "inc-r5", true},
+			}
+			for _, step := range steps {
+				inc, created, err := s.Group(ctx, step.report, window)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if inc.ID != step.wantID || created != step.wantCreated {
+					t.Errorf("Group(%s) = %s, %t, want %s, %t", step.report.RequestID, inc.ID, created, step.wantID, step.wantCreated)
+				}
+			}
+
+			inc, err := s.GetIncident(ctx, "inc-r1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(inc.RequestIDs) != 3 || inc.RequestIDs[0] != "r1" || len(inc.UserIDs) != 2 {
+				t.Errorf("incident = %+v, want 3 requests from 2 users", inc)
+			}
+			if !inc.FirstSeen.Equal(time.Unix(1000, 0)) || !inc.LastSeen.Equal(time.Unix(1800, 0)) {
+				t.Errorf("incident seen %v to %v", inc.FirstSeen, inc.LastSeen)
+			}
+
+			// A failed ticket is not reused.
+			if err := s.SetIncidentTicket(ctx, "inc-r4", Ticket{Status: TicketFailed}); err != nil {
+				t.Fatal(err)
+			}
+			if inc, created, _ := s.Group(ctx, report("r6", "erin", 2410), window); !created || inc.ID != "inc-r6" {
+				t.Errorf("Group() after a failed ticket = %s, %t, want a new incident", inc.ID, created)
+			}
+
+			ticket := Ticket{Status: TicketCreated, ID: "10001", Key: "DEMO-1", URL: "https://jira.example.com/browse/DEMO-1"}
+			if err := s.SetIncidentTicket(ctx, "inc-r6", ticket); err != nil {
+				t.Fatal(err)
+			}
+			if inc, _ := s.GetIncident(ctx, "inc-r6"); inc.Ticket != ticket {
+				t.Errorf("incident ticket = %+v, want %+v", inc.Ticket, ticket)
+			}
+			if _, err := s.GetIncident(ctx, "missing"); !errors.Is(err, ErrIncidentNotFound) {
+				t.Errorf("GetIncident() missing error = %v, want ErrIncidentNotFound", err)
+			}
+			if err := s.SetIncidentTicket(ctx, "missing", ticket); !errors.Is(err, ErrIncidentNotFound) {
+				t.Errorf("SetIncidentTicket() missing error = %v, want ErrIncidentNotFound", err)
+			}
+
+			// The latest incident of a ticket wins, e.g. after an incident's
+			// ticket was re-filed under the same key.
+			if err := s.SetIncidentTicket(ctx, "inc-r1", ticket); err != nil {
+				t.Fatal(err)
+			}
+			if inc, err := s.IncidentByTicket(ctx, "DEMO-1"); err != nil || inc.ID != "inc-r6" || len(inc.RequestIDs) != 1 {
+				t.Errorf("IncidentByTicket(DEMO-1) = %+v, %v, want inc-r6", inc, err)
+			}
+			for _, key := range []string{"DEMO-2", ""} {
+				if _, err := s.IncidentByTicket(ctx, key); !errors.Is(err, ErrIncidentNotFound) {
+					t.Errorf("IncidentByTicket(%q) error = %v, want ErrIncidentNotFound", key, err)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateTransition(t *testing.T) {
+	tests := []struct {
+		from, to string
+		ok       bool
+	}{
+		{StatusCreated, StatusTriaged, true},
+		{StatusCreated, StatusClosed, true},
+		{StatusCreated, StatusResolved, false},
+		{StatusTriaged, StatusInProgress, true},
+		{StatusInProgress, StatusResolved, true},
+		{StatusInProgress, StatusClosed, false},
+		{StatusResolved, StatusClosed, true},
+		{StatusResolved, StatusInProgress, true},
+		{StatusClosed, StatusInProgress, false},
+		{StatusCreated, StatusCreated, false},
+	}
+	for _, tt := range tests {
+		if err := ValidateTransition(tt.from, tt.to); (err == nil) != tt.ok {
+			t.Errorf("ValidateTransition(%s, %s) error = %v, want ok %t", tt.from, tt.to, err, tt.ok)
+		}
+	}
+}
+
+func TestPath(t *testing.T) {
+	tests := []struct {
+		from, to string
+		want     string
+	}{
+		{StatusCreated, StatusTriaged, "TRIAGED"},
+		{StatusCreated, StatusResolved, "TRIAGED,IN_PROGRESS,RESOLVED"},
+		{StatusTriaged, StatusClosed, "CLOSED"},
+		{StatusInProgress, StatusClosed, "RESOLVED,CLOSED"},
+		{StatusResolved, StatusInProgress, "IN_PROGRESS"},
+		{StatusClosed, StatusInProgress, ""},
+		{StatusResolved, StatusResolved, ""},
+		{StatusCreated, "DONE", ""},
+	}
+	for _, tt := range tests {
+		if got := strings.Join(Path(tt.from, tt.to), ","); got != tt.want {
+			t.Errorf("Path(%s, %s) = %s, want %s", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestTimeToRestore(t *testing.T) {
+	change := func(to string, at int64) *pb.SupportRequestStatusChange {
+		return &pb.SupportRequestStatusChange{ToStatus: to, ChangedAt: at}
+	}
+	lifecycle := []*pb.SupportRequestStatusChange{
+		change(StatusCreated, 100),
+		change(StatusTriaged, 160),
+		change(StatusInProgress, 400),
+		change(StatusResolved, 1000),
+	}
+	reopened := append(append([]*pb.SupportRequestStatusChange{}, lifecycle...),
+		change(StatusInProgress, 1200),
+		change(StatusResolved, 1900),
+	)
+
+	tests := []struct {
+		name    string
+		status  string
+		history []*pb.SupportRequestStatusChange
+		want    time.Duration
+		ok      bool
+	}{
+		{"resolved", StatusResolved, lifecycle, 900 * time.Second, true},
+		{"closed after resolution", StatusClosed, append(lifecycle, change(StatusClosed, 2000)), 900 * time.Second, true},
+		{"resolved again", StatusResolved, reopened, 1800 * time.Second, true},
+		{"reopened", StatusInProgress, reopened[:5], 0, false},
+		{"closed unresolved", StatusClosed, []*pb.SupportRequestStatusChange{change(StatusCreated, 100), change(StatusClosed, 200)}, 0, false},
+		{"in progress", StatusInProgress, lifecycle[:3], 0, false},
+	}
+	for _, tt := range tests {
+		req := &pb.SupportRequest{Status: tt.status, CreatedAt: 100, History: tt.history}
+		got, ok := TimeToRestore(req)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("%s: TimeToRestore() = %v, %t, want %v, %t", tt.name, got, ok, tt.want, tt.ok)
+		}
+	}
+}
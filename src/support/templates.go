@@ -0,0 +1,159 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"embed"
+	"io/fs"
+	"net/url"
+	"os"
+	"sync"
+
+	"github.com/opentelemetry/opentelemetry-demo/src/support/adf"
+	pb "github.com/opentelemetry/opentelemetry-demo/src/support/genproto/oteldemo"
+)
+
+// Issue types of the tickets filed for support requests.
+const (
+	issueTypeBug  = "Bug"
+	issueTypeTask = "Task"
+)
+
+//go:embed templates/*.tmpl
+var builtinTemplateFS embed.FS
+
+// builtinTemplates are the ticket templates shipped with the service.
+var builtinTemplates = sync.OnceValues(func() (*adf.Templates, error) {
+	fsys, err := fs.Sub(builtinTemplateFS, "templates")
+	if err != nil {
+		return nil, err
+	}
+	return adf.Load(fsys)
+})
+
+// ticketTemplatesFromEnv loads the ticket templates. The *.tmpl files of
+// TICKET_TEMPLATE_DIR replace the built-in templates of the same name.
+func ticketTemplatesFromEnv() (*adf.Templates, error) {
+	dir := os.Getenv("TICKET_TEMPLATE_DIR")
+	if dir == "" {
+		return builtinTemplates()
+	}
+	builtin, err := fs.Sub(builtinTemplateFS, "templates")
+	if err != nil {
+		return nil, err
+	}
+	return adf.Load(builtin, os.DirFS(dir))
+}
+
+// issueTypeOf files requests that report an error as bugs, and questions as
+// tasks.
+func issueTypeOf(req *pb.CreateSupportRequestRequest) string {
+	if req.ErrorMessage != "" || len(req.FailedItems) > 0 {
+		return issueTypeBug
+	}
+	return issueTypeTask
+}
+
+// ticketTemplateData is what ticket templates are executed with. All values
+// except URLs are escaped, so that text from customers cannot inject markup.
+type ticketTemplateData struct {
+	IssueType string
+	// Overview is the plain text summary of the request.
+	Overview        string
+	Subject         string
+	UserID          string
+	Email           string
+	Description     string
+	ErrorMessage    string
+	Service         string
+	FailedItems     []ticketTemplateItem
+	ShippingAddress *ticketTemplateAddress
+}
+
+type ticketTemplateItem struct {
+	ProductID string
+	Quantity  int32
+	// URL is the product page, if TICKET_PRODUCT_URL is set.
+	URL string
+}
+
+type ticketTemplateAddress struct {
+	StreetAddress string
+	City          string
+	State         string
+	ZipCode       string
+	Country       string
+}
+
+func (s *supportService) ticketTemplateData(overview string, req *pb.CreateSupportRequestRequest) ticketTemplateData {
+	data := ticketTemplateData{
+		IssueType:    issueTypeOf(req),
+		Overview:     adf.Escape(overview),
+		Subject:      adf.Escape(req.Subject),
+		UserID:       adf.Escape(req.UserId),
+		Email:        adf.Escape(req.Email),
+		Description:  adf.Escape(req.Description),
+		ErrorMessage: adf.Escape(req.ErrorMessage),
+		Service:      adf.Escape(req.Service),
+	}
+	for _, item := range req.FailedItems {
+		if item.GetItem() == nil {
+			continue
+		}
+		ti := ticketTemplateItem{
+			ProductID: adf.Escape(item.Item.ProductId),
+			Quantity:  item.Item.Quantity,
+		}
+		if s.productURL != "" {
+			ti.URL = s.productURL + url.PathEscape(item.Item.ProductId)
+		}
+		data.FailedItems = append(data.FailedItems, ti)
+	}
+	if a := req.ShippingAddress; a != nil {
+		data.ShippingAddress = &ticketTemplateAddress{
+			StreetAddress: adf.Escape(a.StreetAddress),
+			City:          adf.Escape(a.City),
+			State:         adf.Escape(a.State),
+			ZipCode:       adf.Escape(a.ZipCode),
+			Country:       adf.Escape(a.Country),
+		}
+	}
+	return data
+}
+
+// jiraContent converts ADF nodes to the content of a Jira description.
+func jiraContent(nodes []adf.Node) []JiraContent {
+	out := make([]JiraContent, 0, len(nodes))
+	for _, n := range nodes {
+		c := JiraContent{Type: n.Type, Attrs: n.Attrs}
+		switch n.Type {
+		case "paragraph", "heading", "codeBlock":
+			c.Content = jiraText(n.Content)
+		case "bulletList", "orderedList":
+			items := make([]JiraListItem, 0, len(n.Content))
+			for _, item := range n.Content {
+				items = append(items, JiraListItem{Type: item.Type, Content: jiraContent(item.Content)})
+			}
+			c.Content = items
+		default:
+			if len(n.Content) > 0 {
+				c.Content = jiraContent(n.Content)
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+func jiraText(nodes []adf.Node) []JiraText {
+	out := make([]JiraText, 0, len(nodes))
+	for _, n := range nodes {
+		t := JiraText{Type: n.Type, Text: n.Text}
+		for _, m := range n.Marks {
+			t.Marks = append(t.Marks, JiraMark{Type: m.Type, Attrs: m.Attrs})
+		}
+		out = append(out, t)
+	}
+	return out
+}
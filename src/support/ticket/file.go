@@ -0,0 +1,133 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ticket
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+)
+
+// FileConfig configures the file provider.
+type FileConfig struct {
+	// Path of the JSONL file issues are appended to. Defaults to tickets.jsonl.
+	Path string
+}
+
+// fileRecord is one line of the file: an issue, or a comment on the issue
+// CommentOn.
+type fileRecord struct {
+	Key         string    `json:"key,omitempty"`
+	Summary     string    `json:"summary,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Type        string    `json:"type,omitempty"`
+	Labels      []string  `json:"labels,omitempty"`
+	CommentOn   string    `json:"comment_on,omitempty"`
+	Comment     string    `json:"comment,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// file appends issues as JSON lines to a local file, for environments
+// without an issue tracker. Keys are LOCAL-1, LOCAL-2 and so on. Comments are
+// appended as records that refer to the key of their issue.
+type file struct {
+	path string
+
+	mu   sync.Mutex
+	next int
+}
+
+func newFile(cfg FileConfig) (*file, error) {
+	if cfg.Path == "" {
+		cfg.Path = "tickets.jsonl"
+	}
+	f := &file{path: cfg.Path, next: 1}
+
+	// Continue numbering after the issues already in the file.
+	existing, err := os.Open(cfg.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return f, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("file: %v", err)
+	}
+	defer existing.Close()
+	scanner := bufio.NewScanner(existing)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var rec fileRecord
+		if len(scanner.Bytes()) > 0 && json.Unmarshal(scanner.Bytes(), &rec) == nil && rec.CommentOn == "" {
+			f.next++
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("file: failed to read %s: %v", cfg.Path, err)
+	}
+	return f, nil
+}
+
+func (f *file) Name() string { return ProviderFile }
+
+func (f *file) Create(_ context.Context, issue Issue) (Ref, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	key := fmt.Sprintf("LOCAL-%d", f.next)
+	if err := f.append(fileRecord{
+		Key:         key,
+		Summary:     issue.Summary,
+		Description: issue.Description,
+		Type:        issue.Type,
+		Labels:      issue.Labels,
+		CreatedAt:   time.Now().UTC(),
+	}); err != nil {
+		return Ref{}, err
+	}
+	f.next++
+	return Ref{ID: key, Key: key}, nil
+}
+
+func (f *file) Comment(_ context.Context, ref Ref, body string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.append(fileRecord{CommentOn: ref.Key, Comment: body, CreatedAt: time.Now().UTC()})
+}
+
+// Ping checks that issues can be appended to the file, creating it if need
+// be.
+func (f *file) Ping(context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	out, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("file: %v", err)
+	}
+	return out.Close()
+}
+
+func (f *file) append(rec fileRecord) error {
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return fmt.Errorf("file: failed to marshal record: %v", err)
+	}
+
+	out, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("file: %v", err)
+	}
+	if _, err := out.Write(append(line, '\n')); err != nil {
+		out.Close()
+		return fmt.Errorf("file: failed to write %s: %v", f.path, err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("file: failed to write %s: %v", f.path, err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ticket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// GitHubConfig configures the GitHub Issues provider.
+type GitHubConfig struct {
+	// APIURL defaults to https://api.github.com. Set it for GitHub Enterprise.
+	APIURL string
+	Token  string
+	// Repository is "owner/name".
+	Repository string
+}
+
+type githubIssue struct {
+	Title  string   `json:"title"`
+	Body   string   `json:"body"`
+	Labels []string `json:"labels,omitempty"`
+}
+
+type githubResponse struct {
+	ID      int64  `json:"id"`
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+}
+
+// github files issues through the GitHub REST API.
+type github struct {
+	cfg    GitHubConfig
+	client *http.Client
+}
+
+func newGitHub(cfg GitHubConfig, client *http.Client) (*github, error) {
+	if cfg.Token == "" || !strings.Contains(cfg.Repository, "/") {
+		return nil, fmt.Errorf("%w: github needs a token and an owner/name repository", ErrNotConfigured)
+	}
+	if cfg.APIURL == "" {
+		cfg.APIURL = "https://api.github.com"
+	}
+	cfg.APIURL = strings.TrimRight(cfg.APIURL, "/")
+	return &github{cfg: cfg, client: client}, nil
+}
+
+func (g *github) Name() string { return ProviderGitHub }
+
+func (g *github) Create(ctx context.Context, issue Issue) (Ref, error) {
+	body := githubIssue{
+		Title:  issue.Summary,
+		Body:   issue.Description,
+		Labels: labels(issue),
+	}
+	var resp githubResponse
+	url := fmt.Sprintf("%s/repos/%s/issues", g.cfg.APIURL, g.cfg.Repository)
+	if err := postJSON(ctx, g.client, url, g.header(), body, &resp, http.StatusCreated); err != nil {
+		return Ref{}, fmt.Errorf("github: %v", err)
+	}
+	return Ref{
+		ID:  strconv.FormatInt(resp.ID, 10),
+		Key: "#" + strconv.Itoa(resp.Number),
+		URL: resp.HTMLURL,
+	}, nil
+}
+
+type githubComment struct {
+	Body string `json:"body"`
+}
+
+// Comment comments on the issue whose key is "#<number>".
+func (g *github) Comment(ctx context.Context, ref Ref, body string) error {
+	var resp githubResponse
+	url := fmt.Sprintf("%s/repos/%s/issues/%s/comments", g.cfg.APIURL, g.cfg.Repository, strings.TrimPrefix(ref.Key, "#"))
+	if err := postJSON(ctx, g.client, url, g.header(), githubComment{Body: body}, &resp, http.StatusCreated); err != nil {
+		return fmt.Errorf("github: %v", err)
+	}
+	return nil
+}
+
+// Ping reads the repository issues are filed in.
+func (g *github) Ping(ctx context.Context) error {
+	if err := getJSON(ctx, g.client, fmt.Sprintf("%s/repos/%s", g.cfg.APIURL, g.cfg.Repository), g.header()); err != nil {
+		return fmt.Errorf("github: %v", err)
+	}
+	return nil
+}
+
+func (g *github) header() http.Header {
+	header := http.Header{}
+	header.Set("Authorization", "Bearer "+g.cfg.Token)
+	header.Set("X-GitHub-Api-Version", "2022-11-28")
+	return header
+}
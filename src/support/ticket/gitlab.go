@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ticket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// GitLabConfig configures the GitLab Issues provider.
+type GitLabConfig struct {
+	// URL defaults to https://gitlab.com.
+	URL   string
+	Token string
+	// Project is the numeric ID or the "group/name" path of the project.
+	Project string
+}
+
+type gitlabIssue struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Labels      string `json:"labels,omitempty"`
+}
+
+type gitlabResponse struct {
+	ID     int64  `json:"id"`
+	IID    int    `json:"iid"`
+	WebURL string `json:"web_url"`
+}
+
+// gitlab files issues through the GitLab REST API v4.
+type gitlab struct {
+	cfg    GitLabConfig
+	client *http.Client
+}
+
+func newGitLab(cfg GitLabConfig, client *http.Client) (*gitlab, error) {
+	if cfg.Token == "" || cfg.Project == "" {
+		return nil, fmt.Errorf("%w: gitlab needs a token and a project", ErrNotConfigured)
+	}
+	if cfg.URL == "" {
+		cfg.URL = "https://gitlab.com"
+	}
+	cfg.URL = strings.TrimRight(cfg.URL, "/")
+	return &gitlab{cfg: cfg, client: client}, nil
+}
+
+func (g *gitlab) Name() string { return ProviderGitLab }
+
+func (g *gitlab) Create(ctx context.Context, issue Issue) (Ref, error) {
+	body := gitlabIssue{
+		Title:       issue.Summary,
+		Description: issue.Description,
+		Labels:      strings.Join(labels(issue), ","),
+	}
+	var resp gitlabResponse
+	endpoint := fmt.Sprintf("%s/api/v4/projects/%s/issues", g.cfg.URL, url.PathEscape(g.cfg.Project))
+	if err := postJSON(ctx, g.client, endpoint, g.header(), body, &resp, http.StatusCreated); err != nil {
+		return Ref{}, fmt.Errorf("gitlab: %v", err)
+	}
+	return Ref{
+		ID:  strconv.FormatInt(resp.ID, 10),
+		Key: "#" + strconv.Itoa(resp.IID),
+		URL: resp.WebURL,
+	}, nil
+}
+
+type gitlabNote struct {
+	Body string `json:"body"`
+}
+
+// Comment adds a note to the issue whose key is "#<iid>".
+func (g *gitlab) Comment(ctx context.Context, ref Ref, body string) error {
+	var resp gitlabResponse
+	endpoint := fmt.Sprintf("%s/api/v4/projects/%s/issues/%s/notes", g.cfg.URL, url.PathEscape(g.cfg.Project), strings.TrimPrefix(ref.Key, "#"))
+	if err := postJSON(ctx, g.client, endpoint, g.header(), gitlabNote{Body: body}, &resp, http.StatusCreated); err != nil {
+		return fmt.Errorf("gitlab: %v", err)
+	}
+	return nil
+}
+
+// Ping reads the project issues are filed in.
+func (g *gitlab) Ping(ctx context.Context) error {
+	endpoint := fmt.Sprintf("%s/api/v4/projects/%s", g.cfg.URL, url.PathEscape(g.cfg.Project))
+	if err := getJSON(ctx, g.client, endpoint, g.header()); err != nil {
+		return fmt.Errorf("gitlab: %v", err)
+	}
+	return nil
+}
+
+func (g *gitlab) header() http.Header {
+	header := http.Header{}
+	header.Set("PRIVATE-TOKEN", g.cfg.Token)
+	return header
+}
@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ticket
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// JiraConfig configures the Jira Cloud provider.
+type JiraConfig struct {
+	URL      string
+	Username string
+	APIToken string
+	Project  string
+}
+
+type jiraIssue struct {
+	Fields jiraFields `json:"fields"`
+}
+
+type jiraFields struct {
+	Project     jiraProject     `json:"project"`
+	Summary     string          `json:"summary"`
+	Description jiraDescription `json:"description"`
+	IssueType   jiraIssueType   `json:"issuetype"`
+	Labels      []string        `json:"labels,omitempty"`
+}
+
+type jiraProject struct {
+	Key string `json:"key"`
+}
+
+type jiraDescription struct {
+	Type    string `json:"type"`
+	Version int    `json:"version"`
+	Content any    `json:"content"`
+}
+
+type jiraIssueType struct {
+	Name string `json:"name"`
+}
+
+type jiraResponse struct {
+	ID  string `json:"id"`
+	Key string `json:"key"`
+}
+
+// jira files issues through the Jira Cloud REST API v3.
+type jira struct {
+	cfg    JiraConfig
+	client *http.Client
+}
+
+func newJira(cfg JiraConfig, client *http.Client) (*jira, error) {
+	if cfg.URL == "" || cfg.APIToken == "" {
+		return nil, fmt.Errorf("%w: jira needs a URL and an API token", ErrNotConfigured)
+	}
+	cfg.URL = strings.TrimRight(cfg.URL, "/")
+	return &jira{cfg: cfg, client: client}, nil
+}
+
+func (j *jira) Name() string { return ProviderJira }
+
+func (j *jira) Create(ctx context.Context, issue Issue) (Ref, error) {
+	content := issue.ADFContent
+	if content == nil {
+		content = adfParagraphs(issue.Description)
+	}
+	issueType := issue.Type
+	if issueType == "" {
+		issueType = "Bug"
+	}
+
+	body := jiraIssue{
+		Fields: jiraFields{
+			Project: jiraProject{Key: j.cfg.Project},
+			Summary: issue.Summary,
+			Description: jiraDescription{
+				Type:    "doc",
+				Version: 1,
+				Content: content,
+			},
+			IssueType: jiraIssueType{Name: issueType},
+			Labels:    issue.Labels,
+		},
+	}
+	var resp jiraResponse
+	if err := postJSON(ctx, j.client, j.cfg.URL+"/rest/api/3/issue", j.header(), body, &resp, http.StatusCreated); err != nil {
+		return Ref{}, fmt.Errorf("jira: %v", err)
+	}
+	return Ref{
+		ID:  resp.ID,
+		Key: resp.Key,
+		URL: fmt.Sprintf("%s/browse/%s", j.cfg.URL, resp.Key),
+	}, nil
+}
+
+type jiraComment struct {
+	Body jiraDescription `json:"body"`
+}
+
+func (j *jira) Comment(ctx context.Context, ref Ref, body string) error {
+	comment := jiraComment{Body: jiraDescription{Type: "doc", Version: 1, Content: adfParagraphs(body)}}
+	var resp jiraResponse
+	endpoint := fmt.Sprintf("%s/rest/api/3/issue/%s/comment", j.cfg.URL, url.PathEscape(ref.Key))
+	if err := postJSON(ctx, j.client, endpoint, j.header(), comment, &resp, http.StatusCreated); err != nil {
+		return fmt.Errorf("jira: %v", err)
+	}
+	return nil
+}
+
+// Ping reads the project issues are filed in.
+func (j *jira) Ping(ctx context.Context) error {
+	endpoint := fmt.Sprintf("%s/rest/api/3/project/%s", j.cfg.URL, url.PathEscape(j.cfg.Project))
+	if err := getJSON(ctx, j.client, endpoint, j.header()); err != nil {
+		return fmt.Errorf("jira: %v", err)
+	}
+	return nil
+}
+
+func (j *jira) header() http.Header {
+	header := http.Header{}
+	header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(j.cfg.Username+":"+j.cfg.APIToken)))
+	return header
+}
+
+// adfParagraphs renders text as ADF paragraphs, one per block of lines
+// separated by a blank line.
+func adfParagraphs(text string) []map[string]any {
+	var out []map[string]any
+	for _, block := range strings.Split(text, "\n\n") {
+		if strings.TrimSpace(block) == "" {
+			continue
+		}
+		out = append(out, map[string]any{
+			"type":    "paragraph",
+			"content": []map[string]any{{"type": "text", "text": block}},
+		})
+	}
+	return out
+}
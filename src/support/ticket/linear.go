@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ticket
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// LinearConfig configures the Linear provider.
+type LinearConfig struct {
+	// APIURL defaults to https://api.linear.app/graphql.
+	APIURL string
+	APIKey string
+	TeamID string
+}
+
+const linearIssueCreate = `mutation IssueCreate($input: IssueCreateInput!) {
+  issueCreate(input: $input) {
+    success
+    issue { id identifier url }
+  }
+}`
+
+const linearCommentCreate = `mutation CommentCreate($input: CommentCreateInput!) {
+  commentCreate(input: $input) {
+    success
+  }
+}`
+
+const linearTeam = `query Team($id: String!) {
+  team(id: $id) { id }
+}`
+
+type linearRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+type linearResponse struct {
+	Data struct {
+		IssueCreate struct {
+			Success bool `json:"success"`
+			Issue   struct {
+				ID         string `json:"id"`
+				Identifier string `json:"identifier"`
+				URL        string `json:"url"`
+			} `json:"issue"`
+		} `json:"issueCreate"`
+		CommentCreate struct {
+			Success bool `json:"success"`
+		} `json:"commentCreate"`
+		Team struct {
+			ID string `json:"id"`
+		} `json:"team"`
+	} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// linear files issues through the Linear GraphQL API. Linear labels are
+// referenced by ID, so the issue type and labels are not sent.
+type linear struct {
+	cfg    LinearConfig
+	client *http.Client
+}
+
+func newLinear(cfg LinearConfig, client *http.Client) (*linear, error) {
+	if cfg.APIKey == "" || cfg.TeamID == "" {
+		return nil, fmt.Errorf("%w: linear needs an API key and a team ID", ErrNotConfigured)
+	}
+	if cfg.APIURL == "" {
+		cfg.APIURL = "https://api.linear.app/graphql"
+	}
+	return &linear{cfg: cfg, client: client}, nil
+}
+
+func (l *linear) Name() string { return ProviderLinear }
+
+func (l *linear) Create(ctx context.Context, issue Issue) (Ref, error) {
+	resp, err := l.mutate(ctx, linearIssueCreate, map[string]any{
+		"teamId":      l.cfg.TeamID,
+		"title":       issue.Summary,
+		"description": issue.Description,
+	})
+	if err != nil {
+		return Ref{}, err
+	}
+	if !resp.Data.IssueCreate.Success {
+		return Ref{}, fmt.Errorf("linear: issue was not created")
+	}
+	created := resp.Data.IssueCreate.Issue
+	return Ref{ID: created.ID, Key: created.Identifier, URL: created.URL}, nil
+}
+
+// Comment comments on the issue with the ID of ref.
+func (l *linear) Comment(ctx context.Context, ref Ref, body string) error {
+	resp, err := l.mutate(ctx, linearCommentCreate, map[string]any{
+		"issueId": ref.ID,
+		"body":    body,
+	})
+	if err != nil {
+		return err
+	}
+	if !resp.Data.CommentCreate.Success {
+		return fmt.Errorf("linear: comment was not created")
+	}
+	return nil
+}
+
+// Ping reads the team issues are filed in.
+func (l *linear) Ping(ctx context.Context) error {
+	resp, err := l.query(ctx, linearTeam, map[string]any{"id": l.cfg.TeamID})
+	if err != nil {
+		return err
+	}
+	if resp.Data.Team.ID == "" {
+		return fmt.Errorf("linear: team %s not found", l.cfg.TeamID)
+	}
+	return nil
+}
+
+// mutate runs a mutation with the given input and fails on GraphQL errors.
+func (l *linear) mutate(ctx context.Context, query string, input map[string]any) (linearResponse, error) {
+	return l.query(ctx, query, map[string]any{"input": input})
+}
+
+// query runs a query with the given variables and fails on GraphQL errors.
+func (l *linear) query(ctx context.Context, query string, variables map[string]any) (linearResponse, error) {
+	body := linearRequest{
+		Query:     query,
+		Variables: variables,
+	}
+	header := http.Header{}
+	header.Set("Authorization", l.cfg.APIKey)
+
+	var resp linearResponse
+	if err := postJSON(ctx, l.client, l.cfg.APIURL, header, body, &resp, http.StatusOK); err != nil {
+		return resp, fmt.Errorf("linear: %v", err)
+	}
+	if len(resp.Errors) > 0 {
+		var msgs []string
+		for _, e := range resp.Errors {
+			msgs = append(msgs, e.Message)
+		}
+		return resp, fmt.Errorf("linear: %s", strings.Join(msgs, "; "))
+	}
+	return resp, nil
+}
@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package ticket files support requests as issues in an issue tracker and
+// comments on them when the same failure is reported again. The
+// Provider interface is implemented for Jira, GitHub Issues, GitLab Issues,
+// Linear and a local JSONL file for environments without a tracker.
+package ticket
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Names of the providers, as accepted by Config.Provider.
+const (
+	ProviderJira   = "jira"
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+	ProviderLinear = "linear"
+	ProviderFile   = "file"
+)
+
+// ErrNotConfigured is returned by New when the selected provider lacks the
+// settings it needs, e.g. an API token.
+var ErrNotConfigured = errors.New("ticket provider is not configured")
+
+// Issue is an issue to be filed.
+type Issue struct {
+	Summary string
+	// Description is the plain text (Markdown where supported) body.
+	Description string
+	// Type is the kind of issue, e.g. "Bug". Providers without issue types
+	// add it as a label.
+	Type   string
+	Labels []string
+	// ADFContent optionally holds the body as Atlassian Document Format
+	// content nodes. The Jira provider uses it instead of Description.
+	ADFContent any
+}
+
+// Ref identifies a filed issue.
+type Ref struct {
+	// ID is the tracker's internal identifier.
+	ID string
+	// Key is the human readable identifier, e.g. "DEMO-42" or "#42".
+	Key string
+	// URL links to the issue in the tracker's UI. It may be empty.
+	URL string
+}
+
+// Provider files issues in an issue tracker.
+type Provider interface {
+	// Name returns the provider name, one of the Provider constants.
+	Name() string
+	// Create files issue and returns a reference to it.
+	Create(ctx context.Context, issue Issue) (Ref, error)
+	// Comment adds a plain text comment to the issue ref.
+	Comment(ctx context.Context, ref Ref, body string) error
+	// Ping checks that the tracker can be reached and that the configured
+	// credentials give access to the project issues are filed in.
+	Ping(ctx context.Context) error
+}
+
+// Config selects and configures a Provider.
+type Config struct {
+	// Provider is one of the Provider constants. Defaults to ProviderJira.
+	Provider string
+
+	Jira   JiraConfig
+	GitHub GitHubConfig
+	GitLab GitLabConfig
+	Linear LinearConfig
+	File   FileConfig
+
+	// HTTPClient is used by the providers that call an API. Defaults to a
+	// client with a 30 second timeout.
+	HTTPClient *http.Client
+}
+
+// New returns the provider selected by cfg.
+func New(cfg Config) (Provider, error) {
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 30 * time.Second}
+	}
+
+	var p Provider
+	var err error
+	switch cfg.Provider {
+	case "", ProviderJira:
+		p, err = newJira(cfg.Jira, client)
+	case ProviderGitHub:
+		p, err = newGitHub(cfg.GitHub, client)
+	case ProviderGitLab:
+		p, err = newGitLab(cfg.GitLab, client)
+	case ProviderLinear:
+		p, err = newLinear(cfg.Linear, client)
+	case ProviderFile:
+		p, err = newFile(cfg.File)
+	default:
+		return nil, fmt.Errorf("unsupported ticket provider %q", cfg.Provider)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// labels returns the labels of issue, including its type.
+func labels(issue Issue) []string {
+	out := append([]string{}, issue.Labels...)
+	if issue.Type != "" {
+		out = append(out, issue.Type)
+	}
+	return out
+}
+
+// getJSON fails unless a GET of url returns 200 OK. The response body is
+// discarded.
+func getJSON(ctx context.Context, client *http.Client, url string, header http.Header) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %v", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make API request: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(data))
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	return nil
+}
+
+// postJSON sends body as JSON to url and decodes the response into out. The
+// request fails unless the response status is one of ok.
+func postJSON(ctx context.Context, client *http.Client, url string, header http.Header, body, out any, ok ...int) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %v", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP request: %v", err)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make API request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	accepted := false
+	for _, code := range ok {
+		accepted = accepted || resp.StatusCode == code
+	}
+	if !accepted {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(data))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode API response: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,376 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package ticket
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var testIssue = Issue{
+	Summary:     "Checkout failed",
+	Description: "Order contains expensive items\n\nUser: test-user",
+	Type:        "Bug",
+	Labels:      []string{"checkout"},
+}
+
+const testComment = "Also reported by user-2"
+
+// standIn describes a stand-in for the API of one provider.
+type standIn struct {
+	provider string
+	// config returns a Config pointing the provider at url.
+	config func(url string) Config
+	// handle checks the request and writes the API's success response.
+	handle func(t *testing.T, w http.ResponseWriter, r *http.Request, body map[string]any)
+	want   Ref
+	// comment checks a request commenting on want and writes the API's
+	// success response.
+	comment func(t *testing.T, w http.ResponseWriter, r *http.Request, body map[string]any)
+	// ping checks the request of Ping and writes the API's success
+	// response.
+	ping func(t *testing.T, w http.ResponseWriter, r *http.Request)
+}
+
+var standIns = []standIn{
+	{
+		provider: ProviderJira,
+		config: func(url string) Config {
+			return Config{Provider: ProviderJira, Jira: JiraConfig{URL: url, Username: "bot", APIToken: "secret", Project: "DEMO"}}
+		},
+		handle: func(t *testing.T, w http.ResponseWriter, r *http.Request, body map[string]any) {
+			expect(t, r, "/rest/api/3/issue", "Authorization", "Basic Ym90OnNlY3JldA==")
+			fields := body["fields"].(map[string]any)
+			if fields["summary"] != testIssue.Summary {
+				t.Errorf("summary = %v", fields["summary"])
+			}
+			if fields["issuetype"].(map[string]any)["name"] != "Bug" {
+				t.Errorf("issuetype = %v", fields["issuetype"])
+			}
+			if content := fields["description"].(map[string]any)["content"].([]any); len(content) != 2 {
+				t.Errorf("description has %d paragraphs, want 2", len(content))
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"10001","key":"DEMO-1"}`))
+		},
+		want: Ref{ID: "10001", Key: "DEMO-1", URL: "{url}/browse/DEMO-1"},
+		comment: func(t *testing.T, w http.ResponseWriter, r *http.Request, body map[string]any) {
+			expect(t, r, "/rest/api/3/issue/DEMO-1/comment", "Authorization", "Basic Ym90OnNlY3JldA==")
+			if body["body"].(map[string]any)["type"] != "doc" {
+				t.Errorf("body = %v", body)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":"20001"}`))
+		},
+		ping: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			expectGet(t, r, "/rest/api/3/project/DEMO", "Authorization", "Basic Ym90OnNlY3JldA==")
+			w.Write([]byte(`{"id":"10000","key":"DEMO"}`))
+		},
+	},
+	{
+		provider: ProviderGitHub,
+		config: func(url string) Config {
+			return Config{Provider: ProviderGitHub, GitHub: GitHubConfig{APIURL: url, Token: "secret", Repository: "acme/shop"}}
+		},
+		handle: func(t *testing.T, w http.ResponseWriter, r *http.Request, body map[string]any) {
+			expect(t, r, "/repos/acme/shop/issues", "Authorization", "Bearer secret")
+			if body["title"] != testIssue.Summary || body["body"] != testIssue.Description {
+				t.Errorf("body = %v", body)
+			}
+			if labels := body["labels"].([]any); len(labels) != 2 || labels[1] != "Bug" {
+				t.Errorf("labels = %v, want the labels and the type", labels)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":555,"number":7,"html_url":"https://github.com/acme/shop/issues/7"}`))
+		},
+		want: Ref{ID: "555", Key: "#7", URL: "https://github.com/acme/shop/issues/7"},
+		comment: func(t *testing.T, w http.ResponseWriter, r *http.Request, body map[string]any) {
+			expect(t, r, "/repos/acme/shop/issues/7/comments", "Authorization", "Bearer secret")
+			if body["body"] != testComment {
+				t.Errorf("body = %v", body)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":556}`))
+		},
+		ping: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			expectGet(t, r, "/repos/acme/shop", "Authorization", "Bearer secret")
+			w.Write([]byte(`{"id":42,"full_name":"acme/shop"}`))
+		},
+	},
+	{
+		provider: ProviderGitLab,
+		config: func(url string) Config {
+			return Config{Provider: ProviderGitLab, GitLab: GitLabConfig{URL: url, Token: "secret", Project: "acme/shop"}}
+		},
+		handle: func(t *testing.T, w http.ResponseWriter, r *http.Request, body map[string]any) {
+			if r.URL.EscapedPath() != "/api/v4/projects/acme%2Fshop/issues" {
+				t.Errorf("path = %s", r.URL.EscapedPath())
+			}
+			expect(t, r, "", "Private-Token", "secret")
+			if body["title"] != testIssue.Summary || body["labels"] != "checkout,Bug" {
+				t.Errorf("body = %v", body)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":900,"iid":3,"web_url":"https://gitlab.com/acme/shop/-/issues/3"}`))
+		},
+		want: Ref{ID: "900", Key: "#3", URL: "https://gitlab.com/acme/shop/-/issues/3"},
+		comment: func(t *testing.T, w http.ResponseWriter, r *http.Request, body map[string]any) {
+			if r.URL.EscapedPath() != "/api/v4/projects/acme%2Fshop/issues/3/notes" {
+				t.Errorf("path = %s", r.URL.EscapedPath())
+			}
+			expect(t, r, "", "Private-Token", "secret")
+			if body["body"] != testComment {
+				t.Errorf("body = %v", body)
+			}
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"id":901}`))
+		},
+		ping: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			if r.URL.EscapedPath() != "/api/v4/projects/acme%2Fshop" {
+				t.Errorf("path = %s", r.URL.EscapedPath())
+			}
+			expectGet(t, r, "", "Private-Token", "secret")
+			w.Write([]byte(`{"id":77}`))
+		},
+	},
+	{
+		provider: ProviderLinear,
+		config: func(url string) Config {
+			return Config{Provider: ProviderLinear, Linear: LinearConfig{APIURL: url, APIKey: "lin_secret", TeamID: "team-1"}}
+		},
+		handle: func(t *testing.T, w http.ResponseWriter, r *http.Request, body map[string]any) {
+			expect(t, r, "", "Authorization", "lin_secret")
+			input := body["variables"].(map[string]any)["input"].(map[string]any)
+			if input["teamId"] != "team-1" || input["title"] != testIssue.Summary {
+				t.Errorf("input = %v", input)
+			}
+			w.Write([]byte(`{"data":{"issueCreate":{"success":true,"issue":{"id":"abc","identifier":"ENG-12","url":"https://linear.app/acme/issue/ENG-12"}}}}`))
+		},
+		want: Ref{ID: "abc", Key: "ENG-12", URL: "https://linear.app/acme/issue/ENG-12"},
+		comment: func(t *testing.T, w http.ResponseWriter, r *http.Request, body map[string]any) {
+			expect(t, r, "", "Authorization", "lin_secret")
+			input := body["variables"].(map[string]any)["input"].(map[string]any)
+			if input["issueId"] != "abc" || input["body"] != testComment {
+				t.Errorf("input = %v", input)
+			}
+			w.Write([]byte(`{"data":{"commentCreate":{"success":true}}}`))
+		},
+		ping: func(t *testing.T, w http.ResponseWriter, r *http.Request) {
+			expect(t, r, "", "Authorization", "lin_secret")
+			var body map[string]any
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("request body is not JSON: %v", err)
+			}
+			if id := body["variables"].(map[string]any)["id"]; id != "team-1" {
+				t.Errorf("team id = %v", id)
+			}
+			w.Write([]byte(`{"data":{"team":{"id":"team-1"}}}`))
+		},
+	},
+}
+
+func expect(t *testing.T, r *http.Request, path, header, value string) {
+	t.Helper()
+	if r.Method != http.MethodPost {
+		t.Errorf("method = %s, want POST", r.Method)
+	}
+	if path != "" && r.URL.Path != path {
+		t.Errorf("path = %s, want %s", r.URL.Path, path)
+	}
+	if got := r.Header.Get(header); got != value {
+		t.Errorf("%s = %q, want %q", header, got, value)
+	}
+}
+
+func expectGet(t *testing.T, r *http.Request, path, header, value string) {
+	t.Helper()
+	if r.Method != http.MethodGet {
+		t.Errorf("method = %s, want GET", r.Method)
+	}
+	if path != "" && r.URL.Path != path {
+		t.Errorf("path = %s, want %s", r.URL.Path, path)
+	}
+	if got := r.Header.Get(header); got != value {
+		t.Errorf("%s = %q, want %q", header, got, value)
+	}
+}
+
+func TestProviderConformance(t *testing.T) {
+	for _, si := range standIns {
+		t.Run(si.provider, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]any
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Errorf("request body is not JSON: %v", err)
+				}
+				si.handle(t, w, r, body)
+			}))
+			defer srv.Close()
+
+			p, err := New(si.config(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if p.Name() != si.provider {
+				t.Errorf("Name() = %q, want %q", p.Name(), si.provider)
+			}
+			ref, err := p.Create(context.Background(), testIssue)
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+			want := si.want
+			want.URL = strings.ReplaceAll(want.URL, "{url}", srv.URL)
+			if ref != want {
+				t.Errorf("Create() = %+v, want %+v", ref, want)
+			}
+		})
+	}
+}
+
+func TestProviderComment(t *testing.T) {
+	for _, si := range standIns {
+		t.Run(si.provider, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]any
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Errorf("request body is not JSON: %v", err)
+				}
+				si.comment(t, w, r, body)
+			}))
+			defer srv.Close()
+
+			p, err := New(si.config(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := p.Comment(context.Background(), si.want, testComment); err != nil {
+				t.Errorf("Comment() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestProviderPing(t *testing.T) {
+	for _, si := range standIns {
+		t.Run(si.provider, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				si.ping(t, w, r)
+			}))
+			defer srv.Close()
+
+			p, err := New(si.config(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := p.Ping(context.Background()); err != nil {
+				t.Errorf("Ping() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestProviderConformanceAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"boom"}`, http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	for _, si := range standIns {
+		t.Run(si.provider, func(t *testing.T) {
+			p, err := New(si.config(srv.URL))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := p.Create(context.Background(), testIssue); err == nil || !strings.Contains(err.Error(), "500") {
+				t.Errorf("Create() error = %v, want the API status", err)
+			}
+			if err := p.Comment(context.Background(), si.want, testComment); err == nil || !strings.Contains(err.Error(), "500") {
+				t.Errorf("Comment() error = %v, want the API status", err)
+			}
+			if err := p.Ping(context.Background()); err == nil || !strings.Contains(err.Error(), "500") {
+				t.Errorf("Ping() error = %v, want the API status", err)
+			}
+		})
+	}
+}
+
+func TestProviderNotConfigured(t *testing.T) {
+	for _, provider := range []string{ProviderJira, ProviderGitHub, ProviderGitLab, ProviderLinear} {
+		if _, err := New(Config{Provider: provider}); !errors.Is(err, ErrNotConfigured) {
+			t.Errorf("New(%s) error = %v, want ErrNotConfigured", provider, err)
+		}
+	}
+	if _, err := New(Config{Provider: "redmine"}); err == nil {
+		t.Error("New(redmine) error = nil, want error")
+	}
+}
+
+func TestFileProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tickets.jsonl")
+	cfg := Config{Provider: ProviderFile, File: FileConfig{Path: path}}
+
+	p, err := New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Ping(context.Background()); err != nil {
+		t.Errorf("Ping() error = %v", err)
+	}
+	missing, err := New(Config{Provider: ProviderFile, File: FileConfig{Path: filepath.Join(t.TempDir(), "missing", "tickets.jsonl")}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := missing.Ping(context.Background()); err == nil {
+		t.Error("Ping() of a file in a missing directory error = nil, want error")
+	}
+	for _, want := range []string{"LOCAL-1", "LOCAL-2"} {
+		ref, err := p.Create(context.Background(), testIssue)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ref.Key != want {
+			t.Errorf("Create() key = %q, want %q", ref.Key, want)
+		}
+	}
+
+	if err := p.Comment(context.Background(), Ref{ID: "LOCAL-2", Key: "LOCAL-2"}, testComment); err != nil {
+		t.Fatal(err)
+	}
+
+	// A new provider continues the numbering of the existing file, skipping
+	// comments.
+	p, err = New(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref, err := p.Create(context.Background(), testIssue); err != nil || ref.Key != "LOCAL-3" {
+		t.Errorf("Create() = %+v, %v, want LOCAL-3", ref, err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	var records []fileRecord
+	for scanner.Scan() {
+		var rec fileRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, rec)
+	}
+	if len(records) != 4 || records[2].CommentOn != "LOCAL-2" || records[3].Summary != testIssue.Summary || records[3].Key != "LOCAL-3" {
+		t.Errorf("file records = %+v", records)
+	}
+}
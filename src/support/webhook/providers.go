@@ -0,0 +1,261 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	errMissingSignature = errors.New("missing signature")
+	errBadSignature     = errors.New("signature mismatch")
+)
+
+// verifyHMAC checks that signature is the hex HMAC-SHA256 of body keyed with
+// secret.
+func verifyHMAC(signature string, body []byte, secret string) error {
+	if signature == "" {
+		return errMissingSignature
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return errBadSignature
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return errBadSignature
+	}
+	return nil
+}
+
+// verifyToken checks a shared secret in constant time.
+func verifyToken(token, secret string) error {
+	if token == "" {
+		return errMissingSignature
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+		return errBadSignature
+	}
+	return nil
+}
+
+// verifyJira accepts the signature of a Jira webhook with a secret, or the
+// secret in the query of the webhook URL for Jira instances that cannot sign
+// webhooks.
+func verifyJira(r *http.Request, body []byte, secret string) error {
+	if sig := r.Header.Get("X-Hub-Signature"); sig != "" {
+		return verifyHMAC(strings.TrimPrefix(sig, "sha256="), body, secret)
+	}
+	return verifyToken(r.URL.Query().Get("secret"), secret)
+}
+
+func verifyGitHub(r *http.Request, body []byte, secret string) error {
+	sig, ok := strings.CutPrefix(r.Header.Get("X-Hub-Signature-256"), "sha256=")
+	if !ok {
+		return errMissingSignature
+	}
+	return verifyHMAC(sig, body, secret)
+}
+
+func verifyGitLab(r *http.Request, _ []byte, secret string) error {
+	return verifyToken(r.Header.Get("X-Gitlab-Token"), secret)
+}
+
+func verifyLinear(r *http.Request, body []byte, secret string) error {
+	return verifyHMAC(r.Header.Get("Linear-Signature"), body, secret)
+}
+
+type jiraWebhook struct {
+	WebhookEvent string `json:"webhookEvent"`
+	User         struct {
+		DisplayName string `json:"displayName"`
+	} `json:"user"`
+	Issue struct {
+		Key    string `json:"key"`
+		Fields struct {
+			Status struct {
+				Name           string `json:"name"`
+				StatusCategory struct {
+					Key string `json:"key"`
+				} `json:"statusCategory"`
+			} `json:"status"`
+		} `json:"fields"`
+	} `json:"issue"`
+	Changelog struct {
+		Items []struct {
+			Field    string `json:"field"`
+			ToString string `json:"toString"`
+		} `json:"items"`
+	} `json:"changelog"`
+}
+
+// parseJira parses issue updates that change the status of the issue.
+func parseJira(_ *http.Request, body []byte) (Event, bool, error) {
+	var w jiraWebhook
+	if err := json.Unmarshal(body, &w); err != nil {
+		return Event{}, false, err
+	}
+	if w.WebhookEvent != "jira:issue_updated" {
+		return Event{}, false, nil
+	}
+	for _, item := range w.Changelog.Items {
+		if item.Field != "status" {
+			continue
+		}
+		if w.Issue.Key == "" {
+			return Event{}, false, errors.New("issue has no key")
+		}
+		ev := Event{
+			TicketKey:    w.Issue.Key,
+			TicketStatus: item.ToString,
+			Actor:        w.User.DisplayName,
+		}
+		if w.Issue.Fields.Status.Name == item.ToString {
+			ev.category = w.Issue.Fields.Status.StatusCategory.Key
+		}
+		return ev, true, nil
+	}
+	return Event{}, false, nil
+}
+
+type gitHubWebhook struct {
+	Action string `json:"action"`
+	Issue  struct {
+		Number      int    `json:"number"`
+		StateReason string `json:"state_reason"`
+	} `json:"issue"`
+	Sender struct {
+		Login string `json:"login"`
+	} `json:"sender"`
+}
+
+// parseGitHub parses issues being closed and reopened.
+func parseGitHub(r *http.Request, body []byte) (Event, bool, error) {
+	if r.Header.Get("X-GitHub-Event") != "issues" {
+		return Event{}, false, nil
+	}
+	var w gitHubWebhook
+	if err := json.Unmarshal(body, &w); err != nil {
+		return Event{}, false, err
+	}
+	var status string
+	switch w.Action {
+	case "closed":
+		status = "closed"
+		if w.Issue.StateReason == "not_planned" {
+			status = "not planned"
+		}
+	case "reopened":
+		status = "reopened"
+	default:
+		return Event{}, false, nil
+	}
+	if w.Issue.Number == 0 {
+		return Event{}, false, errors.New("issue has no number")
+	}
+	return Event{
+		TicketKey:    "#" + strconv.Itoa(w.Issue.Number),
+		TicketStatus: status,
+		Actor:        w.Sender.Login,
+	}, true, nil
+}
+
+type gitLabWebhook struct {
+	ObjectKind string `json:"object_kind"`
+	User       struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	ObjectAttributes struct {
+		IID    int    `json:"iid"`
+		Action string `json:"action"`
+	} `json:"object_attributes"`
+}
+
+// parseGitLab parses issues being closed and reopened.
+func parseGitLab(_ *http.Request, body []byte) (Event, bool, error) {
+	var w gitLabWebhook
+	if err := json.Unmarshal(body, &w); err != nil {
+		return Event{}, false, err
+	}
+	if w.ObjectKind != "issue" {
+		return Event{}, false, nil
+	}
+	var status string
+	switch w.ObjectAttributes.Action {
+	case "close":
+		status = "closed"
+	case "reopen":
+		status = "reopened"
+	default:
+		return Event{}, false, nil
+	}
+	if w.ObjectAttributes.IID == 0 {
+		return Event{}, false, errors.New("issue has no iid")
+	}
+	return Event{
+		TicketKey:    fmt.Sprintf("#%d", w.ObjectAttributes.IID),
+		TicketStatus: status,
+		Actor:        w.User.Username,
+	}, true, nil
+}
+
+type linearWebhook struct {
+	Action string `json:"action"`
+	Type   string `json:"type"`
+	Actor  struct {
+		Name string `json:"name"`
+	} `json:"actor"`
+	Data struct {
+		Identifier string `json:"identifier"`
+		State      struct {
+			Name string `json:"name"`
+			Type string `json:"type"`
+		} `json:"state"`
+	} `json:"data"`
+	UpdatedFrom map[string]any `json:"updatedFrom"`
+}
+
+// linearCategories maps the types of Linear workflow states to the
+// categories Jira uses.
+var linearCategories = map[string]string{
+	"triage":    categoryNew,
+	"backlog":   categoryNew,
+	"unstarted": categoryNew,
+	"started":   categoryStarted,
+	"completed": categoryDone,
+	"canceled":  categoryCanceled,
+}
+
+// parseLinear parses issue updates that change the state of the issue.
+func parseLinear(_ *http.Request, body []byte) (Event, bool, error) {
+	var w linearWebhook
+	if err := json.Unmarshal(body, &w); err != nil {
+		return Event{}, false, err
+	}
+	if w.Type != "Issue" || w.Action != "update" {
+		return Event{}, false, nil
+	}
+	if _, ok := w.UpdatedFrom["stateId"]; !ok {
+		return Event{}, false, nil
+	}
+	if w.Data.Identifier == "" {
+		return Event{}, false, errors.New("issue has no identifier")
+	}
+	return Event{
+		TicketKey:    w.Data.Identifier,
+		TicketStatus: w.Data.State.Name,
+		Actor:        w.Actor.Name,
+		category:     linearCategories[w.Data.State.Type],
+	}, true, nil
+}
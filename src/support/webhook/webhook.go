@@ -0,0 +1,250 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+// Package webhook receives the webhooks issue trackers send when a ticket
+// changes. It verifies that each webhook comes from the tracker, using the
+// tracker's signature or shared secret, and translates ticket transitions
+// into support request statuses. Jira, GitHub, GitLab and Linear are
+// supported at POST /webhooks/{provider}.
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/opentelemetry/opentelemetry-demo/src/support/store"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/ticket"
+)
+
+// maxBody bounds the size of a webhook request.
+const maxBody = 1 << 20
+
+// ErrUnknownTicket is returned by an Applier for a ticket the support service
+// did not file. Such webhooks are acknowledged and ignored.
+var ErrUnknownTicket = errors.New("unknown ticket")
+
+// Event is a ticket transition reported by a tracker.
+type Event struct {
+	// Provider is the tracker, one of the ticket.Provider names.
+	Provider  string
+	TicketKey string
+	// TicketStatus is the status of the ticket in the tracker, e.g. "Done".
+	TicketStatus string
+	// Status is the support request status TicketStatus maps to.
+	Status string
+	// Actor is the user of the tracker who moved the ticket, if known.
+	Actor string
+
+	// category is the tracker's classification of TicketStatus, which is
+	// used when the status name is not mapped.
+	category string
+}
+
+// Applier applies a ticket transition to the support requests of the
+// ticket.
+type Applier func(ctx context.Context, ev Event) error
+
+// Config configures the Handler.
+type Config struct {
+	// Secrets maps a provider to the secret its webhooks are verified with.
+	// Webhooks of providers without a secret are rejected.
+	Secrets map[string]string
+	// Statuses maps tracker status names, case-insensitively, to support
+	// request statuses. It extends and overrides DefaultStatuses.
+	Statuses map[string]string
+}
+
+// DefaultStatuses maps common tracker status names to support request
+// statuses. GitHub and GitLab issues only have the statuses "closed", "not
+// planned" (GitHub) and "reopened".
+var DefaultStatuses = map[string]string{
+	"open":                     store.StatusTriaged,
+	"to do":                    store.StatusTriaged,
+	"todo":                     store.StatusTriaged,
+	"backlog":                  store.StatusTriaged,
+	"triage":                   store.StatusTriaged,
+	"selected for development": store.StatusTriaged,
+	"in progress":              store.StatusInProgress,
+	"in review":                store.StatusInProgress,
+	"reopened":                 store.StatusInProgress,
+	"done":                     store.StatusResolved,
+	"resolved":                 store.StatusResolved,
+	"fixed":                    store.StatusResolved,
+	"completed":                store.StatusResolved,
+	"closed":                   store.StatusResolved,
+	"won't do":                 store.StatusClosed,
+	"won't fix":                store.StatusClosed,
+	"not planned":              store.StatusClosed,
+	"duplicate":                store.StatusClosed,
+	"declined":                 store.StatusClosed,
+	"canceled":                 store.StatusClosed,
+	"cancelled":                store.StatusClosed,
+}
+
+// Categories of tracker statuses, as Jira and Linear classify them.
+const (
+	categoryNew      = "new"
+	categoryStarted  = "indeterminate"
+	categoryDone     = "done"
+	categoryCanceled = "canceled"
+)
+
+var categoryStatuses = map[string]string{
+	categoryNew:      store.StatusTriaged,
+	categoryStarted:  store.StatusInProgress,
+	categoryDone:     store.StatusResolved,
+	categoryCanceled: store.StatusClosed,
+}
+
+// provider verifies and parses the webhooks of one tracker. parse reports
+// false for webhooks that are not ticket transitions.
+type provider struct {
+	verify func(r *http.Request, body []byte, secret string) error
+	parse  func(r *http.Request, body []byte) (Event, bool, error)
+}
+
+var providers = map[string]provider{
+	ticket.ProviderJira:   {verify: verifyJira, parse: parseJira},
+	ticket.ProviderGitHub: {verify: verifyGitHub, parse: parseGitHub},
+	ticket.ProviderGitLab: {verify: verifyGitLab, parse: parseGitLab},
+	ticket.ProviderLinear: {verify: verifyLinear, parse: parseLinear},
+}
+
+// Handler serves the webhooks of the trackers.
+type Handler struct {
+	secrets  map[string]string
+	statuses map[string]string
+	apply    Applier
+	log      *logrus.Logger
+	mux      *http.ServeMux
+}
+
+// NewHandler returns a Handler that passes the ticket transitions of
+// verified webhooks to apply.
+func NewHandler(cfg Config, apply Applier, log *logrus.Logger) (*Handler, error) {
+	h := &Handler{
+		secrets:  make(map[string]string),
+		statuses: make(map[string]string),
+		apply:    apply,
+		log:      log,
+		mux:      http.NewServeMux(),
+	}
+	for name, secret := range cfg.Secrets {
+		if _, ok := providers[name]; !ok {
+			return nil, fmt.Errorf("webhooks of provider %q are not supported", name)
+		}
+		if secret != "" {
+			h.secrets[name] = secret
+		}
+	}
+	for name, status := range DefaultStatuses {
+		h.statuses[name] = status
+	}
+	for name, status := range cfg.Statuses {
+		if !store.ValidStatus(status) {
+			return nil, fmt.Errorf("ticket status %q maps to unknown support request status %q", name, status)
+		}
+		h.statuses[strings.ToLower(strings.TrimSpace(name))] = status
+	}
+	h.mux.HandleFunc("POST /webhooks/{provider}", h.serveWebhook)
+	return h, nil
+}
+
+// Providers returns the providers whose webhooks are accepted.
+func (h *Handler) Providers() []string {
+	var names []string
+	for name := range h.secrets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) serveWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	span := trace.SpanFromContext(ctx)
+	name := r.PathValue("provider")
+	span.SetAttributes(attribute.String("app.webhook.provider", name))
+	log := h.log.WithContext(ctx).WithField("provider", name)
+
+	p, ok := providers[name]
+	if !ok {
+		http.Error(w, "unknown provider", http.StatusNotFound)
+		return
+	}
+	secret := h.secrets[name]
+	if secret == "" {
+		log.Warnf("Rejected webhook: no secret is configured")
+		http.Error(w, "webhooks of this provider are not enabled", http.StatusForbidden)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBody))
+	if err != nil {
+		http.Error(w, "failed to read body", http.StatusBadRequest)
+		return
+	}
+	if err := p.verify(r, body, secret); err != nil {
+		log.Warnf("Rejected webhook: %v", err)
+		span.AddEvent("webhook_rejected", trace.WithAttributes(attribute.String("error", err.Error())))
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	ev, ok, err := p.parse(r, body)
+	if err != nil {
+		log.Warnf("Failed to parse webhook: %v", err)
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+	if !ok {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	ev.Provider = name
+	ev.Status = h.status(ev)
+	span.SetAttributes(
+		attribute.String("app.ticket.key", ev.TicketKey),
+		attribute.String("app.ticket.status", ev.TicketStatus),
+		attribute.String("app.support.status", ev.Status),
+	)
+	if ev.Status == "" {
+		log.Infof("Ignored ticket %s moving to unmapped status %q", ev.TicketKey, ev.TicketStatus)
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	err = h.apply(ctx, ev)
+	switch {
+	case errors.Is(err, ErrUnknownTicket):
+		log.Infof("Ignored webhook for ticket %s, which no support request refers to", ev.TicketKey)
+		w.WriteHeader(http.StatusAccepted)
+	case err != nil:
+		// The tracker retries webhooks that fail.
+		log.Errorf("Failed to apply ticket %s moving to %q: %v", ev.TicketKey, ev.TicketStatus, err)
+		http.Error(w, "failed to apply the ticket transition", http.StatusInternalServerError)
+	default:
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// status maps the ticket status of ev to a support request status, or
+// returns "" if it is not mapped.
+func (h *Handler) status(ev Event) string {
+	if status, ok := h.statuses[strings.ToLower(strings.TrimSpace(ev.TicketStatus))]; ok {
+		return status
+	}
+	return categoryStatuses[ev.category]
+}
@@ -0,0 +1,216 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/opentelemetry/opentelemetry-demo/src/support/store"
+)
+
+const secret = "s3cret"
+
+func sign(body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+const (
+	jiraDone = `{"webhookEvent": "jira:issue_updated", "user": {"displayName": "Ada"},
+		"issue": {"key": "DEMO-1", "fields": {"status": {"name": "Done", "statusCategory": {"key": "done"}}}},
+		"changelog": {"items": [{"field": "assignee", "toString": "Ada"}, {"field": "status", "toString": "Done"}]}}`
+	jiraCustom = `{"webhookEvent": "jira:issue_updated",
+		"issue": {"key": "DEMO-2", "fields": {"status": {"name": "Shipped", "statusCategory": {"key": "done"}}}},
+		"changelog": {"items": [{"field": "status", "toString": "Shipped"}]}}`
+	jiraUnmapped = `{"webhookEvent": "jira:issue_updated",
+		"issue": {"key": "DEMO-3", "fields": {"status": {"name": "Blocked"}}},
+		"changelog": {"items": [{"field": "status", "toString": "Blocked"}]}}`
+	jiraAssigned = `{"webhookEvent": "jira:issue_updated", "issue": {"key": "DEMO-1"},
+		"changelog": {"items": [{"field": "assignee", "toString": "Ada"}]}}`
+	gitHubNotPlanned = `{"action": "closed", "issue": {"number": 12, "state_reason": "not_planned"}, "sender": {"login": "ada"}}`
+	gitHubLabeled    = `{"action": "labeled", "issue": {"number": 12}}`
+	gitLabReopen     = `{"object_kind": "issue", "user": {"username": "ada"}, "object_attributes": {"iid": 4, "action": "reopen"}}`
+	linearStarted    = `{"action": "update", "type": "Issue", "actor": {"name": "Ada"},
+		"data": {"identifier": "SUP-9", "state": {"name": "Doing", "type": "started"}}, "updatedFrom": {"stateId": "abc"}}`
+	linearRenamed = `{"action": "update", "type": "Issue",
+		"data": {"identifier": "SUP-9", "state": {"name": "Doing", "type": "started"}}, "updatedFrom": {"title": "old"}}`
+)
+
+func TestHandler(t *testing.T) {
+	var applied []Event
+	apply := func(_ context.Context, ev Event) error {
+		switch ev.TicketKey {
+		case "DEMO-404":
+			return ErrUnknownTicket
+		case "DEMO-500":
+			return errors.New("store unavailable")
+		}
+		applied = append(applied, ev)
+		return nil
+	}
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	h, err := NewHandler(Config{
+		Secrets:  map[string]string{"jira": secret, "github": secret, "gitlab": secret, "linear": secret},
+		Statuses: map[string]string{" SHIPPED ": store.StatusClosed},
+	}, apply, log)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		body     string
+		header   map[string]string
+		wantCode int
+		want     *Event
+	}{
+		{
+			name: "jira signed", path: "/webhooks/jira", body: jiraDone,
+			header:   map[string]string{"X-Hub-Signature": "sha256=" + sign(jiraDone)},
+			wantCode: http.StatusNoContent,
+			want:     &Event{Provider: "jira", TicketKey: "DEMO-1", TicketStatus: "Done", Status: store.StatusResolved, Actor: "Ada"},
+		},
+		{
+			name: "jira secret in query", path: "/webhooks/jira?secret=" + secret, body: jiraDone,
+			wantCode: http.StatusNoContent,
+			want:     &Event{Provider: "jira", TicketKey: "DEMO-1", TicketStatus: "Done", Status: store.StatusResolved, Actor: "Ada"},
+		},
+		{
+			name: "configured status overrides category", path: "/webhooks/jira?secret=" + secret, body: jiraCustom,
+			wantCode: http.StatusNoContent,
+			want:     &Event{Provider: "jira", TicketKey: "DEMO-2", TicketStatus: "Shipped", Status: store.StatusClosed},
+		},
+		{
+			name: "unmapped status", path: "/webhooks/jira?secret=" + secret, body: jiraUnmapped,
+			wantCode: http.StatusAccepted,
+		},
+		{
+			name: "not a transition", path: "/webhooks/jira?secret=" + secret, body: jiraAssigned,
+			wantCode: http.StatusAccepted,
+		},
+		{
+			name: "unknown ticket", path: "/webhooks/jira?secret=" + secret,
+			body:     strings.Replace(jiraDone, "DEMO-1", "DEMO-404", 1),
+			wantCode: http.StatusAccepted,
+		},
+		{
+			name: "apply fails", path: "/webhooks/jira?secret=" + secret,
+			body:     strings.Replace(jiraDone, "DEMO-1", "DEMO-500", 1),
+			wantCode: http.StatusInternalServerError,
+		},
+		{
+			name: "wrong secret", path: "/webhooks/jira?secret=guess", body: jiraDone,
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "wrong signature", path: "/webhooks/jira", body: jiraDone,
+			header:   map[string]string{"X-Hub-Signature": "sha256=" + sign(jiraAssigned)},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "invalid json", path: "/webhooks/jira?secret=" + secret, body: "{",
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name: "github not planned", path: "/webhooks/github", body: gitHubNotPlanned,
+			header:   map[string]string{"X-GitHub-Event": "issues", "X-Hub-Signature-256": "sha256=" + sign(gitHubNotPlanned)},
+			wantCode: http.StatusNoContent,
+			want:     &Event{Provider: "github", TicketKey: "#12", TicketStatus: "not planned", Status: store.StatusClosed, Actor: "ada"},
+		},
+		{
+			name: "github unsigned", path: "/webhooks/github", body: gitHubNotPlanned,
+			header:   map[string]string{"X-GitHub-Event": "issues"},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name: "github other action", path: "/webhooks/github", body: gitHubLabeled,
+			header:   map[string]string{"X-GitHub-Event": "issues", "X-Hub-Signature-256": "sha256=" + sign(gitHubLabeled)},
+			wantCode: http.StatusAccepted,
+		},
+		{
+			name: "gitlab reopen", path: "/webhooks/gitlab", body: gitLabReopen,
+			header:   map[string]string{"X-Gitlab-Token": secret},
+			wantCode: http.StatusNoContent,
+			want:     &Event{Provider: "gitlab", TicketKey: "#4", TicketStatus: "reopened", Status: store.StatusInProgress, Actor: "ada"},
+		},
+		{
+			name: "linear state category", path: "/webhooks/linear", body: linearStarted,
+			header:   map[string]string{"Linear-Signature": sign(linearStarted)},
+			wantCode: http.StatusNoContent,
+			want:     &Event{Provider: "linear", TicketKey: "SUP-9", TicketStatus: "Doing", Status: store.StatusInProgress, Actor: "Ada"},
+		},
+		{
+			name: "linear other update", path: "/webhooks/linear", body: linearRenamed,
+			header:   map[string]string{"Linear-Signature": sign(linearRenamed)},
+			wantCode: http.StatusAccepted,
+		},
+		{
+			name: "unknown provider", path: "/webhooks/trello", body: "{}",
+			wantCode: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			applied = nil
+			r := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body))
+			for k, v := range tt.header {
+				r.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.wantCode, w.Body)
+			}
+			if tt.want == nil {
+				return
+			}
+			if len(applied) != 1 {
+				t.Fatalf("applied %d events, want 1", len(applied))
+			}
+			got := applied[0]
+			got.category = ""
+			if got != *tt.want {
+				t.Errorf("applied %+v, want %+v", got, *tt.want)
+			}
+		})
+	}
+}
+
+func TestNewHandler(t *testing.T) {
+	noop := func(context.Context, Event) error { return nil }
+	h, err := NewHandler(Config{Secrets: map[string]string{"linear": "x", "jira": "y", "gitlab": ""}}, noop, logrus.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(h.Providers(), ","); got != "jira,linear" {
+		t.Errorf("Providers() = %s, want jira,linear", got)
+	}
+	r := httptest.NewRequest(http.MethodPost, "/webhooks/gitlab", strings.NewReader("{}"))
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusForbidden {
+		t.Errorf("webhook without secret: status = %d, want %d", w.Code, http.StatusForbidden)
+	}
+
+	if _, err := NewHandler(Config{Secrets: map[string]string{"trello": "x"}}, noop, logrus.New()); err == nil {
+		t.Error("NewHandler() with unsupported provider: err = nil")
+	}
+	if _, err := NewHandler(Config{Statuses: map[string]string{"Done": "FINISHED"}}, noop, logrus.New()); err == nil {
+		t.Error("NewHandler() with unknown status: err = nil")
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright The OpenTelemetry Authors
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/opentelemetry/opentelemetry-demo/src/support/store"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/ticket"
+	"github.com/opentelemetry/opentelemetry-demo/src/support/webhook"
+)
+
+// webhookConfigFromEnv reads the secrets the webhooks of each ticket provider
+// are verified with, and WEBHOOK_STATUS_MAP, a comma separated list of
+// "Ticket Status=SUPPORT_STATUS" pairs that extends the default mapping.
+func webhookConfigFromEnv() (webhook.Config, error) {
+	cfg := webhook.Config{
+		Secrets: map[string]string{
+			ticket.ProviderJira:   getEnvOrDefault("JIRA_WEBHOOK_SECRET", ""),
+			ticket.ProviderGitHub: getEnvOrDefault("GITHUB_WEBHOOK_SECRET", ""),
+			ticket.ProviderGitLab: getEnvOrDefault("GITLAB_WEBHOOK_TOKEN", ""),
+			ticket.ProviderLinear: getEnvOrDefault("LINEAR_WEBHOOK_SECRET", ""),
+		},
+		Statuses: make(map[string]string),
+	}
+	for _, pair := range strings.Split(getEnvOrDefault("WEBHOOK_STATUS_MAP", ""), ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		name, status, ok := strings.Cut(pair, "=")
+		if !ok {
+			return cfg, fmt.Errorf("invalid WEBHOOK_STATUS_MAP entry %q, want \"Ticket Status=STATUS\"", pair)
+		}
+		cfg.Statuses[strings.TrimSpace(name)] = strings.ToUpper(strings.TrimSpace(status))
+	}
+	return cfg, nil
+}
+
+// newWebhookServer returns the HTTP server of the ticket webhooks, listening
+// on SUPPORT_WEBHOOK_PORT, or nil if no provider has a webhook secret.
+func (s *supportService) newWebhookServer() (*http.Server, net.Listener, error) {
+	cfg, err := webhookConfigFromEnv()
+	if err != nil {
+		return nil, nil, err
+	}
+	h, err := webhook.NewHandler(cfg, s.applyTicketEvent, logger)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(h.Providers()) == 0 {
+		logger.Warnf("Ticket webhooks are disabled: no webhook secret is configured")
+		return nil, nil, nil
+	}
+
+	port := getEnvOrDefault("SUPPORT_WEBHOOK_PORT", "8081")
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
+	if err != nil {
+		return nil, nil, err
+	}
+	logger.Infof("Ticket webhooks of %v listening on %s", h.Providers(), lis.Addr().String())
+	srv := &http.Server{
+		Handler:           otelhttp.NewHandler(h, "ticket-webhook"),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	return srv, lis, nil
+}
+
+// applyTicketEvent moves the support requests of the incident whose ticket
+// moved in the tracker to the status the ticket status maps to, one allowed
+// transition at a time. Requests that already have that status are left
+// alone, so that redelivered webhooks change nothing, and requests that
+// cannot reach it, such as closed ones, are skipped.
+func (s *supportService) applyTicketEvent(ctx context.Context, ev webhook.Event) error {
+	incident, err := s.store.IncidentByTicket(ctx, ev.TicketKey)
+	if errors.Is(err, store.ErrIncidentNotFound) {
+		return webhook.ErrUnknownTicket
+	}
+	if err != nil {
+		return err
+	}
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(
+		attribute.String("app.support.incident.id", incident.ID),
+		attribute.Int("app.support.count", len(incident.RequestIDs)),
+	)
+
+	actor := ev.Provider
+	if ev.Actor != "" {
+		actor += ":" + ev.Actor
+	}
+	reason := fmt.Sprintf("%s ticket %s moved to %s", ev.Provider, ev.TicketKey, ev.TicketStatus)
+	for _, id := range incident.RequestIDs {
+		sr, err := s.store.Get(ctx, id)
+		if err != nil {
+			return fmt.Errorf("failed to load support request %s: %v", id, err)
+		}
+		if sr.Status == ev.Status {
+			continue
+		}
+		path := store.Path(sr.Status, ev.Status)
+		if path == nil {
+			logger.WithContext(ctx).Infof("Support request %s cannot move from %s to %s, ignoring %s", sr.Id, sr.Status, ev.Status, reason)
+			continue
+		}
+		for _, to := range path {
+			sr, err = s.store.UpdateStatus(ctx, id, store.StatusChange{
+				To:     to,
+				Actor:  actor,
+				Reason: reason,
+				At:     time.Now(),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to move support request %s to %s: %v", id, to, err)
+			}
+			s.afterStatusChange(ctx, sr)
+		}
+		logger.WithContext(ctx).Infof("Moved support request %s to %s: %s", sr.Id, sr.Status, reason)
+	}
+	return nil
+}